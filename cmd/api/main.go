@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"io/fs"
 	"log"
 	"net/http"
 	"os/signal"
@@ -11,15 +13,36 @@ import (
 
 	"github.com/faisalhardin/employee-payroll-system/internal/config"
 	"github.com/faisalhardin/employee-payroll-system/internal/server"
+	"github.com/faisalhardin/employee-payroll-system/migrations"
+	liblog "github.com/faisalhardin/employee-payroll-system/pkg/common/log"
+	"github.com/faisalhardin/employee-payroll-system/pkg/metrics"
+	"github.com/faisalhardin/employee-payroll-system/pkg/migration"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
 
+	"github.com/faisalhardin/employee-payroll-system/internal/database"
 	attendancedb "github.com/faisalhardin/employee-payroll-system/internal/repo/db/attendance"
+	auditdb "github.com/faisalhardin/employee-payroll-system/internal/repo/db/audit"
+	kioskdb "github.com/faisalhardin/employee-payroll-system/internal/repo/db/kiosk"
+	organizationdb "github.com/faisalhardin/employee-payroll-system/internal/repo/db/organization"
+	scheduledb "github.com/faisalhardin/employee-payroll-system/internal/repo/db/schedule"
+	tenantdb "github.com/faisalhardin/employee-payroll-system/internal/repo/db/tenant"
 	userdb "github.com/faisalhardin/employee-payroll-system/internal/repo/db/user"
 	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/idempotency"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/ratelimit"
 
 	attendanceusecase "github.com/faisalhardin/employee-payroll-system/internal/repo/usecase/attendance"
+	auditusecase "github.com/faisalhardin/employee-payroll-system/internal/repo/usecase/audit"
+	kioskusecase "github.com/faisalhardin/employee-payroll-system/internal/repo/usecase/kiosk"
+	payrolljobusecase "github.com/faisalhardin/employee-payroll-system/internal/repo/usecase/payrolljob"
+	schedulerusecase "github.com/faisalhardin/employee-payroll-system/internal/repo/usecase/scheduler"
+	tenantusecase "github.com/faisalhardin/employee-payroll-system/internal/repo/usecase/tenant"
 	userusecase "github.com/faisalhardin/employee-payroll-system/internal/repo/usecase/user"
 
 	attendancehandler "github.com/faisalhardin/employee-payroll-system/internal/repo/handler/attendance"
+	audithandler "github.com/faisalhardin/employee-payroll-system/internal/repo/handler/audit"
+	kioskhandler "github.com/faisalhardin/employee-payroll-system/internal/repo/handler/kiosk"
+	tenanthandler "github.com/faisalhardin/employee-payroll-system/internal/repo/handler/tenant"
 	userhandler "github.com/faisalhardin/employee-payroll-system/internal/repo/handler/user"
 
 	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
@@ -50,22 +73,92 @@ func gracefulShutdown(apiServer *http.Server, done chan bool) {
 	done <- true
 }
 
+// runMigrations applies the pending migrations and seeds when enabled in config
+func runMigrations(db *xormlib.DBConnect, cfg config.Migration) error {
+	ctx := context.Background()
+	sources := []struct {
+		enabled bool
+		fsys    fs.FS
+		table   string
+	}{
+		{cfg.MigrateOnStart, migrations.Schema(), migration.MigrationsTable},
+		{cfg.SeedOnStart, migrations.Seeds(), migration.SeedsTable},
+	}
+	for _, source := range sources {
+		if !source.enabled {
+			continue
+		}
+		runner, err := migration.New(db.MasterDB.DB().DB, source.fsys, source.table)
+		if err != nil {
+			return err
+		}
+		applied, err := runner.Up(ctx)
+		for _, m := range applied {
+			log.Printf("applied %s %03d_%s", source.table, m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func main() {
+	configPath := flag.String("config", config.DefaultPath, "path to the yaml config")
+	flag.Parse()
 
 	loc, _ := time.LoadLocation("Asia/Jakarta")
 	time.Local = loc
 
 	// init config
-	cfg, err := config.New("envconfig")
+	cfg, err := config.New(*configPath)
 	if err != nil {
 		log.Fatalf("failed to init the config: %v", err)
 	}
+	if cfg.Log.Level != "" {
+		liblog.SetLevelString(cfg.Log.Level)
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatalf("failed to init tracing: %v", err)
+		return
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.Printf("failed to flush traces: %v", err)
+		}
+	}()
 
-	db, err := xormlib.NewDBConnection(cfg.DBConfig.DBMaster)
+	db, err := xormlib.NewDBConnection(cfg.DBConfig.DBMaster, cfg.DBConfig.DBReplicas, cfg.DBConfig.ReplicaPolicy)
 	if err != nil {
 		log.Fatalf("failed to init db: %v", err)
 		return
 	}
+	db.Timeouts = cfg.DBConfig.Timeouts
+
+	err = metrics.RegisterDB("master", db.MasterDB.DB().DB)
+	if err != nil {
+		log.Fatalf("failed to register db metrics: %v", err)
+		return
+	}
+	if db.ReadDB != nil {
+		for i, replica := range db.ReadDB.Slaves() {
+			err = metrics.RegisterDB(fmt.Sprintf("replica_%d", i), replica.DB().DB)
+			if err != nil {
+				log.Fatalf("failed to register db metrics: %v", err)
+				return
+			}
+		}
+	}
+
+	err = runMigrations(db, cfg.Migration)
+	if err != nil {
+		log.Fatalf("failed to migrate db: %v", err)
+		return
+	}
 
 	authRepo, err := auth.New(&cfg.JWTConfig)
 	if err != nil {
@@ -80,16 +173,84 @@ func main() {
 		DB: db,
 	})
 
+	auditDB := auditdb.New(&auditdb.Conn{
+		DB: db,
+	})
+
+	kioskDB := kioskdb.New(&kioskdb.Conn{
+		DB: db,
+	})
+
+	scheduleDB := scheduledb.New(&scheduledb.Conn{
+		DB: db,
+	})
+
+	tenantDB := tenantdb.New(&tenantdb.Conn{
+		DB: db,
+	})
+
+	organizationDB := organizationdb.New(&organizationdb.Conn{
+		DB: db,
+	})
+
 	userUC := userusecase.New(&userusecase.Usecase{
 		Cfg:      cfg,
 		UserDB:   userDB,
 		AuthRepo: authRepo,
 	})
 	attendanceUC := attendanceusecase.New(attendanceusecase.Usecase{
-		AttendanceDB: attendanceRepo,
-		UserDB:       userDB,
+		Cfg:            cfg.Attendance,
+		AttendanceDB:   attendanceRepo,
+		UserDB:         userDB,
+		KioskDB:        kioskDB,
+		ScheduleDB:     scheduleDB,
+		TenantDB:       tenantDB,
+		OrganizationDB: organizationDB,
+		AuthRepo:       authRepo,
+	})
+	kioskUC := kioskusecase.New(&kioskusecase.Usecase{
+		Cfg:      cfg,
+		KioskDB:  kioskDB,
+		AuthRepo: authRepo,
+	})
+	auditUC := auditusecase.New(&auditusecase.Usecase{
+		AuditDB: auditDB,
+	})
+	tenantUC := tenantusecase.New(&tenantusecase.Usecase{
+		TenantDB: tenantDB,
 	})
 
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	payrollJobWorker := payrolljobusecase.New(payrolljobusecase.Usecase{
+		Cfg:               cfg.PayrollJob,
+		AttendanceDB:      attendanceRepo,
+		AttendanceUsecase: attendanceUC,
+	})
+	go payrollJobWorker.Run(backgroundCtx)
+
+	idempotencyStore := idempotency.NewDBStore(db)
+	cleanupInterval := time.Duration(cfg.Idempotency.CleanupIntervalInMinutes) * time.Minute
+	if cleanupInterval <= 0 {
+		cleanupInterval = time.Hour
+	}
+	go idempotencyStore.RunCleanup(backgroundCtx, cleanupInterval)
+
+	if cfg.Scheduler.Enabled {
+		payrollScheduler, err := schedulerusecase.New(schedulerusecase.Usecase{
+			Cfg:               cfg.Scheduler,
+			AttendanceDB:      attendanceRepo,
+			TenantDB:          tenantDB,
+			AttendanceUsecase: attendanceUC,
+		})
+		if err != nil {
+			log.Fatalf("failed to init scheduler: %v", err)
+			return
+		}
+		go payrollScheduler.Run(backgroundCtx)
+	}
+
 	userHandler := userhandler.New(&userhandler.UserHandler{
 		UserUsecase: userUC,
 	})
@@ -98,16 +259,53 @@ func main() {
 		AttendanceUsecase: attendanceUC,
 	})
 
+	auditHandler := audithandler.New(&audithandler.AuditHandler{
+		AuditUsecase: auditUC,
+	})
+
+	kioskHandler := kioskhandler.New(&kioskhandler.KioskHandler{
+		KioskUsecase: kioskUC,
+	})
+
+	tenantHandler := tenanthandler.New(&tenanthandler.TenantHandler{
+		TenantUsecase: tenantUC,
+	})
+
 	handlers := &server.Handlers{
 		UserHandler:       userHandler,
 		AttendanceHandler: attendanceHandler,
+		AuditHandler:      auditHandler,
+		KioskHandler:      kioskHandler,
+		TenantHandler:     tenantHandler,
 	}
 
+	metricsServer := server.NewMetricsServer(cfg)
 	server := server.NewServer(cfg, &server.Modules{
 		Handlers:       handlers,
 		AuthMiddleware: authRepo,
+		Database:       database.New(db),
+		LoginLimiter: ratelimit.Handler(ratelimit.NewMemoryStore(),
+			ratelimit.Rule{Key: ratelimit.ByIP, Limit: cfg.Login.PerIP},
+			ratelimit.Rule{Key: ratelimit.ByBodyField("username"), Limit: cfg.Login.PerUsername},
+		),
+		KioskLoginLimiter: ratelimit.Handler(ratelimit.NewMemoryStore(),
+			ratelimit.Rule{Key: ratelimit.ByIP, Limit: cfg.Login.PerIP},
+		),
+		Idempotency: idempotency.Handler(idempotencyStore, time.Duration(cfg.Idempotency.TTLInHours)*time.Hour, cfg.Idempotency.MaxBodyInBytes),
 	})
 
+	if metricsServer != nil {
+		server.RegisterOnShutdown(func() {
+			_ = metricsServer.Close()
+		})
+		go func() {
+			err := metricsServer.ListenAndServe()
+			if err != nil && err != http.ErrServerClosed {
+				log.Printf("metrics server error: %v", err)
+			}
+		}()
+	}
+
 	// Create a done channel to signal when the shutdown is complete
 	done := make(chan bool, 1)
 
@@ -118,6 +316,7 @@ func main() {
 	if err != nil && err != http.ErrServerClosed {
 		panic(fmt.Sprintf("http server error: %s", err))
 	}
+	stopBackground()
 
 	// Wait for the graceful shutdown to complete
 	<-done
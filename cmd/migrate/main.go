@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/config"
+	"github.com/faisalhardin/employee-payroll-system/migrations"
+	"github.com/faisalhardin/employee-payroll-system/pkg/migration"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const usage = `usage: migrate [-config path] [-steps n] <command>
+
+commands:
+  up             apply every pending migration
+  down           revert the latest -steps migrations (default 1)
+  status         list migrations and whether they are applied
+  seed           insert the sample data that has not been inserted yet
+  force VERSION  mark migrations up to VERSION as applied without running them
+  set-password USERNAME
+                 set the password of USERNAME to the first line read from stdin
+`
+
+func main() {
+	configPath := flag.String("config", config.DefaultPath, "path to the yaml config")
+	steps := flag.Int("steps", 1, "number of migrations to revert with down")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.New(*configPath)
+	if err != nil {
+		log.Fatalf("failed to init the config: %v", err)
+	}
+
+	db, err := xormlib.NewDBConnection(cfg.DBConfig.DBMaster, nil, "")
+	if err != nil {
+		log.Fatalf("failed to init db: %v", err)
+	}
+	defer db.MasterDB.Close()
+
+	schemaRunner, err := migration.New(db.MasterDB.DB().DB, migrations.Schema(), migration.MigrationsTable)
+	if err != nil {
+		log.Fatalf("failed to load migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	switch command := flag.Arg(0); command {
+	case "up":
+		applied, err := schemaRunner.Up(ctx)
+		printMigrations("applied", applied)
+		if err != nil {
+			log.Fatal(err)
+		}
+	case "down":
+		reverted, err := schemaRunner.Down(ctx, *steps)
+		printMigrations("reverted", reverted)
+		if err != nil {
+			log.Fatal(err)
+		}
+	case "status":
+		statuses, err := schemaRunner.Status(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%03d_%-40s %s\n", status.Version, status.Name, appliedAt)
+		}
+	case "seed":
+		seedRunner, err := migration.New(db.MasterDB.DB().DB, migrations.Seeds(), migration.SeedsTable)
+		if err != nil {
+			log.Fatalf("failed to load seeds: %v", err)
+		}
+		applied, err := seedRunner.Up(ctx)
+		printMigrations("seeded", applied)
+		if err != nil {
+			log.Fatal(err)
+		}
+	case "force":
+		version, err := strconv.ParseInt(flag.Arg(1), 10, 64)
+		if err != nil {
+			log.Fatalf("force needs a numeric version: %v", err)
+		}
+		err = schemaRunner.Force(ctx, version)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("marked migrations up to %d as applied\n", version)
+	case "set-password":
+		username := flag.Arg(1)
+		if username == "" {
+			log.Fatal("set-password needs a username")
+		}
+		password, err := readPassword(os.Stdin)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = setPassword(ctx, db.MasterDB.DB().DB, username, password)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("set the password of %s\n", username)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func printMigrations(action string, list []migration.Migration) {
+	if len(list) == 0 {
+		fmt.Printf("nothing %s\n", action)
+		return
+	}
+	for _, m := range list {
+		fmt.Printf("%s %03d_%s\n", action, m.Version, m.Name)
+	}
+}
+
+// readPassword reads the first line of r, so the password stays out of the
+// shell history and the process list
+func readPassword(r *os.File) (string, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", errors.New("set-password reads the password from stdin, got nothing")
+	}
+	password := strings.TrimRight(scanner.Text(), "\r")
+	if password == "" {
+		return "", errors.New("the password is empty")
+	}
+	return password, nil
+}
+
+// setPassword stores the bcrypt hash of password for the user and lifts a
+// lockout, the only way to give an admin a password when none can sign in
+func setPassword(ctx context.Context, db *sql.DB, username, password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	result, err := db.ExecContext(ctx,
+		"UPDATE mst_user SET password_hash = $1, failed_login_attempts = 0, locked_until = NULL, updated_at = now() WHERE username = $2", string(hash), username)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("no user is named %s", username)
+	}
+	return nil
+}
@@ -5,18 +5,47 @@ import (
 	"os"
 
 	auth "github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/ratelimit"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/secureheaders"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
 	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
 	"gopkg.in/yaml.v3"
 )
 
+// DefaultPath is the config file read when no --config flag is given,
+// relative to the working directory.
+const DefaultPath = "files/env/envconfig.yaml"
+
 type Config struct {
-	Server    Server         `yaml:"server"`
-	JWTConfig auth.JWTConfig `yaml:"jwt_config"`
-	DBConfig  DBConfig       `yaml:"db_config"`
+	Server     Server         `yaml:"server"`
+	JWTConfig  auth.JWTConfig `yaml:"jwt_config"`
+	DBConfig   DBConfig       `yaml:"db_config"`
+	Scheduler  Scheduler      `yaml:"scheduler"`
+	PayrollJob PayrollJob     `yaml:"payroll_job"`
+	Migration  Migration      `yaml:"migration"`
+	Log        Log            `yaml:"log"`
+	Tracing    tracing.Config `yaml:"tracing"`
+	Login      Login          `yaml:"login"`
+	CORS       CORS           `yaml:"cors"`
+	// SecurityHeaders are sent with every response
+	SecurityHeaders secureheaders.Config `yaml:"security_headers"`
+	// Idempotency remembers the responses of POST requests sent with an
+	// Idempotency-Key header
+	Idempotency Idempotency `yaml:"idempotency"`
+	// Attendance fences tap ins around the offices
+	Attendance Attendance `yaml:"attendance"`
+	Kiosk      Kiosk      `yaml:"kiosk"`
 }
 
+// DBConfig lists the master and its read replicas. Reads are balanced over
+// the replicas with ReplicaPolicy, one of round_robin (default), random or
+// least_conn, and go to the master when there are none. Timeouts bound every
+// repository operation.
 type DBConfig struct {
-	DBMaster xormlib.Config `yaml:"db_master"`
+	DBMaster      xormlib.Config   `yaml:"db_master"`
+	DBReplicas    []xormlib.Config `yaml:"db_replicas"`
+	ReplicaPolicy string           `yaml:"replica_policy"`
+	Timeouts      xormlib.Timeouts `yaml:"timeouts"`
 }
 
 type Server struct {
@@ -24,13 +53,113 @@ type Server struct {
 	Host    string `yaml:"host"`
 	Port    string `yaml:"port"`
 	BaseURL string `yaml:"base_url"`
+	// TrustedProxies are the addresses or ranges of the load balancers in
+	// front of the API, the only peers whose X-Forwarded-For and X-Real-IP
+	// headers are believed
+	TrustedProxies []string `yaml:"trusted_proxies"`
+	// MetricsPort serves /metrics on its own listener so it stays off the
+	// public ingress. Metrics are not served when it is empty.
+	MetricsPort string `yaml:"metrics_port"`
+}
+
+// Scheduler configures the recurring payroll period schedule.
+// Monthly schedules take one cut-off day, semi-monthly schedules take two.
+// A cut-off day past the end of a month falls on the last day of that month.
+type Scheduler struct {
+	Enabled           bool   `yaml:"enabled"`
+	Frequency         string `yaml:"frequency"`
+	CutoffDays        []int  `yaml:"cutoff_days"`
+	PeriodsAhead      int    `yaml:"periods_ahead"`
+	AutoGenerate      bool   `yaml:"auto_generate"`
+	GenerateAfterDays int    `yaml:"generate_after_days"`
+	IntervalInMinutes int    `yaml:"interval_in_minutes"`
+}
+
+// PayrollJob configures the background workers that generate payroll.
+// A running job whose heartbeat is older than StaleAfterInMinutes is assumed
+// to be interrupted and is queued again, up to MaxAttempts attempts.
+type PayrollJob struct {
+	Workers               int `yaml:"workers"`
+	PollIntervalInSeconds int `yaml:"poll_interval_in_seconds"`
+	StaleAfterInMinutes   int `yaml:"stale_after_in_minutes"`
+	MaxAttempts           int `yaml:"max_attempts"`
+}
+
+// Migration controls what the API applies to the database when it starts.
+// Seeds only insert sample data and should stay off outside development.
+type Migration struct {
+	MigrateOnStart bool `yaml:"migrate_on_start"`
+	SeedOnStart    bool `yaml:"seed_on_start"`
 }
 
-func New(repoName string) (*Config, error) {
-	dir, _ := os.Getwd()
-	filename := "files/env/" + repoName + ".yaml"
+// Log sets the lowest level written by the service logger, info by default.
+type Log struct {
+	Level string `yaml:"level"`
+}
+
+// Login protects sign in against brute force. Requests are throttled per
+// client address and per username, and an account is locked for
+// LockoutInMinutes after MaxFailedAttempts failed attempts in a row. A zero
+// MaxFailedAttempts never locks.
+type Login struct {
+	PerIP             ratelimit.Limit `yaml:"per_ip"`
+	PerUsername       ratelimit.Limit `yaml:"per_username"`
+	MaxFailedAttempts int             `yaml:"max_failed_attempts"`
+	LockoutInMinutes  int             `yaml:"lockout_in_minutes"`
+}
+
+// Idempotency keeps a key and its response for TTLInHours, 24 by default.
+// Expired keys are deleted every CleanupIntervalInMinutes, 60 by default.
+// Bodies of requests with a key are buffered up to MaxBodyInBytes, 10 MiB by
+// default.
+type Idempotency struct {
+	TTLInHours               int   `yaml:"ttl_in_hours"`
+	CleanupIntervalInMinutes int   `yaml:"cleanup_interval_in_minutes"`
+	MaxBodyInBytes           int64 `yaml:"max_body_in_bytes"`
+}
+
+// Attendance checks tap ins against OfficeLocations. A tap in within the
+// radius of any office is inside, what happens outside depends on the work
+// arrangement of the employee. Fencing is off without office locations.
+// BindDevice ties an employee to the device of their first tap in, which
+// an admin can reset.
+type Attendance struct {
+	OfficeLocations []OfficeLocation `yaml:"office_locations"`
+	BindDevice      bool             `yaml:"bind_device"`
+}
 
-	f, err := os.Open(fmt.Sprintf("%s/%s", dir, filename))
+// Kiosk sets how long a kiosk stays signed in, 12 hours by default, and how
+// long the nonce behind its QR code is valid, 30 seconds by default. A
+// shorter nonce makes a photographed code useless sooner.
+type Kiosk struct {
+	TokenDurationInHours int `yaml:"token_duration_in_hours"`
+	NonceTTLInSeconds    int `yaml:"nonce_ttl_in_seconds"`
+}
+
+type OfficeLocation struct {
+	Name           string  `yaml:"name"`
+	Latitude       float64 `yaml:"latitude"`
+	Longitude      float64 `yaml:"longitude"`
+	RadiusInMeters float64 `yaml:"radius_in_meters"`
+}
+
+// CORS lists the browser origins allowed to call the API. No origin is
+// allowed when AllowedOrigins is empty. Methods and headers default to
+// auth.AllowedMethodRequest and auth.AllowedHeaders. Credentials should only
+// be allowed together with exact origins.
+type CORS struct {
+	AllowedOrigins   []string `yaml:"allowed_origins"`
+	AllowedMethods   []string `yaml:"allowed_methods"`
+	AllowedHeaders   []string `yaml:"allowed_headers"`
+	ExposedHeaders   []string `yaml:"exposed_headers"`
+	AllowCredentials bool     `yaml:"allow_credentials"`
+	MaxAgeInSeconds  int      `yaml:"max_age_in_seconds"`
+}
+
+// New loads the yaml file at path, applies the environment overrides and
+// secret files on top (see env.go) and validates the result.
+func New(path string) (*Config, error) {
+	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
@@ -42,6 +171,16 @@ func New(repoName string) (*Config, error) {
 
 	var cfg Config
 	err = yaml.NewDecoder(f).Decode(&cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	err = cfg.applyEnv(os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.Validate()
 	if err != nil {
 		return nil, err
 	}
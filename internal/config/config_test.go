@@ -0,0 +1,212 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/stretchr/testify/assert"
+)
+
+const testYAML = `
+server:
+  port: "8080"
+jwt_config:
+  jwt_credentials:
+    secret: "0123456789abcdef0123456789abcdef"
+db_config:
+  db_master:
+    dsn: "postgresql://user@localhost:5432/postgres?sslmode=disable"
+cors:
+  allowed_origins: ["http://localhost:3000"]
+migration:
+  migrate_on_start: true
+`
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNew(t *testing.T) {
+	path := writeFile(t, "envconfig.yaml", testYAML)
+	secretPath := writeFile(t, "jwt_secret", "fedcba9876543210fedcba9876543210\n")
+
+	tests := []struct {
+		name    string
+		path    string
+		env     map[string]string
+		check   func(t *testing.T, cfg *Config)
+		wantErr string
+	}{
+		{
+			name: "yaml only",
+			path: path,
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "8080", cfg.Server.Port)
+				assert.Equal(t, []string{"http://localhost:3000"}, cfg.CORS.AllowedOrigins)
+				assert.True(t, cfg.Migration.MigrateOnStart)
+			},
+		},
+		{
+			name: "environment overrides yaml",
+			path: path,
+			env: map[string]string{
+				"SERVER_PORT":          "9090",
+				"DB_PASSWORD":          "password",
+				"CORS_ALLOWED_ORIGINS": "https://a.example.com, https://b.example.com,",
+				"MIGRATE_ON_START":     "false",
+			},
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "9090", cfg.Server.Port)
+				assert.Equal(t, "password", cfg.DBConfig.DBMaster.Password)
+				assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, cfg.CORS.AllowedOrigins)
+				assert.False(t, cfg.Migration.MigrateOnStart)
+			},
+		},
+		{
+			name: "secret file wins over environment",
+			path: path,
+			env: map[string]string{
+				"JWT_SECRET":      "ignored-ignored-ignored-ignored-",
+				"JWT_SECRET_FILE": secretPath,
+			},
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "fedcba9876543210fedcba9876543210", cfg.JWTConfig.Credentials.Secret)
+			},
+		},
+		{
+			name:    "missing secret file",
+			path:    path,
+			env:     map[string]string{"DB_PASSWORD_FILE": filepath.Join(t.TempDir(), "missing")},
+			wantErr: "failed to read DB_PASSWORD_FILE",
+		},
+		{
+			name:    "invalid boolean",
+			path:    path,
+			env:     map[string]string{"SEED_ON_START": "sometimes"},
+			wantErr: "SEED_ON_START is not a boolean",
+		},
+		{
+			name:    "invalid after overrides",
+			path:    path,
+			env:     map[string]string{"JWT_SECRET": "short"},
+			wantErr: "jwt_config.jwt_credentials.secret must be at least 32 bytes",
+		},
+		{
+			name:    "missing file",
+			path:    filepath.Join(t.TempDir(), "missing.yaml"),
+			wantErr: "no such file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			cfg, err := New(tt.path)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() Config {
+		var cfg Config
+		cfg.Server.Port = "8080"
+		cfg.JWTConfig.Credentials.Secret = "0123456789abcdef0123456789abcdef"
+		cfg.DBConfig.DBMaster.DSN = "host=localhost user=user dbname=postgres"
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		patch   func(cfg *Config)
+		wantErr []string
+	}{
+		{name: "valid", patch: func(cfg *Config) {}},
+		{
+			name: "every invalid setting is reported",
+			patch: func(cfg *Config) {
+				cfg.Server.Port = "80a"
+				cfg.JWTConfig.Credentials.Secret = "anewKeyForMe"
+				cfg.DBConfig.DBMaster.DSN = ""
+			},
+			wantErr: []string{"server.port", "jwt_config.jwt_credentials.secret", "db_config.db_master: dsn is empty"},
+		},
+		{
+			name:    "port out of range",
+			patch:   func(cfg *Config) { cfg.Server.Port = "70000" },
+			wantErr: []string{"server.port \"70000\""},
+		},
+		{
+			name:    "metrics on the api port",
+			patch:   func(cfg *Config) { cfg.Server.MetricsPort = "8080" },
+			wantErr: []string{"server.metrics_port must differ"},
+		},
+		{
+			name:    "invalid metrics port",
+			patch:   func(cfg *Config) { cfg.Server.MetricsPort = "metrics" },
+			wantErr: []string{"server.metrics_port \"metrics\""},
+		},
+		{
+			name:    "invalid trusted proxy",
+			patch:   func(cfg *Config) { cfg.Server.TrustedProxies = []string{"10.0.0.0/8", "lb.internal"} },
+			wantErr: []string{"server.trusted_proxies: trusted proxy \"lb.internal\""},
+		},
+		{
+			name: "invalid replica",
+			patch: func(cfg *Config) {
+				cfg.DBConfig.DBReplicas = []xormlib.Config{{DSN: "replica:5432"}}
+				cfg.DBConfig.ReplicaPolicy = "weighted"
+			},
+			wantErr: []string{"db_config.db_replicas[0]", "db_config.replica_policy"},
+		},
+		{
+			name: "any origin with credentials",
+			patch: func(cfg *Config) {
+				cfg.CORS.AllowedOrigins = []string{"*"}
+				cfg.CORS.AllowCredentials = true
+			},
+			wantErr: []string{"cors.allowed_origins"},
+		},
+		{
+			name: "invalid office locations",
+			patch: func(cfg *Config) {
+				cfg.Attendance.OfficeLocations = []OfficeLocation{
+					{Name: "HQ", Latitude: -6.2, Longitude: 106.8, RadiusInMeters: 150},
+					{Latitude: -91, Longitude: 106.8},
+				}
+			},
+			wantErr: []string{
+				"attendance.office_locations[1].name",
+				"attendance.office_locations[1] is not a valid coordinate",
+				"attendance.office_locations[1].radius_in_meters",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.patch(&cfg)
+			err := cfg.Validate()
+			if len(tt.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			for _, want := range tt.wantErr {
+				assert.ErrorContains(t, err, want)
+			}
+			assert.NotContains(t, err.Error(), "anewKeyForMe")
+		})
+	}
+}
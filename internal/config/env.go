@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// fileSuffix marks a variable holding the path of a file with the value, e.g.
+// JWT_SECRET_FILE for a mounted secret.
+const fileSuffix = "_FILE"
+
+// envSetting is a setting that an environment variable overrides.
+type envSetting struct {
+	name string
+	set  func(cfg *Config, value string) error
+}
+
+// envSettings are applied in order over the yaml file. A NAME_FILE variable
+// is read after NAME and wins over it.
+var envSettings = []envSetting{
+	stringSetting("SERVER_PORT", func(cfg *Config) *string { return &cfg.Server.Port }),
+	stringSetting("SERVER_BASE_URL", func(cfg *Config) *string { return &cfg.Server.BaseURL }),
+	stringSetting("METRICS_PORT", func(cfg *Config) *string { return &cfg.Server.MetricsPort }),
+	listSetting("TRUSTED_PROXIES", func(cfg *Config) *[]string { return &cfg.Server.TrustedProxies }),
+	stringSetting("JWT_SECRET", func(cfg *Config) *string { return &cfg.JWTConfig.Credentials.Secret }),
+	stringSetting("DB_DSN", func(cfg *Config) *string { return &cfg.DBConfig.DBMaster.DSN }),
+	stringSetting("DB_PASSWORD", func(cfg *Config) *string { return &cfg.DBConfig.DBMaster.Password }),
+	stringSetting("LOG_LEVEL", func(cfg *Config) *string { return &cfg.Log.Level }),
+	stringSetting("TRACING_EXPORTER", func(cfg *Config) *string { return &cfg.Tracing.Exporter }),
+	stringSetting("TRACING_ENDPOINT", func(cfg *Config) *string { return &cfg.Tracing.Endpoint }),
+	listSetting("CORS_ALLOWED_ORIGINS", func(cfg *Config) *[]string { return &cfg.CORS.AllowedOrigins }),
+	boolSetting("MIGRATE_ON_START", func(cfg *Config) *bool { return &cfg.Migration.MigrateOnStart }),
+	boolSetting("SEED_ON_START", func(cfg *Config) *bool { return &cfg.Migration.SeedOnStart }),
+}
+
+func stringSetting(name string, field func(cfg *Config) *string) envSetting {
+	return envSetting{name: name, set: func(cfg *Config, value string) error {
+		*field(cfg) = value
+		return nil
+	}}
+}
+
+// listSetting splits a comma separated value, an empty value clears the list.
+func listSetting(name string, field func(cfg *Config) *[]string) envSetting {
+	return envSetting{name: name, set: func(cfg *Config, value string) error {
+		list := []string{}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		*field(cfg) = list
+		return nil
+	}}
+}
+
+func boolSetting(name string, field func(cfg *Config) *bool) envSetting {
+	return envSetting{name: name, set: func(cfg *Config, value string) error {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s is not a boolean", name)
+		}
+		*field(cfg) = parsed
+		return nil
+	}}
+}
+
+// applyEnv overrides the settings found with lookup, then the ones with a
+// secret file. Surrounding whitespace of a file, such as the trailing newline,
+// is dropped.
+func (cfg *Config) applyEnv(lookup func(name string) (string, bool)) error {
+	for _, setting := range envSettings {
+		value, found := lookup(setting.name)
+		if !found {
+			continue
+		}
+		if err := setting.set(cfg, value); err != nil {
+			return err
+		}
+	}
+
+	for _, setting := range envSettings {
+		path, found := lookup(setting.name + fileSuffix)
+		if !found || path == "" {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s%s: %w", setting.name, fileSuffix, err)
+		}
+		if err := setting.set(cfg, strings.TrimSpace(string(content))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
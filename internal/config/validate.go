@@ -0,0 +1,80 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/realip"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+)
+
+// minJWTSecretLength is the HS256 key size in bytes
+const minJWTSecretLength = 32
+
+// Validate reports every invalid setting at once so a broken deployment is
+// fixed in one go. Messages never include secret values.
+func (cfg *Config) Validate() error {
+	var errs []error
+
+	port, err := strconv.Atoi(cfg.Server.Port)
+	if err != nil || port < 1 || port > 65535 {
+		errs = append(errs, fmt.Errorf("server.port %q is not a port between 1 and 65535", cfg.Server.Port))
+	}
+
+	if cfg.Server.MetricsPort != "" {
+		metricsPort, err := strconv.Atoi(cfg.Server.MetricsPort)
+		if err != nil || metricsPort < 1 || metricsPort > 65535 {
+			errs = append(errs, fmt.Errorf("server.metrics_port %q is not a port between 1 and 65535", cfg.Server.MetricsPort))
+		} else if metricsPort == port {
+			errs = append(errs, fmt.Errorf("server.metrics_port must differ from server.port"))
+		}
+	}
+
+	if _, err := realip.Parse(cfg.Server.TrustedProxies); err != nil {
+		errs = append(errs, fmt.Errorf("server.trusted_proxies: %w", err))
+	}
+
+	if len(cfg.JWTConfig.Credentials.Secret) < minJWTSecretLength {
+		errs = append(errs, fmt.Errorf("jwt_config.jwt_credentials.secret must be at least %d bytes", minJWTSecretLength))
+	}
+
+	if _, err := cfg.DBConfig.DBMaster.DataSourceName(); err != nil {
+		errs = append(errs, fmt.Errorf("db_config.db_master: %w", err))
+	}
+
+	for i, replica := range cfg.DBConfig.DBReplicas {
+		if _, err := replica.DataSourceName(); err != nil {
+			errs = append(errs, fmt.Errorf("db_config.db_replicas[%d]: %w", i, err))
+		}
+	}
+	if _, err := xormlib.NewGroupPolicy(cfg.DBConfig.ReplicaPolicy); err != nil {
+		errs = append(errs, fmt.Errorf("db_config.replica_policy: %w", err))
+	}
+
+	if cfg.CORS.AllowCredentials {
+		for _, origin := range cfg.CORS.AllowedOrigins {
+			if origin == "*" {
+				errs = append(errs, errors.New("cors.allowed_origins cannot be \"*\" with allow_credentials"))
+				break
+			}
+		}
+	}
+
+	for i, office := range cfg.Attendance.OfficeLocations {
+		if office.Name == "" {
+			errs = append(errs, fmt.Errorf("attendance.office_locations[%d].name is empty", i))
+		}
+		if office.Latitude < -90 || office.Latitude > 90 || office.Longitude < -180 || office.Longitude > 180 {
+			errs = append(errs, fmt.Errorf("attendance.office_locations[%d] is not a valid coordinate", i))
+		}
+		if office.RadiusInMeters <= 0 {
+			errs = append(errs, fmt.Errorf("attendance.office_locations[%d].radius_in_meters must be positive", i))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config: %w", errors.Join(errs...))
+	}
+	return nil
+}
@@ -4,20 +4,29 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"log"
-	"os"
 	"strconv"
-	"time"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
-	_ "github.com/joho/godotenv/autoload"
+	"github.com/faisalhardin/employee-payroll-system/pkg/migration"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	// noMigrationVersion is reported before the migration runner applied
+	// anything, while its table is missing or empty
+	noMigrationVersion = "none"
+	// unknownMigrationVersion is reported when the migration table cannot be
+	// read
+	unknownMigrationVersion = "unknown"
 )
 
 // Service represents a service that interacts with a database.
 type Service interface {
 	// Health returns a map of health status information.
 	// The keys and values in the map are service-specific.
-	Health() map[string]string
+	Health(ctx context.Context) map[string]string
 
 	// Close terminates the database connection.
 	// It returns an error if the connection cannot be closed.
@@ -25,58 +34,39 @@ type Service interface {
 }
 
 type service struct {
-	db *sql.DB
+	db *xormlib.DBConnect
 }
 
-var (
-	database   = os.Getenv("DB_DATABASE")
-	password   = os.Getenv("DB_PASSWORD")
-	username   = os.Getenv("DB_USERNAME")
-	port       = os.Getenv("DB_PORT")
-	host       = os.Getenv("DB_HOST")
-	schema     = os.Getenv("DB_SCHEMA")
-	dbInstance *service
-)
-
-func New() Service {
-	// Reuse Connection
-	if dbInstance != nil {
-		return dbInstance
-	}
-	connStr := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable&search_path=%s", username, password, host, port, database, schema)
-	db, err := sql.Open("pgx", connStr)
-	if err != nil {
-		log.Fatal(err)
-	}
-	dbInstance = &service{
+// New reports on the same xorm pool the repositories use.
+func New(db *xormlib.DBConnect) Service {
+	return &service{
 		db: db,
 	}
-	return dbInstance
 }
 
 // Health checks the health of the database connection by pinging the database.
-// It returns a map with keys indicating various health statistics.
-func (s *service) Health() map[string]string {
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-	defer cancel()
-
+// It returns a map with keys indicating various health statistics. A database
+// that cannot be reached is reported with status "down" rather than stopping
+// the process, so callers can answer readiness probes with 503.
+func (s *service) Health(ctx context.Context) map[string]string {
 	stats := make(map[string]string)
 
 	// Ping the database
-	err := s.db.PingContext(ctx)
+	err := s.db.MasterDB.PingContext(ctx)
 	if err != nil {
-		stats["status"] = "down"
+		stats["status"] = StatusDown
 		stats["error"] = fmt.Sprintf("db down: %v", err)
-		log.Fatalf("db down: %v", err) // Log the error and terminate the program
 		return stats
 	}
 
 	// Database is up, add more statistics
-	stats["status"] = "up"
+	stats["status"] = StatusUp
 	stats["message"] = "It's healthy"
+	stats["migration_version"] = s.migrationVersion(ctx)
 
 	// Get database stats (like open connections, in use, idle, etc.)
-	dbStats := s.db.Stats()
+	dbStats := s.db.MasterDB.DB().Stats()
+	stats["max_open_connections"] = strconv.Itoa(dbStats.MaxOpenConnections)
 	stats["open_connections"] = strconv.Itoa(dbStats.OpenConnections)
 	stats["in_use"] = strconv.Itoa(dbStats.InUse)
 	stats["idle"] = strconv.Itoa(dbStats.Idle)
@@ -105,11 +95,38 @@ func (s *service) Health() map[string]string {
 	return stats
 }
 
+// migrationVersion reports the latest version recorded by the migration
+// runner. The table is looked up first since it only exists once the runner
+// ran, and a missing table is not an error.
+func (s *service) migrationVersion(ctx context.Context) string {
+	db := s.db.MasterDB.DB()
+
+	var exists bool
+	err := db.QueryRowContext(ctx, "SELECT to_regclass($1) IS NOT NULL", migration.MigrationsTable).Scan(&exists)
+	if err != nil {
+		return unknownMigrationVersion
+	}
+	if !exists {
+		return noMigrationVersion
+	}
+
+	var version sql.NullInt64
+	err = db.QueryRowContext(ctx, "SELECT MAX(version) FROM "+migration.MigrationsTable).Scan(&version)
+	if err != nil {
+		return unknownMigrationVersion
+	}
+	if !version.Valid {
+		return noMigrationVersion
+	}
+	return strconv.FormatInt(version.Int64, 10)
+}
+
 // Close closes the database connection.
-// It logs a message indicating the disconnection from the specific database.
-// If the connection is successfully closed, it returns nil.
 // If an error occurs while closing the connection, it returns the error.
 func (s *service) Close() error {
-	log.Printf("Disconnected from database: %s", database)
-	return s.db.Close()
+	if s.db.ReadDB != nil {
+		// closes the master with the replicas
+		return s.db.ReadDB.Close()
+	}
+	return s.db.MasterDB.Close()
 }
@@ -2,10 +2,13 @@ package database
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"testing"
 	"time"
 
+	"github.com/faisalhardin/employee-payroll-system/pkg/migration"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -33,10 +36,6 @@ func mustStartPostgresContainer() (func(context.Context, ...testcontainers.Termi
 		return nil, err
 	}
 
-	database = dbName
-	password = dbPwd
-	username = dbUser
-
 	dbHost, err := dbContainer.Host(context.Background())
 	if err != nil {
 		return dbContainer.Terminate, err
@@ -47,12 +46,21 @@ func mustStartPostgresContainer() (func(context.Context, ...testcontainers.Termi
 		return dbContainer.Terminate, err
 	}
 
-	host = dbHost
-	port = dbPort.Port()
+	dsn = fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", dbUser, dbPwd, dbHost, dbPort.Port(), dbName)
 
 	return dbContainer.Terminate, err
 }
 
+var dsn string
+
+func newTestService(t *testing.T) Service {
+	db, err := xormlib.NewDBConnection(xormlib.Config{DSN: dsn}, nil, "")
+	if err != nil {
+		t.Fatalf("could not connect to postgres: %v", err)
+	}
+	return New(db)
+}
+
 func TestMain(m *testing.M) {
 	teardown, err := mustStartPostgresContainer()
 	if err != nil {
@@ -67,16 +75,16 @@ func TestMain(m *testing.M) {
 }
 
 func TestNew(t *testing.T) {
-	srv := New()
+	srv := newTestService(t)
 	if srv == nil {
 		t.Fatal("New() returned nil")
 	}
 }
 
 func TestHealth(t *testing.T) {
-	srv := New()
+	srv := newTestService(t)
 
-	stats := srv.Health()
+	stats := srv.Health(context.Background())
 
 	if stats["status"] != "up" {
 		t.Fatalf("expected status to be up, got %s", stats["status"])
@@ -89,10 +97,53 @@ func TestHealth(t *testing.T) {
 	if stats["message"] != "It's healthy" {
 		t.Fatalf("expected message to be 'It's healthy', got %s", stats["message"])
 	}
+
+	// the container has no migrations applied
+	if stats["migration_version"] != noMigrationVersion {
+		t.Fatalf("expected migration_version to be none, got %s", stats["migration_version"])
+	}
+}
+
+func TestHealthMigrationVersion(t *testing.T) {
+	srv := newTestService(t)
+	db := srv.(*service).db.MasterDB.DB()
+
+	_, err := db.Exec("CREATE TABLE " + migration.MigrationsTable + " (version BIGINT PRIMARY KEY, name VARCHAR(255) NOT NULL)")
+	if err != nil {
+		t.Fatalf("could not create the migration table: %v", err)
+	}
+	defer func() { _, _ = db.Exec("DROP TABLE " + migration.MigrationsTable) }()
+
+	if got := srv.Health(context.Background())["migration_version"]; got != noMigrationVersion {
+		t.Fatalf("expected migration_version to be none, got %s", got)
+	}
+
+	_, err = db.Exec("INSERT INTO " + migration.MigrationsTable + " (version, name) VALUES (1, 'init_users'), (21, 'allow_system_actor')")
+	if err != nil {
+		t.Fatalf("could not record migrations: %v", err)
+	}
+	if got := srv.Health(context.Background())["migration_version"]; got != "21" {
+		t.Fatalf("expected migration_version to be 21, got %s", got)
+	}
+}
+
+func TestHealthDown(t *testing.T) {
+	srv := newTestService(t)
+	if err := srv.Close(); err != nil {
+		t.Fatalf("expected Close() to return nil")
+	}
+
+	stats := srv.Health(context.Background())
+	if stats["status"] != StatusDown {
+		t.Fatalf("expected status to be down, got %s", stats["status"])
+	}
+	if stats["error"] == "" {
+		t.Fatalf("expected error to be present")
+	}
 }
 
 func TestClose(t *testing.T) {
-	srv := New()
+	srv := newTestService(t)
 
 	if srv.Close() != nil {
 		t.Fatalf("expected Close() to return nil")
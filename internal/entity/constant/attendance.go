@@ -0,0 +1,39 @@
+package constant
+
+// Work arrangements decide what happens to a tap in outside every office.
+// Office employees are refused, hybrid employees are let in but flagged and
+// remote employees are not checked.
+const (
+	WorkArrangementOffice = "office"
+	WorkArrangementHybrid = "hybrid"
+	WorkArrangementRemote = "remote"
+)
+
+// How the location of a tap in matched the offices. Outside and unknown are
+// the flagged ones, an empty status means fencing is off.
+const (
+	LocationStatusInside  = "inside"
+	LocationStatusOutside = "outside"
+	LocationStatusUnknown = "unknown"
+	LocationStatusRemote  = "remote"
+)
+
+// How an attendance was recorded
+const (
+	AttendanceSourceSelf       = "self"
+	AttendanceSourceKioskQR    = "kiosk_qr"
+	AttendanceSourceKioskBadge = "kiosk_badge"
+	AttendanceSourceImport     = "import"
+	AttendanceSourceCorrection = "correction"
+)
+
+// Attendance corrections add a missed day or remove a wrong one. Requests of
+// employees stay pending until reviewed, those of admins are approved at once.
+const (
+	AttendanceCorrectionAdd    = "add"
+	AttendanceCorrectionRemove = "remove"
+
+	AttendanceCorrectionStatusPending  = "pending"
+	AttendanceCorrectionStatusApproved = "approved"
+	AttendanceCorrectionStatusRejected = "rejected"
+)
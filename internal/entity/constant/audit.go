@@ -0,0 +1,15 @@
+package constant
+
+const (
+	AuditActionCreate              = "create"
+	AuditActionUpdate              = "update"
+	AuditActionDelete              = "delete"
+	AuditActionAssignPayrollPeriod = "assign_payroll_period"
+	AuditActionResetPayrollPeriod  = "reset_payroll_period"
+	AuditActionUnlock              = "unlock"
+	AuditActionResetDevice         = "reset_device"
+	AuditActionDeactivate          = "deactivate"
+	AuditActionSetManager          = "set_manager"
+	AuditActionSetRoster           = "set_roster"
+	AuditActionSetPayrollPolicy    = "set_payroll_policy"
+)
@@ -0,0 +1,8 @@
+package constant
+
+// Reasons a sign in attempt failed
+const (
+	LoginFailureUnknownUser     = "unknown_user"
+	LoginFailureInvalidPassword = "invalid_password"
+	LoginFailureLocked          = "locked"
+)
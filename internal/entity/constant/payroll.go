@@ -0,0 +1,16 @@
+package constant
+
+const (
+	PayrollPeriodStatusOpen      = "open"
+	PayrollPeriodStatusProcessed = "processed"
+
+	PayrollJobStatusQueued    = "queued"
+	PayrollJobStatusRunning   = "running"
+	PayrollJobStatusCompleted = "completed"
+	PayrollJobStatusFailed    = "failed"
+
+	LabourCostGroupByDepartment = "department"
+	LabourCostGroupByCostCentre = "cost_centre"
+
+	LabourCostFormatCSV = "csv"
+)
@@ -0,0 +1,16 @@
+package constant
+
+const (
+	SchedulerJobPayrollPeriod = "payroll_period_schedule"
+
+	// SystemActorScheduler names the scheduler in the audit log, it acts for
+	// every tenant without being one of their users
+	SystemActorScheduler = "system:scheduler"
+
+	SchedulerRunStatusSuccess = "success"
+	SchedulerRunStatusPartial = "partial"
+	SchedulerRunStatusFailed  = "failed"
+
+	ScheduleFrequencyMonthly     = "monthly"
+	ScheduleFrequencySemiMonthly = "semi_monthly"
+)
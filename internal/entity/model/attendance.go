@@ -2,12 +2,14 @@ package model
 
 import (
 	"database/sql"
+	"io"
 	"time"
 )
 
 // MstAttendance represents employee attendance records
 type MstAttendance struct {
 	ID                 int64         `json:"id" xorm:"'id' pk autoincr"`
+	IDMstTenant        int64         `json:"-" xorm:"id_mst_tenant"`
 	IDMstUser          int64         `json:"user_id" xorm:"id_mst_user"`
 	AttendanceDate     time.Time     `json:"attendance_date" xorm:"attendance_date"`
 	IDMstPayrollPeriod sql.NullInt64 `json:"payroll_period_id" xorm:"id_mst_payroll_period"`
@@ -15,6 +17,19 @@ type MstAttendance struct {
 	UpdatedAt          time.Time     `json:"updated_at" xorm:"'updated_at' updated"`
 	CreatedBy          sql.NullInt64 `json:"created_by,omitempty" xorm:"created_by"`
 	UpdatedBy          sql.NullInt64 `json:"updated_by,omitempty" xorm:"updated_by"`
+	// Latitude, Longitude and DeviceID are what the employee tapped in with.
+	// OfficeName and DistanceInMeters point at the nearest office and
+	// LocationStatus tells how the tap in matched the offices.
+	Latitude         sql.NullFloat64 `json:"latitude" xorm:"latitude"`
+	Longitude        sql.NullFloat64 `json:"longitude" xorm:"longitude"`
+	DeviceID         string          `json:"device_id,omitempty" xorm:"device_id"`
+	OfficeName       string          `json:"office_name,omitempty" xorm:"office_name"`
+	DistanceInMeters sql.NullInt64   `json:"distance_in_meters" xorm:"distance_in_meters"`
+	LocationStatus   string          `json:"location_status,omitempty" xorm:"location_status"`
+	// Source is one of constant.AttendanceSource*, IDMstKiosk the kiosk a
+	// kiosk tap in was made at
+	Source     string        `json:"source" xorm:"source"`
+	IDMstKiosk sql.NullInt64 `json:"kiosk_id" xorm:"id_mst_kiosk"`
 }
 
 type ListAttendanceParams struct {
@@ -23,15 +38,95 @@ type ListAttendanceParams struct {
 	StartDate              time.Time `xorm:"start_date"`
 	EndDate                time.Time `xorm:"end_date"`
 	IsForGeneratingPayroll bool      `xorm:"is_for_generating_payroll"`
+	Cursor                 int64     `xorm:"-"`
+	Limit                  int       `xorm:"-"`
+}
+
+// TapInRequest carries where and from which device the employee taps in.
+// Both coordinates are optional but go together.
+type TapInRequest struct {
+	Latitude  *float64 `json:"latitude" validate:"omitempty,latitude"`
+	Longitude *float64 `json:"longitude" validate:"omitempty,longitude"`
+	DeviceID  string   `json:"device_id" validate:"omitempty,max=128"`
 }
 
 type TapInResponse struct {
 	AttendanceDate time.Time `json:"attendance_date" xorm:"attendance_date"`
+	LocationStatus string    `json:"location_status,omitempty"`
+	OfficeName     string    `json:"office_name,omitempty"`
+	// Flagged marks a tap in accepted from outside every office
+	Flagged bool `json:"flagged,omitempty"`
+	// Username is set for tap ins made at a kiosk on behalf of an employee
+	Username string `json:"username,omitempty"`
+}
+
+// KioskTapInRequest carries the nonce an employee scanned from a kiosk
+type KioskTapInRequest struct {
+	Nonce string `json:"nonce" validate:"required"`
+}
+
+// BadgeTapInRequest carries the badge a kiosk read from an employee
+type BadgeTapInRequest struct {
+	BadgeID string `json:"badge_id" validate:"required,max=64"`
+}
+
+// ImportAttendanceRequest is a log exported by a biometric device. With
+// Preview set nothing is recorded.
+type ImportAttendanceRequest struct {
+	File    io.Reader `schema:"-"`
+	Preview bool      `schema:"preview"`
+}
+
+// ImportAttendanceResponse reports every line of an import. Imported counts
+// the attendances recorded, or that would be recorded in a preview.
+type ImportAttendanceResponse struct {
+	Preview      bool                    `json:"preview"`
+	TotalLines   int                     `json:"total_lines"`
+	Imported     int                     `json:"imported"`
+	Skipped      int                     `json:"skipped"`
+	Failed       int                     `json:"failed"`
+	Attendances  []ImportedAttendance    `json:"attendances"`
+	SkippedLines []ImportAttendanceIssue `json:"skipped_lines"`
+	Errors       []ImportAttendanceIssue `json:"errors"`
+}
+
+type ImportedAttendance struct {
+	Line           int       `json:"line"`
+	BadgeID        string    `json:"badge_id"`
+	Username       string    `json:"username"`
+	AttendanceDate time.Time `json:"attendance_date"`
+}
+
+// ImportAttendanceIssue explains why a line was skipped or failed
+type ImportAttendanceIssue struct {
+	Line    int    `json:"line"`
+	BadgeID string `json:"badge_id,omitempty"`
+	Message string `json:"message"`
+}
+
+type ListMyAttendanceRequest struct {
+	CursorPagination
+	StartDate string `schema:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate   string `schema:"end_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type MyAttendanceResponse struct {
+	ID              int64  `json:"id"`
+	AttendanceDate  string `json:"attendance_date"`
+	PayrollPeriodID int64  `json:"payroll_period_id,omitempty"`
+	LocationStatus  string `json:"location_status,omitempty"`
+	Source          string `json:"source,omitempty"`
+}
+
+type ListMyAttendanceResponse struct {
+	Attendances []MyAttendanceResponse `json:"attendances"`
+	NextCursor  int64                  `json:"next_cursor,omitempty"`
 }
 
 // MstPayrollPeriod represents payroll periods
 type MstPayrollPeriod struct {
 	ID                   int64         `json:"id" xorm:"'id' pk autoincr"`
+	IDMstTenant          int64         `json:"-" xorm:"id_mst_tenant"`
 	StartDate            time.Time     `json:"start_date" xorm:"start_date"`
 	EndDate              time.Time     `json:"end_date" xorm:"end_date"`
 	PayrollProcessedDate sql.NullTime  `json:"payroll_processed_date" xorm:"payroll_processed_date"`
@@ -42,9 +137,28 @@ type MstPayrollPeriod struct {
 }
 
 type PayrollPeriodResponse struct {
-	ID        int64     `json:"id" xorm:"id"`
-	StartDate time.Time `json:"start_date" xorm:"start_date"`
-	EndDate   time.Time `json:"end_date" xorm:"end_date"`
+	ID                   int64      `json:"id" xorm:"id"`
+	StartDate            time.Time  `json:"start_date" xorm:"start_date"`
+	EndDate              time.Time  `json:"end_date" xorm:"end_date"`
+	Status               string     `json:"status,omitempty" xorm:"-"`
+	PayrollProcessedDate *time.Time `json:"payroll_processed_date,omitempty" xorm:"-"`
+}
+
+type ListPayrollPeriodParams struct {
+	IDs    []int64
+	Status string
+	Cursor int64
+	Limit  int
+}
+
+type ListPayrollPeriodRequest struct {
+	CursorPagination
+	Status string `schema:"status" validate:"omitempty,oneof=open processed"`
+}
+
+type ListPayrollPeriodResponse struct {
+	PayrollPeriods []PayrollPeriodResponse `json:"payroll_periods"`
+	NextCursor     int64                   `json:"next_cursor,omitempty"`
 }
 
 type PayrollPeriodRequest struct {
@@ -55,6 +169,7 @@ type PayrollPeriodRequest struct {
 // TrxOvertime represents overtime submissions
 type TrxOvertime struct {
 	ID                 int64         `xorm:"'id' pk autoincr"`
+	IDMstTenant        int64         `xorm:"id_mst_tenant"`
 	UserID             int64         `xorm:"id_mst_user"`
 	IDMstPayrollPeriod sql.NullInt64 `xorm:"id_mst_payroll_period"`
 	OvertimeDate       time.Time     `xorm:"overtime_date"`
@@ -81,6 +196,29 @@ type ListOvertimeParams struct {
 	UserIDs                []int64
 	IDMstPayrollPeriod     int64
 	IsForGeneratingPayroll bool
+	IsProcessed            bool
+	Cursor                 int64
+	Limit                  int
+}
+
+type ListMyOvertimeRequest struct {
+	CursorPagination
+	StartDate string `schema:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate   string `schema:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	Status    string `schema:"status" validate:"omitempty,oneof=pending processed"`
+}
+
+type MyOvertimeResponse struct {
+	ID              int64     `json:"id"`
+	OvertimeDate    time.Time `json:"overtime_date"`
+	Hours           int       `json:"hours"`
+	PayrollPeriodID int64     `json:"payroll_period_id,omitempty"`
+	Status          string    `json:"status"`
+}
+
+type ListMyOvertimeResponse struct {
+	Overtimes  []MyOvertimeResponse `json:"overtimes"`
+	NextCursor int64                `json:"next_cursor,omitempty"`
 }
 
 type SubmitOvertimeResponse struct {
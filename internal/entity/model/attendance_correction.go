@@ -0,0 +1,80 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// TrxAttendanceCorrection adds or removes the attendance of an employee on a
+// day. IDMstAttendance is the attendance added or removed once approved.
+type TrxAttendanceCorrection struct {
+	ID              int64         `json:"id" xorm:"'id' pk autoincr"`
+	IDMstTenant     int64         `json:"-" xorm:"id_mst_tenant"`
+	IDMstUser       int64         `json:"user_id" xorm:"id_mst_user"`
+	AttendanceDate  time.Time     `json:"attendance_date" xorm:"attendance_date"`
+	Action          string        `json:"action" xorm:"action"`
+	Reason          string        `json:"reason" xorm:"reason"`
+	Status          string        `json:"status" xorm:"status"`
+	IDMstAttendance sql.NullInt64 `json:"attendance_id" xorm:"id_mst_attendance"`
+	ReviewedBy      sql.NullInt64 `json:"reviewed_by" xorm:"reviewed_by"`
+	ReviewNote      string        `json:"review_note" xorm:"review_note"`
+	ReviewedAt      sql.NullTime  `json:"reviewed_at" xorm:"reviewed_at"`
+	CreatedAt       time.Time     `json:"created_at" xorm:"'created_at' created"`
+	UpdatedAt       time.Time     `json:"updated_at" xorm:"'updated_at' updated"`
+	CreatedBy       sql.NullInt64 `json:"created_by,omitempty" xorm:"created_by"`
+	UpdatedBy       sql.NullInt64 `json:"updated_by,omitempty" xorm:"updated_by"`
+}
+
+type ListAttendanceCorrectionParams struct {
+	IDsMstUser     []int64
+	AttendanceDate time.Time
+	Status         string
+	// IDMstManager keeps the corrections of the employees reporting to the
+	// manager
+	IDMstManager int64
+	Cursor       int64
+	Limit        int
+}
+
+// AttendanceCorrectionRequest asks for a missed day to be added
+type AttendanceCorrectionRequest struct {
+	AttendanceDate string `json:"attendance_date" validate:"required,datetime=2006-01-02"`
+	Reason         string `json:"reason" validate:"required,max=500"`
+}
+
+// AdjustAttendanceRequest adds or removes attendance directly, admin only
+type AdjustAttendanceRequest struct {
+	UserID         int64  `json:"user_id" validate:"required"`
+	AttendanceDate string `json:"attendance_date" validate:"required,datetime=2006-01-02"`
+	Action         string `json:"action" validate:"required,oneof=add remove"`
+	Reason         string `json:"reason" validate:"required,max=500"`
+}
+
+type ReviewAttendanceCorrectionRequest struct {
+	ID   int64  `json:"-"`
+	Note string `json:"note" validate:"max=500"`
+}
+
+type ListAttendanceCorrectionRequest struct {
+	CursorPagination
+	Status string `schema:"status" validate:"omitempty,oneof=pending approved rejected"`
+}
+
+type AttendanceCorrectionResponse struct {
+	ID             int64      `json:"id"`
+	UserID         int64      `json:"user_id"`
+	AttendanceDate string     `json:"attendance_date"`
+	Action         string     `json:"action"`
+	Reason         string     `json:"reason"`
+	Status         string     `json:"status"`
+	AttendanceID   int64      `json:"attendance_id,omitempty"`
+	ReviewedBy     int64      `json:"reviewed_by,omitempty"`
+	ReviewNote     string     `json:"review_note,omitempty"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+	RequestedAt    time.Time  `json:"requested_at"`
+}
+
+type ListAttendanceCorrectionResponse struct {
+	Corrections []AttendanceCorrectionResponse `json:"corrections"`
+	NextCursor  int64                          `json:"next_cursor,omitempty"`
+}
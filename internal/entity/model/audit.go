@@ -0,0 +1,68 @@
+package model
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// TrxAuditLog is one append-only entry of the audit trail. Before and After
+// hold the JSON of the row around the change.
+type TrxAuditLog struct {
+	ID            int64          `xorm:"'id' pk autoincr"`
+	IDMstTenant   sql.NullInt64  `xorm:"id_mst_tenant"`
+	ActorID       sql.NullInt64  `xorm:"actor_id"`
+	ActorUsername string         `xorm:"actor_username"`
+	Action        string         `xorm:"action"`
+	Entity        string         `xorm:"entity"`
+	EntityID      sql.NullInt64  `xorm:"entity_id"`
+	Before        sql.NullString `xorm:"before"`
+	After         sql.NullString `xorm:"after"`
+	RequestID     string         `xorm:"request_id"`
+	IPAddress     string         `xorm:"ip_address"`
+	CreatedAt     time.Time      `xorm:"'created_at' created"`
+}
+
+// ListAuditLogParams filters the audit trail. CreatedFrom is inclusive and
+// CreatedBefore exclusive.
+type ListAuditLogParams struct {
+	ActorID       int64
+	Action        string
+	Entity        string
+	EntityID      int64
+	RequestID     string
+	CreatedFrom   time.Time
+	CreatedBefore time.Time
+	Cursor        int64
+	Limit         int
+}
+
+type ListAuditLogRequest struct {
+	CursorPagination
+	ActorID   int64  `schema:"actor_id"`
+	Action    string `schema:"action"`
+	Entity    string `schema:"entity"`
+	EntityID  int64  `schema:"entity_id"`
+	RequestID string `schema:"request_id"`
+	StartDate string `schema:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate   string `schema:"end_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type AuditLogResponse struct {
+	ID            int64           `json:"id"`
+	ActorID       int64           `json:"actor_id,omitempty"`
+	ActorUsername string          `json:"actor_username,omitempty"`
+	Action        string          `json:"action"`
+	Entity        string          `json:"entity"`
+	EntityID      int64           `json:"entity_id,omitempty"`
+	Before        json.RawMessage `json:"before,omitempty"`
+	After         json.RawMessage `json:"after,omitempty"`
+	RequestID     string          `json:"request_id,omitempty"`
+	IPAddress     string          `json:"ip_address,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+type ListAuditLogResponse struct {
+	AuditLogs  []AuditLogResponse `json:"audit_logs"`
+	NextCursor int64              `json:"next_cursor,omitempty"`
+}
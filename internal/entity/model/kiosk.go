@@ -0,0 +1,56 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// MstKiosk is a shared device employees tap in at. Only the SHA-256 of its
+// secret is kept.
+type MstKiosk struct {
+	ID          int64         `json:"id" xorm:"'id' pk autoincr"`
+	IDMstTenant int64         `json:"-" xorm:"id_mst_tenant"`
+	Name        string        `json:"name" xorm:"name"`
+	OfficeName  string        `json:"office_name,omitempty" xorm:"office_name"`
+	SecretHash  string        `json:"-" xorm:"secret_hash"`
+	Active      bool          `json:"active" xorm:"active"`
+	CreatedAt   time.Time     `json:"created_at" xorm:"'created_at' created"`
+	UpdatedAt   time.Time     `json:"updated_at" xorm:"'updated_at' updated"`
+	CreatedBy   sql.NullInt64 `json:"created_by,omitempty" xorm:"created_by"`
+	UpdatedBy   sql.NullInt64 `json:"updated_by,omitempty" xorm:"updated_by"`
+}
+
+// TrxKioskNonce is a nonce that was used to tap in, kept until ExpiresAt so it
+// is not accepted again
+type TrxKioskNonce struct {
+	ID          string    `xorm:"'id' pk"`
+	IDMstTenant int64     `xorm:"id_mst_tenant"`
+	IDMstKiosk  int64     `xorm:"id_mst_kiosk"`
+	IDMstUser   int64     `xorm:"id_mst_user"`
+	ExpiresAt   time.Time `xorm:"expires_at"`
+}
+
+type RegisterKioskRequest struct {
+	Name       string `json:"name" validate:"required,max=100"`
+	OfficeName string `json:"office_name" validate:"max=100"`
+}
+
+// RegisterKioskResponse is the only time the secret is shown
+type RegisterKioskResponse struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	OfficeName string `json:"office_name,omitempty"`
+	Secret     string `json:"secret"`
+}
+
+type KioskSignInRequest struct {
+	ID     int64  `json:"id" validate:"required"`
+	Secret string `json:"secret" validate:"required"`
+}
+
+// KioskNonceResponse is the nonce a kiosk shows as a QR code until
+// ExpiresAt
+type KioskNonceResponse struct {
+	Nonce     string    `json:"nonce"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,127 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+type MstDepartment struct {
+	ID          int64         `json:"id" xorm:"'id' pk autoincr"`
+	IDMstTenant int64         `json:"-" xorm:"id_mst_tenant"`
+	Code        string        `json:"code" xorm:"code"`
+	Name        string        `json:"name" xorm:"name"`
+	CreatedAt   time.Time     `json:"created_at" xorm:"'created_at' created"`
+	UpdatedAt   time.Time     `json:"updated_at" xorm:"'updated_at' updated"`
+	CreatedBy   sql.NullInt64 `json:"created_by,omitempty" xorm:"created_by"`
+	UpdatedBy   sql.NullInt64 `json:"updated_by,omitempty" xorm:"updated_by"`
+}
+
+type MstCostCentre struct {
+	ID          int64         `json:"id" xorm:"'id' pk autoincr"`
+	IDMstTenant int64         `json:"-" xorm:"id_mst_tenant"`
+	Code        string        `json:"code" xorm:"code"`
+	Name        string        `json:"name" xorm:"name"`
+	CreatedAt   time.Time     `json:"created_at" xorm:"'created_at' created"`
+	UpdatedAt   time.Time     `json:"updated_at" xorm:"'updated_at' updated"`
+	CreatedBy   sql.NullInt64 `json:"created_by,omitempty" xorm:"created_by"`
+	UpdatedBy   sql.NullInt64 `json:"updated_by,omitempty" xorm:"updated_by"`
+}
+
+// TrxUserAssignment puts an employee in a department and a cost centre from
+// StartDate, until EndDate when set
+type TrxUserAssignment struct {
+	ID              int64         `json:"id" xorm:"'id' pk autoincr"`
+	IDMstTenant     int64         `json:"-" xorm:"id_mst_tenant"`
+	IDMstUser       int64         `json:"user_id" xorm:"id_mst_user"`
+	IDMstDepartment int64         `json:"department_id" xorm:"id_mst_department"`
+	IDMstCostCentre int64         `json:"cost_centre_id" xorm:"id_mst_cost_centre"`
+	StartDate       time.Time     `json:"start_date" xorm:"start_date"`
+	EndDate         sql.NullTime  `json:"end_date" xorm:"end_date"`
+	CreatedAt       time.Time     `json:"created_at" xorm:"'created_at' created"`
+	UpdatedAt       time.Time     `json:"updated_at" xorm:"'updated_at' updated"`
+	CreatedBy       sql.NullInt64 `json:"created_by,omitempty" xorm:"created_by"`
+	UpdatedBy       sql.NullInt64 `json:"updated_by,omitempty" xorm:"updated_by"`
+}
+
+type DepartmentRequest struct {
+	Code string `json:"code" validate:"required,max=20"`
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type CostCentreRequest struct {
+	Code string `json:"code" validate:"required,max=20"`
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// AssignOrganizationRequest moves an employee to a department and a cost
+// centre from StartDate
+type AssignOrganizationRequest struct {
+	UserID       int64  `json:"user_id" validate:"required"`
+	DepartmentID int64  `json:"department_id" validate:"required"`
+	CostCentreID int64  `json:"cost_centre_id" validate:"required"`
+	StartDate    string `json:"start_date" validate:"required,datetime=2006-01-02"`
+}
+
+// AssignmentResponse is an assignment of an employee with the names of its
+// department and cost centre
+type AssignmentResponse struct {
+	ID             int64        `json:"id"`
+	UserID         int64        `json:"user_id"`
+	DepartmentID   int64        `json:"department_id"`
+	DepartmentName string       `json:"department_name"`
+	CostCentreID   int64        `json:"cost_centre_id"`
+	CostCentreName string       `json:"cost_centre_name"`
+	StartDate      time.Time    `json:"start_date"`
+	EndDate        sql.NullTime `json:"end_date"`
+}
+
+// LabourCostParams sums the payslips of the periods lying between StartDate
+// and EndDate by department or by cost centre
+type LabourCostParams struct {
+	GroupBy   string
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// LabourCostRow is the labour cost of an employee within one group. Employees
+// without an assignment fall in the group with GroupID 0.
+type LabourCostRow struct {
+	GroupID             int64  `xorm:"group_id"`
+	GroupCode           string `xorm:"group_code"`
+	GroupName           string `xorm:"group_name"`
+	IDMstUser           int64  `xorm:"id_mst_user"`
+	Salary              int64  `xorm:"salary"`
+	OvertimeHours       int    `xorm:"overtime_hours"`
+	OvertimePay         int64  `xorm:"overtime_pay"`
+	TotalReimbursements int64  `xorm:"total_reimbursements"`
+	TotalCost           int64  `xorm:"total_cost"`
+}
+
+// LabourCostRequest reports one period, or every period from StartPeriodID
+// to EndPeriodID
+type LabourCostRequest struct {
+	GroupBy       string `schema:"group_by" validate:"required,oneof=department cost_centre"`
+	StartPeriodID int64  `schema:"start_period_id" validate:"required"`
+	EndPeriodID   int64  `schema:"end_period_id"`
+	Format        string `schema:"format" validate:"omitempty,oneof=json csv"`
+}
+
+type LabourCostGroupResponse struct {
+	ID                  int64  `json:"id"`
+	Code                string `json:"code"`
+	Name                string `json:"name"`
+	Headcount           int    `json:"headcount"`
+	Salary              int64  `json:"salary"`
+	OvertimeHours       int    `json:"overtime_hours"`
+	OvertimePay         int64  `json:"overtime_pay"`
+	TotalReimbursements int64  `json:"total_reimbursements"`
+	TotalCost           int64  `json:"total_cost"`
+}
+
+type LabourCostResponse struct {
+	GroupBy   string                    `json:"group_by"`
+	StartDate time.Time                 `json:"start_date"`
+	EndDate   time.Time                 `json:"end_date"`
+	Groups    []LabourCostGroupResponse `json:"groups"`
+	Total     LabourCostGroupResponse   `json:"total"`
+}
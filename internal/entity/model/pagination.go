@@ -0,0 +1,24 @@
+package model
+
+const (
+	DefaultPaginationLimit = 20
+	MaxPaginationLimit     = 100
+)
+
+// CursorPagination is the keyset pagination query shared by list endpoints.
+// Cursor is the last id returned by the previous page.
+type CursorPagination struct {
+	Cursor int64 `schema:"cursor" json:"cursor"`
+	Limit  int   `schema:"limit" json:"limit" validate:"omitempty,gt=0,lte=100"`
+}
+
+// GetLimit returns the requested page size, falling back to the default
+func (p CursorPagination) GetLimit() int {
+	if p.Limit <= 0 {
+		return DefaultPaginationLimit
+	}
+	if p.Limit > MaxPaginationLimit {
+		return MaxPaginationLimit
+	}
+	return p.Limit
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// TrxPayrollJob tracks a payroll generation running in the background
+type TrxPayrollJob struct {
+	ID                 int64        `xorm:"'id' pk autoincr"`
+	IDMstTenant        int64        `xorm:"id_mst_tenant"`
+	IDMstPayrollPeriod int64        `xorm:"id_mst_payroll_period"`
+	Status             string       `xorm:"status"`
+	Progress           int          `xorm:"progress"`
+	Stage              string       `xorm:"stage"`
+	ErrorMessage       string       `xorm:"error_message"`
+	Attempts           int          `xorm:"attempts"`
+	StartedAt          sql.NullTime `xorm:"started_at"`
+	FinishedAt         sql.NullTime `xorm:"finished_at"`
+	CreatedAt          time.Time    `xorm:"'created_at' created"`
+	UpdatedAt          time.Time    `xorm:"'updated_at' updated"`
+	// CreatedBy is empty for jobs queued by the scheduler
+	CreatedBy sql.NullInt64 `xorm:"created_by"`
+}
+
+type ListPayrollJobParams struct {
+	IDMstPayrollPeriod int64
+	Statuses           []string
+}
+
+type PayrollJobResponse struct {
+	ID              int64      `json:"id"`
+	PayrollPeriodID int64      `json:"payroll_period_id"`
+	Status          string     `json:"status"`
+	Progress        int        `json:"progress"`
+	Stage           string     `json:"stage,omitempty"`
+	Error           string     `json:"error,omitempty"`
+	Attempts        int        `json:"attempts"`
+	CreatedAt       time.Time  `json:"created_at"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+}
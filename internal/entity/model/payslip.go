@@ -8,11 +8,13 @@ import (
 // TrxUserPayslip represents payslip data for employees
 type TrxUserPayslip struct {
 	ID                  int64         `xorm:"'id' pk autoincr" json:"-"`
+	IDMstTenant         int64         `xorm:"id_mst_tenant" json:"-"`
 	UserID              int64         `xorm:"id_mst_user" json:"-"`
 	Username            string        `xorm:"username" json:"username"`
 	IDMstPayrollPeriod  int64         `xorm:"id_mst_payroll_period" json:"payrol_period_id"`
 	BaseSalary          int64         `xorm:"base_salary" json:"base_salary"`
 	WorkingDays         int           `xorm:"working_days" json:"working_days"`
+	WorkingHours        int           `xorm:"working_hours" json:"working_hours"`
 	AttendedDays        int           `xorm:"attended_days" json:"attended_days"`
 	ProratedSalary      int64         `xorm:"prorated_salary" json:"prorated_salary"`
 	OvertimeHours       int           `xorm:"overtime_hours" json:"overtime_hours"`
@@ -43,11 +45,12 @@ type GetPayrollResponse struct {
 // DtlPayroll represents summary for companywide payroll
 type DtlPayroll struct {
 	ID                 int64         `xorm:"'id' pk autoincr"`
+	IDMstTenant        int64         `xorm:"id_mst_tenant"`
 	IDMstPayrollPeriod int64         `xorm:"id_mst_payroll_period"`
 	TotalTakeHome      int64         `xorm:"total_take_home"`
 	CreatedAt          time.Time     `xorm:"created_at"`
 	UpdatedAt          time.Time     `xorm:"updated_at"`
-	CreatedBy          int64         `xorm:"created_by"`
+	CreatedBy          sql.NullInt64 `xorm:"created_by"`
 	UpdatedBy          sql.NullInt64 `xorm:"updated_by"`
 }
 
@@ -61,6 +64,7 @@ type GetPayslipResponse struct {
 	TotalTakeHomePay    int64                         `json:"total_take_home_pay"`
 	AttendanceDate      []string                      `json:"attendance_date"`
 	WorkingDays         int                           `json:"working_days"`
+	WorkingHours        int                           `json:"working_hours"`
 	AttendedDays        int                           `json:"attended_days"`
 	ProratedSalary      int64                         `json:"prorated_salary"`
 	OvertimeHours       int                           `json:"overtime_hours"`
@@ -72,5 +76,41 @@ type GetPayslipResponse struct {
 
 type GetPayslipRequest struct {
 	IDMstPayrollPeriod int64 `schema:"payroll_period_id"`
-	UserID             int64 `json:"-"`
+	UserID             int64 `json:"-" schema:"-"`
+	Cursor             int64 `json:"-" schema:"-"`
+	Limit              int   `json:"-" schema:"-"`
+}
+
+type ListMyPayslipRequest struct {
+	CursorPagination
+}
+
+type MyPayslipResponse struct {
+	ID                  int64     `json:"id"`
+	PayrollPeriodID     int64     `json:"payroll_period_id"`
+	StartDate           time.Time `json:"start_date"`
+	EndDate             time.Time `json:"end_date"`
+	WorkingDays         int       `json:"working_days"`
+	WorkingHours        int       `json:"working_hours"`
+	AttendedDays        int       `json:"attended_days"`
+	ProratedSalary      int64     `json:"prorated_salary"`
+	OvertimeHours       int       `json:"overtime_hours"`
+	OvertimePay         int64     `json:"overtime_pay"`
+	TotalReimbursements int64     `json:"total_reimbursements"`
+	TotalTakeHomePay    int64     `json:"total_take_home_pay"`
+}
+
+type ListMyPayslipResponse struct {
+	Payslips   []MyPayslipResponse `json:"payslips"`
+	NextCursor int64               `json:"next_cursor,omitempty"`
+}
+
+// AssignPayrollPeriodParams tags a set of attendance, overtime or
+// reimbursement rows with the payroll period that paid them
+type AssignPayrollPeriodParams struct {
+	IDs                []int64       `json:"ids"`
+	IDMstPayrollPeriod int64         `json:"id_mst_payroll_period"`
+	UpdatedBy          sql.NullInt64 `json:"updated_by"`
+	// Status is only applied to reimbursements
+	Status string `json:"status,omitempty"`
 }
@@ -8,6 +8,7 @@ import (
 // TrxReimbursement represents reimbursement requests
 type TrxReimbursement struct {
 	ID                 int64         `xorm:"'id' pk autoincr"`
+	IDMstTenant        int64         `xorm:"id_mst_tenant"`
 	UserID             int64         `xorm:"'id_mst_user'"`
 	IDMstPayrollPeriod sql.NullInt64 `xorm:"id_mst_payroll_period"`
 	Status             string        `xorm:"'status'"`
@@ -37,4 +38,25 @@ type ListReimbursementParams struct {
 	StartDate          time.Time `json:"start_date"`
 	EndDate            time.Time `json:"end_date"`
 	Status             string    `json:"status"`
+	Cursor             int64     `json:"-"`
+	Limit              int       `json:"-"`
+}
+
+type ListMyReimbursementRequest struct {
+	CursorPagination
+	Status string `schema:"status" validate:"omitempty,oneof=pending paid"`
+}
+
+type MyReimbursementResponse struct {
+	ID              int64     `json:"id"`
+	Amount          int64     `json:"amount"`
+	Status          string    `json:"status"`
+	Description     string    `json:"description"`
+	PayrollPeriodID int64     `json:"payroll_period_id,omitempty"`
+	SubmittedAt     time.Time `json:"submitted_at"`
+}
+
+type ListMyReimbursementResponse struct {
+	Reimbursements []MyReimbursementResponse `json:"reimbursements"`
+	NextCursor     int64                     `json:"next_cursor,omitempty"`
 }
@@ -0,0 +1,142 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// MstShift is a working shift. A shift ending before it starts runs past
+// midnight and belongs to the day it starts.
+type MstShift struct {
+	ID           int64         `json:"id" xorm:"'id' pk autoincr"`
+	IDMstTenant  int64         `json:"-" xorm:"id_mst_tenant"`
+	Name         string        `json:"name" xorm:"name"`
+	StartTime    string        `json:"start_time" xorm:"start_time"`
+	EndTime      string        `json:"end_time" xorm:"end_time"`
+	WorkingHours int           `json:"working_hours" xorm:"working_hours"`
+	CreatedAt    time.Time     `json:"created_at" xorm:"'created_at' created"`
+	UpdatedAt    time.Time     `json:"updated_at" xorm:"'updated_at' updated"`
+	CreatedBy    sql.NullInt64 `json:"created_by,omitempty" xorm:"created_by"`
+	UpdatedBy    sql.NullInt64 `json:"updated_by,omitempty" xorm:"updated_by"`
+}
+
+// MstWorkPattern repeats every CycleDays days from the start of the schedule
+// using it
+type MstWorkPattern struct {
+	ID          int64         `json:"id" xorm:"'id' pk autoincr"`
+	IDMstTenant int64         `json:"-" xorm:"id_mst_tenant"`
+	Name        string        `json:"name" xorm:"name"`
+	CycleDays   int           `json:"cycle_days" xorm:"cycle_days"`
+	CreatedAt   time.Time     `json:"created_at" xorm:"'created_at' created"`
+	UpdatedAt   time.Time     `json:"updated_at" xorm:"'updated_at' updated"`
+	CreatedBy   sql.NullInt64 `json:"created_by,omitempty" xorm:"created_by"`
+	UpdatedBy   sql.NullInt64 `json:"updated_by,omitempty" xorm:"updated_by"`
+}
+
+// DtlWorkPatternDay is a working day of a pattern, DayNumber counting from 0.
+// Days of the cycle without one are off.
+type DtlWorkPatternDay struct {
+	ID               int64 `xorm:"'id' pk autoincr"`
+	IDMstWorkPattern int64 `xorm:"id_mst_work_pattern"`
+	DayNumber        int   `xorm:"day_number"`
+	IDMstShift       int64 `xorm:"id_mst_shift"`
+}
+
+// TrxUserSchedule follows a pattern from StartDate, until EndDate when set
+type TrxUserSchedule struct {
+	ID               int64         `json:"id" xorm:"'id' pk autoincr"`
+	IDMstTenant      int64         `json:"-" xorm:"id_mst_tenant"`
+	IDMstUser        int64         `json:"user_id" xorm:"id_mst_user"`
+	IDMstWorkPattern int64         `json:"work_pattern_id" xorm:"id_mst_work_pattern"`
+	StartDate        time.Time     `json:"start_date" xorm:"start_date"`
+	EndDate          sql.NullTime  `json:"end_date" xorm:"end_date"`
+	CreatedAt        time.Time     `json:"created_at" xorm:"'created_at' created"`
+	UpdatedAt        time.Time     `json:"updated_at" xorm:"'updated_at' updated"`
+	CreatedBy        sql.NullInt64 `json:"created_by,omitempty" xorm:"created_by"`
+	UpdatedBy        sql.NullInt64 `json:"updated_by,omitempty" xorm:"updated_by"`
+}
+
+// TrxUserRoster overrides the pattern of an employee on one day, a null
+// IDMstShift is a day off
+type TrxUserRoster struct {
+	ID          int64         `xorm:"'id' pk autoincr"`
+	IDMstTenant int64         `xorm:"id_mst_tenant"`
+	IDMstUser   int64         `xorm:"id_mst_user"`
+	RosterDate  time.Time     `xorm:"roster_date"`
+	IDMstShift  sql.NullInt64 `xorm:"id_mst_shift"`
+	CreatedAt   time.Time     `xorm:"'created_at' created"`
+	UpdatedAt   time.Time     `xorm:"'updated_at' updated"`
+	CreatedBy   sql.NullInt64 `xorm:"created_by"`
+	UpdatedBy   sql.NullInt64 `xorm:"updated_by"`
+}
+
+// ListScheduleParams keeps the schedules and roster days of the employees
+// between StartDate and EndDate, every employee when IDsMstUser is empty
+type ListScheduleParams struct {
+	IDsMstUser []int64
+	StartDate  time.Time
+	EndDate    time.Time
+}
+
+type ShiftRequest struct {
+	Name         string `json:"name" validate:"required,max=100"`
+	StartTime    string `json:"start_time" validate:"required,datetime=15:04"`
+	EndTime      string `json:"end_time" validate:"required,datetime=15:04"`
+	WorkingHours int    `json:"working_hours" validate:"required,gt=0,lte=24"`
+}
+
+// WorkPatternRequest lists the shift of every day of the cycle, 0 for a day
+// off
+type WorkPatternRequest struct {
+	Name   string  `json:"name" validate:"required,max=100"`
+	Shifts []int64 `json:"shifts" validate:"required,min=1,max=56,dive,gte=0"`
+}
+
+type WorkPatternResponse struct {
+	ID     int64   `json:"id"`
+	Name   string  `json:"name"`
+	Shifts []int64 `json:"shifts"`
+}
+
+// AssignScheduleRequest moves an employee to a pattern from StartDate, day
+// 0 of the pattern falling on StartDate
+type AssignScheduleRequest struct {
+	UserID        int64  `json:"user_id" validate:"required"`
+	WorkPatternID int64  `json:"work_pattern_id" validate:"required"`
+	StartDate     string `json:"start_date" validate:"required,datetime=2006-01-02"`
+}
+
+type RosterRequest struct {
+	UserID int64              `json:"user_id" validate:"required"`
+	Days   []RosterDayRequest `json:"days" validate:"required,min=1,max=62,dive"`
+}
+
+// RosterDayRequest sets the shift of a day, 0 for a day off
+type RosterDayRequest struct {
+	Date    string `json:"date" validate:"required,datetime=2006-01-02"`
+	ShiftID int64  `json:"shift_id" validate:"gte=0"`
+}
+
+type GetScheduleRequest struct {
+	UserID    int64  `schema:"-"`
+	StartDate string `schema:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate   string `schema:"end_date" validate:"required,datetime=2006-01-02"`
+}
+
+// ScheduleDayResponse is the shift of a day, without one when the day is off
+type ScheduleDayResponse struct {
+	Date         string `json:"date"`
+	Working      bool   `json:"working"`
+	ShiftID      int64  `json:"shift_id,omitempty"`
+	ShiftName    string `json:"shift_name,omitempty"`
+	StartTime    string `json:"start_time,omitempty"`
+	EndTime      string `json:"end_time,omitempty"`
+	WorkingHours int    `json:"working_hours,omitempty"`
+}
+
+type GetScheduleResponse struct {
+	UserID       int64                 `json:"user_id"`
+	WorkingDays  int                   `json:"working_days"`
+	WorkingHours int                   `json:"working_hours"`
+	Days         []ScheduleDayResponse `json:"days"`
+}
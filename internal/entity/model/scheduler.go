@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+)
+
+// TrxSchedulerRun records the outcome of every scheduled job execution
+type TrxSchedulerRun struct {
+	ID               int64     `json:"id" xorm:"'id' pk autoincr"`
+	IDMstTenant      int64     `json:"-" xorm:"id_mst_tenant"`
+	JobName          string    `json:"job_name" xorm:"job_name"`
+	Status           string    `json:"status" xorm:"status"`
+	Message          string    `json:"message" xorm:"message"`
+	CreatedPeriods   int       `json:"created_periods" xorm:"created_periods"`
+	GeneratedPeriods int       `json:"generated_periods" xorm:"generated_periods"`
+	StartedAt        time.Time `json:"started_at" xorm:"started_at"`
+	FinishedAt       time.Time `json:"finished_at" xorm:"finished_at"`
+	CreatedAt        time.Time `json:"-" xorm:"'created_at' created"`
+}
+
+type ListSchedulerRunParams struct {
+	JobName string
+	Status  string
+	Cursor  int64
+	Limit   int
+}
+
+type ListSchedulerRunRequest struct {
+	CursorPagination
+	Status string `schema:"status" validate:"omitempty,oneof=success partial failed"`
+}
+
+type ListSchedulerRunResponse struct {
+	Runs       []TrxSchedulerRun `json:"runs"`
+	NextCursor int64             `json:"next_cursor,omitempty"`
+}
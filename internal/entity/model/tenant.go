@@ -0,0 +1,27 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// MstTenant is one legal entity of the group. Its payroll policy applies to
+// the payroll of its employees only.
+type MstTenant struct {
+	ID                 int64         `json:"id" xorm:"'id' pk autoincr"`
+	Code               string        `json:"code" xorm:"code"`
+	Name               string        `json:"name" xorm:"name"`
+	OvertimeMultiplier int           `json:"overtime_multiplier" xorm:"overtime_multiplier"`
+	MaxOvertimeHours   int           `json:"max_overtime_hours" xorm:"max_overtime_hours"`
+	CreatedAt          time.Time     `json:"created_at" xorm:"'created_at' created"`
+	UpdatedAt          time.Time     `json:"updated_at" xorm:"'updated_at' updated"`
+	CreatedBy          sql.NullInt64 `json:"-" xorm:"created_by"`
+	UpdatedBy          sql.NullInt64 `json:"-" xorm:"updated_by"`
+}
+
+// PayrollPolicyRequest sets how the overtime of the employees of a tenant is
+// capped and paid
+type PayrollPolicyRequest struct {
+	OvertimeMultiplier int `json:"overtime_multiplier" validate:"required,gt=0,lte=10"`
+	MaxOvertimeHours   int `json:"max_overtime_hours" validate:"required,gt=0,lte=24"`
+}
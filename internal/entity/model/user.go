@@ -8,6 +8,7 @@ import (
 // MstUser represents both employees and admin for mst_user table
 type MstUser struct {
 	ID           int64          `json:"id" xorm:"'id'"`
+	IDMstTenant  int64          `json:"-" xorm:"id_mst_tenant"`
 	Username     string         `json:"username" xorm:"'username'"`
 	PasswordHash string         `json:"-" xorm:"'password_hash'"`
 	Role         string         `json:"role" xorm:"'role'"`
@@ -16,9 +17,57 @@ type MstUser struct {
 	UpdatedAt    time.Time      `json:"updated_at" xorm:"'updated_at' updated"`
 	CreatedBy    sql.NullString `json:"created_by,omitempty" xorm:"created_by"`
 	UpdatedBy    sql.NullString `json:"updated_by,omitempty" xorm:"updated_by"`
+	// FailedLoginAttempts counts the failed sign ins since the last success
+	// or lock, LockedUntil is set while the account is locked
+	FailedLoginAttempts int          `json:"-" xorm:"'failed_login_attempts'"`
+	LockedUntil         sql.NullTime `json:"-" xorm:"'locked_until'"`
+	// WorkArrangement is one of constant.WorkArrangement*, DeviceID the
+	// device bound to the employee by the first tap in
+	WorkArrangement string `json:"work_arrangement,omitempty" xorm:"'work_arrangement'"`
+	DeviceID        string `json:"-" xorm:"'device_id'"`
+	// BadgeID identifies the employee at kiosks and biometric devices
+	BadgeID sql.NullString `json:"-" xorm:"'badge_id'"`
+	// IDMstManager is the manager reviewing the attendance corrections of
+	// the employee
+	IDMstManager sql.NullInt64 `json:"-" xorm:"'id_mst_manager'"`
 }
 
 type SignInRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
 }
+
+// TrxLoginAttempt records one sign in attempt. IDMstUser is empty when the
+// username is unknown.
+type TrxLoginAttempt struct {
+	ID            int64         `xorm:"'id' pk autoincr"`
+	Username      string        `xorm:"username"`
+	IDMstUser     sql.NullInt64 `xorm:"id_mst_user"`
+	Success       bool          `xorm:"success"`
+	FailureReason string        `xorm:"failure_reason"`
+	IPAddress     string        `xorm:"ip_address"`
+	RequestID     string        `xorm:"request_id"`
+	CreatedAt     time.Time     `xorm:"'created_at' created"`
+}
+
+type UnlockUserResponse struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+type ResetUserDeviceResponse struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+// SetManagerRequest names the manager of an employee, a zero ManagerID
+// removes it
+type SetManagerRequest struct {
+	ManagerID int64 `json:"manager_id" validate:"gte=0"`
+}
+
+type SetManagerResponse struct {
+	ID        int64  `json:"id"`
+	Username  string `json:"username"`
+	ManagerID int64  `json:"manager_id,omitempty"`
+}
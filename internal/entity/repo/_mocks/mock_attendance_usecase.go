@@ -35,6 +35,96 @@ func (m *MockAttendanceUsecaseRepository) EXPECT() *MockAttendanceUsecaseReposit
 	return m.recorder
 }
 
+// AdjustAttendance mocks base method.
+func (m *MockAttendanceUsecaseRepository) AdjustAttendance(arg0 context.Context, arg1 model.AdjustAttendanceRequest) (model.AttendanceCorrectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustAttendance", arg0, arg1)
+	ret0, _ := ret[0].(model.AttendanceCorrectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustAttendance indicates an expected call of AdjustAttendance.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) AdjustAttendance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustAttendance", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).AdjustAttendance), arg0, arg1)
+}
+
+// ApproveAttendanceCorrection mocks base method.
+func (m *MockAttendanceUsecaseRepository) ApproveAttendanceCorrection(arg0 context.Context, arg1 model.ReviewAttendanceCorrectionRequest) (model.AttendanceCorrectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveAttendanceCorrection", arg0, arg1)
+	ret0, _ := ret[0].(model.AttendanceCorrectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveAttendanceCorrection indicates an expected call of ApproveAttendanceCorrection.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) ApproveAttendanceCorrection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveAttendanceCorrection", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).ApproveAttendanceCorrection), arg0, arg1)
+}
+
+// AssignOrganization mocks base method.
+func (m *MockAttendanceUsecaseRepository) AssignOrganization(arg0 context.Context, arg1 model.AssignOrganizationRequest) (model.TrxUserAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignOrganization", arg0, arg1)
+	ret0, _ := ret[0].(model.TrxUserAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignOrganization indicates an expected call of AssignOrganization.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) AssignOrganization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignOrganization", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).AssignOrganization), arg0, arg1)
+}
+
+// AssignSchedule mocks base method.
+func (m *MockAttendanceUsecaseRepository) AssignSchedule(arg0 context.Context, arg1 model.AssignScheduleRequest) (model.TrxUserSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignSchedule", arg0, arg1)
+	ret0, _ := ret[0].(model.TrxUserSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignSchedule indicates an expected call of AssignSchedule.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) AssignSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignSchedule", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).AssignSchedule), arg0, arg1)
+}
+
+// CreateCostCentre mocks base method.
+func (m *MockAttendanceUsecaseRepository) CreateCostCentre(arg0 context.Context, arg1 model.CostCentreRequest) (model.MstCostCentre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCostCentre", arg0, arg1)
+	ret0, _ := ret[0].(model.MstCostCentre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCostCentre indicates an expected call of CreateCostCentre.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) CreateCostCentre(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCostCentre", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).CreateCostCentre), arg0, arg1)
+}
+
+// CreateDepartment mocks base method.
+func (m *MockAttendanceUsecaseRepository) CreateDepartment(arg0 context.Context, arg1 model.DepartmentRequest) (model.MstDepartment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDepartment", arg0, arg1)
+	ret0, _ := ret[0].(model.MstDepartment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDepartment indicates an expected call of CreateDepartment.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) CreateDepartment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDepartment", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).CreateDepartment), arg0, arg1)
+}
+
 // CreatePayrollPeriod mocks base method.
 func (m *MockAttendanceUsecaseRepository) CreatePayrollPeriod(arg0 context.Context, arg1 model.PayrollPeriodRequest) (model.PayrollPeriodResponse, error) {
 	m.ctrl.T.Helper()
@@ -50,18 +140,63 @@ func (mr *MockAttendanceUsecaseRepositoryMockRecorder) CreatePayrollPeriod(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayrollPeriod", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).CreatePayrollPeriod), arg0, arg1)
 }
 
-// GeneratePayroll mocks base method.
-func (m *MockAttendanceUsecaseRepository) GeneratePayroll(arg0 context.Context, arg1 model.GeneratePayrollRequest) error {
+// CreateShift mocks base method.
+func (m *MockAttendanceUsecaseRepository) CreateShift(arg0 context.Context, arg1 model.ShiftRequest) (model.MstShift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShift", arg0, arg1)
+	ret0, _ := ret[0].(model.MstShift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShift indicates an expected call of CreateShift.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) CreateShift(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShift", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).CreateShift), arg0, arg1)
+}
+
+// CreateWorkPattern mocks base method.
+func (m *MockAttendanceUsecaseRepository) CreateWorkPattern(arg0 context.Context, arg1 model.WorkPatternRequest) (model.WorkPatternResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GeneratePayroll", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateWorkPattern", arg0, arg1)
+	ret0, _ := ret[0].(model.WorkPatternResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkPattern indicates an expected call of CreateWorkPattern.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) CreateWorkPattern(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkPattern", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).CreateWorkPattern), arg0, arg1)
+}
+
+// DeletePayrollPeriod mocks base method.
+func (m *MockAttendanceUsecaseRepository) DeletePayrollPeriod(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePayrollPeriod", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// GeneratePayroll indicates an expected call of GeneratePayroll.
-func (mr *MockAttendanceUsecaseRepositoryMockRecorder) GeneratePayroll(arg0, arg1 interface{}) *gomock.Call {
+// DeletePayrollPeriod indicates an expected call of DeletePayrollPeriod.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) DeletePayrollPeriod(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePayrollPeriod", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).DeletePayrollPeriod), arg0, arg1)
+}
+
+// EnqueuePayrollGeneration mocks base method.
+func (m *MockAttendanceUsecaseRepository) EnqueuePayrollGeneration(arg0 context.Context, arg1 model.GeneratePayrollRequest) (model.PayrollJobResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueuePayrollGeneration", arg0, arg1)
+	ret0, _ := ret[0].(model.PayrollJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueuePayrollGeneration indicates an expected call of EnqueuePayrollGeneration.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) EnqueuePayrollGeneration(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePayroll", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).GeneratePayroll), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueuePayrollGeneration", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).EnqueuePayrollGeneration), arg0, arg1)
 }
 
 // GetEmployeePayslip mocks base method.
@@ -79,6 +214,21 @@ func (mr *MockAttendanceUsecaseRepositoryMockRecorder) GetEmployeePayslip(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeePayslip", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).GetEmployeePayslip), arg0, arg1)
 }
 
+// GetLabourCost mocks base method.
+func (m *MockAttendanceUsecaseRepository) GetLabourCost(arg0 context.Context, arg1 model.LabourCostRequest) (model.LabourCostResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabourCost", arg0, arg1)
+	ret0, _ := ret[0].(model.LabourCostResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabourCost indicates an expected call of GetLabourCost.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) GetLabourCost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabourCost", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).GetLabourCost), arg0, arg1)
+}
+
 // GetPayroll mocks base method.
 func (m *MockAttendanceUsecaseRepository) GetPayroll(arg0 context.Context, arg1 model.GetPayrollRequest) (model.GetPayrollResponse, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +244,320 @@ func (mr *MockAttendanceUsecaseRepositoryMockRecorder) GetPayroll(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayroll", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).GetPayroll), arg0, arg1)
 }
 
+// GetPayrollJob mocks base method.
+func (m *MockAttendanceUsecaseRepository) GetPayrollJob(arg0 context.Context, arg1 int64) (model.PayrollJobResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayrollJob", arg0, arg1)
+	ret0, _ := ret[0].(model.PayrollJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayrollJob indicates an expected call of GetPayrollJob.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) GetPayrollJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayrollJob", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).GetPayrollJob), arg0, arg1)
+}
+
+// GetPayrollPeriod mocks base method.
+func (m *MockAttendanceUsecaseRepository) GetPayrollPeriod(arg0 context.Context, arg1 int64) (model.PayrollPeriodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayrollPeriod", arg0, arg1)
+	ret0, _ := ret[0].(model.PayrollPeriodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayrollPeriod indicates an expected call of GetPayrollPeriod.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) GetPayrollPeriod(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayrollPeriod", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).GetPayrollPeriod), arg0, arg1)
+}
+
+// GetSchedule mocks base method.
+func (m *MockAttendanceUsecaseRepository) GetSchedule(arg0 context.Context, arg1 model.GetScheduleRequest) (model.GetScheduleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", arg0, arg1)
+	ret0, _ := ret[0].(model.GetScheduleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) GetSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).GetSchedule), arg0, arg1)
+}
+
+// ImportAttendance mocks base method.
+func (m *MockAttendanceUsecaseRepository) ImportAttendance(arg0 context.Context, arg1 model.ImportAttendanceRequest) (model.ImportAttendanceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportAttendance", arg0, arg1)
+	ret0, _ := ret[0].(model.ImportAttendanceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportAttendance indicates an expected call of ImportAttendance.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) ImportAttendance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportAttendance", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).ImportAttendance), arg0, arg1)
+}
+
+// ListAssignments mocks base method.
+func (m *MockAttendanceUsecaseRepository) ListAssignments(arg0 context.Context, arg1 int64) ([]model.AssignmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAssignments", arg0, arg1)
+	ret0, _ := ret[0].([]model.AssignmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAssignments indicates an expected call of ListAssignments.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) ListAssignments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssignments", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).ListAssignments), arg0, arg1)
+}
+
+// ListAttendanceCorrections mocks base method.
+func (m *MockAttendanceUsecaseRepository) ListAttendanceCorrections(arg0 context.Context, arg1 model.ListAttendanceCorrectionRequest) (model.ListAttendanceCorrectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttendanceCorrections", arg0, arg1)
+	ret0, _ := ret[0].(model.ListAttendanceCorrectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttendanceCorrections indicates an expected call of ListAttendanceCorrections.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) ListAttendanceCorrections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttendanceCorrections", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).ListAttendanceCorrections), arg0, arg1)
+}
+
+// ListCostCentres mocks base method.
+func (m *MockAttendanceUsecaseRepository) ListCostCentres(arg0 context.Context) ([]model.MstCostCentre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCostCentres", arg0)
+	ret0, _ := ret[0].([]model.MstCostCentre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCostCentres indicates an expected call of ListCostCentres.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) ListCostCentres(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCostCentres", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).ListCostCentres), arg0)
+}
+
+// ListDepartments mocks base method.
+func (m *MockAttendanceUsecaseRepository) ListDepartments(arg0 context.Context) ([]model.MstDepartment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDepartments", arg0)
+	ret0, _ := ret[0].([]model.MstDepartment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDepartments indicates an expected call of ListDepartments.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) ListDepartments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDepartments", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).ListDepartments), arg0)
+}
+
+// ListMyAttendance mocks base method.
+func (m *MockAttendanceUsecaseRepository) ListMyAttendance(arg0 context.Context, arg1 model.ListMyAttendanceRequest) (model.ListMyAttendanceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMyAttendance", arg0, arg1)
+	ret0, _ := ret[0].(model.ListMyAttendanceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMyAttendance indicates an expected call of ListMyAttendance.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) ListMyAttendance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMyAttendance", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).ListMyAttendance), arg0, arg1)
+}
+
+// ListMyAttendanceCorrections mocks base method.
+func (m *MockAttendanceUsecaseRepository) ListMyAttendanceCorrections(arg0 context.Context, arg1 model.ListAttendanceCorrectionRequest) (model.ListAttendanceCorrectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMyAttendanceCorrections", arg0, arg1)
+	ret0, _ := ret[0].(model.ListAttendanceCorrectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMyAttendanceCorrections indicates an expected call of ListMyAttendanceCorrections.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) ListMyAttendanceCorrections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMyAttendanceCorrections", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).ListMyAttendanceCorrections), arg0, arg1)
+}
+
+// ListMyOvertime mocks base method.
+func (m *MockAttendanceUsecaseRepository) ListMyOvertime(arg0 context.Context, arg1 model.ListMyOvertimeRequest) (model.ListMyOvertimeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMyOvertime", arg0, arg1)
+	ret0, _ := ret[0].(model.ListMyOvertimeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMyOvertime indicates an expected call of ListMyOvertime.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) ListMyOvertime(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMyOvertime", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).ListMyOvertime), arg0, arg1)
+}
+
+// ListMyPayslips mocks base method.
+func (m *MockAttendanceUsecaseRepository) ListMyPayslips(arg0 context.Context, arg1 model.ListMyPayslipRequest) (model.ListMyPayslipResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMyPayslips", arg0, arg1)
+	ret0, _ := ret[0].(model.ListMyPayslipResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMyPayslips indicates an expected call of ListMyPayslips.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) ListMyPayslips(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMyPayslips", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).ListMyPayslips), arg0, arg1)
+}
+
+// ListMyReimbursements mocks base method.
+func (m *MockAttendanceUsecaseRepository) ListMyReimbursements(arg0 context.Context, arg1 model.ListMyReimbursementRequest) (model.ListMyReimbursementResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMyReimbursements", arg0, arg1)
+	ret0, _ := ret[0].(model.ListMyReimbursementResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMyReimbursements indicates an expected call of ListMyReimbursements.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) ListMyReimbursements(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMyReimbursements", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).ListMyReimbursements), arg0, arg1)
+}
+
+// ListPayrollPeriods mocks base method.
+func (m *MockAttendanceUsecaseRepository) ListPayrollPeriods(arg0 context.Context, arg1 model.ListPayrollPeriodRequest) (model.ListPayrollPeriodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayrollPeriods", arg0, arg1)
+	ret0, _ := ret[0].(model.ListPayrollPeriodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayrollPeriods indicates an expected call of ListPayrollPeriods.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) ListPayrollPeriods(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayrollPeriods", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).ListPayrollPeriods), arg0, arg1)
+}
+
+// ListPayrollScheduleRuns mocks base method.
+func (m *MockAttendanceUsecaseRepository) ListPayrollScheduleRuns(arg0 context.Context, arg1 model.ListSchedulerRunRequest) (model.ListSchedulerRunResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayrollScheduleRuns", arg0, arg1)
+	ret0, _ := ret[0].(model.ListSchedulerRunResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayrollScheduleRuns indicates an expected call of ListPayrollScheduleRuns.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) ListPayrollScheduleRuns(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayrollScheduleRuns", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).ListPayrollScheduleRuns), arg0, arg1)
+}
+
+// ListShifts mocks base method.
+func (m *MockAttendanceUsecaseRepository) ListShifts(arg0 context.Context) ([]model.MstShift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShifts", arg0)
+	ret0, _ := ret[0].([]model.MstShift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShifts indicates an expected call of ListShifts.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) ListShifts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShifts", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).ListShifts), arg0)
+}
+
+// ListWorkPatterns mocks base method.
+func (m *MockAttendanceUsecaseRepository) ListWorkPatterns(arg0 context.Context) ([]model.WorkPatternResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkPatterns", arg0)
+	ret0, _ := ret[0].([]model.WorkPatternResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkPatterns indicates an expected call of ListWorkPatterns.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) ListWorkPatterns(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkPatterns", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).ListWorkPatterns), arg0)
+}
+
+// RejectAttendanceCorrection mocks base method.
+func (m *MockAttendanceUsecaseRepository) RejectAttendanceCorrection(arg0 context.Context, arg1 model.ReviewAttendanceCorrectionRequest) (model.AttendanceCorrectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectAttendanceCorrection", arg0, arg1)
+	ret0, _ := ret[0].(model.AttendanceCorrectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectAttendanceCorrection indicates an expected call of RejectAttendanceCorrection.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) RejectAttendanceCorrection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectAttendanceCorrection", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).RejectAttendanceCorrection), arg0, arg1)
+}
+
+// RequestAttendanceCorrection mocks base method.
+func (m *MockAttendanceUsecaseRepository) RequestAttendanceCorrection(arg0 context.Context, arg1 model.AttendanceCorrectionRequest) (model.AttendanceCorrectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestAttendanceCorrection", arg0, arg1)
+	ret0, _ := ret[0].(model.AttendanceCorrectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestAttendanceCorrection indicates an expected call of RequestAttendanceCorrection.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) RequestAttendanceCorrection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestAttendanceCorrection", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).RequestAttendanceCorrection), arg0, arg1)
+}
+
+// RunPayrollJob mocks base method.
+func (m *MockAttendanceUsecaseRepository) RunPayrollJob(arg0 context.Context, arg1 model.TrxPayrollJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunPayrollJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunPayrollJob indicates an expected call of RunPayrollJob.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) RunPayrollJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunPayrollJob", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).RunPayrollJob), arg0, arg1)
+}
+
+// SetRoster mocks base method.
+func (m *MockAttendanceUsecaseRepository) SetRoster(arg0 context.Context, arg1 model.RosterRequest) ([]model.ScheduleDayResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRoster", arg0, arg1)
+	ret0, _ := ret[0].([]model.ScheduleDayResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRoster indicates an expected call of SetRoster.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) SetRoster(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRoster", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).SetRoster), arg0, arg1)
+}
+
 // SubmitOvertime mocks base method.
 func (m *MockAttendanceUsecaseRepository) SubmitOvertime(arg0 context.Context, arg1 model.SubmitOvertimeRequest) (model.SubmitOvertimeResponse, error) {
 	m.ctrl.T.Helper()
@@ -125,7 +589,7 @@ func (mr *MockAttendanceUsecaseRepositoryMockRecorder) SubmitReimbursement(arg0,
 }
 
 // TapIn mocks base method.
-func (m *MockAttendanceUsecaseRepository) TapIn(arg0 context.Context, arg1 model.MstAttendance) (model.TapInResponse, error) {
+func (m *MockAttendanceUsecaseRepository) TapIn(arg0 context.Context, arg1 model.TapInRequest) (model.TapInResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "TapIn", arg0, arg1)
 	ret0, _ := ret[0].(model.TapInResponse)
@@ -138,3 +602,48 @@ func (mr *MockAttendanceUsecaseRepositoryMockRecorder) TapIn(arg0, arg1 interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TapIn", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).TapIn), arg0, arg1)
 }
+
+// TapInAtKiosk mocks base method.
+func (m *MockAttendanceUsecaseRepository) TapInAtKiosk(arg0 context.Context, arg1 model.KioskTapInRequest) (model.TapInResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TapInAtKiosk", arg0, arg1)
+	ret0, _ := ret[0].(model.TapInResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TapInAtKiosk indicates an expected call of TapInAtKiosk.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) TapInAtKiosk(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TapInAtKiosk", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).TapInAtKiosk), arg0, arg1)
+}
+
+// TapInWithBadge mocks base method.
+func (m *MockAttendanceUsecaseRepository) TapInWithBadge(arg0 context.Context, arg1 model.BadgeTapInRequest) (model.TapInResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TapInWithBadge", arg0, arg1)
+	ret0, _ := ret[0].(model.TapInResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TapInWithBadge indicates an expected call of TapInWithBadge.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) TapInWithBadge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TapInWithBadge", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).TapInWithBadge), arg0, arg1)
+}
+
+// UpdatePayrollPeriod mocks base method.
+func (m *MockAttendanceUsecaseRepository) UpdatePayrollPeriod(arg0 context.Context, arg1 int64, arg2 model.PayrollPeriodRequest) (model.PayrollPeriodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePayrollPeriod", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.PayrollPeriodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePayrollPeriod indicates an expected call of UpdatePayrollPeriod.
+func (mr *MockAttendanceUsecaseRepositoryMockRecorder) UpdatePayrollPeriod(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePayrollPeriod", reflect.TypeOf((*MockAttendanceUsecaseRepository)(nil).UpdatePayrollPeriod), arg0, arg1, arg2)
+}
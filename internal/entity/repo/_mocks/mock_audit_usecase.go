@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase (interfaces: AuditUsecaseRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuditUsecaseRepository is a mock of AuditUsecaseRepository interface.
+type MockAuditUsecaseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditUsecaseRepositoryMockRecorder
+}
+
+// MockAuditUsecaseRepositoryMockRecorder is the mock recorder for MockAuditUsecaseRepository.
+type MockAuditUsecaseRepositoryMockRecorder struct {
+	mock *MockAuditUsecaseRepository
+}
+
+// NewMockAuditUsecaseRepository creates a new mock instance.
+func NewMockAuditUsecaseRepository(ctrl *gomock.Controller) *MockAuditUsecaseRepository {
+	mock := &MockAuditUsecaseRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditUsecaseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditUsecaseRepository) EXPECT() *MockAuditUsecaseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListAuditLogs mocks base method.
+func (m *MockAuditUsecaseRepository) ListAuditLogs(arg0 context.Context, arg1 model.ListAuditLogRequest) (model.ListAuditLogResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditLogs", arg0, arg1)
+	ret0, _ := ret[0].(model.ListAuditLogResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditLogs indicates an expected call of ListAuditLogs.
+func (mr *MockAuditUsecaseRepositoryMockRecorder) ListAuditLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditLogs", reflect.TypeOf((*MockAuditUsecaseRepository)(nil).ListAuditLogs), arg0, arg1)
+}
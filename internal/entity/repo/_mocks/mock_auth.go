@@ -66,6 +66,36 @@ func (mr *MockAuthenticatorMockRecorder) CreateJWTToken(arg0, arg1, arg2, arg3 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJWTToken", reflect.TypeOf((*MockAuthenticator)(nil).CreateJWTToken), arg0, arg1, arg2, arg3)
 }
 
+// CreateKioskNonce mocks base method.
+func (m *MockAuthenticator) CreateKioskNonce(arg0 context.Context, arg1 auth.KioskJWTPayload, arg2, arg3 time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKioskNonce", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKioskNonce indicates an expected call of CreateKioskNonce.
+func (mr *MockAuthenticatorMockRecorder) CreateKioskNonce(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKioskNonce", reflect.TypeOf((*MockAuthenticator)(nil).CreateKioskNonce), arg0, arg1, arg2, arg3)
+}
+
+// CreateKioskToken mocks base method.
+func (m *MockAuthenticator) CreateKioskToken(arg0 context.Context, arg1 auth.KioskJWTPayload, arg2, arg3 time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKioskToken", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKioskToken indicates an expected call of CreateKioskToken.
+func (mr *MockAuthenticatorMockRecorder) CreateKioskToken(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKioskToken", reflect.TypeOf((*MockAuthenticator)(nil).CreateKioskToken), arg0, arg1, arg2, arg3)
+}
+
 // GetTokenClaims mocks base method.
 func (m *MockAuthenticator) GetTokenClaims(arg0 string) (*auth.Claims, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +126,20 @@ func (mr *MockAuthenticatorMockRecorder) HandleAuthMiddleware(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleAuthMiddleware", reflect.TypeOf((*MockAuthenticator)(nil).HandleAuthMiddleware), arg0, arg1)
 }
 
+// KioskAuthHandler mocks base method.
+func (m *MockAuthenticator) KioskAuthHandler(arg0 http.Handler) http.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KioskAuthHandler", arg0)
+	ret0, _ := ret[0].(http.Handler)
+	return ret0
+}
+
+// KioskAuthHandler indicates an expected call of KioskAuthHandler.
+func (mr *MockAuthenticatorMockRecorder) KioskAuthHandler(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KioskAuthHandler", reflect.TypeOf((*MockAuthenticator)(nil).KioskAuthHandler), arg0)
+}
+
 // VerifyJWT mocks base method.
 func (m *MockAuthenticator) VerifyJWT(arg0 string, arg1 interface{}) error {
 	m.ctrl.T.Helper()
@@ -109,3 +153,18 @@ func (mr *MockAuthenticatorMockRecorder) VerifyJWT(arg0, arg1 interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyJWT", reflect.TypeOf((*MockAuthenticator)(nil).VerifyJWT), arg0, arg1)
 }
+
+// VerifyKioskNonce mocks base method.
+func (m *MockAuthenticator) VerifyKioskNonce(arg0 string) (auth.KioskNonce, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyKioskNonce", arg0)
+	ret0, _ := ret[0].(auth.KioskNonce)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyKioskNonce indicates an expected call of VerifyKioskNonce.
+func (mr *MockAuthenticatorMockRecorder) VerifyKioskNonce(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyKioskNonce", reflect.TypeOf((*MockAuthenticator)(nil).VerifyKioskNonce), arg0)
+}
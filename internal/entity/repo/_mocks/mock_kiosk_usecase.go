@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase (interfaces: KioskUsecaseRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockKioskUsecaseRepository is a mock of KioskUsecaseRepository interface.
+type MockKioskUsecaseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockKioskUsecaseRepositoryMockRecorder
+}
+
+// MockKioskUsecaseRepositoryMockRecorder is the mock recorder for MockKioskUsecaseRepository.
+type MockKioskUsecaseRepositoryMockRecorder struct {
+	mock *MockKioskUsecaseRepository
+}
+
+// NewMockKioskUsecaseRepository creates a new mock instance.
+func NewMockKioskUsecaseRepository(ctrl *gomock.Controller) *MockKioskUsecaseRepository {
+	mock := &MockKioskUsecaseRepository{ctrl: ctrl}
+	mock.recorder = &MockKioskUsecaseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKioskUsecaseRepository) EXPECT() *MockKioskUsecaseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeactivateKiosk mocks base method.
+func (m *MockKioskUsecaseRepository) DeactivateKiosk(arg0 context.Context, arg1 int64) (model.MstKiosk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateKiosk", arg0, arg1)
+	ret0, _ := ret[0].(model.MstKiosk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateKiosk indicates an expected call of DeactivateKiosk.
+func (mr *MockKioskUsecaseRepositoryMockRecorder) DeactivateKiosk(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateKiosk", reflect.TypeOf((*MockKioskUsecaseRepository)(nil).DeactivateKiosk), arg0, arg1)
+}
+
+// IssueNonce mocks base method.
+func (m *MockKioskUsecaseRepository) IssueNonce(arg0 context.Context) (model.KioskNonceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueNonce", arg0)
+	ret0, _ := ret[0].(model.KioskNonceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueNonce indicates an expected call of IssueNonce.
+func (mr *MockKioskUsecaseRepositoryMockRecorder) IssueNonce(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueNonce", reflect.TypeOf((*MockKioskUsecaseRepository)(nil).IssueNonce), arg0)
+}
+
+// ListKiosks mocks base method.
+func (m *MockKioskUsecaseRepository) ListKiosks(arg0 context.Context) ([]model.MstKiosk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKiosks", arg0)
+	ret0, _ := ret[0].([]model.MstKiosk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKiosks indicates an expected call of ListKiosks.
+func (mr *MockKioskUsecaseRepositoryMockRecorder) ListKiosks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKiosks", reflect.TypeOf((*MockKioskUsecaseRepository)(nil).ListKiosks), arg0)
+}
+
+// RegisterKiosk mocks base method.
+func (m *MockKioskUsecaseRepository) RegisterKiosk(arg0 context.Context, arg1 model.RegisterKioskRequest) (model.RegisterKioskResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterKiosk", arg0, arg1)
+	ret0, _ := ret[0].(model.RegisterKioskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterKiosk indicates an expected call of RegisterKiosk.
+func (mr *MockKioskUsecaseRepositoryMockRecorder) RegisterKiosk(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterKiosk", reflect.TypeOf((*MockKioskUsecaseRepository)(nil).RegisterKiosk), arg0, arg1)
+}
+
+// SignIn mocks base method.
+func (m *MockKioskUsecaseRepository) SignIn(arg0 context.Context, arg1 model.KioskSignInRequest) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignIn", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignIn indicates an expected call of SignIn.
+func (mr *MockKioskUsecaseRepositoryMockRecorder) SignIn(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockKioskUsecaseRepository)(nil).SignIn), arg0, arg1)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase (interfaces: TenantUsecaseRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTenantUsecaseRepository is a mock of TenantUsecaseRepository interface.
+type MockTenantUsecaseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantUsecaseRepositoryMockRecorder
+}
+
+// MockTenantUsecaseRepositoryMockRecorder is the mock recorder for MockTenantUsecaseRepository.
+type MockTenantUsecaseRepositoryMockRecorder struct {
+	mock *MockTenantUsecaseRepository
+}
+
+// NewMockTenantUsecaseRepository creates a new mock instance.
+func NewMockTenantUsecaseRepository(ctrl *gomock.Controller) *MockTenantUsecaseRepository {
+	mock := &MockTenantUsecaseRepository{ctrl: ctrl}
+	mock.recorder = &MockTenantUsecaseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantUsecaseRepository) EXPECT() *MockTenantUsecaseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetTenant mocks base method.
+func (m *MockTenantUsecaseRepository) GetTenant(arg0 context.Context) (model.MstTenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenant", arg0)
+	ret0, _ := ret[0].(model.MstTenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenant indicates an expected call of GetTenant.
+func (mr *MockTenantUsecaseRepositoryMockRecorder) GetTenant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenant", reflect.TypeOf((*MockTenantUsecaseRepository)(nil).GetTenant), arg0)
+}
+
+// UpdatePayrollPolicy mocks base method.
+func (m *MockTenantUsecaseRepository) UpdatePayrollPolicy(arg0 context.Context, arg1 model.PayrollPolicyRequest) (model.MstTenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePayrollPolicy", arg0, arg1)
+	ret0, _ := ret[0].(model.MstTenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePayrollPolicy indicates an expected call of UpdatePayrollPolicy.
+func (mr *MockTenantUsecaseRepositoryMockRecorder) UpdatePayrollPolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePayrollPolicy", reflect.TypeOf((*MockTenantUsecaseRepository)(nil).UpdatePayrollPolicy), arg0, arg1)
+}
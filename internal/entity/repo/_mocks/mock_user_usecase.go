@@ -35,6 +35,36 @@ func (m *MockUserUsecaseRepository) EXPECT() *MockUserUsecaseRepositoryMockRecor
 	return m.recorder
 }
 
+// ResetUserDevice mocks base method.
+func (m *MockUserUsecaseRepository) ResetUserDevice(arg0 context.Context, arg1 int64) (model.ResetUserDeviceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetUserDevice", arg0, arg1)
+	ret0, _ := ret[0].(model.ResetUserDeviceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetUserDevice indicates an expected call of ResetUserDevice.
+func (mr *MockUserUsecaseRepositoryMockRecorder) ResetUserDevice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUserDevice", reflect.TypeOf((*MockUserUsecaseRepository)(nil).ResetUserDevice), arg0, arg1)
+}
+
+// SetManager mocks base method.
+func (m *MockUserUsecaseRepository) SetManager(arg0 context.Context, arg1 int64, arg2 model.SetManagerRequest) (model.SetManagerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetManager", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.SetManagerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetManager indicates an expected call of SetManager.
+func (mr *MockUserUsecaseRepositoryMockRecorder) SetManager(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetManager", reflect.TypeOf((*MockUserUsecaseRepository)(nil).SetManager), arg0, arg1, arg2)
+}
+
 // SignIn mocks base method.
 func (m *MockUserUsecaseRepository) SignIn(arg0 context.Context, arg1 model.SignInRequest) (string, error) {
 	m.ctrl.T.Helper()
@@ -49,3 +79,18 @@ func (mr *MockUserUsecaseRepositoryMockRecorder) SignIn(arg0, arg1 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockUserUsecaseRepository)(nil).SignIn), arg0, arg1)
 }
+
+// UnlockUser mocks base method.
+func (m *MockUserUsecaseRepository) UnlockUser(arg0 context.Context, arg1 int64) (model.UnlockUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", arg0, arg1)
+	ret0, _ := ret[0].(model.UnlockUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockUserUsecaseRepositoryMockRecorder) UnlockUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockUserUsecaseRepository)(nil).UnlockUser), arg0, arg1)
+}
@@ -15,4 +15,8 @@ type Authenticator interface {
 	VerifyJWT(jwtToken string, claims any) (err error)
 	GetTokenClaims(token string) (claims *authrepo.Claims, err error)
 	HandleAuthMiddleware(ctx context.Context, token string) (ret authrepo.UserJWTPayload, err error)
+	CreateKioskToken(ctx context.Context, kiosk authrepo.KioskJWTPayload, timeNow, timeExpired time.Time) (tokenStr string, err error)
+	CreateKioskNonce(ctx context.Context, kiosk authrepo.KioskJWTPayload, timeNow, timeExpired time.Time) (nonce string, err error)
+	VerifyKioskNonce(nonce string) (res authrepo.KioskNonce, err error)
+	KioskAuthHandler(next http.Handler) http.Handler
 }
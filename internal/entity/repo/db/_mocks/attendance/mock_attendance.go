@@ -7,6 +7,7 @@ package attendance
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/faisalhardin/employee-payroll-system/internal/entity/model"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,92 @@ func (m *MockAttendanceRepository) EXPECT() *MockAttendanceRepositoryMockRecorde
 	return m.recorder
 }
 
+// AssignAttendancePayrollPeriod mocks base method.
+func (m *MockAttendanceRepository) AssignAttendancePayrollPeriod(arg0 context.Context, arg1 model.AssignPayrollPeriodParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignAttendancePayrollPeriod", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignAttendancePayrollPeriod indicates an expected call of AssignAttendancePayrollPeriod.
+func (mr *MockAttendanceRepositoryMockRecorder) AssignAttendancePayrollPeriod(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignAttendancePayrollPeriod", reflect.TypeOf((*MockAttendanceRepository)(nil).AssignAttendancePayrollPeriod), arg0, arg1)
+}
+
+// AssignOvertimePayrollPeriod mocks base method.
+func (m *MockAttendanceRepository) AssignOvertimePayrollPeriod(arg0 context.Context, arg1 model.AssignPayrollPeriodParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignOvertimePayrollPeriod", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignOvertimePayrollPeriod indicates an expected call of AssignOvertimePayrollPeriod.
+func (mr *MockAttendanceRepositoryMockRecorder) AssignOvertimePayrollPeriod(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignOvertimePayrollPeriod", reflect.TypeOf((*MockAttendanceRepository)(nil).AssignOvertimePayrollPeriod), arg0, arg1)
+}
+
+// AssignReimbursementPayrollPeriod mocks base method.
+func (m *MockAttendanceRepository) AssignReimbursementPayrollPeriod(arg0 context.Context, arg1 model.AssignPayrollPeriodParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignReimbursementPayrollPeriod", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignReimbursementPayrollPeriod indicates an expected call of AssignReimbursementPayrollPeriod.
+func (mr *MockAttendanceRepositoryMockRecorder) AssignReimbursementPayrollPeriod(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignReimbursementPayrollPeriod", reflect.TypeOf((*MockAttendanceRepository)(nil).AssignReimbursementPayrollPeriod), arg0, arg1)
+}
+
+// ClaimPayrollJob mocks base method.
+func (m *MockAttendanceRepository) ClaimPayrollJob(arg0 context.Context) (model.TrxPayrollJob, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPayrollJob", arg0)
+	ret0, _ := ret[0].(model.TrxPayrollJob)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ClaimPayrollJob indicates an expected call of ClaimPayrollJob.
+func (mr *MockAttendanceRepositoryMockRecorder) ClaimPayrollJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPayrollJob", reflect.TypeOf((*MockAttendanceRepository)(nil).ClaimPayrollJob), arg0)
+}
+
+// CreateAttendanceCorrection mocks base method.
+func (m *MockAttendanceRepository) CreateAttendanceCorrection(arg0 context.Context, arg1 *model.TrxAttendanceCorrection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttendanceCorrection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAttendanceCorrection indicates an expected call of CreateAttendanceCorrection.
+func (mr *MockAttendanceRepositoryMockRecorder) CreateAttendanceCorrection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttendanceCorrection", reflect.TypeOf((*MockAttendanceRepository)(nil).CreateAttendanceCorrection), arg0, arg1)
+}
+
+// CreatePayrollJob mocks base method.
+func (m *MockAttendanceRepository) CreatePayrollJob(arg0 context.Context, arg1 *model.TrxPayrollJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayrollJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePayrollJob indicates an expected call of CreatePayrollJob.
+func (mr *MockAttendanceRepositoryMockRecorder) CreatePayrollJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayrollJob", reflect.TypeOf((*MockAttendanceRepository)(nil).CreatePayrollJob), arg0, arg1)
+}
+
 // CreatePayrollPeriod mocks base method.
 func (m *MockAttendanceRepository) CreatePayrollPeriod(arg0 context.Context, arg1 *model.MstPayrollPeriod) error {
 	m.ctrl.T.Helper()
@@ -49,6 +136,34 @@ func (mr *MockAttendanceRepositoryMockRecorder) CreatePayrollPeriod(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayrollPeriod", reflect.TypeOf((*MockAttendanceRepository)(nil).CreatePayrollPeriod), arg0, arg1)
 }
 
+// CreateSchedulerRun mocks base method.
+func (m *MockAttendanceRepository) CreateSchedulerRun(arg0 context.Context, arg1 *model.TrxSchedulerRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchedulerRun", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSchedulerRun indicates an expected call of CreateSchedulerRun.
+func (mr *MockAttendanceRepositoryMockRecorder) CreateSchedulerRun(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedulerRun", reflect.TypeOf((*MockAttendanceRepository)(nil).CreateSchedulerRun), arg0, arg1)
+}
+
+// DeletePayrollPeriod mocks base method.
+func (m *MockAttendanceRepository) DeletePayrollPeriod(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePayrollPeriod", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePayrollPeriod indicates an expected call of DeletePayrollPeriod.
+func (mr *MockAttendanceRepositoryMockRecorder) DeletePayrollPeriod(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePayrollPeriod", reflect.TypeOf((*MockAttendanceRepository)(nil).DeletePayrollPeriod), arg0, arg1)
+}
+
 // GetAttendance mocks base method.
 func (m *MockAttendanceRepository) GetAttendance(arg0 context.Context, arg1 model.MstAttendance) (model.MstAttendance, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +179,21 @@ func (mr *MockAttendanceRepositoryMockRecorder) GetAttendance(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttendance", reflect.TypeOf((*MockAttendanceRepository)(nil).GetAttendance), arg0, arg1)
 }
 
+// GetAttendanceCorrection mocks base method.
+func (m *MockAttendanceRepository) GetAttendanceCorrection(arg0 context.Context, arg1 int64) (model.TrxAttendanceCorrection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttendanceCorrection", arg0, arg1)
+	ret0, _ := ret[0].(model.TrxAttendanceCorrection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttendanceCorrection indicates an expected call of GetAttendanceCorrection.
+func (mr *MockAttendanceRepositoryMockRecorder) GetAttendanceCorrection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttendanceCorrection", reflect.TypeOf((*MockAttendanceRepository)(nil).GetAttendanceCorrection), arg0, arg1)
+}
+
 // GetOvertime mocks base method.
 func (m *MockAttendanceRepository) GetOvertime(arg0 context.Context, arg1 model.TrxOvertime) (model.TrxOvertime, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +224,21 @@ func (mr *MockAttendanceRepositoryMockRecorder) GetPayrollDetail(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayrollDetail", reflect.TypeOf((*MockAttendanceRepository)(nil).GetPayrollDetail), arg0, arg1)
 }
 
+// GetPayrollJob mocks base method.
+func (m *MockAttendanceRepository) GetPayrollJob(arg0 context.Context, arg1 int64) (model.TrxPayrollJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayrollJob", arg0, arg1)
+	ret0, _ := ret[0].(model.TrxPayrollJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayrollJob indicates an expected call of GetPayrollJob.
+func (mr *MockAttendanceRepositoryMockRecorder) GetPayrollJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayrollJob", reflect.TypeOf((*MockAttendanceRepository)(nil).GetPayrollJob), arg0, arg1)
+}
+
 // GetPayrollPeriod mocks base method.
 func (m *MockAttendanceRepository) GetPayrollPeriod(arg0 context.Context, arg1 int64) (model.MstPayrollPeriod, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +254,21 @@ func (mr *MockAttendanceRepositoryMockRecorder) GetPayrollPeriod(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayrollPeriod", reflect.TypeOf((*MockAttendanceRepository)(nil).GetPayrollPeriod), arg0, arg1)
 }
 
+// GetPayrollPeriodByDate mocks base method.
+func (m *MockAttendanceRepository) GetPayrollPeriodByDate(arg0 context.Context, arg1 time.Time) (model.MstPayrollPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayrollPeriodByDate", arg0, arg1)
+	ret0, _ := ret[0].(model.MstPayrollPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayrollPeriodByDate indicates an expected call of GetPayrollPeriodByDate.
+func (mr *MockAttendanceRepositoryMockRecorder) GetPayrollPeriodByDate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayrollPeriodByDate", reflect.TypeOf((*MockAttendanceRepository)(nil).GetPayrollPeriodByDate), arg0, arg1)
+}
+
 // GetPayslips mocks base method.
 func (m *MockAttendanceRepository) GetPayslips(arg0 context.Context, arg1 model.GetPayslipRequest) ([]model.TrxUserPayslip, error) {
 	m.ctrl.T.Helper()
@@ -139,6 +299,21 @@ func (mr *MockAttendanceRepositoryMockRecorder) ListAttendanceByParams(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttendanceByParams", reflect.TypeOf((*MockAttendanceRepository)(nil).ListAttendanceByParams), arg0, arg1)
 }
 
+// ListAttendanceCorrectionByParams mocks base method.
+func (m *MockAttendanceRepository) ListAttendanceCorrectionByParams(arg0 context.Context, arg1 model.ListAttendanceCorrectionParams) ([]model.TrxAttendanceCorrection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttendanceCorrectionByParams", arg0, arg1)
+	ret0, _ := ret[0].([]model.TrxAttendanceCorrection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttendanceCorrectionByParams indicates an expected call of ListAttendanceCorrectionByParams.
+func (mr *MockAttendanceRepositoryMockRecorder) ListAttendanceCorrectionByParams(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttendanceCorrectionByParams", reflect.TypeOf((*MockAttendanceRepository)(nil).ListAttendanceCorrectionByParams), arg0, arg1)
+}
+
 // ListOvertimeByParams mocks base method.
 func (m *MockAttendanceRepository) ListOvertimeByParams(arg0 context.Context, arg1 model.ListOvertimeParams) ([]model.TrxOvertime, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +329,36 @@ func (mr *MockAttendanceRepositoryMockRecorder) ListOvertimeByParams(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOvertimeByParams", reflect.TypeOf((*MockAttendanceRepository)(nil).ListOvertimeByParams), arg0, arg1)
 }
 
+// ListPayrollJobByParams mocks base method.
+func (m *MockAttendanceRepository) ListPayrollJobByParams(arg0 context.Context, arg1 model.ListPayrollJobParams) ([]model.TrxPayrollJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayrollJobByParams", arg0, arg1)
+	ret0, _ := ret[0].([]model.TrxPayrollJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayrollJobByParams indicates an expected call of ListPayrollJobByParams.
+func (mr *MockAttendanceRepositoryMockRecorder) ListPayrollJobByParams(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayrollJobByParams", reflect.TypeOf((*MockAttendanceRepository)(nil).ListPayrollJobByParams), arg0, arg1)
+}
+
+// ListPayrollPeriodByParams mocks base method.
+func (m *MockAttendanceRepository) ListPayrollPeriodByParams(arg0 context.Context, arg1 model.ListPayrollPeriodParams) ([]model.MstPayrollPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayrollPeriodByParams", arg0, arg1)
+	ret0, _ := ret[0].([]model.MstPayrollPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayrollPeriodByParams indicates an expected call of ListPayrollPeriodByParams.
+func (mr *MockAttendanceRepositoryMockRecorder) ListPayrollPeriodByParams(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayrollPeriodByParams", reflect.TypeOf((*MockAttendanceRepository)(nil).ListPayrollPeriodByParams), arg0, arg1)
+}
+
 // ListReimbursementByParams mocks base method.
 func (m *MockAttendanceRepository) ListReimbursementByParams(arg0 context.Context, arg1 model.ListReimbursementParams) ([]model.TrxReimbursement, error) {
 	m.ctrl.T.Helper()
@@ -169,6 +374,21 @@ func (mr *MockAttendanceRepositoryMockRecorder) ListReimbursementByParams(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReimbursementByParams", reflect.TypeOf((*MockAttendanceRepository)(nil).ListReimbursementByParams), arg0, arg1)
 }
 
+// ListSchedulerRunByParams mocks base method.
+func (m *MockAttendanceRepository) ListSchedulerRunByParams(arg0 context.Context, arg1 model.ListSchedulerRunParams) ([]model.TrxSchedulerRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSchedulerRunByParams", arg0, arg1)
+	ret0, _ := ret[0].([]model.TrxSchedulerRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchedulerRunByParams indicates an expected call of ListSchedulerRunByParams.
+func (mr *MockAttendanceRepositoryMockRecorder) ListSchedulerRunByParams(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedulerRunByParams", reflect.TypeOf((*MockAttendanceRepository)(nil).ListSchedulerRunByParams), arg0, arg1)
+}
+
 // RecordAttendance mocks base method.
 func (m *MockAttendanceRepository) RecordAttendance(arg0 context.Context, arg1 *model.MstAttendance) error {
 	m.ctrl.T.Helper()
@@ -183,6 +403,64 @@ func (mr *MockAttendanceRepositoryMockRecorder) RecordAttendance(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAttendance", reflect.TypeOf((*MockAttendanceRepository)(nil).RecordAttendance), arg0, arg1)
 }
 
+// RecordAttendances mocks base method.
+func (m *MockAttendanceRepository) RecordAttendances(arg0 context.Context, arg1 []*model.MstAttendance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAttendances", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAttendances indicates an expected call of RecordAttendances.
+func (mr *MockAttendanceRepositoryMockRecorder) RecordAttendances(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAttendances", reflect.TypeOf((*MockAttendanceRepository)(nil).RecordAttendances), arg0, arg1)
+}
+
+// RequeueStalePayrollJobs mocks base method.
+func (m *MockAttendanceRepository) RequeueStalePayrollJobs(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueStalePayrollJobs", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueStalePayrollJobs indicates an expected call of RequeueStalePayrollJobs.
+func (mr *MockAttendanceRepositoryMockRecorder) RequeueStalePayrollJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueStalePayrollJobs", reflect.TypeOf((*MockAttendanceRepository)(nil).RequeueStalePayrollJobs), arg0, arg1)
+}
+
+// ResetPayrollPeriodResults mocks base method.
+func (m *MockAttendanceRepository) ResetPayrollPeriodResults(arg0 context.Context, arg1 int64, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPayrollPeriodResults", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPayrollPeriodResults indicates an expected call of ResetPayrollPeriodResults.
+func (mr *MockAttendanceRepositoryMockRecorder) ResetPayrollPeriodResults(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPayrollPeriodResults", reflect.TypeOf((*MockAttendanceRepository)(nil).ResetPayrollPeriodResults), arg0, arg1, arg2)
+}
+
+// ResolveAttendanceCorrection mocks base method.
+func (m *MockAttendanceRepository) ResolveAttendanceCorrection(arg0 context.Context, arg1 *model.TrxAttendanceCorrection) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveAttendanceCorrection", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveAttendanceCorrection indicates an expected call of ResolveAttendanceCorrection.
+func (mr *MockAttendanceRepositoryMockRecorder) ResolveAttendanceCorrection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveAttendanceCorrection", reflect.TypeOf((*MockAttendanceRepository)(nil).ResolveAttendanceCorrection), arg0, arg1)
+}
+
 // SubmitOvertime mocks base method.
 func (m *MockAttendanceRepository) SubmitOvertime(arg0 context.Context, arg1 *model.TrxOvertime) error {
 	m.ctrl.T.Helper()
@@ -239,6 +517,20 @@ func (mr *MockAttendanceRepositoryMockRecorder) SubmitReimbursement(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitReimbursement", reflect.TypeOf((*MockAttendanceRepository)(nil).SubmitReimbursement), arg0, arg1)
 }
 
+// TouchPayrollJob mocks base method.
+func (m *MockAttendanceRepository) TouchPayrollJob(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchPayrollJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchPayrollJob indicates an expected call of TouchPayrollJob.
+func (mr *MockAttendanceRepositoryMockRecorder) TouchPayrollJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchPayrollJob", reflect.TypeOf((*MockAttendanceRepository)(nil).TouchPayrollJob), arg0, arg1)
+}
+
 // UpdateAttendance mocks base method.
 func (m *MockAttendanceRepository) UpdateAttendance(arg0 context.Context, arg1 *model.MstAttendance) error {
 	m.ctrl.T.Helper()
@@ -267,6 +559,20 @@ func (mr *MockAttendanceRepositoryMockRecorder) UpdateOvertime(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOvertime", reflect.TypeOf((*MockAttendanceRepository)(nil).UpdateOvertime), arg0, arg1)
 }
 
+// UpdatePayrollJob mocks base method.
+func (m *MockAttendanceRepository) UpdatePayrollJob(arg0 context.Context, arg1 *model.TrxPayrollJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePayrollJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePayrollJob indicates an expected call of UpdatePayrollJob.
+func (mr *MockAttendanceRepositoryMockRecorder) UpdatePayrollJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePayrollJob", reflect.TypeOf((*MockAttendanceRepository)(nil).UpdatePayrollJob), arg0, arg1)
+}
+
 // UpdatePayrollPeriod mocks base method.
 func (m *MockAttendanceRepository) UpdatePayrollPeriod(arg0 context.Context, arg1 *model.MstPayrollPeriod) error {
 	m.ctrl.T.Helper()
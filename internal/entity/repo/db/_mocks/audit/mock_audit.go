@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/audit (interfaces: AuditRepository)
+
+// Package audit is a generated GoMock package.
+package audit
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuditRepository is a mock of AuditRepository interface.
+type MockAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepositoryMockRecorder
+}
+
+// MockAuditRepositoryMockRecorder is the mock recorder for MockAuditRepository.
+type MockAuditRepositoryMockRecorder struct {
+	mock *MockAuditRepository
+}
+
+// NewMockAuditRepository creates a new mock instance.
+func NewMockAuditRepository(ctrl *gomock.Controller) *MockAuditRepository {
+	mock := &MockAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepository) EXPECT() *MockAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListAuditLogByParams mocks base method.
+func (m *MockAuditRepository) ListAuditLogByParams(arg0 context.Context, arg1 model.ListAuditLogParams) ([]model.TrxAuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditLogByParams", arg0, arg1)
+	ret0, _ := ret[0].([]model.TrxAuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditLogByParams indicates an expected call of ListAuditLogByParams.
+func (mr *MockAuditRepositoryMockRecorder) ListAuditLogByParams(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditLogByParams", reflect.TypeOf((*MockAuditRepository)(nil).ListAuditLogByParams), arg0, arg1)
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/kiosk (interfaces: KioskRepository)
+
+// Package kiosk is a generated GoMock package.
+package kiosk
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockKioskRepository is a mock of KioskRepository interface.
+type MockKioskRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockKioskRepositoryMockRecorder
+}
+
+// MockKioskRepositoryMockRecorder is the mock recorder for MockKioskRepository.
+type MockKioskRepositoryMockRecorder struct {
+	mock *MockKioskRepository
+}
+
+// NewMockKioskRepository creates a new mock instance.
+func NewMockKioskRepository(ctrl *gomock.Controller) *MockKioskRepository {
+	mock := &MockKioskRepository{ctrl: ctrl}
+	mock.recorder = &MockKioskRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKioskRepository) EXPECT() *MockKioskRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimNonce mocks base method.
+func (m *MockKioskRepository) ClaimNonce(arg0 context.Context, arg1 *model.TrxKioskNonce) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimNonce", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimNonce indicates an expected call of ClaimNonce.
+func (mr *MockKioskRepositoryMockRecorder) ClaimNonce(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNonce", reflect.TypeOf((*MockKioskRepository)(nil).ClaimNonce), arg0, arg1)
+}
+
+// DeactivateKiosk mocks base method.
+func (m *MockKioskRepository) DeactivateKiosk(arg0 context.Context, arg1, arg2 int64) (model.MstKiosk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateKiosk", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.MstKiosk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateKiosk indicates an expected call of DeactivateKiosk.
+func (mr *MockKioskRepositoryMockRecorder) DeactivateKiosk(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateKiosk", reflect.TypeOf((*MockKioskRepository)(nil).DeactivateKiosk), arg0, arg1, arg2)
+}
+
+// GetKioskByID mocks base method.
+func (m *MockKioskRepository) GetKioskByID(arg0 context.Context, arg1 int64) (model.MstKiosk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKioskByID", arg0, arg1)
+	ret0, _ := ret[0].(model.MstKiosk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKioskByID indicates an expected call of GetKioskByID.
+func (mr *MockKioskRepositoryMockRecorder) GetKioskByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKioskByID", reflect.TypeOf((*MockKioskRepository)(nil).GetKioskByID), arg0, arg1)
+}
+
+// GetKioskForSignIn mocks base method.
+func (m *MockKioskRepository) GetKioskForSignIn(arg0 context.Context, arg1 int64) (model.MstKiosk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKioskForSignIn", arg0, arg1)
+	ret0, _ := ret[0].(model.MstKiosk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKioskForSignIn indicates an expected call of GetKioskForSignIn.
+func (mr *MockKioskRepositoryMockRecorder) GetKioskForSignIn(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKioskForSignIn", reflect.TypeOf((*MockKioskRepository)(nil).GetKioskForSignIn), arg0, arg1)
+}
+
+// ListKiosks mocks base method.
+func (m *MockKioskRepository) ListKiosks(arg0 context.Context) ([]model.MstKiosk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKiosks", arg0)
+	ret0, _ := ret[0].([]model.MstKiosk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKiosks indicates an expected call of ListKiosks.
+func (mr *MockKioskRepositoryMockRecorder) ListKiosks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKiosks", reflect.TypeOf((*MockKioskRepository)(nil).ListKiosks), arg0)
+}
+
+// RegisterKiosk mocks base method.
+func (m *MockKioskRepository) RegisterKiosk(arg0 context.Context, arg1 *model.MstKiosk) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterKiosk", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterKiosk indicates an expected call of RegisterKiosk.
+func (mr *MockKioskRepositoryMockRecorder) RegisterKiosk(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterKiosk", reflect.TypeOf((*MockKioskRepository)(nil).RegisterKiosk), arg0, arg1)
+}
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/organization (interfaces: OrganizationRepository)
+
+// Package organization is a generated GoMock package.
+package organization
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOrganizationRepository is a mock of OrganizationRepository interface.
+type MockOrganizationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationRepositoryMockRecorder
+}
+
+// MockOrganizationRepositoryMockRecorder is the mock recorder for MockOrganizationRepository.
+type MockOrganizationRepositoryMockRecorder struct {
+	mock *MockOrganizationRepository
+}
+
+// NewMockOrganizationRepository creates a new mock instance.
+func NewMockOrganizationRepository(ctrl *gomock.Controller) *MockOrganizationRepository {
+	mock := &MockOrganizationRepository{ctrl: ctrl}
+	mock.recorder = &MockOrganizationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationRepository) EXPECT() *MockOrganizationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AssignOrganization mocks base method.
+func (m *MockOrganizationRepository) AssignOrganization(arg0 context.Context, arg1 *model.TrxUserAssignment) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignOrganization", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignOrganization indicates an expected call of AssignOrganization.
+func (mr *MockOrganizationRepositoryMockRecorder) AssignOrganization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignOrganization", reflect.TypeOf((*MockOrganizationRepository)(nil).AssignOrganization), arg0, arg1)
+}
+
+// CreateCostCentre mocks base method.
+func (m *MockOrganizationRepository) CreateCostCentre(arg0 context.Context, arg1 *model.MstCostCentre) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCostCentre", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCostCentre indicates an expected call of CreateCostCentre.
+func (mr *MockOrganizationRepositoryMockRecorder) CreateCostCentre(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCostCentre", reflect.TypeOf((*MockOrganizationRepository)(nil).CreateCostCentre), arg0, arg1)
+}
+
+// CreateDepartment mocks base method.
+func (m *MockOrganizationRepository) CreateDepartment(arg0 context.Context, arg1 *model.MstDepartment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDepartment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDepartment indicates an expected call of CreateDepartment.
+func (mr *MockOrganizationRepositoryMockRecorder) CreateDepartment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDepartment", reflect.TypeOf((*MockOrganizationRepository)(nil).CreateDepartment), arg0, arg1)
+}
+
+// ListAssignments mocks base method.
+func (m *MockOrganizationRepository) ListAssignments(arg0 context.Context, arg1 int64) ([]model.TrxUserAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAssignments", arg0, arg1)
+	ret0, _ := ret[0].([]model.TrxUserAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAssignments indicates an expected call of ListAssignments.
+func (mr *MockOrganizationRepositoryMockRecorder) ListAssignments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssignments", reflect.TypeOf((*MockOrganizationRepository)(nil).ListAssignments), arg0, arg1)
+}
+
+// ListCostCentres mocks base method.
+func (m *MockOrganizationRepository) ListCostCentres(arg0 context.Context, arg1 []int64) ([]model.MstCostCentre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCostCentres", arg0, arg1)
+	ret0, _ := ret[0].([]model.MstCostCentre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCostCentres indicates an expected call of ListCostCentres.
+func (mr *MockOrganizationRepositoryMockRecorder) ListCostCentres(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCostCentres", reflect.TypeOf((*MockOrganizationRepository)(nil).ListCostCentres), arg0, arg1)
+}
+
+// ListDepartments mocks base method.
+func (m *MockOrganizationRepository) ListDepartments(arg0 context.Context, arg1 []int64) ([]model.MstDepartment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDepartments", arg0, arg1)
+	ret0, _ := ret[0].([]model.MstDepartment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDepartments indicates an expected call of ListDepartments.
+func (mr *MockOrganizationRepositoryMockRecorder) ListDepartments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDepartments", reflect.TypeOf((*MockOrganizationRepository)(nil).ListDepartments), arg0, arg1)
+}
+
+// ListLabourCost mocks base method.
+func (m *MockOrganizationRepository) ListLabourCost(arg0 context.Context, arg1 model.LabourCostParams) ([]model.LabourCostRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLabourCost", arg0, arg1)
+	ret0, _ := ret[0].([]model.LabourCostRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLabourCost indicates an expected call of ListLabourCost.
+func (mr *MockOrganizationRepositoryMockRecorder) ListLabourCost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLabourCost", reflect.TypeOf((*MockOrganizationRepository)(nil).ListLabourCost), arg0, arg1)
+}
@@ -0,0 +1,168 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/schedule (interfaces: ScheduleRepository)
+
+// Package schedule is a generated GoMock package.
+package schedule
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockScheduleRepository is a mock of ScheduleRepository interface.
+type MockScheduleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduleRepositoryMockRecorder
+}
+
+// MockScheduleRepositoryMockRecorder is the mock recorder for MockScheduleRepository.
+type MockScheduleRepositoryMockRecorder struct {
+	mock *MockScheduleRepository
+}
+
+// NewMockScheduleRepository creates a new mock instance.
+func NewMockScheduleRepository(ctrl *gomock.Controller) *MockScheduleRepository {
+	mock := &MockScheduleRepository{ctrl: ctrl}
+	mock.recorder = &MockScheduleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduleRepository) EXPECT() *MockScheduleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AssignSchedule mocks base method.
+func (m *MockScheduleRepository) AssignSchedule(arg0 context.Context, arg1 *model.TrxUserSchedule) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignSchedule", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignSchedule indicates an expected call of AssignSchedule.
+func (mr *MockScheduleRepositoryMockRecorder) AssignSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignSchedule", reflect.TypeOf((*MockScheduleRepository)(nil).AssignSchedule), arg0, arg1)
+}
+
+// CreateShift mocks base method.
+func (m *MockScheduleRepository) CreateShift(arg0 context.Context, arg1 *model.MstShift) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShift", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateShift indicates an expected call of CreateShift.
+func (mr *MockScheduleRepositoryMockRecorder) CreateShift(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShift", reflect.TypeOf((*MockScheduleRepository)(nil).CreateShift), arg0, arg1)
+}
+
+// CreateWorkPattern mocks base method.
+func (m *MockScheduleRepository) CreateWorkPattern(arg0 context.Context, arg1 *model.MstWorkPattern, arg2 []model.DtlWorkPatternDay) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkPattern", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWorkPattern indicates an expected call of CreateWorkPattern.
+func (mr *MockScheduleRepositoryMockRecorder) CreateWorkPattern(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkPattern", reflect.TypeOf((*MockScheduleRepository)(nil).CreateWorkPattern), arg0, arg1, arg2)
+}
+
+// ListRosters mocks base method.
+func (m *MockScheduleRepository) ListRosters(arg0 context.Context, arg1 model.ListScheduleParams) ([]model.TrxUserRoster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRosters", arg0, arg1)
+	ret0, _ := ret[0].([]model.TrxUserRoster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRosters indicates an expected call of ListRosters.
+func (mr *MockScheduleRepositoryMockRecorder) ListRosters(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRosters", reflect.TypeOf((*MockScheduleRepository)(nil).ListRosters), arg0, arg1)
+}
+
+// ListSchedules mocks base method.
+func (m *MockScheduleRepository) ListSchedules(arg0 context.Context, arg1 model.ListScheduleParams) ([]model.TrxUserSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSchedules", arg0, arg1)
+	ret0, _ := ret[0].([]model.TrxUserSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchedules indicates an expected call of ListSchedules.
+func (mr *MockScheduleRepositoryMockRecorder) ListSchedules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedules", reflect.TypeOf((*MockScheduleRepository)(nil).ListSchedules), arg0, arg1)
+}
+
+// ListShifts mocks base method.
+func (m *MockScheduleRepository) ListShifts(arg0 context.Context) ([]model.MstShift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShifts", arg0)
+	ret0, _ := ret[0].([]model.MstShift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShifts indicates an expected call of ListShifts.
+func (mr *MockScheduleRepositoryMockRecorder) ListShifts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShifts", reflect.TypeOf((*MockScheduleRepository)(nil).ListShifts), arg0)
+}
+
+// ListWorkPatternDays mocks base method.
+func (m *MockScheduleRepository) ListWorkPatternDays(arg0 context.Context, arg1 []int64) ([]model.DtlWorkPatternDay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkPatternDays", arg0, arg1)
+	ret0, _ := ret[0].([]model.DtlWorkPatternDay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkPatternDays indicates an expected call of ListWorkPatternDays.
+func (mr *MockScheduleRepositoryMockRecorder) ListWorkPatternDays(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkPatternDays", reflect.TypeOf((*MockScheduleRepository)(nil).ListWorkPatternDays), arg0, arg1)
+}
+
+// ListWorkPatterns mocks base method.
+func (m *MockScheduleRepository) ListWorkPatterns(arg0 context.Context, arg1 []int64) ([]model.MstWorkPattern, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkPatterns", arg0, arg1)
+	ret0, _ := ret[0].([]model.MstWorkPattern)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkPatterns indicates an expected call of ListWorkPatterns.
+func (mr *MockScheduleRepositoryMockRecorder) ListWorkPatterns(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkPatterns", reflect.TypeOf((*MockScheduleRepository)(nil).ListWorkPatterns), arg0, arg1)
+}
+
+// SetRoster mocks base method.
+func (m *MockScheduleRepository) SetRoster(arg0 context.Context, arg1 []model.TrxUserRoster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRoster", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRoster indicates an expected call of SetRoster.
+func (mr *MockScheduleRepositoryMockRecorder) SetRoster(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRoster", reflect.TypeOf((*MockScheduleRepository)(nil).SetRoster), arg0, arg1)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/tenant (interfaces: TenantRepository)
+
+// Package tenant is a generated GoMock package.
+package tenant
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTenantRepository is a mock of TenantRepository interface.
+type MockTenantRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantRepositoryMockRecorder
+}
+
+// MockTenantRepositoryMockRecorder is the mock recorder for MockTenantRepository.
+type MockTenantRepositoryMockRecorder struct {
+	mock *MockTenantRepository
+}
+
+// NewMockTenantRepository creates a new mock instance.
+func NewMockTenantRepository(ctrl *gomock.Controller) *MockTenantRepository {
+	mock := &MockTenantRepository{ctrl: ctrl}
+	mock.recorder = &MockTenantRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantRepository) EXPECT() *MockTenantRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetTenant mocks base method.
+func (m *MockTenantRepository) GetTenant(arg0 context.Context) (model.MstTenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenant", arg0)
+	ret0, _ := ret[0].(model.MstTenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenant indicates an expected call of GetTenant.
+func (mr *MockTenantRepositoryMockRecorder) GetTenant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenant", reflect.TypeOf((*MockTenantRepository)(nil).GetTenant), arg0)
+}
+
+// ListTenants mocks base method.
+func (m *MockTenantRepository) ListTenants(arg0 context.Context) ([]model.MstTenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTenants", arg0)
+	ret0, _ := ret[0].([]model.MstTenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTenants indicates an expected call of ListTenants.
+func (mr *MockTenantRepositoryMockRecorder) ListTenants(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTenants", reflect.TypeOf((*MockTenantRepository)(nil).ListTenants), arg0)
+}
+
+// UpdatePayrollPolicy mocks base method.
+func (m *MockTenantRepository) UpdatePayrollPolicy(arg0 context.Context, arg1 model.PayrollPolicyRequest, arg2 int64) (model.MstTenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePayrollPolicy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.MstTenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePayrollPolicy indicates an expected call of UpdatePayrollPolicy.
+func (mr *MockTenantRepositoryMockRecorder) UpdatePayrollPolicy(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePayrollPolicy", reflect.TypeOf((*MockTenantRepository)(nil).UpdatePayrollPolicy), arg0, arg1, arg2)
+}
@@ -6,7 +6,9 @@ package user
 
 import (
 	context "context"
+	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/faisalhardin/employee-payroll-system/internal/entity/model"
 	gomock "github.com/golang/mock/gomock"
@@ -35,19 +37,64 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
-// GetUser mocks base method.
-func (m *MockUserRepository) GetUser(arg0 context.Context, arg1 model.SignInRequest) (model.MstUser, error) {
+// BindDevice mocks base method.
+func (m *MockUserRepository) BindDevice(arg0 context.Context, arg1 int64, arg2 string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "BindDevice", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BindDevice indicates an expected call of BindDevice.
+func (mr *MockUserRepositoryMockRecorder) BindDevice(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindDevice", reflect.TypeOf((*MockUserRepository)(nil).BindDevice), arg0, arg1, arg2)
+}
+
+// GetUserByBadgeID mocks base method.
+func (m *MockUserRepository) GetUserByBadgeID(arg0 context.Context, arg1 string) (model.MstUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByBadgeID", arg0, arg1)
 	ret0, _ := ret[0].(model.MstUser)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockUserRepositoryMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+// GetUserByBadgeID indicates an expected call of GetUserByBadgeID.
+func (mr *MockUserRepositoryMockRecorder) GetUserByBadgeID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserRepository)(nil).GetUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByBadgeID", reflect.TypeOf((*MockUserRepository)(nil).GetUserByBadgeID), arg0, arg1)
+}
+
+// GetUserByID mocks base method.
+func (m *MockUserRepository) GetUserByID(arg0 context.Context, arg1 int64) (model.MstUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", arg0, arg1)
+	ret0, _ := ret[0].(model.MstUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockUserRepositoryMockRecorder) GetUserByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserRepository)(nil).GetUserByID), arg0, arg1)
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockUserRepository) GetUserByUsername(arg0 context.Context, arg1 string) (model.MstUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", arg0, arg1)
+	ret0, _ := ret[0].(model.MstUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockUserRepositoryMockRecorder) GetUserByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockUserRepository)(nil).GetUserByUsername), arg0, arg1)
 }
 
 // ListUser mocks base method.
@@ -64,3 +111,106 @@ func (mr *MockUserRepositoryMockRecorder) ListUser(arg0 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUser", reflect.TypeOf((*MockUserRepository)(nil).ListUser), arg0)
 }
+
+// ListUsersByBadgeIDs mocks base method.
+func (m *MockUserRepository) ListUsersByBadgeIDs(arg0 context.Context, arg1 []string) ([]model.MstUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsersByBadgeIDs", arg0, arg1)
+	ret0, _ := ret[0].([]model.MstUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsersByBadgeIDs indicates an expected call of ListUsersByBadgeIDs.
+func (mr *MockUserRepositoryMockRecorder) ListUsersByBadgeIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersByBadgeIDs", reflect.TypeOf((*MockUserRepository)(nil).ListUsersByBadgeIDs), arg0, arg1)
+}
+
+// RecordLoginAttempt mocks base method.
+func (m *MockUserRepository) RecordLoginAttempt(arg0 context.Context, arg1 *model.TrxLoginAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordLoginAttempt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordLoginAttempt indicates an expected call of RecordLoginAttempt.
+func (mr *MockUserRepositoryMockRecorder) RecordLoginAttempt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLoginAttempt", reflect.TypeOf((*MockUserRepository)(nil).RecordLoginAttempt), arg0, arg1)
+}
+
+// RegisterFailedLogin mocks base method.
+func (m *MockUserRepository) RegisterFailedLogin(arg0 context.Context, arg1 int64, arg2 int, arg3 time.Time) (sql.NullTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterFailedLogin", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(sql.NullTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterFailedLogin indicates an expected call of RegisterFailedLogin.
+func (mr *MockUserRepositoryMockRecorder) RegisterFailedLogin(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFailedLogin", reflect.TypeOf((*MockUserRepository)(nil).RegisterFailedLogin), arg0, arg1, arg2, arg3)
+}
+
+// ResetDevice mocks base method.
+func (m *MockUserRepository) ResetDevice(arg0 context.Context, arg1 int64) (model.MstUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetDevice", arg0, arg1)
+	ret0, _ := ret[0].(model.MstUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetDevice indicates an expected call of ResetDevice.
+func (mr *MockUserRepositoryMockRecorder) ResetDevice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetDevice", reflect.TypeOf((*MockUserRepository)(nil).ResetDevice), arg0, arg1)
+}
+
+// ResetFailedLogins mocks base method.
+func (m *MockUserRepository) ResetFailedLogins(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetFailedLogins", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetFailedLogins indicates an expected call of ResetFailedLogins.
+func (mr *MockUserRepositoryMockRecorder) ResetFailedLogins(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetFailedLogins", reflect.TypeOf((*MockUserRepository)(nil).ResetFailedLogins), arg0, arg1)
+}
+
+// SetManager mocks base method.
+func (m *MockUserRepository) SetManager(arg0 context.Context, arg1 int64, arg2 sql.NullInt64) (model.MstUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetManager", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.MstUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetManager indicates an expected call of SetManager.
+func (mr *MockUserRepositoryMockRecorder) SetManager(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetManager", reflect.TypeOf((*MockUserRepository)(nil).SetManager), arg0, arg1, arg2)
+}
+
+// UnlockUser mocks base method.
+func (m *MockUserRepository) UnlockUser(arg0 context.Context, arg1 int64) (model.MstUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", arg0, arg1)
+	ret0, _ := ret[0].(model.MstUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockUserRepositoryMockRecorder) UnlockUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockUserRepository)(nil).UnlockUser), arg0, arg1)
+}
@@ -2,6 +2,7 @@ package attendance
 
 import (
 	"context"
+	"time"
 
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
 	_ "github.com/golang/mock/mockgen/model"
@@ -10,12 +11,16 @@ import (
 //go:generate go run -mod=mod github.com/golang/mock/mockgen -self_package=github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/attendance -destination=../_mocks/attendance/mock_attendance.go -package=attendance github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/attendance AttendanceRepository
 type AttendanceRepository interface {
 	RecordAttendance(ctx context.Context, attendance *model.MstAttendance) error
+	RecordAttendances(ctx context.Context, attendances []*model.MstAttendance) error
 	GetAttendance(ctx context.Context, params model.MstAttendance) (res model.MstAttendance, err error)
 	ListAttendanceByParams(ctx context.Context, params model.ListAttendanceParams) (res []model.MstAttendance, err error)
 	UpdateAttendance(ctx context.Context, attendance *model.MstAttendance) (err error)
 	CreatePayrollPeriod(ctx context.Context, payrolPeriod *model.MstPayrollPeriod) (err error)
 	GetPayrollPeriod(ctx context.Context, id int64) (res model.MstPayrollPeriod, err error)
+	GetPayrollPeriodByDate(ctx context.Context, date time.Time) (res model.MstPayrollPeriod, err error)
+	ListPayrollPeriodByParams(ctx context.Context, params model.ListPayrollPeriodParams) (res []model.MstPayrollPeriod, err error)
 	UpdatePayrollPeriod(ctx context.Context, payrolPeriod *model.MstPayrollPeriod) (err error)
+	DeletePayrollPeriod(ctx context.Context, id int64) (err error)
 	SubmitOvertime(ctx context.Context, overtime *model.TrxOvertime) (err error)
 	GetOvertime(ctx context.Context, params model.TrxOvertime) (res model.TrxOvertime, err error)
 	UpdateOvertime(ctx context.Context, overtime *model.TrxOvertime) (err error)
@@ -26,7 +31,27 @@ type AttendanceRepository interface {
 	UpdateReimbursement(ctx context.Context, reimbursement *model.TrxReimbursement) (err error)
 
 	SubmitPayslips(ctx context.Context, payslips []model.TrxUserPayslip) (err error)
+	AssignAttendancePayrollPeriod(ctx context.Context, params model.AssignPayrollPeriodParams) (err error)
+	AssignOvertimePayrollPeriod(ctx context.Context, params model.AssignPayrollPeriodParams) (err error)
+	AssignReimbursementPayrollPeriod(ctx context.Context, params model.AssignPayrollPeriodParams) (err error)
 	GetPayslips(ctx context.Context, params model.GetPayslipRequest) (payslips []model.TrxUserPayslip, err error)
 	SubmitPayroll(ctx context.Context, payroll model.DtlPayroll) (err error)
 	GetPayrollDetail(ctx context.Context, params model.GetDtlPayrollRequest) (payrollDetail model.DtlPayroll, err error)
+
+	CreateAttendanceCorrection(ctx context.Context, correction *model.TrxAttendanceCorrection) (err error)
+	GetAttendanceCorrection(ctx context.Context, id int64) (res model.TrxAttendanceCorrection, err error)
+	ListAttendanceCorrectionByParams(ctx context.Context, params model.ListAttendanceCorrectionParams) (res []model.TrxAttendanceCorrection, err error)
+	ResolveAttendanceCorrection(ctx context.Context, correction *model.TrxAttendanceCorrection) (resolved bool, err error)
+
+	CreateSchedulerRun(ctx context.Context, run *model.TrxSchedulerRun) (err error)
+	ListSchedulerRunByParams(ctx context.Context, params model.ListSchedulerRunParams) (res []model.TrxSchedulerRun, err error)
+
+	CreatePayrollJob(ctx context.Context, job *model.TrxPayrollJob) (err error)
+	GetPayrollJob(ctx context.Context, id int64) (res model.TrxPayrollJob, err error)
+	ListPayrollJobByParams(ctx context.Context, params model.ListPayrollJobParams) (res []model.TrxPayrollJob, err error)
+	UpdatePayrollJob(ctx context.Context, job *model.TrxPayrollJob) (err error)
+	TouchPayrollJob(ctx context.Context, id int64) (err error)
+	ClaimPayrollJob(ctx context.Context) (job model.TrxPayrollJob, found bool, err error)
+	RequeueStalePayrollJobs(ctx context.Context, staleBefore time.Time) (affected int64, err error)
+	ResetPayrollPeriodResults(ctx context.Context, payrollPeriodID int64, pendingReimbursementStatus string) (err error)
 }
@@ -0,0 +1,12 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen -self_package=github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/audit -destination=../_mocks/audit/mock_audit.go -package=audit github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/audit AuditRepository
+type AuditRepository interface {
+	ListAuditLogByParams(ctx context.Context, params model.ListAuditLogParams) (res []model.TrxAuditLog, err error)
+}
@@ -0,0 +1,17 @@
+package kiosk
+
+import (
+	"context"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen -self_package=github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/kiosk -destination=../_mocks/kiosk/mock_kiosk.go -package=kiosk github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/kiosk KioskRepository
+type KioskRepository interface {
+	RegisterKiosk(ctx context.Context, kiosk *model.MstKiosk) (err error)
+	GetKioskByID(ctx context.Context, kioskID int64) (res model.MstKiosk, err error)
+	GetKioskForSignIn(ctx context.Context, kioskID int64) (res model.MstKiosk, err error)
+	ListKiosks(ctx context.Context) (res []model.MstKiosk, err error)
+	DeactivateKiosk(ctx context.Context, kioskID, updatedBy int64) (kiosk model.MstKiosk, err error)
+	ClaimNonce(ctx context.Context, nonce *model.TrxKioskNonce) (claimed bool, err error)
+}
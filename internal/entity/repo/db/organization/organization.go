@@ -0,0 +1,18 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen -self_package=github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/organization -destination=../_mocks/organization/mock_organization.go -package=organization github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/organization OrganizationRepository
+type OrganizationRepository interface {
+	CreateDepartment(ctx context.Context, department *model.MstDepartment) (err error)
+	ListDepartments(ctx context.Context, ids []int64) (res []model.MstDepartment, err error)
+	CreateCostCentre(ctx context.Context, costCentre *model.MstCostCentre) (err error)
+	ListCostCentres(ctx context.Context, ids []int64) (res []model.MstCostCentre, err error)
+	AssignOrganization(ctx context.Context, assignment *model.TrxUserAssignment) (assigned bool, err error)
+	ListAssignments(ctx context.Context, userID int64) (res []model.TrxUserAssignment, err error)
+	ListLabourCost(ctx context.Context, params model.LabourCostParams) (res []model.LabourCostRow, err error)
+}
@@ -0,0 +1,20 @@
+package schedule
+
+import (
+	"context"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen -self_package=github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/schedule -destination=../_mocks/schedule/mock_schedule.go -package=schedule github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/schedule ScheduleRepository
+type ScheduleRepository interface {
+	CreateShift(ctx context.Context, shift *model.MstShift) (err error)
+	ListShifts(ctx context.Context) (res []model.MstShift, err error)
+	CreateWorkPattern(ctx context.Context, pattern *model.MstWorkPattern, days []model.DtlWorkPatternDay) (err error)
+	ListWorkPatterns(ctx context.Context, ids []int64) (res []model.MstWorkPattern, err error)
+	ListWorkPatternDays(ctx context.Context, patternIDs []int64) (res []model.DtlWorkPatternDay, err error)
+	AssignSchedule(ctx context.Context, schedule *model.TrxUserSchedule) (assigned bool, err error)
+	ListSchedules(ctx context.Context, params model.ListScheduleParams) (res []model.TrxUserSchedule, err error)
+	SetRoster(ctx context.Context, rosters []model.TrxUserRoster) (err error)
+	ListRosters(ctx context.Context, params model.ListScheduleParams) (res []model.TrxUserRoster, err error)
+}
@@ -0,0 +1,14 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen -self_package=github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/tenant -destination=../_mocks/tenant/mock_tenant.go -package=tenant github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/tenant TenantRepository
+type TenantRepository interface {
+	ListTenants(ctx context.Context) (res []model.MstTenant, err error)
+	GetTenant(ctx context.Context) (res model.MstTenant, err error)
+	UpdatePayrollPolicy(ctx context.Context, policy model.PayrollPolicyRequest, updatedBy int64) (before model.MstTenant, err error)
+}
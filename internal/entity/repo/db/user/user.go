@@ -2,12 +2,24 @@ package user
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
 )
 
 //go:generate go run -mod=mod github.com/golang/mock/mockgen -self_package=github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/user -destination=../_mocks/user/mock_user.go -package=user github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/user UserRepository
 type UserRepository interface {
-	GetUser(ctx context.Context, params model.SignInRequest) (res model.MstUser, err error)
+	GetUserByUsername(ctx context.Context, username string) (res model.MstUser, err error)
+	GetUserByID(ctx context.Context, userID int64) (res model.MstUser, err error)
+	GetUserByBadgeID(ctx context.Context, badgeID string) (res model.MstUser, err error)
+	ListUsersByBadgeIDs(ctx context.Context, badgeIDs []string) (res []model.MstUser, err error)
 	ListUser(ctx context.Context) (res []model.MstUser, err error)
+	RecordLoginAttempt(ctx context.Context, attempt *model.TrxLoginAttempt) (err error)
+	RegisterFailedLogin(ctx context.Context, userID int64, maxAttempts int, lockUntil time.Time) (lockedUntil sql.NullTime, err error)
+	ResetFailedLogins(ctx context.Context, userID int64) (err error)
+	UnlockUser(ctx context.Context, userID int64) (user model.MstUser, err error)
+	BindDevice(ctx context.Context, userID int64, deviceID string) (bound bool, err error)
+	ResetDevice(ctx context.Context, userID int64) (user model.MstUser, err error)
+	SetManager(ctx context.Context, userID int64, managerID sql.NullInt64) (user model.MstUser, err error)
 }
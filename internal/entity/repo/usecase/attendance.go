@@ -8,13 +8,48 @@ import (
 
 //go:generate go run -mod=mod github.com/golang/mock/mockgen -self_package=github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase -destination=../_mocks/mock_attendance_usecase.go -package=mock github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase AttendanceUsecaseRepository
 type AttendanceUsecaseRepository interface {
-	TapIn(ctx context.Context, tapInRequest model.MstAttendance) (resp model.TapInResponse, err error)
+	TapIn(ctx context.Context, tapInRequest model.TapInRequest) (resp model.TapInResponse, err error)
+	TapInAtKiosk(ctx context.Context, request model.KioskTapInRequest) (resp model.TapInResponse, err error)
+	TapInWithBadge(ctx context.Context, request model.BadgeTapInRequest) (resp model.TapInResponse, err error)
+	ImportAttendance(ctx context.Context, request model.ImportAttendanceRequest) (resp model.ImportAttendanceResponse, err error)
+	RequestAttendanceCorrection(ctx context.Context, request model.AttendanceCorrectionRequest) (resp model.AttendanceCorrectionResponse, err error)
+	ListMyAttendanceCorrections(ctx context.Context, request model.ListAttendanceCorrectionRequest) (resp model.ListAttendanceCorrectionResponse, err error)
+	ListAttendanceCorrections(ctx context.Context, request model.ListAttendanceCorrectionRequest) (resp model.ListAttendanceCorrectionResponse, err error)
+	ApproveAttendanceCorrection(ctx context.Context, request model.ReviewAttendanceCorrectionRequest) (resp model.AttendanceCorrectionResponse, err error)
+	RejectAttendanceCorrection(ctx context.Context, request model.ReviewAttendanceCorrectionRequest) (resp model.AttendanceCorrectionResponse, err error)
+	AdjustAttendance(ctx context.Context, request model.AdjustAttendanceRequest) (resp model.AttendanceCorrectionResponse, err error)
+	CreateShift(ctx context.Context, request model.ShiftRequest) (resp model.MstShift, err error)
+	ListShifts(ctx context.Context) (resp []model.MstShift, err error)
+	CreateWorkPattern(ctx context.Context, request model.WorkPatternRequest) (resp model.WorkPatternResponse, err error)
+	ListWorkPatterns(ctx context.Context) (resp []model.WorkPatternResponse, err error)
+	AssignSchedule(ctx context.Context, request model.AssignScheduleRequest) (resp model.TrxUserSchedule, err error)
+	SetRoster(ctx context.Context, request model.RosterRequest) (resp []model.ScheduleDayResponse, err error)
+	GetSchedule(ctx context.Context, request model.GetScheduleRequest) (resp model.GetScheduleResponse, err error)
+	CreateDepartment(ctx context.Context, request model.DepartmentRequest) (resp model.MstDepartment, err error)
+	ListDepartments(ctx context.Context) (resp []model.MstDepartment, err error)
+	CreateCostCentre(ctx context.Context, request model.CostCentreRequest) (resp model.MstCostCentre, err error)
+	ListCostCentres(ctx context.Context) (resp []model.MstCostCentre, err error)
+	AssignOrganization(ctx context.Context, request model.AssignOrganizationRequest) (resp model.TrxUserAssignment, err error)
+	ListAssignments(ctx context.Context, userID int64) (resp []model.AssignmentResponse, err error)
 	CreatePayrollPeriod(ctx context.Context, payrollPeriodRequest model.PayrollPeriodRequest) (resp model.PayrollPeriodResponse, err error)
+	ListPayrollPeriods(ctx context.Context, request model.ListPayrollPeriodRequest) (resp model.ListPayrollPeriodResponse, err error)
+	GetPayrollPeriod(ctx context.Context, id int64) (resp model.PayrollPeriodResponse, err error)
+	UpdatePayrollPeriod(ctx context.Context, id int64, payrollPeriodRequest model.PayrollPeriodRequest) (resp model.PayrollPeriodResponse, err error)
+	DeletePayrollPeriod(ctx context.Context, id int64) (err error)
+	ListPayrollScheduleRuns(ctx context.Context, request model.ListSchedulerRunRequest) (resp model.ListSchedulerRunResponse, err error)
 	SubmitOvertime(ctx context.Context, overtimeRequest model.SubmitOvertimeRequest) (resp model.SubmitOvertimeResponse, err error)
 
-	GeneratePayroll(ctx context.Context, request model.GeneratePayrollRequest) (err error)
+	EnqueuePayrollGeneration(ctx context.Context, request model.GeneratePayrollRequest) (resp model.PayrollJobResponse, err error)
+	GetPayrollJob(ctx context.Context, id int64) (resp model.PayrollJobResponse, err error)
+	RunPayrollJob(ctx context.Context, job model.TrxPayrollJob) (err error)
 	GetPayroll(ctx context.Context, request model.GetPayrollRequest) (payrollSummary model.GetPayrollResponse, err error)
+	GetLabourCost(ctx context.Context, request model.LabourCostRequest) (resp model.LabourCostResponse, err error)
 	GetEmployeePayslip(ctx context.Context, request model.GetPayslipRequest) (payslip model.GetPayslipResponse, err error)
 
 	SubmitReimbursement(ctx context.Context, submitReimbursementRequest model.SubmitReimbursementRequest) (resp model.SubmitReimbursementResponse, err error)
+
+	ListMyPayslips(ctx context.Context, request model.ListMyPayslipRequest) (resp model.ListMyPayslipResponse, err error)
+	ListMyAttendance(ctx context.Context, request model.ListMyAttendanceRequest) (resp model.ListMyAttendanceResponse, err error)
+	ListMyOvertime(ctx context.Context, request model.ListMyOvertimeRequest) (resp model.ListMyOvertimeResponse, err error)
+	ListMyReimbursements(ctx context.Context, request model.ListMyReimbursementRequest) (resp model.ListMyReimbursementResponse, err error)
 }
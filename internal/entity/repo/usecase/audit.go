@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen -self_package=github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase -destination=../_mocks/mock_audit_usecase.go -package=mock github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase AuditUsecaseRepository
+type AuditUsecaseRepository interface {
+	ListAuditLogs(ctx context.Context, request model.ListAuditLogRequest) (resp model.ListAuditLogResponse, err error)
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen -self_package=github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase -destination=../_mocks/mock_kiosk_usecase.go -package=mock github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase KioskUsecaseRepository
+type KioskUsecaseRepository interface {
+	RegisterKiosk(ctx context.Context, request model.RegisterKioskRequest) (resp model.RegisterKioskResponse, err error)
+	ListKiosks(ctx context.Context) (resp []model.MstKiosk, err error)
+	DeactivateKiosk(ctx context.Context, kioskID int64) (resp model.MstKiosk, err error)
+	SignIn(ctx context.Context, request model.KioskSignInRequest) (token string, err error)
+	IssueNonce(ctx context.Context) (resp model.KioskNonceResponse, err error)
+}
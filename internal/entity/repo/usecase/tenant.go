@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+)
+
+//go:generate go run -mod=mod github.com/golang/mock/mockgen -self_package=github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase -destination=../_mocks/mock_tenant_usecase.go -package=mock github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase TenantUsecaseRepository
+type TenantUsecaseRepository interface {
+	GetTenant(ctx context.Context) (resp model.MstTenant, err error)
+	UpdatePayrollPolicy(ctx context.Context, request model.PayrollPolicyRequest) (resp model.MstTenant, err error)
+}
@@ -9,4 +9,7 @@ import (
 //go:generate go run -mod=mod github.com/golang/mock/mockgen -self_package=github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase -destination=../_mocks/mock_user_usecase.go -package=mock github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase UserUsecaseRepository
 type UserUsecaseRepository interface {
 	SignIn(ctx context.Context, params model.SignInRequest) (jwt string, err error)
+	UnlockUser(ctx context.Context, userID int64) (resp model.UnlockUserResponse, err error)
+	ResetUserDevice(ctx context.Context, userID int64) (resp model.ResetUserDeviceResponse, err error)
+	SetManager(ctx context.Context, userID int64, request model.SetManagerRequest) (resp model.SetManagerResponse, err error)
 }
@@ -2,9 +2,15 @@ package attendance
 
 import (
 	"context"
+	"time"
 
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/internal/repo/db/audit"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
 	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/go-xorm/xorm"
 	"github.com/lib/pq"
 	"github.com/pkg/errors"
 )
@@ -25,18 +31,80 @@ func New(conn *Conn) *Conn {
 	return conn
 }
 
-func (c *Conn) RecordAttendance(ctx context.Context, attendance *model.MstAttendance) error {
-	session := c.DB.MasterDB.Table(MstAttendanceTable)
-	_, err := session.InsertOne(attendance)
+func (c *Conn) RecordAttendance(ctx context.Context, attendance *model.MstAttendance) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.RecordAttendance")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.RecordAttendance")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, WrapMsgCreate)
+	}
+	attendance.IDMstTenant = tenantID
+
+	err = insertAudited(ctx, c.DB, MstAttendanceTable, attendance, func() int64 { return attendance.ID })
 	if err != nil {
 		return errors.Wrap(err, WrapMsgCreate)
 	}
 	return nil
 }
 
+// RecordAttendances inserts the attendances in one transaction, either all
+// of them are recorded or none
+func (c *Conn) RecordAttendances(ctx context.Context, attendances []*model.MstAttendance) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.RecordAttendances")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.RecordAttendances")
+	defer finish(&err)
+
+	if len(attendances) == 0 {
+		return nil
+	}
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.RecordAttendances")
+	}
+	for _, attendance := range attendances {
+		attendance.IDMstTenant = tenantID
+	}
+
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		entries := make([]audit.Entry, 0, len(attendances))
+		for _, attendance := range attendances {
+			_, err := session.Table(MstAttendanceTable).InsertOne(attendance)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, audit.Entry{
+				Action:   constant.AuditActionCreate,
+				Entity:   MstAttendanceTable,
+				EntityID: attendance.ID,
+				After:    attendance,
+			})
+		}
+		return entries, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "conn.RecordAttendances")
+	}
+	return nil
+}
+
 func (c *Conn) GetAttendance(ctx context.Context, params model.MstAttendance) (res model.MstAttendance, err error) {
-	session := c.DB.MasterDB.Table(MstAttendanceTable)
-	_, err = session.Where("id_mst_user = ? AND attendance_date = ?", params.IDMstUser, params.AttendanceDate.Format("2006-01-02")).Get(&res)
+	ctx, span := tracing.Start(ctx, "attendance.Conn.GetAttendance")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.GetAttendance")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.GetAttendance")
+	}
+
+	session := c.DB.Reader(ctx).Table(MstAttendanceTable)
+	_, err = session.Where("id_mst_tenant = ? AND id_mst_user = ? AND attendance_date = ?", tenantID, params.IDMstUser, params.AttendanceDate.Format("2006-01-02")).Get(&res)
 	if err != nil {
 		return res, errors.Wrap(err, "conn.GetAttendance")
 	}
@@ -44,7 +112,17 @@ func (c *Conn) GetAttendance(ctx context.Context, params model.MstAttendance) (r
 }
 
 func (c *Conn) ListAttendanceByParams(ctx context.Context, params model.ListAttendanceParams) (res []model.MstAttendance, err error) {
-	session := c.DB.MasterDB.Table(MstAttendanceTable)
+	ctx, span := tracing.Start(ctx, "attendance.Conn.ListAttendanceByParams")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.ListAttendanceByParams")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.ListAttenanceByParams")
+	}
+
+	session := c.DB.Reader(ctx).Table(MstAttendanceTable).Where("id_mst_tenant = ?", tenantID)
 
 	if len(params.IDsMstUser) > 0 {
 		session.Where("id_mst_user = ANY(?)", pq.Array(params.IDsMstUser))
@@ -62,6 +140,14 @@ func (c *Conn) ListAttendanceByParams(ctx context.Context, params model.ListAtte
 		session.Where("id_mst_payroll_period is null")
 	}
 
+	if params.Cursor > 0 {
+		session.Where("id > ?", params.Cursor)
+	}
+
+	if params.Limit > 0 {
+		session.Limit(params.Limit)
+	}
+
 	err = session.
 		OrderBy("id ASC").
 		Find(&res)
@@ -72,8 +158,12 @@ func (c *Conn) ListAttendanceByParams(ctx context.Context, params model.ListAtte
 }
 
 func (c *Conn) UpdateAttendance(ctx context.Context, attendance *model.MstAttendance) (err error) {
-	session := c.DB.MasterDB.Table(MstAttendanceTable)
-	_, err = session.Where("id = ?", attendance.ID).Update(attendance)
+	ctx, span := tracing.Start(ctx, "attendance.Conn.UpdateAttendance")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.UpdateAttendance")
+	defer finish(&err)
+
+	err = updateAudited(ctx, c.DB, MstAttendanceTable, attendance.ID, attendance)
 	if err != nil {
 		return errors.Wrap(err, "conn.UpdateAttendance")
 	}
@@ -81,8 +171,18 @@ func (c *Conn) UpdateAttendance(ctx context.Context, attendance *model.MstAttend
 }
 
 func (c *Conn) CreatePayrollPeriod(ctx context.Context, payrolPeriod *model.MstPayrollPeriod) (err error) {
-	session := c.DB.MasterDB.Table(MstPayrollPeriodTable)
-	_, err = session.InsertOne(payrolPeriod)
+	ctx, span := tracing.Start(ctx, "attendance.Conn.CreatePayrollPeriod")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.CreatePayrollPeriod")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.CreatePayrollPeriod")
+	}
+	payrolPeriod.IDMstTenant = tenantID
+
+	err = insertAudited(ctx, c.DB, MstPayrollPeriodTable, payrolPeriod, func() int64 { return payrolPeriod.ID })
 	if err != nil {
 		return errors.Wrap(err, "conn.CreatePayrollPeriod")
 	}
@@ -90,17 +190,139 @@ func (c *Conn) CreatePayrollPeriod(ctx context.Context, payrolPeriod *model.MstP
 }
 
 func (c *Conn) GetPayrollPeriod(ctx context.Context, id int64) (res model.MstPayrollPeriod, err error) {
-	session := c.DB.MasterDB.Table(MstPayrollPeriodTable)
-	_, err = session.Where("id = ?", id).Get(&res)
+	ctx, span := tracing.Start(ctx, "attendance.Conn.GetPayrollPeriod")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.GetPayrollPeriod")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.GetPayrollPeriod")
+	}
+
+	session := c.DB.Reader(ctx).Table(MstPayrollPeriodTable)
+	_, err = session.Where("id = ? AND id_mst_tenant = ?", id, tenantID).Get(&res)
 	if err != nil {
 		return res, errors.Wrap(err, "conn.GetPayrollPeriod")
 	}
 	return res, nil
 }
 
+// GetPayrollPeriodByDate returns the payroll period covering date, periods
+// never overlap
+func (c *Conn) GetPayrollPeriodByDate(ctx context.Context, date time.Time) (res model.MstPayrollPeriod, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.GetPayrollPeriodByDate")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.GetPayrollPeriodByDate")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.GetPayrollPeriodByDate")
+	}
+
+	day := date.Format("2006-01-02")
+	session := c.DB.Reader(ctx).Table(MstPayrollPeriodTable)
+	_, err = session.Where("id_mst_tenant = ? AND start_date <= ? AND end_date >= ?", tenantID, day, day).Get(&res)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.GetPayrollPeriodByDate")
+	}
+	return res, nil
+}
+
+func (c *Conn) ListPayrollPeriodByParams(ctx context.Context, params model.ListPayrollPeriodParams) (res []model.MstPayrollPeriod, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.ListPayrollPeriodByParams")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.ListPayrollPeriodByParams")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.ListPayrollPeriodByParams")
+	}
+
+	session := c.DB.Reader(ctx).Table(MstPayrollPeriodTable).Where("id_mst_tenant = ?", tenantID)
+
+	if len(params.IDs) > 0 {
+		session.Where("id = ANY(?)", pq.Array(params.IDs))
+	}
+
+	switch params.Status {
+	case constant.PayrollPeriodStatusOpen:
+		session.Where("payroll_processed_date is null")
+	case constant.PayrollPeriodStatusProcessed:
+		session.Where("payroll_processed_date is not null")
+	}
+
+	orderBy := "start_date ASC"
+	if params.Cursor > 0 || params.Limit > 0 {
+		orderBy = "id ASC"
+	}
+	if params.Cursor > 0 {
+		session.Where("id > ?", params.Cursor)
+	}
+	if params.Limit > 0 {
+		session.Limit(params.Limit)
+	}
+
+	err = session.
+		OrderBy(orderBy).
+		Find(&res)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.ListPayrollPeriodByParams")
+	}
+	return res, nil
+}
+
+func (c *Conn) DeletePayrollPeriod(ctx context.Context, id int64) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.DeletePayrollPeriod")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.DeletePayrollPeriod")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.DeletePayrollPeriod")
+	}
+
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		var before model.MstPayrollPeriod
+		found, err := session.Table(MstPayrollPeriodTable).
+			Where("id = ? AND id_mst_tenant = ?", id, tenantID).
+			Where("payroll_processed_date is null").
+			ForUpdate().
+			Get(&before)
+		if err != nil || !found {
+			return nil, err
+		}
+
+		_, err = session.Table(MstPayrollPeriodTable).
+			Where("id = ? AND id_mst_tenant = ?", id, tenantID).
+			Where("payroll_processed_date is null").
+			Delete(&model.MstPayrollPeriod{})
+		if err != nil {
+			return nil, err
+		}
+		return []audit.Entry{{
+			Action:   constant.AuditActionDelete,
+			Entity:   MstPayrollPeriodTable,
+			EntityID: id,
+			Before:   before,
+		}}, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "conn.DeletePayrollPeriod")
+	}
+	return nil
+}
+
 func (c *Conn) UpdatePayrollPeriod(ctx context.Context, payrolPeriod *model.MstPayrollPeriod) (err error) {
-	session := c.DB.MasterDB.Table(MstPayrollPeriodTable)
-	_, err = session.Where("id = ?", payrolPeriod.ID).Update(payrolPeriod)
+	ctx, span := tracing.Start(ctx, "attendance.Conn.UpdatePayrollPeriod")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.UpdatePayrollPeriod")
+	defer finish(&err)
+
+	err = updateAudited(ctx, c.DB, MstPayrollPeriodTable, payrolPeriod.ID, payrolPeriod)
 	if err != nil {
 		return errors.Wrap(err, "conn.UpdatePayrollPeriod")
 	}
@@ -108,8 +330,18 @@ func (c *Conn) UpdatePayrollPeriod(ctx context.Context, payrolPeriod *model.MstP
 }
 
 func (c *Conn) SubmitOvertime(ctx context.Context, overtime *model.TrxOvertime) (err error) {
-	session := c.DB.MasterDB.Table(TrxOvertime)
-	_, err = session.InsertOne(overtime)
+	ctx, span := tracing.Start(ctx, "attendance.Conn.SubmitOvertime")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.SubmitOvertime")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.SubmitOvertime")
+	}
+	overtime.IDMstTenant = tenantID
+
+	err = insertAudited(ctx, c.DB, TrxOvertime, overtime, func() int64 { return overtime.ID })
 	if err != nil {
 		return errors.Wrap(err, "conn.SubmitOvertime")
 	}
@@ -117,8 +349,18 @@ func (c *Conn) SubmitOvertime(ctx context.Context, overtime *model.TrxOvertime)
 }
 
 func (c *Conn) GetOvertime(ctx context.Context, params model.TrxOvertime) (res model.TrxOvertime, err error) {
-	session := c.DB.MasterDB.Table(TrxOvertime)
-	_, err = session.Where("id_mst_user = ? AND overtime_date = ?", params.UserID, params.OvertimeDate.Format("2006-01-02")).Get(&res)
+	ctx, span := tracing.Start(ctx, "attendance.Conn.GetOvertime")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.GetOvertime")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.GetOvertime")
+	}
+
+	session := c.DB.Reader(ctx).Table(TrxOvertime)
+	_, err = session.Where("id_mst_tenant = ? AND id_mst_user = ? AND overtime_date = ?", tenantID, params.UserID, params.OvertimeDate.Format("2006-01-02")).Get(&res)
 	if err != nil {
 		return res, errors.Wrap(err, "conn.GetOvertime")
 	}
@@ -126,8 +368,12 @@ func (c *Conn) GetOvertime(ctx context.Context, params model.TrxOvertime) (res m
 }
 
 func (c *Conn) UpdateOvertime(ctx context.Context, overtime *model.TrxOvertime) (err error) {
-	session := c.DB.MasterDB.Table(TrxOvertime)
-	_, err = session.Where("id = ?", overtime.ID).Update(overtime)
+	ctx, span := tracing.Start(ctx, "attendance.Conn.UpdateOvertime")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.UpdateOvertime")
+	defer finish(&err)
+
+	err = updateAudited(ctx, c.DB, TrxOvertime, overtime.ID, overtime)
 	if err != nil {
 		return errors.Wrap(err, "conn.UpdateOvertime")
 	}
@@ -135,7 +381,17 @@ func (c *Conn) UpdateOvertime(ctx context.Context, overtime *model.TrxOvertime)
 }
 
 func (c *Conn) ListOvertimeByParams(ctx context.Context, params model.ListOvertimeParams) (res []model.TrxOvertime, err error) {
-	session := c.DB.MasterDB.Table(TrxOvertime)
+	ctx, span := tracing.Start(ctx, "attendance.Conn.ListOvertimeByParams")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.ListOvertimeByParams")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.ListOvertime")
+	}
+
+	session := c.DB.Reader(ctx).Table(TrxOvertime).Where("id_mst_tenant = ?", tenantID)
 
 	if !params.StartDate.IsZero() && !params.EndDate.IsZero() {
 		session.Where("overtime_date BETWEEN ? and ?", params.StartDate.Format("2006-01-02"), params.EndDate.Format("2006-01-02"))
@@ -145,14 +401,26 @@ func (c *Conn) ListOvertimeByParams(ctx context.Context, params model.ListOverti
 		session.Where("id_mst_payroll_period is null")
 	}
 
+	if params.IsProcessed {
+		session.Where("id_mst_payroll_period is not null")
+	}
+
 	if params.IDMstPayrollPeriod > 0 {
 		session.Where("id_mst_payroll_period = ?", params.IDMstPayrollPeriod)
 	}
 	if len(params.UserIDs) > 0 {
 		session.Where("id_mst_user = any(?)", pq.Array(params.UserIDs))
 	}
+	if params.Cursor > 0 {
+		session.Where("id > ?", params.Cursor)
+	}
+	if params.Limit > 0 {
+		session.Limit(params.Limit)
+	}
 
-	err = session.Find(&res)
+	err = session.
+		OrderBy("id ASC").
+		Find(&res)
 	if err != nil {
 		return res, errors.Wrap(err, "conn.ListOvertime")
 	}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
 	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
 	"github.com/pkg/errors"
 )
@@ -44,7 +45,7 @@ func Test_RecordAttendance(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				attendance: &model.MstAttendance{
 					IDMstUser:      1,
 					AttendanceDate: time.Now(),
@@ -52,11 +53,14 @@ func Test_RecordAttendance(t *testing.T) {
 			},
 			wantErr: false,
 			patch: func() {
+				mockDB.ExpectBegin()
 				mockDB.
 					ExpectQuery("^INSERT INTO \"mst_attendance\"").
 					WillReturnRows(
 						sqlmock.NewRows([]string{"id"}).AddRow(1),
 					)
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
 			},
 		},
 		{
@@ -67,7 +71,7 @@ func Test_RecordAttendance(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				attendance: &model.MstAttendance{
 					IDMstUser:      1,
 					AttendanceDate: time.Now(),
@@ -75,9 +79,11 @@ func Test_RecordAttendance(t *testing.T) {
 			},
 			wantErr: true,
 			patch: func() {
+				mockDB.ExpectBegin()
 				mockDB.
 					ExpectQuery("^INSERT INTO \"mst_attendance\"").
 					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
 			},
 		},
 	}
@@ -95,6 +101,77 @@ func Test_RecordAttendance(t *testing.T) {
 	}
 }
 
+func Test_RecordAttendances(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	newAttendances := func() []*model.MstAttendance {
+		return []*model.MstAttendance{
+			{IDMstUser: 1, AttendanceDate: time.Date(2025, 7, 1, 8, 0, 0, 0, time.UTC)},
+			{IDMstUser: 2, AttendanceDate: time.Date(2025, 7, 1, 8, 5, 0, 0, time.UTC)},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		attendances []*model.MstAttendance
+		wantErr     bool
+		patch       func()
+	}{
+		{
+			name:        "Successful",
+			attendances: newAttendances(),
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^INSERT INTO \"mst_attendance\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectQuery("^INSERT INTO \"mst_attendance\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				expectAuditEntry(mockDB)
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:  "Nothing to record",
+			patch: func() {},
+		},
+		{
+			name:        "Failed at second insert",
+			attendances: newAttendances(),
+			wantErr:     true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^INSERT INTO \"mst_attendance\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectQuery("^INSERT INTO \"mst_attendance\"").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			err := c.RecordAttendances(tenant.NewContext(context.Background(), 1), tt.attendances)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.RecordAttendances() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func Test_GetAttendance(t *testing.T) {
 	mockConn, mockDB := xormlib.NewMockDB()
 	defer func() {
@@ -128,7 +205,7 @@ func Test_GetAttendance(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				params: model.MstAttendance{
 					IDMstUser:      1,
 					AttendanceDate: time.Now(),
@@ -200,7 +277,7 @@ func Test_ListAttendanceByParams(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				params: model.ListAttendanceParams{
 					IDsMstUser: []int64{1, 2},
 					StartDate:  time.Now(),
@@ -274,7 +351,7 @@ func Test_UpdateAttendance(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				attendance: &model.MstAttendance{
 					ID:             1,
 					IDMstUser:      1,
@@ -283,8 +360,13 @@ func Test_UpdateAttendance(t *testing.T) {
 			},
 			wantErr: false,
 			patch: func() {
+				mockDB.ExpectBegin()
+				expectRowLock(mockDB, MstAttendanceTable)
 				mockDB.ExpectExec("^UPDATE \"mst_attendance\"").
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectRowSnapshot(mockDB, MstAttendanceTable)
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
 			},
 		},
 	}
@@ -334,7 +416,7 @@ func Test_SubmitOvertime(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				overtime: &model.TrxOvertime{
 					UserID:       1,
 					OvertimeDate: time.Now(),
@@ -343,11 +425,14 @@ func Test_SubmitOvertime(t *testing.T) {
 			},
 			wantErr: false,
 			patch: func() {
+				mockDB.ExpectBegin()
 				mockDB.
 					ExpectQuery("^INSERT INTO \"trx_overtime\"").
 					WillReturnRows(
 						sqlmock.NewRows([]string{"id"}).AddRow(1),
 					)
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
 			},
 		},
 		{
@@ -358,7 +443,7 @@ func Test_SubmitOvertime(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				overtime: &model.TrxOvertime{
 					UserID:       1,
 					OvertimeDate: time.Now(),
@@ -367,9 +452,11 @@ func Test_SubmitOvertime(t *testing.T) {
 			},
 			wantErr: true,
 			patch: func() {
+				mockDB.ExpectBegin()
 				mockDB.
 					ExpectQuery("^INSERT INTO \"trx_overtime\"").
 					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
 			},
 		},
 	}
@@ -419,7 +506,7 @@ func Test_CreatePayrollPeriod(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				payrolPeriod: &model.MstPayrollPeriod{
 					StartDate: time.Now(),
 					EndDate:   time.Now().AddDate(0, 1, 0),
@@ -427,11 +514,14 @@ func Test_CreatePayrollPeriod(t *testing.T) {
 			},
 			wantErr: false,
 			patch: func() {
+				mockDB.ExpectBegin()
 				mockDB.
 					ExpectQuery("^INSERT INTO \"mst_payroll_period\"").
 					WillReturnRows(
 						sqlmock.NewRows([]string{"id"}).AddRow(1),
 					)
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
 			},
 		},
 		{
@@ -442,7 +532,7 @@ func Test_CreatePayrollPeriod(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				payrolPeriod: &model.MstPayrollPeriod{
 					StartDate: time.Now(),
 					EndDate:   time.Now().AddDate(0, 1, 0),
@@ -450,9 +540,11 @@ func Test_CreatePayrollPeriod(t *testing.T) {
 			},
 			wantErr: true,
 			patch: func() {
+				mockDB.ExpectBegin()
 				mockDB.
 					ExpectQuery("^INSERT INTO \"mst_payroll_period\"").
 					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
 			},
 		},
 	}
@@ -503,7 +595,7 @@ func Test_GetPayrollPeriod(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				id:  1,
 			},
 			want: model.MstPayrollPeriod{
@@ -528,7 +620,7 @@ func Test_GetPayrollPeriod(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				id:  1,
 			},
 			wantErr: true,
@@ -556,6 +648,206 @@ func Test_GetPayrollPeriod(t *testing.T) {
 	}
 }
 
+func Test_GetPayrollPeriodByDate(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantID  int64
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name:   "Successful",
+			wantID: 1,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_payroll_period\" WHERE \\(id_mst_tenant = \\$1 AND start_date <= \\$2 AND end_date >= \\$3\\) LIMIT 1").
+					WithArgs(1, "2025-07-21", "2025-07-21").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+		},
+		{
+			name: "No period",
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_payroll_period\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+		},
+		{
+			name:    "Failed because query method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_payroll_period\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.GetPayrollPeriodByDate(tenant.NewContext(context.Background(), 1), time.Date(2025, 7, 21, 9, 0, 0, 0, time.UTC))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.GetPayrollPeriodByDate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.ID != tt.wantID {
+				t.Errorf("Conn.GetPayrollPeriodByDate() id = %v, want %v", got.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func Test_ListPayrollPeriodByParams(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	type args struct {
+		ctx    context.Context
+		params model.ListPayrollPeriodParams
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantLen int
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			args: args{
+				ctx: tenant.NewContext(context.Background(), 1),
+				params: model.ListPayrollPeriodParams{
+					IDs: []int64{1, 2},
+				},
+			},
+			wantLen: 2,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* WHERE \\(id_mst_tenant = \\$1\\) AND \\(id = ANY\\(\\$2\\)\\) ORDER BY start_date ASC").
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "start_date", "end_date"}).
+							AddRow(1, time.Now(), time.Now().AddDate(0, 1, 0)).
+							AddRow(2, time.Now().AddDate(0, 1, 0), time.Now().AddDate(0, 2, 0)),
+					)
+			},
+		},
+		{
+			name: "Failed because find method",
+			args: args{
+				ctx: tenant.NewContext(context.Background(), 1),
+			},
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .*").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.ListPayrollPeriodByParams(tt.args.ctx, tt.args.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ListPayrollPeriodByParams() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("Conn.ListPayrollPeriodByParams() len = %v, want %v", len(got), tt.wantLen)
+			}
+		})
+	}
+}
+
+func Test_DeletePayrollPeriod(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		id      int64
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			id:   1,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_payroll_period\" WHERE \\(id = \\$1 AND id_mst_tenant = \\$2\\) AND \\(payroll_processed_date is null\\)").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectExec("^DELETE FROM \"mst_payroll_period\" WHERE \\(id = \\$1 AND id_mst_tenant = \\$2\\) AND \\(payroll_processed_date is null\\)").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "Processed period is neither deleted nor recorded",
+			id:   1,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_payroll_period\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:    "Failed because delete method",
+			id:      1,
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_payroll_period\" WHERE \\(id = \\$1 AND id_mst_tenant = \\$2\\) AND \\(payroll_processed_date is null\\)").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectExec("^DELETE FROM \"mst_payroll_period\"").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			err := c.DeletePayrollPeriod(tenant.NewContext(context.Background(), 1), tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.DeletePayrollPeriod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func Test_UpdatePayrollPeriod(t *testing.T) {
 	mockConn, mockDB := xormlib.NewMockDB()
 	defer func() {
@@ -588,7 +880,7 @@ func Test_UpdatePayrollPeriod(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				payrolPeriod: &model.MstPayrollPeriod{
 					ID:        1,
 					StartDate: time.Now(),
@@ -597,8 +889,13 @@ func Test_UpdatePayrollPeriod(t *testing.T) {
 			},
 			wantErr: false,
 			patch: func() {
+				mockDB.ExpectBegin()
+				expectRowLock(mockDB, MstPayrollPeriodTable)
 				mockDB.ExpectExec("^UPDATE \"mst_payroll_period\"").
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectRowSnapshot(mockDB, MstPayrollPeriodTable)
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
 			},
 		},
 		{
@@ -609,7 +906,7 @@ func Test_UpdatePayrollPeriod(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				payrolPeriod: &model.MstPayrollPeriod{
 					ID:        1,
 					StartDate: time.Now(),
@@ -618,8 +915,11 @@ func Test_UpdatePayrollPeriod(t *testing.T) {
 			},
 			wantErr: true,
 			patch: func() {
+				mockDB.ExpectBegin()
+				expectRowLock(mockDB, MstPayrollPeriodTable)
 				mockDB.ExpectExec("^UPDATE \"mst_payroll_period\"").
 					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
 			},
 		},
 	}
@@ -670,7 +970,7 @@ func Test_GetOvertime(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				params: model.TrxOvertime{
 					UserID:       1,
 					OvertimeDate: time.Now(),
@@ -699,7 +999,7 @@ func Test_GetOvertime(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				params: model.TrxOvertime{
 					UserID:       1,
 					OvertimeDate: time.Now(),
@@ -762,7 +1062,7 @@ func Test_UpdateOvertime(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				overtime: &model.TrxOvertime{
 					ID:           1,
 					UserID:       1,
@@ -772,8 +1072,13 @@ func Test_UpdateOvertime(t *testing.T) {
 			},
 			wantErr: false,
 			patch: func() {
+				mockDB.ExpectBegin()
+				expectRowLock(mockDB, TrxOvertime)
 				mockDB.ExpectExec("^UPDATE \"trx_overtime\"").
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectRowSnapshot(mockDB, TrxOvertime)
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
 			},
 		},
 		{
@@ -784,7 +1089,7 @@ func Test_UpdateOvertime(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				overtime: &model.TrxOvertime{
 					ID:           1,
 					UserID:       1,
@@ -794,8 +1099,11 @@ func Test_UpdateOvertime(t *testing.T) {
 			},
 			wantErr: true,
 			patch: func() {
+				mockDB.ExpectBegin()
+				expectRowLock(mockDB, TrxOvertime)
 				mockDB.ExpectExec("^UPDATE \"trx_overtime\"").
 					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
 			},
 		},
 	}
@@ -850,7 +1158,7 @@ func Test_ListOvertimeByParams(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				params: model.ListOvertimeParams{
 					StartDate:              fixedTime1,
 					EndDate:                fixedTime2,
@@ -891,7 +1199,7 @@ func Test_ListOvertimeByParams(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				params: model.ListOvertimeParams{
 					StartDate:              fixedTime1,
 					EndDate:                fixedTime2,
@@ -916,7 +1224,7 @@ func Test_ListOvertimeByParams(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				params: model.ListOvertimeParams{
 					StartDate: fixedTime1,
 					EndDate:   fixedTime2,
@@ -0,0 +1,79 @@
+package attendance
+
+import (
+	"context"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/internal/repo/db/audit"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/go-xorm/xorm"
+)
+
+// insertAudited inserts row and records it as created
+func insertAudited(ctx context.Context, db *xormlib.DBConnect, table string, row interface{}, id func() int64) error {
+	return audit.Change(ctx, db, func(session *xorm.Session) ([]audit.Entry, error) {
+		_, err := session.Table(table).InsertOne(row)
+		if err != nil {
+			return nil, err
+		}
+		return []audit.Entry{{
+			Action:   constant.AuditActionCreate,
+			Entity:   table,
+			EntityID: id(),
+			After:    row,
+		}}, nil
+	})
+}
+
+// updateAudited updates the row with the given id and records it as it was
+// before and after the update. Nothing is recorded when the row is missing
+// or belongs to another tenant.
+func updateAudited[T any](ctx context.Context, db *xormlib.DBConnect, table string, id int64, row *T) error {
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+
+	return audit.Change(ctx, db, func(session *xorm.Session) ([]audit.Entry, error) {
+		var before, after T
+		found, err := session.Table(table).Where("id = ? AND id_mst_tenant = ?", id, tenantID).ForUpdate().Get(&before)
+		if err != nil || !found {
+			return nil, err
+		}
+
+		_, err = session.Table(table).Where("id = ?", id).Update(row)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = session.Table(table).Where("id = ?", id).Get(&after)
+		if err != nil {
+			return nil, err
+		}
+		return []audit.Entry{{
+			Action:   constant.AuditActionUpdate,
+			Entity:   table,
+			EntityID: id,
+			Before:   before,
+			After:    after,
+		}}, nil
+	})
+}
+
+// assignPayrollPeriodAudited runs the set-based assignment query and records
+// one entry holding the assigned ids
+func assignPayrollPeriodAudited(ctx context.Context, db *xormlib.DBConnect, table string, params model.AssignPayrollPeriodParams, query ...interface{}) error {
+	return audit.Change(ctx, db, func(session *xorm.Session) ([]audit.Entry, error) {
+		_, err := session.Exec(query...)
+		if err != nil {
+			return nil, err
+		}
+		return []audit.Entry{{
+			Action: constant.AuditActionAssignPayrollPeriod,
+			Entity: table,
+			After:  params,
+		}}, nil
+	})
+}
@@ -0,0 +1,109 @@
+package attendance
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+)
+
+// expectAuditEntry expects the audit trail insert that precedes the commit of
+// every audited change
+func expectAuditEntry(mockDB sqlmock.Sqlmock) {
+	mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+}
+
+// expectRowLock expects the read updateAudited locks the row of the tenant
+// with before the update
+func expectRowLock(mockDB sqlmock.Sqlmock, table string) {
+	mockDB.ExpectQuery("^SELECT .* FROM \"" + table + "\" WHERE \\(id = \\$1 AND id_mst_tenant = \\$2\\) LIMIT 1 FOR UPDATE").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+}
+
+// expectRowSnapshot expects the read updateAudited takes after the update
+func expectRowSnapshot(mockDB sqlmock.Sqlmock, table string) {
+	mockDB.ExpectQuery("^SELECT .* FROM \"" + table + "\" WHERE \\(id = \\$1\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+}
+
+func Test_updateAudited(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name     string
+		noTenant bool
+		wantErr  bool
+		patch    func()
+	}{
+		{
+			name: "Successful",
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_attendance\" WHERE \\(id = \\$1 AND id_mst_tenant = \\$2\\) LIMIT 1 FOR UPDATE").
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectExec("^UPDATE \"mst_attendance\"").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRowSnapshot(mockDB, MstAttendanceTable)
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "Missing row is neither updated nor recorded",
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_attendance\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:    "Rolled back when the audit entry fails",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				expectRowLock(mockDB, MstAttendanceTable)
+				mockDB.ExpectExec("^UPDATE \"mst_attendance\"").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectRowSnapshot(mockDB, MstAttendanceTable)
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "Failed because no tenant in context",
+			noTenant: true,
+			wantErr:  true,
+			patch:    func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &xormlib.DBConnect{
+				MasterDB: mockConn,
+			}
+			tt.patch()
+			ctx := tenant.NewContext(t.Context(), 1)
+			if tt.noTenant {
+				ctx = t.Context()
+			}
+			err := updateAudited(ctx, db, MstAttendanceTable, 1, &model.MstAttendance{ID: 1, IDMstUser: 2})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("updateAudited() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
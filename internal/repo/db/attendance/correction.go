@@ -0,0 +1,266 @@
+package attendance
+
+import (
+	"context"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/internal/repo/db/audit"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
+	"github.com/go-xorm/xorm"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+const (
+	TrxAttendanceCorrectionTable = "trx_attendance_correction"
+)
+
+// errNotResolved rolls a resolution back when its checks no longer hold
+var errNotResolved = errors.New("attendance correction not resolved")
+
+func (c *Conn) CreateAttendanceCorrection(ctx context.Context, correction *model.TrxAttendanceCorrection) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.CreateAttendanceCorrection")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.CreateAttendanceCorrection")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.CreateAttendanceCorrection")
+	}
+	correction.IDMstTenant = tenantID
+
+	err = insertAudited(ctx, c.DB, TrxAttendanceCorrectionTable, correction, func() int64 { return correction.ID })
+	if err != nil {
+		return errors.Wrap(err, "conn.CreateAttendanceCorrection")
+	}
+	return nil
+}
+
+func (c *Conn) GetAttendanceCorrection(ctx context.Context, id int64) (res model.TrxAttendanceCorrection, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.GetAttendanceCorrection")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.GetAttendanceCorrection")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.GetAttendanceCorrection")
+	}
+
+	session := c.DB.Reader(ctx).Table(TrxAttendanceCorrectionTable)
+	_, err = session.Where("id = ? AND id_mst_tenant = ?", id, tenantID).Get(&res)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.GetAttendanceCorrection")
+	}
+	return res, nil
+}
+
+func (c *Conn) ListAttendanceCorrectionByParams(ctx context.Context, params model.ListAttendanceCorrectionParams) (res []model.TrxAttendanceCorrection, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.ListAttendanceCorrectionByParams")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.ListAttendanceCorrectionByParams")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.ListAttendanceCorrectionByParams")
+	}
+
+	session := c.DB.Reader(ctx).Table(TrxAttendanceCorrectionTable).Where("id_mst_tenant = ?", tenantID)
+
+	if len(params.IDsMstUser) > 0 {
+		session.Where("id_mst_user = ANY(?)", pq.Array(params.IDsMstUser))
+	}
+	if params.IDMstManager != 0 {
+		session.Where("id_mst_user IN (SELECT id FROM mst_user WHERE id_mst_manager = ?)", params.IDMstManager)
+	}
+	if !params.AttendanceDate.IsZero() {
+		session.Where("attendance_date = ?", params.AttendanceDate.Format("2006-01-02"))
+	}
+	if params.Status != "" {
+		session.Where("status = ?", params.Status)
+	}
+	if params.Cursor > 0 {
+		session.Where("id > ?", params.Cursor)
+	}
+	if params.Limit > 0 {
+		session.Limit(params.Limit)
+	}
+
+	err = session.
+		OrderBy("id ASC").
+		Find(&res)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.ListAttendanceCorrectionByParams")
+	}
+	return res, nil
+}
+
+// ResolveAttendanceCorrection stores the review of a correction. A pending
+// correction is updated only while it is still pending, a new one is
+// inserted. Approving adds or removes the attendance in the same transaction,
+// refused when the payroll period of the day is processed, has a payroll job
+// queued or running, or the attendance changed meanwhile. resolved is false when refused and nothing is stored.
+func (c *Conn) ResolveAttendanceCorrection(ctx context.Context, correction *model.TrxAttendanceCorrection) (resolved bool, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.ResolveAttendanceCorrection")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.ResolveAttendanceCorrection")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "conn.ResolveAttendanceCorrection")
+	}
+	correction.IDMstTenant = tenantID
+
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		var before model.TrxAttendanceCorrection
+		if correction.ID != 0 {
+			found, err := session.Table(TrxAttendanceCorrectionTable).
+				Where("id = ? AND id_mst_tenant = ?", correction.ID, tenantID).
+				Where("status = ?", constant.AttendanceCorrectionStatusPending).
+				ForUpdate().
+				Get(&before)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				return nil, errNotResolved
+			}
+		}
+
+		entries := []audit.Entry{}
+		if correction.Status == constant.AttendanceCorrectionStatusApproved {
+			entry, err := applyAttendanceCorrection(session, tenantID, correction)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		}
+
+		if correction.ID == 0 {
+			_, err := session.Table(TrxAttendanceCorrectionTable).InsertOne(correction)
+			if err != nil {
+				return nil, err
+			}
+			return append(entries, audit.Entry{
+				Action:   constant.AuditActionCreate,
+				Entity:   TrxAttendanceCorrectionTable,
+				EntityID: correction.ID,
+				After:    correction,
+			}), nil
+		}
+
+		_, err := session.Table(TrxAttendanceCorrectionTable).
+			Where("id = ?", correction.ID).
+			Cols("status", "id_mst_attendance", "reviewed_by", "review_note", "reviewed_at", "updated_by").
+			Update(correction)
+		if err != nil {
+			return nil, err
+		}
+		return append(entries, audit.Entry{
+			Action:   constant.AuditActionUpdate,
+			Entity:   TrxAttendanceCorrectionTable,
+			EntityID: correction.ID,
+			Before:   before,
+			After:    correction,
+		}), nil
+	})
+	if errors.Is(err, errNotResolved) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "conn.ResolveAttendanceCorrection")
+	}
+	return true, nil
+}
+
+// applyAttendanceCorrection adds or removes the attendance, holding the
+// payroll period of the day so it cannot be processed or queued meanwhile. A
+// period whose payroll is queued or being generated is refused, the job would
+// miss the change.
+func applyAttendanceCorrection(session *xorm.Session, tenantID int64, correction *model.TrxAttendanceCorrection) (entry audit.Entry, err error) {
+	day := correction.AttendanceDate.Format("2006-01-02")
+
+	var period model.MstPayrollPeriod
+	found, err := session.Table(MstPayrollPeriodTable).
+		Where("id_mst_tenant = ? AND start_date <= ? AND end_date >= ?", tenantID, day, day).
+		ForUpdate().
+		Get(&period)
+	if err != nil {
+		return entry, err
+	}
+	if found && period.PayrollProcessedDate.Valid {
+		return entry, errNotResolved
+	}
+	if found {
+		activeJobs, err := session.Table(TrxPayrollJobTable).
+			Where("id_mst_tenant = ? AND id_mst_payroll_period = ?", tenantID, period.ID).
+			Where("status = ANY(?)", pq.Array([]string{constant.PayrollJobStatusQueued, constant.PayrollJobStatusRunning})).
+			Count(&model.TrxPayrollJob{})
+		if err != nil {
+			return entry, err
+		}
+		if activeJobs > 0 {
+			return entry, errNotResolved
+		}
+	}
+
+	var attendance model.MstAttendance
+	found, err = session.Table(MstAttendanceTable).
+		Where("id_mst_tenant = ? AND id_mst_user = ? AND attendance_date = ?", tenantID, correction.IDMstUser, day).
+		ForUpdate().
+		Get(&attendance)
+	if err != nil {
+		return entry, err
+	}
+
+	switch correction.Action {
+	case constant.AttendanceCorrectionAdd:
+		if found {
+			return entry, errNotResolved
+		}
+		attendance = model.MstAttendance{
+			IDMstTenant:    tenantID,
+			IDMstUser:      correction.IDMstUser,
+			AttendanceDate: correction.AttendanceDate,
+			Source:         constant.AttendanceSourceCorrection,
+			CreatedBy:      correction.ReviewedBy,
+		}
+		_, err = session.Table(MstAttendanceTable).InsertOne(&attendance)
+		if err != nil {
+			return entry, err
+		}
+		entry = audit.Entry{
+			Action:   constant.AuditActionCreate,
+			Entity:   MstAttendanceTable,
+			EntityID: attendance.ID,
+			After:    attendance,
+		}
+	case constant.AttendanceCorrectionRemove:
+		if !found {
+			return entry, errNotResolved
+		}
+		_, err = session.Table(MstAttendanceTable).
+			Where("id = ?", attendance.ID).
+			Delete(&model.MstAttendance{})
+		if err != nil {
+			return entry, err
+		}
+		entry = audit.Entry{
+			Action:   constant.AuditActionDelete,
+			Entity:   MstAttendanceTable,
+			EntityID: attendance.ID,
+			Before:   attendance,
+		}
+	default:
+		return entry, errors.Errorf("unknown attendance correction action %q", correction.Action)
+	}
+
+	correction.IDMstAttendance.Int64 = attendance.ID
+	correction.IDMstAttendance.Valid = true
+	return entry, nil
+}
@@ -0,0 +1,400 @@
+package attendance
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateAttendanceCorrection(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^INSERT INTO \"trx_attendance_correction\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:    "Failed at InsertOne",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^INSERT INTO \"trx_attendance_correction\"").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			err := c.CreateAttendanceCorrection(tenant.NewContext(context.Background(), 1), &model.TrxAttendanceCorrection{
+				IDMstUser:      2,
+				AttendanceDate: time.Date(2025, 7, 21, 0, 0, 0, 0, time.UTC),
+				Action:         constant.AttendanceCorrectionAdd,
+				Reason:         "forgot to tap in",
+				Status:         constant.AttendanceCorrectionStatusPending,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.CreateAttendanceCorrection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_GetAttendanceCorrection(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantID  int64
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name:   "Successful",
+			wantID: 4,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_attendance_correction\" WHERE \\(id = \\$1 AND id_mst_tenant = \\$2\\) LIMIT 1").
+					WithArgs(4, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(4, "pending"))
+			},
+		},
+		{
+			name:    "Failed because query method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_attendance_correction\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.GetAttendanceCorrection(tenant.NewContext(context.Background(), 1), 4)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.GetAttendanceCorrection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantID, got.ID)
+		})
+	}
+}
+
+func Test_ListAttendanceCorrectionByParams(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		params  model.ListAttendanceCorrectionParams
+		wantLen int
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful for the reports of a manager",
+			params: model.ListAttendanceCorrectionParams{
+				IDMstManager: 5,
+				Status:       constant.AttendanceCorrectionStatusPending,
+				Cursor:       3,
+				Limit:        10,
+			},
+			wantLen: 2,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_attendance_correction\" WHERE \\(id_mst_tenant = \\$1\\) AND \\(id_mst_user IN \\(SELECT id FROM mst_user WHERE id_mst_manager = \\$2\\)\\) AND \\(status = \\$3\\) AND \\(id > \\$4\\) ORDER BY id ASC LIMIT 10").
+					WithArgs(1, 5, "pending", 3).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4).AddRow(6))
+			},
+		},
+		{
+			name: "Successful for a user and day",
+			params: model.ListAttendanceCorrectionParams{
+				IDsMstUser:     []int64{2},
+				AttendanceDate: time.Date(2025, 7, 21, 0, 0, 0, 0, time.UTC),
+			},
+			wantLen: 1,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_attendance_correction\" WHERE \\(id_mst_tenant = \\$1\\) AND \\(id_mst_user = ANY\\(\\$2\\)\\) AND \\(attendance_date = \\$3\\)").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+			},
+		},
+		{
+			name:    "Failed because query method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_attendance_correction\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.ListAttendanceCorrectionByParams(tenant.NewContext(context.Background(), 1), tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ListAttendanceCorrectionByParams() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Len(t, got, tt.wantLen)
+		})
+	}
+}
+
+func Test_ResolveAttendanceCorrection(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	day := time.Date(2025, 7, 21, 0, 0, 0, 0, time.UTC)
+	reviewedBy := sql.NullInt64{Int64: 1, Valid: true}
+	expectPending := func() {
+		mockDB.ExpectQuery("^SELECT .* FROM \"trx_attendance_correction\" WHERE \\(id = \\$1 AND id_mst_tenant = \\$2\\) AND \\(status = \\$3\\) LIMIT 1 FOR UPDATE").
+			WithArgs(4, 1, "pending").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(4, "pending"))
+	}
+	expectPeriod := func(processed bool) {
+		rows := sqlmock.NewRows([]string{"id", "payroll_processed_date"})
+		if processed {
+			rows.AddRow(1, time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC))
+		} else {
+			rows.AddRow(1, nil)
+		}
+		mockDB.ExpectQuery("^SELECT .* FROM \"mst_payroll_period\" WHERE \\(id_mst_tenant = \\$1 AND start_date <= \\$2 AND end_date >= \\$3\\) LIMIT 1 FOR UPDATE").
+			WithArgs(1, "2025-07-21", "2025-07-21").
+			WillReturnRows(rows)
+	}
+	expectActiveJobs := func(count int) {
+		mockDB.ExpectQuery("^SELECT count\\(\\*\\) FROM \"trx_payroll_job\" WHERE \\(id_mst_tenant = \\$1 AND id_mst_payroll_period = \\$2\\) AND \\(status = ANY\\(\\$3\\)\\)").
+			WithArgs(1, 1, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+	}
+	expectAttendance := func(found bool) {
+		rows := sqlmock.NewRows([]string{"id"})
+		if found {
+			rows.AddRow(9)
+		}
+		mockDB.ExpectQuery("^SELECT .* FROM \"mst_attendance\" WHERE \\(id_mst_tenant = \\$1 AND id_mst_user = \\$2 AND attendance_date = \\$3\\) LIMIT 1 FOR UPDATE").
+			WithArgs(1, 2, "2025-07-21").
+			WillReturnRows(rows)
+	}
+
+	tests := []struct {
+		name             string
+		correction       model.TrxAttendanceCorrection
+		wantResolved     bool
+		wantAttendanceID sql.NullInt64
+		wantErr          bool
+		patch            func()
+	}{
+		{
+			name: "Successful admin adds attendance",
+			correction: model.TrxAttendanceCorrection{
+				IDMstUser: 2, AttendanceDate: day, Action: constant.AttendanceCorrectionAdd,
+				Status: constant.AttendanceCorrectionStatusApproved, ReviewedBy: reviewedBy,
+			},
+			wantResolved:     true,
+			wantAttendanceID: sql.NullInt64{Int64: 10, Valid: true},
+			patch: func() {
+				mockDB.ExpectBegin()
+				expectPeriod(false)
+				expectActiveJobs(0)
+				expectAttendance(false)
+				mockDB.ExpectQuery("^INSERT INTO \"mst_attendance\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_attendance_correction\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				expectAuditEntry(mockDB)
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "Successful approval removes attendance",
+			correction: model.TrxAttendanceCorrection{
+				ID: 4, IDMstUser: 2, AttendanceDate: day, Action: constant.AttendanceCorrectionRemove,
+				Status: constant.AttendanceCorrectionStatusApproved, ReviewedBy: reviewedBy,
+			},
+			wantResolved:     true,
+			wantAttendanceID: sql.NullInt64{Int64: 9, Valid: true},
+			patch: func() {
+				mockDB.ExpectBegin()
+				expectPending()
+				expectPeriod(false)
+				expectActiveJobs(0)
+				expectAttendance(true)
+				mockDB.ExpectExec("^DELETE FROM \"mst_attendance\" WHERE \\(id = \\$1\\)").
+					WithArgs(9).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectExec("^UPDATE \"trx_attendance_correction\" SET .* WHERE \\(id = \\$\\d+\\)").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAuditEntry(mockDB)
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "Successful rejection leaves attendance alone",
+			correction: model.TrxAttendanceCorrection{
+				ID: 4, IDMstUser: 2, AttendanceDate: day, Action: constant.AttendanceCorrectionAdd,
+				Status: constant.AttendanceCorrectionStatusRejected, ReviewedBy: reviewedBy,
+			},
+			wantResolved: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				expectPending()
+				mockDB.ExpectExec("^UPDATE \"trx_attendance_correction\"").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "Already reviewed is not resolved",
+			correction: model.TrxAttendanceCorrection{
+				ID: 4, IDMstUser: 2, AttendanceDate: day, Action: constant.AttendanceCorrectionAdd,
+				Status: constant.AttendanceCorrectionStatusApproved,
+			},
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_attendance_correction\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mockDB.ExpectRollback()
+			},
+		},
+		{
+			name: "Processed payroll period is not resolved",
+			correction: model.TrxAttendanceCorrection{
+				IDMstUser: 2, AttendanceDate: day, Action: constant.AttendanceCorrectionAdd,
+				Status: constant.AttendanceCorrectionStatusApproved,
+			},
+			patch: func() {
+				mockDB.ExpectBegin()
+				expectPeriod(true)
+				mockDB.ExpectRollback()
+			},
+		},
+		{
+			name: "Payroll being generated is not resolved",
+			correction: model.TrxAttendanceCorrection{
+				IDMstUser: 2, AttendanceDate: day, Action: constant.AttendanceCorrectionAdd,
+				Status: constant.AttendanceCorrectionStatusApproved,
+			},
+			patch: func() {
+				mockDB.ExpectBegin()
+				expectPeriod(false)
+				expectActiveJobs(1)
+				mockDB.ExpectRollback()
+			},
+		},
+		{
+			name: "Attendance added meanwhile is not resolved",
+			correction: model.TrxAttendanceCorrection{
+				ID: 4, IDMstUser: 2, AttendanceDate: day, Action: constant.AttendanceCorrectionAdd,
+				Status: constant.AttendanceCorrectionStatusApproved,
+			},
+			patch: func() {
+				mockDB.ExpectBegin()
+				expectPending()
+				expectPeriod(false)
+				expectActiveJobs(0)
+				expectAttendance(true)
+				mockDB.ExpectRollback()
+			},
+		},
+		{
+			name: "Failed at insert",
+			correction: model.TrxAttendanceCorrection{
+				IDMstUser: 2, AttendanceDate: day, Action: constant.AttendanceCorrectionAdd,
+				Status: constant.AttendanceCorrectionStatusApproved,
+			},
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				expectPeriod(false)
+				expectActiveJobs(0)
+				expectAttendance(false)
+				mockDB.ExpectQuery("^INSERT INTO \"mst_attendance\"").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			correction := tt.correction
+			resolved, err := c.ResolveAttendanceCorrection(tenant.NewContext(context.Background(), 1), &correction)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ResolveAttendanceCorrection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantResolved, resolved)
+			if tt.wantResolved {
+				assert.Equal(t, tt.wantAttendanceID, correction.IDMstAttendance)
+			}
+		})
+	}
+}
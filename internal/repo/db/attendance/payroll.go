@@ -3,26 +3,152 @@ package attendance
 import (
 	"context"
 
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/internal/repo/db/audit"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
+	"github.com/go-xorm/xorm"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 )
 
 const (
 	TrxUserPayslipTable = "trx_user_payslip"
 	DtlPayrollTable     = "dtl_payroll"
+
+	// payslipInsertBatchSize keeps a single INSERT well below the 65535 bind
+	// parameters postgres accepts per statement
+	payslipInsertBatchSize = 1000
 )
 
+// SubmitPayslips inserts the payslips in batches within one transaction. The
+// audit trail gets one entry for the whole set rather than one per payslip.
 func (c *Conn) SubmitPayslips(ctx context.Context, payslips []model.TrxUserPayslip) (err error) {
-	session := c.DB.MasterDB.Table(TrxUserPayslipTable)
-	_, err = session.Insert(payslips)
+	ctx, span := tracing.Start(ctx, "attendance.Conn.SubmitPayslips")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.SubmitPayslips")
+	defer finish(&err)
+
+	if len(payslips) == 0 {
+		return nil
+	}
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "SubmitPayslip")
+	}
+	for i := range payslips {
+		payslips[i].IDMstTenant = tenantID
+	}
+
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		for start := 0; start < len(payslips); start += payslipInsertBatchSize {
+			end := min(start+payslipInsertBatchSize, len(payslips))
+			_, err := session.Table(TrxUserPayslipTable).Insert(payslips[start:end])
+			if err != nil {
+				return nil, err
+			}
+		}
+		return []audit.Entry{{
+			Action: constant.AuditActionCreate,
+			Entity: TrxUserPayslipTable,
+			After: map[string]int64{
+				"id_mst_payroll_period": payslips[0].IDMstPayrollPeriod,
+				"payslips":              int64(len(payslips)),
+			},
+		}}, nil
+	})
 	if err != nil {
 		return errors.Wrap(err, "SubmitPayslip")
 	}
 	return
 }
 
+func (c *Conn) AssignAttendancePayrollPeriod(ctx context.Context, params model.AssignPayrollPeriodParams) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.AssignAttendancePayrollPeriod")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.AssignAttendancePayrollPeriod")
+	defer finish(&err)
+
+	if len(params.IDs) == 0 {
+		return nil
+	}
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.AssignAttendancePayrollPeriod")
+	}
+	err = assignPayrollPeriodAudited(ctx, c.DB, MstAttendanceTable, params,
+		"UPDATE "+MstAttendanceTable+" SET id_mst_payroll_period = ?, updated_by = ?, updated_at = now() WHERE id = ANY(?) AND id_mst_tenant = ?",
+		params.IDMstPayrollPeriod, params.UpdatedBy, pq.Array(params.IDs), tenantID,
+	)
+	if err != nil {
+		return errors.Wrap(err, "conn.AssignAttendancePayrollPeriod")
+	}
+	return nil
+}
+
+func (c *Conn) AssignOvertimePayrollPeriod(ctx context.Context, params model.AssignPayrollPeriodParams) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.AssignOvertimePayrollPeriod")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.AssignOvertimePayrollPeriod")
+	defer finish(&err)
+
+	if len(params.IDs) == 0 {
+		return nil
+	}
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.AssignOvertimePayrollPeriod")
+	}
+	err = assignPayrollPeriodAudited(ctx, c.DB, TrxOvertime, params,
+		"UPDATE "+TrxOvertime+" SET id_mst_payroll_period = ?, updated_by = ?, updated_at = now() WHERE id = ANY(?) AND id_mst_tenant = ?",
+		params.IDMstPayrollPeriod, params.UpdatedBy, pq.Array(params.IDs), tenantID,
+	)
+	if err != nil {
+		return errors.Wrap(err, "conn.AssignOvertimePayrollPeriod")
+	}
+	return nil
+}
+
+func (c *Conn) AssignReimbursementPayrollPeriod(ctx context.Context, params model.AssignPayrollPeriodParams) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.AssignReimbursementPayrollPeriod")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.AssignReimbursementPayrollPeriod")
+	defer finish(&err)
+
+	if len(params.IDs) == 0 {
+		return nil
+	}
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.AssignReimbursementPayrollPeriod")
+	}
+	err = assignPayrollPeriodAudited(ctx, c.DB, TrxReimbursementTable, params,
+		"UPDATE "+TrxReimbursementTable+" SET id_mst_payroll_period = ?, status = ?, updated_by = ?, updated_at = now() WHERE id = ANY(?) AND id_mst_tenant = ?",
+		params.IDMstPayrollPeriod, params.Status, params.UpdatedBy, pq.Array(params.IDs), tenantID,
+	)
+	if err != nil {
+		return errors.Wrap(err, "conn.AssignReimbursementPayrollPeriod")
+	}
+	return nil
+}
+
 func (c *Conn) GetPayslips(ctx context.Context, params model.GetPayslipRequest) (payslips []model.TrxUserPayslip, err error) {
-	session := c.DB.MasterDB.Table(TrxUserPayslipTable)
+	ctx, span := tracing.Start(ctx, "attendance.Conn.GetPayslips")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.GetPayslips")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetPayslips")
+	}
+
+	session := c.DB.Reader(ctx).Table(TrxUserPayslipTable).Where("id_mst_tenant = ?", tenantID)
 
 	if params.IDMstPayrollPeriod > 0 {
 		session.Where("id_mst_payroll_period = ?", params.IDMstPayrollPeriod)
@@ -30,7 +156,15 @@ func (c *Conn) GetPayslips(ctx context.Context, params model.GetPayslipRequest)
 	if params.UserID > 0 {
 		session.Where("id_mst_user = ?", params.UserID)
 	}
-	err = session.Find(&payslips)
+	if params.Cursor > 0 {
+		session.Where("id > ?", params.Cursor)
+	}
+	if params.Limit > 0 {
+		session.Limit(params.Limit)
+	}
+	err = session.
+		OrderBy("id ASC").
+		Find(&payslips)
 	if err != nil {
 		return nil, errors.Wrap(err, "GetPayslips")
 	}
@@ -38,8 +172,18 @@ func (c *Conn) GetPayslips(ctx context.Context, params model.GetPayslipRequest)
 }
 
 func (c *Conn) SubmitPayroll(ctx context.Context, payroll model.DtlPayroll) (err error) {
-	session := c.DB.MasterDB.Table(DtlPayrollTable)
-	_, err = session.Insert(payroll)
+	ctx, span := tracing.Start(ctx, "attendance.Conn.SubmitPayroll")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.SubmitPayroll")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "SubmitPayroll")
+	}
+	payroll.IDMstTenant = tenantID
+
+	err = insertAudited(ctx, c.DB, DtlPayrollTable, &payroll, func() int64 { return payroll.ID })
 	if err != nil {
 		return errors.Wrap(err, "SubmitPayroll")
 	}
@@ -47,10 +191,20 @@ func (c *Conn) SubmitPayroll(ctx context.Context, payroll model.DtlPayroll) (err
 }
 
 func (c *Conn) GetPayrollDetail(ctx context.Context, params model.GetDtlPayrollRequest) (payrollDetail model.DtlPayroll, err error) {
-	session := c.DB.MasterDB.Table(DtlPayrollTable)
+	ctx, span := tracing.Start(ctx, "attendance.Conn.GetPayrollDetail")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.GetPayrollDetail")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return payrollDetail, errors.Wrap(err, "GetPayrollDetail")
+	}
+
+	session := c.DB.Reader(ctx).Table(DtlPayrollTable)
 
 	_, err = session.
-		Where("id_mst_payroll_period = ?", params.IDMstPayrollPeriod).
+		Where("id_mst_payroll_period = ? AND id_mst_tenant = ?", params.IDMstPayrollPeriod, tenantID).
 		Get(&payrollDetail)
 	if err != nil {
 		return payrollDetail, errors.Wrap(err, "GetPayrollDetail")
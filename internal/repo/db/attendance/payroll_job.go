@@ -0,0 +1,242 @@
+package attendance
+
+import (
+	"context"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/internal/repo/db/audit"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
+	"github.com/go-xorm/xorm"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+const (
+	TrxPayrollJobTable = "trx_payroll_job"
+)
+
+// CreatePayrollJob queues the job holding its payroll period, so an attendance
+// correction being approved for the period finishes before the job exists and
+// one approved later sees the job.
+func (c *Conn) CreatePayrollJob(ctx context.Context, job *model.TrxPayrollJob) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.CreatePayrollJob")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.CreatePayrollJob")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.CreatePayrollJob")
+	}
+	job.IDMstTenant = tenantID
+
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		_, err := session.Table(MstPayrollPeriodTable).
+			Where("id = ? AND id_mst_tenant = ?", job.IDMstPayrollPeriod, tenantID).
+			ForUpdate().
+			Get(&model.MstPayrollPeriod{})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Table(TrxPayrollJobTable).InsertOne(job)
+		return nil, err
+	})
+	if err != nil {
+		return errors.Wrap(err, "conn.CreatePayrollJob")
+	}
+	return nil
+}
+
+func (c *Conn) GetPayrollJob(ctx context.Context, id int64) (res model.TrxPayrollJob, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.GetPayrollJob")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.GetPayrollJob")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.GetPayrollJob")
+	}
+
+	session := c.DB.Reader(ctx).Table(TrxPayrollJobTable)
+	_, err = session.Where("id = ? AND id_mst_tenant = ?", id, tenantID).Get(&res)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.GetPayrollJob")
+	}
+	return res, nil
+}
+
+func (c *Conn) ListPayrollJobByParams(ctx context.Context, params model.ListPayrollJobParams) (res []model.TrxPayrollJob, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.ListPayrollJobByParams")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.ListPayrollJobByParams")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.ListPayrollJobByParams")
+	}
+
+	session := c.DB.Reader(ctx).Table(TrxPayrollJobTable).Where("id_mst_tenant = ?", tenantID)
+
+	if params.IDMstPayrollPeriod > 0 {
+		session.Where("id_mst_payroll_period = ?", params.IDMstPayrollPeriod)
+	}
+	if len(params.Statuses) > 0 {
+		session.Where("status = ANY(?)", pq.Array(params.Statuses))
+	}
+
+	err = session.
+		OrderBy("id ASC").
+		Find(&res)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.ListPayrollJobByParams")
+	}
+	return res, nil
+}
+
+// UpdatePayrollJob writes the progress columns, including zero values, and
+// refreshes updated_at which doubles as the job heartbeat.
+func (c *Conn) UpdatePayrollJob(ctx context.Context, job *model.TrxPayrollJob) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.UpdatePayrollJob")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.UpdatePayrollJob")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.UpdatePayrollJob")
+	}
+
+	session := c.DB.MasterDB.Context(ctx).Table(TrxPayrollJobTable)
+	_, err = session.
+		Where("id = ? AND id_mst_tenant = ?", job.ID, tenantID).
+		Cols("status", "progress", "stage", "error_message", "attempts", "started_at", "finished_at", "updated_at").
+		Update(job)
+	if err != nil {
+		return errors.Wrap(err, "conn.UpdatePayrollJob")
+	}
+	return nil
+}
+
+// TouchPayrollJob refreshes the heartbeat of a running job without touching
+// its progress. Like claiming and requeueing it is done by the workers for
+// all tenants.
+func (c *Conn) TouchPayrollJob(ctx context.Context, id int64) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.TouchPayrollJob")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.TouchPayrollJob")
+	defer finish(&err)
+
+	_, err = c.DB.MasterDB.Context(ctx).Exec("UPDATE "+TrxPayrollJobTable+" SET updated_at = now() WHERE id = ?", id)
+	if err != nil {
+		return errors.Wrap(err, "conn.TouchPayrollJob")
+	}
+	return nil
+}
+
+// ClaimPayrollJob marks the oldest queued job of any tenant as running and
+// returns it. SKIP LOCKED lets several workers poll the table without taking
+// the same job.
+func (c *Conn) ClaimPayrollJob(ctx context.Context) (job model.TrxPayrollJob, found bool, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.ClaimPayrollJob")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.ClaimPayrollJob")
+	defer finish(&err)
+
+	found, err = c.DB.MasterDB.Context(ctx).SQL(`UPDATE `+TrxPayrollJobTable+`
+		SET status = ?, attempts = attempts + 1, started_at = now(), updated_at = now()
+		WHERE id = (
+			SELECT id FROM `+TrxPayrollJobTable+`
+			WHERE status = ?
+			ORDER BY id ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		constant.PayrollJobStatusRunning, constant.PayrollJobStatusQueued,
+	).Get(&job)
+	if err != nil {
+		return job, false, errors.Wrap(err, "conn.ClaimPayrollJob")
+	}
+	return job, found, nil
+}
+
+// RequeueStalePayrollJobs puts running jobs without a heartbeat since
+// staleBefore back in the queue, which is how jobs interrupted by a restart
+// are picked up again.
+func (c *Conn) RequeueStalePayrollJobs(ctx context.Context, staleBefore time.Time) (affected int64, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.RequeueStalePayrollJobs")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.RequeueStalePayrollJobs")
+	defer finish(&err)
+
+	session := c.DB.MasterDB.Context(ctx).Table(TrxPayrollJobTable)
+	affected, err = session.
+		Where("status = ?", constant.PayrollJobStatusRunning).
+		And("updated_at < ?", staleBefore).
+		Cols("status", "stage").
+		Update(&model.TrxPayrollJob{
+			Status: constant.PayrollJobStatusQueued,
+			Stage:  "interrupted, waiting to resume",
+		})
+	if err != nil {
+		return affected, errors.Wrap(err, "conn.RequeueStalePayrollJobs")
+	}
+	return affected, nil
+}
+
+// ResetPayrollPeriodResults removes everything a failed or interrupted payroll
+// generation may have written for the period so it can be generated again. It
+// is only audited when there was something to remove.
+func (c *Conn) ResetPayrollPeriodResults(ctx context.Context, payrollPeriodID int64, pendingReimbursementStatus string) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.ResetPayrollPeriodResults")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.ResetPayrollPeriodResults")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.ResetPayrollPeriodResults")
+	}
+
+	statements := []struct {
+		table string
+		args  []interface{}
+	}{
+		{TrxUserPayslipTable, []interface{}{"DELETE FROM " + TrxUserPayslipTable + " WHERE id_mst_payroll_period = ? AND id_mst_tenant = ?", payrollPeriodID, tenantID}},
+		{DtlPayrollTable, []interface{}{"DELETE FROM " + DtlPayrollTable + " WHERE id_mst_payroll_period = ? AND id_mst_tenant = ?", payrollPeriodID, tenantID}},
+		{MstAttendanceTable, []interface{}{"UPDATE " + MstAttendanceTable + " SET id_mst_payroll_period = NULL WHERE id_mst_payroll_period = ? AND id_mst_tenant = ?", payrollPeriodID, tenantID}},
+		{TrxOvertime, []interface{}{"UPDATE " + TrxOvertime + " SET id_mst_payroll_period = NULL WHERE id_mst_payroll_period = ? AND id_mst_tenant = ?", payrollPeriodID, tenantID}},
+		{TrxReimbursementTable, []interface{}{"UPDATE " + TrxReimbursementTable + " SET id_mst_payroll_period = NULL, status = ? WHERE id_mst_payroll_period = ? AND id_mst_tenant = ?", pendingReimbursementStatus, payrollPeriodID, tenantID}},
+	}
+
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		affectedRows := map[string]int64{}
+		var totalAffected int64
+		for _, statement := range statements {
+			result, err := session.Exec(statement.args...)
+			if err != nil {
+				return nil, err
+			}
+			affectedRows[statement.table], _ = result.RowsAffected()
+			totalAffected += affectedRows[statement.table]
+		}
+		if totalAffected == 0 {
+			return nil, nil
+		}
+		return []audit.Entry{{
+			Action:   constant.AuditActionResetPayrollPeriod,
+			Entity:   MstPayrollPeriodTable,
+			EntityID: payrollPeriodID,
+			After:    affectedRows,
+		}}, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "conn.ResetPayrollPeriodResults")
+	}
+	return nil
+}
@@ -0,0 +1,454 @@
+package attendance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+)
+
+func Test_CreatePayrollJob(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_payroll_period\" WHERE \\(id = \\$1 AND id_mst_tenant = \\$2\\) LIMIT 1 FOR UPDATE").
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_payroll_job\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:    "Failed at InsertOne",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_payroll_period\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_payroll_job\"").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			err := c.CreatePayrollJob(tenant.NewContext(context.Background(), 1), &model.TrxPayrollJob{IDMstPayrollPeriod: 1, Status: "queued"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.CreatePayrollJob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_GetPayrollJob(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		want    int64
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			want: 1,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_payroll_job\" WHERE \\(id = \\$1 AND id_mst_tenant = \\$2\\)").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, "running"))
+			},
+		},
+		{
+			name:    "Failed because get method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .*").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.GetPayrollJob(tenant.NewContext(context.Background(), 1), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.GetPayrollJob() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.ID != tt.want {
+				t.Errorf("Conn.GetPayrollJob() id = %v, want %v", got.ID, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ListPayrollJobByParams(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		params  model.ListPayrollJobParams
+		wantLen int
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			params: model.ListPayrollJobParams{
+				IDMstPayrollPeriod: 1,
+				Statuses:           []string{"queued", "running"},
+			},
+			wantLen: 1,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* WHERE \\(id_mst_tenant = \\$1\\) AND \\(id_mst_payroll_period = \\$2\\) AND \\(status = ANY\\(\\$3\\)\\) ORDER BY id ASC").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, "queued"))
+			},
+		},
+		{
+			name:    "Failed because find method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .*").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.ListPayrollJobByParams(tenant.NewContext(context.Background(), 1), tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ListPayrollJobByParams() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("Conn.ListPayrollJobByParams() len = %v, want %v", len(got), tt.wantLen)
+			}
+		})
+	}
+}
+
+func Test_UpdatePayrollJob(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			patch: func() {
+				mockDB.ExpectExec("^UPDATE \"trx_payroll_job\" SET .*progress.* WHERE \\(id = \\$\\d+ AND id_mst_tenant = \\$\\d+\\)").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:    "Failed because update method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectExec("^UPDATE \"trx_payroll_job\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			err := c.UpdatePayrollJob(tenant.NewContext(context.Background(), 1), &model.TrxPayrollJob{ID: 1, Status: "running"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.UpdatePayrollJob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_TouchPayrollJob(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			patch: func() {
+				mockDB.ExpectExec("^UPDATE trx_payroll_job SET updated_at = now\\(\\) WHERE id = \\$1").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:    "Failed because exec method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectExec("^UPDATE trx_payroll_job").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			err := c.TouchPayrollJob(tenant.NewContext(context.Background(), 1), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.TouchPayrollJob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ClaimPayrollJob(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name      string
+		wantFound bool
+		wantErr   bool
+		patch     func()
+	}{
+		{
+			name:      "Successful",
+			wantFound: true,
+			patch: func() {
+				mockDB.ExpectQuery("^UPDATE trx_payroll_job\\s+SET status = \\$1, attempts = attempts \\+ 1.*FOR UPDATE SKIP LOCKED.*RETURNING \\*").
+					WithArgs("running", "queued").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status", "attempts"}).AddRow(1, "running", 1))
+			},
+		},
+		{
+			name: "Empty queue",
+			patch: func() {
+				mockDB.ExpectQuery("^UPDATE trx_payroll_job").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status", "attempts"}))
+			},
+		},
+		{
+			name:    "Failed because query method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^UPDATE trx_payroll_job").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			_, found, err := c.ClaimPayrollJob(tenant.NewContext(context.Background(), 1))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ClaimPayrollJob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if found != tt.wantFound {
+				t.Errorf("Conn.ClaimPayrollJob() found = %v, want %v", found, tt.wantFound)
+			}
+		})
+	}
+}
+
+func Test_RequeueStalePayrollJobs(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		want    int64
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			want: 2,
+			patch: func() {
+				mockDB.ExpectExec("^UPDATE \"trx_payroll_job\" SET .* WHERE \\(status = \\$\\d+\\) AND \\(updated_at < \\$\\d+\\)").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name:    "Failed because update method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectExec("^UPDATE \"trx_payroll_job\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.RequeueStalePayrollJobs(tenant.NewContext(context.Background(), 1), time.Now())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.RequeueStalePayrollJobs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Conn.RequeueStalePayrollJobs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ResetPayrollPeriodResults(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec("^DELETE FROM trx_user_payslip").WithArgs(5, 1).WillReturnResult(sqlmock.NewResult(0, 3))
+				mockDB.ExpectExec("^DELETE FROM dtl_payroll").WithArgs(5, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectExec("^UPDATE mst_attendance SET id_mst_payroll_period = NULL").WithArgs(5, 1).WillReturnResult(sqlmock.NewResult(0, 10))
+				mockDB.ExpectExec("^UPDATE trx_overtime SET id_mst_payroll_period = NULL").WithArgs(5, 1).WillReturnResult(sqlmock.NewResult(0, 2))
+				mockDB.ExpectExec("^UPDATE trx_reimbursement SET id_mst_payroll_period = NULL, status = \\$1").WithArgs("pending", 5, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "Nothing to reset is not audited",
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec("^DELETE FROM trx_user_payslip").WithArgs(5, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mockDB.ExpectExec("^DELETE FROM dtl_payroll").WithArgs(5, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mockDB.ExpectExec("^UPDATE mst_attendance SET id_mst_payroll_period = NULL").WithArgs(5, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mockDB.ExpectExec("^UPDATE trx_overtime SET id_mst_payroll_period = NULL").WithArgs(5, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mockDB.ExpectExec("^UPDATE trx_reimbursement SET id_mst_payroll_period = NULL, status = \\$1").WithArgs("pending", 5, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:    "Rolled back on failure",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec("^DELETE FROM trx_user_payslip").WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			err := c.ResetPayrollPeriodResults(tenant.NewContext(context.Background(), 1), 5, "pending")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ResetPayrollPeriodResults() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,12 +2,15 @@ package attendance
 
 import (
 	"context"
+	"database/sql"
 	"reflect"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
 	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 )
 
@@ -43,7 +46,7 @@ func Test_SubmitPayslips(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				payslips: []model.TrxUserPayslip{
 					{
 						UserID:             1,
@@ -57,9 +60,36 @@ func Test_SubmitPayslips(t *testing.T) {
 			},
 			wantErr: false,
 			patch: func() {
+				mockDB.ExpectBegin()
 				mockDB.
 					ExpectExec("^INSERT .*").
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "Successful in batches",
+			fields: fields{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			},
+			args: args{
+				ctx:      tenant.NewContext(context.Background(), 1),
+				payslips: make([]model.TrxUserPayslip, payslipInsertBatchSize+1),
+			},
+			wantErr: false,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.
+					ExpectExec("^INSERT INTO \"trx_user_payslip\"").
+					WillReturnResult(sqlmock.NewResult(1, payslipInsertBatchSize))
+				mockDB.
+					ExpectExec("^INSERT INTO \"trx_user_payslip\"").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
 			},
 		},
 		{
@@ -70,7 +100,7 @@ func Test_SubmitPayslips(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				payslips: []model.TrxUserPayslip{
 					{
 						UserID:             1,
@@ -84,9 +114,11 @@ func Test_SubmitPayslips(t *testing.T) {
 			},
 			wantErr: true,
 			patch: func() {
+				mockDB.ExpectBegin()
 				mockDB.
 					ExpectExec("^INSERT INTO \"trx_user_payslip\"").
 					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
 			},
 		},
 	}
@@ -105,6 +137,104 @@ func Test_SubmitPayslips(t *testing.T) {
 	}
 }
 
+func Test_AssignPayrollPeriod(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	c := &Conn{
+		DB: &xormlib.DBConnect{
+			MasterDB: mockConn,
+		},
+	}
+	params := model.AssignPayrollPeriodParams{
+		IDs:                []int64{1, 2, 3},
+		IDMstPayrollPeriod: 5,
+		UpdatedBy:          sql.NullInt64{Int64: 9, Valid: true},
+		Status:             "paid",
+	}
+
+	tests := []struct {
+		name    string
+		assign  func(ctx context.Context, params model.AssignPayrollPeriodParams) error
+		params  model.AssignPayrollPeriodParams
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name:   "attendance",
+			assign: c.AssignAttendancePayrollPeriod,
+			params: params,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(`^UPDATE mst_attendance SET id_mst_payroll_period = \$1, updated_by = \$2, updated_at = now\(\) WHERE id = ANY\(\$3\) AND id_mst_tenant = \$4`).
+					WithArgs(params.IDMstPayrollPeriod, params.UpdatedBy, pq.Array(params.IDs), 1).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:   "overtime",
+			assign: c.AssignOvertimePayrollPeriod,
+			params: params,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(`^UPDATE trx_overtime SET id_mst_payroll_period = \$1, updated_by = \$2, updated_at = now\(\) WHERE id = ANY\(\$3\) AND id_mst_tenant = \$4`).
+					WithArgs(params.IDMstPayrollPeriod, params.UpdatedBy, pq.Array(params.IDs), 1).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:   "reimbursement",
+			assign: c.AssignReimbursementPayrollPeriod,
+			params: params,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(`^UPDATE trx_reimbursement SET id_mst_payroll_period = \$1, status = \$2, updated_by = \$3, updated_at = now\(\) WHERE id = ANY\(\$4\) AND id_mst_tenant = \$5`).
+					WithArgs(params.IDMstPayrollPeriod, params.Status, params.UpdatedBy, pq.Array(params.IDs), 1).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:   "no ids",
+			assign: c.AssignAttendancePayrollPeriod,
+			params: model.AssignPayrollPeriodParams{IDMstPayrollPeriod: 5},
+			patch:  func() {},
+		},
+		{
+			name:    "database error",
+			assign:  c.AssignOvertimePayrollPeriod,
+			params:  params,
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec("^UPDATE trx_overtime").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.patch()
+			err := tt.assign(tenant.NewContext(context.Background(), 1), tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.Assign*PayrollPeriod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func Test_GetPayslips(t *testing.T) {
 	mockConn, mockDB := xormlib.NewMockDB()
 	defer func() {
@@ -138,7 +268,7 @@ func Test_GetPayslips(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				params: model.GetPayslipRequest{
 					IDMstPayrollPeriod: 1,
 					UserID:             1,
@@ -172,7 +302,7 @@ func Test_GetPayslips(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				params: model.GetPayslipRequest{
 					IDMstPayrollPeriod: 1,
 					UserID:             1,
@@ -235,7 +365,7 @@ func Test_SubmitPayroll(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				payroll: model.DtlPayroll{
 					IDMstPayrollPeriod: 1,
 					TotalTakeHome:      157000000,
@@ -243,10 +373,13 @@ func Test_SubmitPayroll(t *testing.T) {
 			},
 			wantErr: false,
 			patch: func() {
+				mockDB.ExpectBegin()
 				mockDB.
 					ExpectQuery("^INSERT .*").WillReturnRows(
 					sqlmock.NewRows([]string{"id"}).AddRow(1),
 				)
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
 			},
 		},
 		{
@@ -257,7 +390,7 @@ func Test_SubmitPayroll(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				payroll: model.DtlPayroll{
 					IDMstPayrollPeriod: 1,
 					TotalTakeHome:      157000000,
@@ -265,11 +398,13 @@ func Test_SubmitPayroll(t *testing.T) {
 			},
 			wantErr: true,
 			patch: func() {
+				mockDB.ExpectBegin()
 				mockDB.
 					ExpectQuery("^INSERT .*").
 					WillReturnError(
 						errors.New("database error"),
 					)
+				mockDB.ExpectRollback()
 			},
 		},
 	}
@@ -321,7 +456,7 @@ func Test_GetPayrollDetail(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				params: model.GetDtlPayrollRequest{
 					IDMstPayrollPeriod: 1,
 				},
@@ -348,7 +483,7 @@ func Test_GetPayrollDetail(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				params: model.GetDtlPayrollRequest{
 					IDMstPayrollPeriod: 1,
 				},
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
 	"github.com/pkg/errors"
 )
 
@@ -12,8 +14,18 @@ const (
 )
 
 func (c *Conn) SubmitReimbursement(ctx context.Context, reimbursement *model.TrxReimbursement) (err error) {
-	session := c.DB.MasterDB.Table(TrxReimbursementTable)
-	_, err = session.InsertOne(reimbursement)
+	ctx, span := tracing.Start(ctx, "attendance.Conn.SubmitReimbursement")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.SubmitReimbursement")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.SubmitReimbursement")
+	}
+	reimbursement.IDMstTenant = tenantID
+
+	err = insertAudited(ctx, c.DB, TrxReimbursementTable, reimbursement, func() int64 { return reimbursement.ID })
 	if err != nil {
 		return errors.Wrap(err, "conn.SubmitReimbursement")
 	}
@@ -21,7 +33,17 @@ func (c *Conn) SubmitReimbursement(ctx context.Context, reimbursement *model.Trx
 }
 
 func (c *Conn) ListReimbursementByParams(ctx context.Context, params model.ListReimbursementParams) (resp []model.TrxReimbursement, err error) {
-	session := c.DB.MasterDB.Table(TrxReimbursementTable)
+	ctx, span := tracing.Start(ctx, "attendance.Conn.ListReimbursementByParams")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.ListReimbursementByParams")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListReimbursementByParams")
+	}
+
+	session := c.DB.Reader(ctx).Table(TrxReimbursementTable).Where("id_mst_tenant = ?", tenantID)
 
 	if params.UserID > 0 {
 		session.Where("id_mst_user = ?", params.UserID)
@@ -35,8 +57,16 @@ func (c *Conn) ListReimbursementByParams(ctx context.Context, params model.ListR
 	if params.IDMstPayrollPeriod != 0 {
 		session.Where("id_mst_payroll_period = ?", params.IDMstPayrollPeriod)
 	}
+	if params.Cursor > 0 {
+		session.Where("id > ?", params.Cursor)
+	}
+	if params.Limit > 0 {
+		session.Limit(params.Limit)
+	}
 
-	err = session.Find(&resp)
+	err = session.
+		OrderBy("id ASC").
+		Find(&resp)
 	if err != nil {
 		return nil, errors.Wrap(err, "conn.ListReimbursementByParams")
 	}
@@ -44,8 +74,12 @@ func (c *Conn) ListReimbursementByParams(ctx context.Context, params model.ListR
 }
 
 func (c *Conn) UpdateReimbursement(ctx context.Context, reimbursement *model.TrxReimbursement) (err error) {
-	session := c.DB.MasterDB.Table(TrxReimbursementTable)
-	_, err = session.Where("id = ?", reimbursement.ID).Update(reimbursement)
+	ctx, span := tracing.Start(ctx, "attendance.Conn.UpdateReimbursement")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.UpdateReimbursement")
+	defer finish(&err)
+
+	err = updateAudited(ctx, c.DB, TrxReimbursementTable, reimbursement.ID, reimbursement)
 	if err != nil {
 		return errors.Wrap(err, "conn.UpdateReimbursementStatus")
 	}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
 	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
 	"github.com/pkg/errors"
 )
@@ -35,7 +36,7 @@ func Test_UpdateReimburse(t *testing.T) {
 		{
 			name: "success update reimbursement",
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				reimbursement: &model.TrxReimbursement{
 					ID:          1,
 					UserID:      1,
@@ -46,14 +47,19 @@ func Test_UpdateReimburse(t *testing.T) {
 			},
 			wantErr: false,
 			patch: func() {
+				mockDB.ExpectBegin()
+				expectRowLock(mockDB, TrxReimbursementTable)
 				mockDB.ExpectExec("^UPDATE .*").
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectRowSnapshot(mockDB, TrxReimbursementTable)
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
 			},
 		},
 		{
 			name: "error update reimbursement - database error",
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				reimbursement: &model.TrxReimbursement{
 					ID:          2,
 					UserID:      2,
@@ -64,8 +70,11 @@ func Test_UpdateReimburse(t *testing.T) {
 			},
 			wantErr: true,
 			patch: func() {
+				mockDB.ExpectBegin()
+				expectRowLock(mockDB, TrxReimbursementTable)
 				mockDB.ExpectExec("^UPDATE .*").
 					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
 			},
 		},
 	}
@@ -120,7 +129,7 @@ func Test_SubmitReimbursement(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				reimbursement: &model.TrxReimbursement{
 					UserID:      1,
 					Status:      "pending",
@@ -130,10 +139,13 @@ func Test_SubmitReimbursement(t *testing.T) {
 			},
 			wantErr: false,
 			patch: func() {
+				mockDB.ExpectBegin()
 				mockDB.
 					ExpectQuery("^INSERT .*").WillReturnRows(
 					sqlmock.NewRows([]string{"id"}).AddRow(1),
 				)
+				expectAuditEntry(mockDB)
+				mockDB.ExpectCommit()
 			},
 		},
 		{
@@ -144,7 +156,7 @@ func Test_SubmitReimbursement(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				reimbursement: &model.TrxReimbursement{
 					UserID:      1,
 					Status:      "pending",
@@ -154,11 +166,13 @@ func Test_SubmitReimbursement(t *testing.T) {
 			},
 			wantErr: true,
 			patch: func() {
+				mockDB.ExpectBegin()
 				mockDB.
 					ExpectQuery("^INSERT .*").
 					WillReturnError(
 						errors.New("database error"),
 					)
+				mockDB.ExpectRollback()
 			},
 		},
 	}
@@ -210,7 +224,7 @@ func Test_ListReimbursementByParams(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				params: model.ListReimbursementParams{
 					UserID: 1,
 					Status: "pending",
@@ -242,7 +256,7 @@ func Test_ListReimbursementByParams(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				params: model.ListReimbursementParams{
 					UserID: 999,
 					Status: "pending",
@@ -265,7 +279,7 @@ func Test_ListReimbursementByParams(t *testing.T) {
 				},
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx: tenant.NewContext(context.Background(), 1),
 				params: model.ListReimbursementParams{
 					UserID: 1,
 					Status: "pending",
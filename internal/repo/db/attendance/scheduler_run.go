@@ -0,0 +1,71 @@
+package attendance
+
+import (
+	"context"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
+	"github.com/pkg/errors"
+)
+
+const (
+	TrxSchedulerRunTable = "trx_scheduler_run"
+)
+
+func (c *Conn) CreateSchedulerRun(ctx context.Context, run *model.TrxSchedulerRun) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.CreateSchedulerRun")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.CreateSchedulerRun")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.CreateSchedulerRun")
+	}
+	run.IDMstTenant = tenantID
+
+	session := c.DB.MasterDB.Context(ctx).Table(TrxSchedulerRunTable)
+	_, err = session.InsertOne(run)
+	if err != nil {
+		return errors.Wrap(err, "conn.CreateSchedulerRun")
+	}
+	return nil
+}
+
+// ListSchedulerRunByParams returns runs newest first, the cursor is the
+// smallest id of the previous page.
+func (c *Conn) ListSchedulerRunByParams(ctx context.Context, params model.ListSchedulerRunParams) (res []model.TrxSchedulerRun, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Conn.ListSchedulerRunByParams")
+	defer func() { tracing.End(span, err) }()
+	ctx, finish := c.DB.Operation(ctx, "attendance.Conn.ListSchedulerRunByParams")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.ListSchedulerRunByParams")
+	}
+
+	session := c.DB.Reader(ctx).Table(TrxSchedulerRunTable).Where("id_mst_tenant = ?", tenantID)
+
+	if params.JobName != "" {
+		session.Where("job_name = ?", params.JobName)
+	}
+	if params.Status != "" {
+		session.Where("status = ?", params.Status)
+	}
+	if params.Cursor > 0 {
+		session.Where("id < ?", params.Cursor)
+	}
+	if params.Limit > 0 {
+		session.Limit(params.Limit)
+	}
+
+	err = session.
+		OrderBy("id DESC").
+		Find(&res)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.ListSchedulerRunByParams")
+	}
+	return res, nil
+}
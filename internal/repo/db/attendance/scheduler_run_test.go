@@ -0,0 +1,132 @@
+package attendance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+)
+
+func Test_CreateSchedulerRun(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		run     *model.TrxSchedulerRun
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			run: &model.TrxSchedulerRun{
+				JobName:    "payroll_period",
+				Status:     "success",
+				StartedAt:  time.Now(),
+				FinishedAt: time.Now(),
+			},
+			patch: func() {
+				mockDB.ExpectQuery("^INSERT INTO \"trx_scheduler_run\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+		},
+		{
+			name:    "Failed at InsertOne",
+			run:     &model.TrxSchedulerRun{},
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^INSERT INTO \"trx_scheduler_run\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			err := c.CreateSchedulerRun(tenant.NewContext(context.Background(), 1), tt.run)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.CreateSchedulerRun() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ListSchedulerRunByParams(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		params  model.ListSchedulerRunParams
+		wantLen int
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			params: model.ListSchedulerRunParams{
+				JobName: "payroll_period",
+				Status:  "failed",
+				Cursor:  10,
+				Limit:   2,
+			},
+			wantLen: 2,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* WHERE \\(id_mst_tenant = \\$1\\) AND \\(job_name = \\$2\\) AND \\(status = \\$3\\) AND \\(id < \\$4\\) ORDER BY id DESC LIMIT 2").
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "job_name", "status"}).
+							AddRow(9, "payroll_period", "failed").
+							AddRow(7, "payroll_period", "failed"),
+					)
+			},
+		},
+		{
+			name:    "Failed because find method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .*").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.ListSchedulerRunByParams(tenant.NewContext(context.Background(), 1), tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ListSchedulerRunByParams() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("Conn.ListSchedulerRunByParams() len = %v, want %v", len(got), tt.wantLen)
+			}
+		})
+	}
+}
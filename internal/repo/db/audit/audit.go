@@ -0,0 +1,215 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/requestinfo"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/go-xorm/xorm"
+	"github.com/pkg/errors"
+)
+
+const (
+	TrxAuditLogTable = "trx_audit_log"
+)
+
+type Conn struct {
+	DB *xormlib.DBConnect
+}
+
+func New(conn *Conn) *Conn {
+	return conn
+}
+
+// Entry describes one change. Before and After are marshalled to JSON, nil
+// leaves the column empty.
+type Entry struct {
+	Action   string
+	Entity   string
+	EntityID int64
+	Before   interface{}
+	After    interface{}
+}
+
+// Change runs change in a transaction together with the audit entries it
+// returns, so the trail can never disagree with the data. Returning no entry
+// means nothing changed and nothing is recorded.
+func Change(ctx context.Context, db *xormlib.DBConnect, change func(session *xorm.Session) ([]Entry, error)) (err error) {
+	session := db.MasterDB.NewSession().Context(ctx)
+	defer session.Close()
+
+	err = session.Begin()
+	if err != nil {
+		return err
+	}
+
+	entries, err := change(session)
+	if err != nil {
+		_ = session.Rollback()
+		return err
+	}
+
+	for _, entry := range entries {
+		err = Record(ctx, session, entry)
+		if err != nil {
+			_ = session.Rollback()
+			return err
+		}
+	}
+
+	return session.Commit()
+}
+
+// Record appends entry to the trail within session, taking the actor from the
+// authenticated user or kiosk, the tenant from the context and the request
+// id and address from the request info.
+func Record(ctx context.Context, session *xorm.Session, entry Entry) (err error) {
+	auditLog := model.TrxAuditLog{
+		Action:   entry.Action,
+		Entity:   entry.Entity,
+		EntityID: sql.NullInt64{Int64: entry.EntityID, Valid: entry.EntityID > 0},
+	}
+	tenantID, found := tenant.FromContext(ctx)
+	auditLog.IDMstTenant = sql.NullInt64{Int64: tenantID, Valid: found}
+
+	if user, found := auth.GetUserDetailFromCtx(ctx); found {
+		auditLog.ActorID = sql.NullInt64{Int64: user.ID, Valid: user.ID > 0}
+		auditLog.ActorUsername = user.Username
+	} else if kiosk, found := auth.GetKioskDetailFromCtx(ctx); found {
+		// kiosks are not users, their changes carry the kiosk name instead
+		auditLog.ActorUsername = "kiosk:" + kiosk.Name
+	}
+	info := requestinfo.FromContext(ctx)
+	auditLog.RequestID = info.RequestID
+	auditLog.IPAddress = info.IPAddress
+
+	auditLog.Before, err = toJSON(entry.Before)
+	if err != nil {
+		return errors.Wrap(err, "audit.Record")
+	}
+	auditLog.After, err = toJSON(entry.After)
+	if err != nil {
+		return errors.Wrap(err, "audit.Record")
+	}
+
+	_, err = session.Table(TrxAuditLogTable).InsertOne(&auditLog)
+	if err != nil {
+		return errors.Wrap(err, "audit.Record")
+	}
+	return nil
+}
+
+// toJSON marshals structs by their xorm column names so the trail reads like
+// the table, with NULL columns as null
+func toJSON(value interface{}) (sql.NullString, error) {
+	if value == nil {
+		return sql.NullString{}, nil
+	}
+
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		if _, isValuer := v.Interface().(driver.Valuer); !isValuer {
+			value = columnsOf(v)
+		}
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(b), Valid: true}, nil
+}
+
+func columnsOf(v reflect.Value) map[string]interface{} {
+	columns := map[string]interface{}{}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := columnName(field)
+		if name == "" {
+			continue
+		}
+
+		value := v.Field(i).Interface()
+		if valuer, ok := value.(driver.Valuer); ok {
+			value, _ = valuer.Value()
+		}
+		columns[name] = value
+	}
+	return columns
+}
+
+func columnName(field reflect.StructField) string {
+	tag := field.Tag.Get("xorm")
+	if tag == "-" {
+		return ""
+	}
+	for _, part := range strings.Fields(tag) {
+		if strings.HasPrefix(part, "'") {
+			return strings.Trim(part, "'")
+		}
+	}
+	if parts := strings.Fields(tag); len(parts) > 0 {
+		return parts[0]
+	}
+	return field.Name
+}
+
+func (c *Conn) ListAuditLogByParams(ctx context.Context, params model.ListAuditLogParams) (res []model.TrxAuditLog, err error) {
+	ctx, finish := c.DB.Operation(ctx, "audit.Conn.ListAuditLogByParams")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListAuditLogByParams")
+	}
+
+	session := c.DB.Reader(ctx).Table(TrxAuditLogTable).Where("id_mst_tenant = ?", tenantID)
+
+	if params.ActorID > 0 {
+		session.Where("actor_id = ?", params.ActorID)
+	}
+	if params.Action != "" {
+		session.Where("action = ?", params.Action)
+	}
+	if params.Entity != "" {
+		session.Where("entity = ?", params.Entity)
+	}
+	if params.EntityID > 0 {
+		session.Where("entity_id = ?", params.EntityID)
+	}
+	if params.RequestID != "" {
+		session.Where("request_id = ?", params.RequestID)
+	}
+	if !params.CreatedFrom.IsZero() {
+		session.Where("created_at >= ?", params.CreatedFrom)
+	}
+	if !params.CreatedBefore.IsZero() {
+		session.Where("created_at < ?", params.CreatedBefore)
+	}
+	if params.Cursor > 0 {
+		session.Where("id < ?", params.Cursor)
+	}
+	if params.Limit > 0 {
+		session.Limit(params.Limit)
+	}
+
+	err = session.OrderBy("id DESC").Find(&res)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListAuditLogByParams")
+	}
+	return res, nil
+}
@@ -0,0 +1,206 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/requestinfo"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/go-xorm/xorm"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Change(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	ctx := auth.SetUserDetailToCtx(tenant.NewContext(context.Background(), 1), auth.UserJWTPayload{ID: 1, Username: "admin"})
+	ctx = requestinfo.NewContext(ctx, requestinfo.Info{RequestID: "req-1", IPAddress: "10.0.0.1"})
+
+	tests := []struct {
+		name    string
+		change  func(session *xorm.Session) ([]Entry, error)
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			change: func(session *xorm.Session) ([]Entry, error) {
+				_, err := session.Exec("UPDATE mst_attendance SET id_mst_payroll_period = NULL")
+				return []Entry{{Action: constant.AuditActionUpdate, Entity: "mst_attendance", EntityID: 2}}, err
+			},
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec("^UPDATE mst_attendance").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WithArgs(int64(1), int64(1), "admin", constant.AuditActionUpdate, "mst_attendance", int64(2), nil, nil, "req-1", "10.0.0.1", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "Nothing changed",
+			change: func(session *xorm.Session) ([]Entry, error) {
+				return nil, nil
+			},
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "Rolled back when the change fails",
+			change: func(session *xorm.Session) ([]Entry, error) {
+				return nil, errors.New("database error")
+			},
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectRollback()
+			},
+		},
+		{
+			name: "Rolled back when the entry fails",
+			change: func(session *xorm.Session) ([]Entry, error) {
+				return []Entry{{Action: constant.AuditActionCreate, Entity: "mst_attendance"}}, nil
+			},
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.patch()
+			err := Change(ctx, &xormlib.DBConnect{MasterDB: mockConn}, tt.change)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Change() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_toJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  sql.NullString
+	}{
+		{
+			name: "nil is left empty",
+		},
+		{
+			name: "struct by column names",
+			value: &model.MstAttendance{
+				ID:                 1,
+				IDMstTenant:        1,
+				IDMstUser:          2,
+				AttendanceDate:     time.Date(2025, 7, 21, 0, 0, 0, 0, time.UTC),
+				IDMstPayrollPeriod: sql.NullInt64{Int64: 3, Valid: true},
+				Latitude:           sql.NullFloat64{Float64: -6.175392, Valid: true},
+				Longitude:          sql.NullFloat64{Float64: 106.827153, Valid: true},
+				LocationStatus:     "inside",
+				Source:             "self",
+			},
+			want: sql.NullString{
+				String: `{"attendance_date":"2025-07-21T00:00:00Z","created_at":"0001-01-01T00:00:00Z","created_by":null,"device_id":"","distance_in_meters":null,"id":1,"id_mst_kiosk":null,"id_mst_payroll_period":3,"id_mst_tenant":1,"id_mst_user":2,"latitude":-6.175392,"location_status":"inside","longitude":106.827153,"office_name":"","source":"self","updated_at":"0001-01-01T00:00:00Z","updated_by":null}`,
+				Valid:  true,
+			},
+		},
+		{
+			name:  "map as is",
+			value: map[string]int64{"payslips": 2},
+			want:  sql.NullString{String: `{"payslips":2}`, Valid: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toJSON(tt.value)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ListAuditLogByParams(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		params  model.ListAuditLogParams
+		wantLen int
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			params: model.ListAuditLogParams{
+				Entity:   "mst_attendance",
+				EntityID: 2,
+				Cursor:   10,
+				Limit:    2,
+			},
+			wantLen: 2,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* WHERE \\(id_mst_tenant = \\$1\\) AND \\(entity = \\$2\\) AND \\(entity_id = \\$3\\) AND \\(id < \\$4\\) ORDER BY id DESC LIMIT 2").
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "action", "entity", "entity_id"}).
+							AddRow(9, "update", "mst_attendance", 2).
+							AddRow(4, "create", "mst_attendance", 2),
+					)
+			},
+		},
+		{
+			name:    "Failed because find method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .*").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.ListAuditLogByParams(tenant.NewContext(context.Background(), 1), tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ListAuditLogByParams() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("Conn.ListAuditLogByParams() len = %v, want %v", len(got), tt.wantLen)
+			}
+		})
+	}
+}
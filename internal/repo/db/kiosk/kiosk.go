@@ -0,0 +1,182 @@
+package kiosk
+
+import (
+	"context"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/internal/repo/db/audit"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/go-xorm/xorm"
+	"github.com/pkg/errors"
+)
+
+const (
+	MstKioskTable      = "mst_kiosk"
+	TrxKioskNonceTable = "trx_kiosk_nonce"
+)
+
+type Conn struct {
+	DB *xormlib.DBConnect
+}
+
+func New(conn *Conn) *Conn {
+	return conn
+}
+
+// RegisterKiosk inserts the kiosk for the tenant of ctx and records it as
+// created, without the secret hash
+func (c *Conn) RegisterKiosk(ctx context.Context, kiosk *model.MstKiosk) (err error) {
+	ctx, finish := c.DB.Operation(ctx, "kiosk.Conn.RegisterKiosk")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.RegisterKiosk")
+	}
+	kiosk.IDMstTenant = tenantID
+
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		_, err := session.Table(MstKioskTable).InsertOne(kiosk)
+		if err != nil {
+			return nil, err
+		}
+		return []audit.Entry{{
+			Action:   constant.AuditActionCreate,
+			Entity:   MstKioskTable,
+			EntityID: kiosk.ID,
+			After:    kioskState(*kiosk),
+		}}, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "conn.RegisterKiosk")
+	}
+	return nil
+}
+
+func (c *Conn) GetKioskByID(ctx context.Context, kioskID int64) (res model.MstKiosk, err error) {
+	ctx, finish := c.DB.Operation(ctx, "kiosk.Conn.GetKioskByID")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.GetKioskByID")
+	}
+
+	_, err = c.DB.Reader(ctx).Table(MstKioskTable).Where("id = ? AND id_mst_tenant = ?", kioskID, tenantID).Get(&res)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.GetKioskByID")
+	}
+	return res, nil
+}
+
+// GetKioskForSignIn looks the kiosk up across all tenants. It is only meant
+// for signing in, before the tenant of the kiosk is known.
+func (c *Conn) GetKioskForSignIn(ctx context.Context, kioskID int64) (res model.MstKiosk, err error) {
+	ctx, finish := c.DB.Operation(ctx, "kiosk.Conn.GetKioskForSignIn")
+	defer finish(&err)
+
+	_, err = c.DB.Reader(ctx).Table(MstKioskTable).Where("id = ?", kioskID).Get(&res)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.GetKioskForSignIn")
+	}
+	return res, nil
+}
+
+func (c *Conn) ListKiosks(ctx context.Context) (res []model.MstKiosk, err error) {
+	ctx, finish := c.DB.Operation(ctx, "kiosk.Conn.ListKiosks")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListKiosks")
+	}
+
+	err = c.DB.Reader(ctx).Table(MstKioskTable).Where("id_mst_tenant = ?", tenantID).OrderBy("id").Find(&res)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListKiosks")
+	}
+	return res, nil
+}
+
+// DeactivateKiosk stops the kiosk from signing in and its tokens and nonces
+// from being accepted. The kiosk is returned as it was before, empty when it
+// does not exist.
+func (c *Conn) DeactivateKiosk(ctx context.Context, kioskID, updatedBy int64) (kiosk model.MstKiosk, err error) {
+	ctx, finish := c.DB.Operation(ctx, "kiosk.Conn.DeactivateKiosk")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return kiosk, errors.Wrap(err, "conn.DeactivateKiosk")
+	}
+
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		found, err := session.Table(MstKioskTable).Where("id = ? AND id_mst_tenant = ?", kioskID, tenantID).ForUpdate().Get(&kiosk)
+		if err != nil || !found {
+			return nil, err
+		}
+
+		_, err = session.Exec(`UPDATE `+MstKioskTable+`
+			SET active = FALSE, updated_by = ?, updated_at = now()
+			WHERE id = ?`, updatedBy, kioskID)
+		if err != nil {
+			return nil, err
+		}
+
+		after := kiosk
+		after.Active = false
+		return []audit.Entry{{
+			Action:   constant.AuditActionDeactivate,
+			Entity:   MstKioskTable,
+			EntityID: kioskID,
+			Before:   kioskState(kiosk),
+			After:    kioskState(after),
+		}}, nil
+	})
+	if err != nil {
+		return kiosk, errors.Wrap(err, "conn.DeactivateKiosk")
+	}
+	return kiosk, nil
+}
+
+// ClaimNonce records the nonce as used for the tenant of ctx. claimed is
+// false when it was already used. The nonces past their expiry are dropped on
+// the way, they are refused before they get here anyway.
+func (c *Conn) ClaimNonce(ctx context.Context, nonce *model.TrxKioskNonce) (claimed bool, err error) {
+	ctx, finish := c.DB.Operation(ctx, "kiosk.Conn.ClaimNonce")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "conn.ClaimNonce")
+	}
+	nonce.IDMstTenant = tenantID
+
+	_, err = c.DB.MasterDB.Context(ctx).Exec(`DELETE FROM ` + TrxKioskNonceTable + ` WHERE expires_at < now()`)
+	if err != nil {
+		return false, errors.Wrap(err, "conn.ClaimNonce")
+	}
+
+	var row model.TrxKioskNonce
+	claimed, err = c.DB.MasterDB.Context(ctx).SQL(`INSERT INTO `+TrxKioskNonceTable+`
+		(id, id_mst_tenant, id_mst_kiosk, id_mst_user, expires_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING
+		RETURNING id`, nonce.ID, nonce.IDMstTenant, nonce.IDMstKiosk, nonce.IDMstUser, nonce.ExpiresAt).
+		Get(&row)
+	if err != nil {
+		return false, errors.Wrap(err, "conn.ClaimNonce")
+	}
+	return claimed, nil
+}
+
+// kioskState keeps the secret hash out of the audit trail
+func kioskState(kiosk model.MstKiosk) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        kiosk.Name,
+		"office_name": kiosk.OfficeName,
+		"active":      kiosk.Active,
+	}
+}
@@ -0,0 +1,382 @@
+package kiosk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RegisterKiosk(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantID  int64
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name:   "Successful",
+			wantID: 3,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^INSERT INTO \"mst_kiosk\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WithArgs(int64(1), nil, "", "create", MstKioskTable, int64(3), nil,
+						`{"active":true,"name":"Warehouse gate","office_name":"HQ"}`, "", "", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:    "Failed because insert method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^INSERT INTO \"mst_kiosk\"").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			kiosk := &model.MstKiosk{Name: "Warehouse gate", OfficeName: "HQ", SecretHash: "hash", Active: true}
+			err := c.RegisterKiosk(tenant.NewContext(context.Background(), 1), kiosk)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.RegisterKiosk() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantID, kiosk.ID)
+		})
+	}
+}
+
+func Test_GetKioskByID(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name     string
+		noTenant bool
+		want     model.MstKiosk
+		wantErr  bool
+		patch    func()
+	}{
+		{
+			name: "Successful",
+			want: model.MstKiosk{ID: 3, Name: "Warehouse gate", SecretHash: "hash", Active: true},
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_kiosk\" WHERE \\(id = \\$1 AND id_mst_tenant = \\$2\\) LIMIT 1").
+					WithArgs(3, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "secret_hash", "active"}).
+						AddRow(3, "Warehouse gate", "hash", true))
+			},
+		},
+		{
+			name:    "Failed because query method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_kiosk\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+		{
+			name:     "Failed because no tenant in context",
+			noTenant: true,
+			wantErr:  true,
+			patch:    func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			ctx := tenant.NewContext(context.Background(), 1)
+			if tt.noTenant {
+				ctx = context.Background()
+			}
+			got, err := c.GetKioskByID(ctx, 3)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.GetKioskByID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_GetKioskForSignIn(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		want    model.MstKiosk
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful without a tenant",
+			want: model.MstKiosk{ID: 3, IDMstTenant: 2, Name: "Warehouse gate", SecretHash: "hash", Active: true},
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_kiosk\" WHERE \\(id = \\$1\\) LIMIT 1").
+					WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "id_mst_tenant", "name", "secret_hash", "active"}).
+						AddRow(3, 2, "Warehouse gate", "hash", true))
+			},
+		},
+		{
+			name:    "Failed because query method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_kiosk\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.GetKioskForSignIn(context.Background(), 3)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.GetKioskForSignIn() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ListKiosks(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantLen int
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name:    "Successful",
+			wantLen: 2,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_kiosk\" WHERE \\(id_mst_tenant = \\$1\\) ORDER BY id").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+						AddRow(3, "Warehouse gate").
+						AddRow(4, "Lobby"))
+			},
+		},
+		{
+			name:    "Failed because find method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_kiosk\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.ListKiosks(tenant.NewContext(context.Background(), 1))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ListKiosks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Len(t, got, tt.wantLen)
+		})
+	}
+}
+
+func Test_DeactivateKiosk(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantID  int64
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name:   "Successful",
+			wantID: 3,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_kiosk\" WHERE \\(id = \\$1 AND id_mst_tenant = \\$2\\) LIMIT 1 FOR UPDATE").
+					WithArgs(3, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "active"}).AddRow(3, "Warehouse gate", true))
+				mockDB.ExpectExec("^UPDATE mst_kiosk\\s+SET active = FALSE, updated_by = \\$1").
+					WithArgs(1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WithArgs(int64(1), nil, "", "deactivate", MstKioskTable, int64(3),
+						`{"active":true,"name":"Warehouse gate","office_name":""}`,
+						`{"active":false,"name":"Warehouse gate","office_name":""}`, "", "", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "Missing kiosk is neither updated nor recorded",
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_kiosk\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:    "Failed because exec method",
+			wantID:  3,
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_kiosk\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mockDB.ExpectExec("^UPDATE mst_kiosk").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.DeactivateKiosk(tenant.NewContext(context.Background(), 1), 3, 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.DeactivateKiosk() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantID, got.ID)
+		})
+	}
+}
+
+func Test_ClaimNonce(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	expiresAt := time.Date(2025, 7, 21, 8, 0, 30, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		want    bool
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			want: true,
+			patch: func() {
+				mockDB.ExpectExec("^DELETE FROM trx_kiosk_nonce WHERE expires_at < now\\(\\)").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mockDB.ExpectQuery("^INSERT INTO trx_kiosk_nonce").
+					WithArgs("abc", int64(1), int64(3), int64(2), expiresAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("abc"))
+			},
+		},
+		{
+			name: "Nonce already used",
+			patch: func() {
+				mockDB.ExpectExec("^DELETE FROM trx_kiosk_nonce").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mockDB.ExpectQuery("^INSERT INTO trx_kiosk_nonce").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+		},
+		{
+			name:    "Failed because insert method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectExec("^DELETE FROM trx_kiosk_nonce").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mockDB.ExpectQuery("^INSERT INTO trx_kiosk_nonce").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			nonce := &model.TrxKioskNonce{ID: "abc", IDMstKiosk: 3, IDMstUser: 2, ExpiresAt: expiresAt}
+			got, err := c.ClaimNonce(tenant.NewContext(context.Background(), 1), nonce)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ClaimNonce() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
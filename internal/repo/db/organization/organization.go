@@ -0,0 +1,272 @@
+package organization
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/internal/repo/db/attendance"
+	"github.com/faisalhardin/employee-payroll-system/internal/repo/db/audit"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/go-xorm/xorm"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+const (
+	MstDepartmentTable     = "mst_department"
+	MstCostCentreTable     = "mst_cost_centre"
+	TrxUserAssignmentTable = "trx_user_assignment"
+
+	dateFormat = "2006-01-02"
+)
+
+// errNotAssigned rolls an assignment back when a later assignment exists
+var errNotAssigned = errors.New("organization not assigned")
+
+type Conn struct {
+	DB *xormlib.DBConnect
+}
+
+func New(conn *Conn) *Conn {
+	return conn
+}
+
+func (c *Conn) CreateDepartment(ctx context.Context, department *model.MstDepartment) (err error) {
+	ctx, finish := c.DB.Operation(ctx, "organization.Conn.CreateDepartment")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.CreateDepartment")
+	}
+	department.IDMstTenant = tenantID
+
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		_, err := session.Table(MstDepartmentTable).InsertOne(department)
+		if err != nil {
+			return nil, err
+		}
+		return []audit.Entry{{
+			Action:   constant.AuditActionCreate,
+			Entity:   MstDepartmentTable,
+			EntityID: department.ID,
+			After:    department,
+		}}, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "conn.CreateDepartment")
+	}
+	return nil
+}
+
+// ListDepartments lists the departments with the given ids, all of them when
+// ids is empty
+func (c *Conn) ListDepartments(ctx context.Context, ids []int64) (res []model.MstDepartment, err error) {
+	ctx, finish := c.DB.Operation(ctx, "organization.Conn.ListDepartments")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListDepartments")
+	}
+
+	session := c.DB.Reader(ctx).Table(MstDepartmentTable).Where("id_mst_tenant = ?", tenantID)
+	if len(ids) > 0 {
+		session.Where("id = ANY(?)", pq.Array(ids))
+	}
+	err = session.OrderBy("code").Find(&res)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListDepartments")
+	}
+	return res, nil
+}
+
+func (c *Conn) CreateCostCentre(ctx context.Context, costCentre *model.MstCostCentre) (err error) {
+	ctx, finish := c.DB.Operation(ctx, "organization.Conn.CreateCostCentre")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.CreateCostCentre")
+	}
+	costCentre.IDMstTenant = tenantID
+
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		_, err := session.Table(MstCostCentreTable).InsertOne(costCentre)
+		if err != nil {
+			return nil, err
+		}
+		return []audit.Entry{{
+			Action:   constant.AuditActionCreate,
+			Entity:   MstCostCentreTable,
+			EntityID: costCentre.ID,
+			After:    costCentre,
+		}}, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "conn.CreateCostCentre")
+	}
+	return nil
+}
+
+// ListCostCentres lists the cost centres with the given ids, all of them
+// when ids is empty
+func (c *Conn) ListCostCentres(ctx context.Context, ids []int64) (res []model.MstCostCentre, err error) {
+	ctx, finish := c.DB.Operation(ctx, "organization.Conn.ListCostCentres")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListCostCentres")
+	}
+
+	session := c.DB.Reader(ctx).Table(MstCostCentreTable).Where("id_mst_tenant = ?", tenantID)
+	if len(ids) > 0 {
+		session.Where("id = ANY(?)", pq.Array(ids))
+	}
+	err = session.OrderBy("code").Find(&res)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListCostCentres")
+	}
+	return res, nil
+}
+
+// AssignOrganization moves the employee to the department and cost centre
+// from the start date of the assignment, ending the one they are in the day
+// before. assigned is false and nothing is stored when one of their
+// assignments starts on or after it.
+func (c *Conn) AssignOrganization(ctx context.Context, assignment *model.TrxUserAssignment) (assigned bool, err error) {
+	ctx, finish := c.DB.Operation(ctx, "organization.Conn.AssignOrganization")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "conn.AssignOrganization")
+	}
+	assignment.IDMstTenant = tenantID
+
+	startDate := assignment.StartDate.Format(dateFormat)
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		var current []model.TrxUserAssignment
+		err := session.Table(TrxUserAssignmentTable).
+			Where("id_mst_user = ? AND id_mst_tenant = ?", assignment.IDMstUser, tenantID).
+			Where("(end_date IS NULL OR end_date >= ?)", startDate).
+			ForUpdate().
+			Find(&current)
+		if err != nil {
+			return nil, err
+		}
+
+		entries := []audit.Entry{}
+		for _, previous := range current {
+			if previous.StartDate.Format(dateFormat) >= startDate {
+				return nil, errNotAssigned
+			}
+
+			ended := previous
+			ended.EndDate = sql.NullTime{Time: assignment.StartDate.AddDate(0, 0, -1), Valid: true}
+			ended.UpdatedBy = assignment.CreatedBy
+			_, err = session.Table(TrxUserAssignmentTable).
+				Where("id = ?", previous.ID).
+				Cols("end_date", "updated_by").
+				Update(&ended)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, audit.Entry{
+				Action:   constant.AuditActionUpdate,
+				Entity:   TrxUserAssignmentTable,
+				EntityID: previous.ID,
+				Before:   previous,
+				After:    ended,
+			})
+		}
+
+		_, err = session.Table(TrxUserAssignmentTable).InsertOne(assignment)
+		if err != nil {
+			return nil, err
+		}
+		return append(entries, audit.Entry{
+			Action:   constant.AuditActionCreate,
+			Entity:   TrxUserAssignmentTable,
+			EntityID: assignment.ID,
+			After:    assignment,
+		}), nil
+	})
+	if errors.Is(err, errNotAssigned) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "conn.AssignOrganization")
+	}
+	return true, nil
+}
+
+// ListAssignments lists the assignments of the employee, oldest first
+func (c *Conn) ListAssignments(ctx context.Context, userID int64) (res []model.TrxUserAssignment, err error) {
+	ctx, finish := c.DB.Operation(ctx, "organization.Conn.ListAssignments")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListAssignments")
+	}
+
+	err = c.DB.Reader(ctx).Table(TrxUserAssignmentTable).
+		Where("id_mst_tenant = ? AND id_mst_user = ?", tenantID, userID).
+		OrderBy("start_date").
+		Find(&res)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListAssignments")
+	}
+	return res, nil
+}
+
+// ListLabourCost sums the payslips of every employee by department or cost
+// centre over the processed periods lying between the dates. A payslip
+// counts towards the assignment the employee had on the last day of its
+// period.
+func (c *Conn) ListLabourCost(ctx context.Context, params model.LabourCostParams) (res []model.LabourCostRow, err error) {
+	ctx, finish := c.DB.Operation(ctx, "organization.Conn.ListLabourCost")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListLabourCost")
+	}
+
+	var groupTable, groupColumn string
+	switch params.GroupBy {
+	case constant.LabourCostGroupByDepartment:
+		groupTable, groupColumn = MstDepartmentTable, "id_mst_department"
+	case constant.LabourCostGroupByCostCentre:
+		groupTable, groupColumn = MstCostCentreTable, "id_mst_cost_centre"
+	default:
+		return nil, errors.Wrap(fmt.Errorf("unknown group %q", params.GroupBy), "conn.ListLabourCost")
+	}
+
+	err = c.DB.Reader(ctx).SQL(`SELECT COALESCE(g.id, 0) AS group_id, COALESCE(g.code, '') AS group_code,
+			COALESCE(g.name, '') AS group_name, p.id_mst_user,
+			SUM(p.prorated_salary) AS salary, SUM(p.overtime_hours) AS overtime_hours,
+			SUM(p.overtime_pay) AS overtime_pay, SUM(p.total_reimbursements) AS total_reimbursements,
+			SUM(p.total_take_home) AS total_cost
+		FROM `+attendance.TrxUserPayslipTable+` p
+		JOIN `+attendance.MstPayrollPeriodTable+` pp ON pp.id = p.id_mst_payroll_period AND pp.id_mst_tenant = p.id_mst_tenant
+		LEFT JOIN `+TrxUserAssignmentTable+` a ON a.id_mst_user = p.id_mst_user AND a.id_mst_tenant = p.id_mst_tenant
+			AND a.start_date <= pp.end_date AND (a.end_date IS NULL OR a.end_date >= pp.end_date)
+		LEFT JOIN `+groupTable+` g ON g.id = a.`+groupColumn+`
+		WHERE p.id_mst_tenant = ? AND pp.payroll_processed_date IS NOT NULL
+			AND pp.start_date >= ? AND pp.end_date <= ?
+		GROUP BY g.id, g.code, g.name, p.id_mst_user
+		ORDER BY g.code, p.id_mst_user`,
+		tenantID, params.StartDate.Format(dateFormat), params.EndDate.Format(dateFormat)).
+		Find(&res)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListLabourCost")
+	}
+	return res, nil
+}
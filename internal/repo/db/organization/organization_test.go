@@ -0,0 +1,376 @@
+package organization
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateDepartment(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantID  int64
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name:   "Successful",
+			ctx:    tenant.NewContext(context.Background(), 1),
+			wantID: 3,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^INSERT INTO \"mst_department\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WithArgs(int64(1), nil, "", "create", MstDepartmentTable, int64(3), nil, sqlmock.AnyArg(), "", "", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:    "Failed because no tenant in context",
+			ctx:     context.Background(),
+			wantErr: true,
+			patch:   func() {},
+		},
+		{
+			name:    "Failed because insert method",
+			ctx:     tenant.NewContext(context.Background(), 1),
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^INSERT INTO \"mst_department\"").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			department := &model.MstDepartment{Code: "ENG", Name: "Engineering"}
+			err := c.CreateDepartment(tt.ctx, department)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.CreateDepartment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantID, department.ID)
+		})
+	}
+}
+
+func Test_ListCostCentres(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		ids     []int64
+		want    []model.MstCostCentre
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			want: []model.MstCostCentre{{ID: 4, Code: "CC-100"}},
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_cost_centre\" WHERE \\(id_mst_tenant = \\$1\\) ORDER BY code").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code"}).AddRow(4, "CC-100"))
+			},
+		},
+		{
+			name: "Successful by ids",
+			ids:  []int64{4},
+			want: []model.MstCostCentre{{ID: 4, Code: "CC-100"}},
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_cost_centre\" WHERE \\(id_mst_tenant = \\$1\\) AND \\(id = ANY\\(\\$2\\)\\) ORDER BY code").
+					WithArgs(1, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code"}).AddRow(4, "CC-100"))
+			},
+		},
+		{
+			name:    "Failed because find method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_cost_centre\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.ListCostCentres(tenant.NewContext(context.Background(), 1), tt.ids)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ListCostCentres() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_AssignOrganization(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	startDate := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		wantAssigned bool
+		wantErr      bool
+		patch        func()
+	}{
+		{
+			name:         "Successful ending the current assignment",
+			wantAssigned: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_user_assignment\" WHERE \\(id_mst_user = \\$1 AND id_mst_tenant = \\$2\\) AND \\(\\(end_date IS NULL OR end_date >= \\$3\\)\\) FOR UPDATE").
+					WithArgs(2, 1, "2025-08-01").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "id_mst_user", "id_mst_department", "id_mst_cost_centre", "start_date"}).
+						AddRow(5, 2, 1, 1, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+				mockDB.ExpectExec("^UPDATE \"trx_user_assignment\" SET \"end_date\" = \\$1, \"updated_by\" = \\$2").
+					WithArgs(time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC), 1, sqlmock.AnyArg(), 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_user_assignment\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(6))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WithArgs(int64(1), nil, "", "update", TrxUserAssignmentTable, int64(5), sqlmock.AnyArg(), sqlmock.AnyArg(), "", "", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WithArgs(int64(1), nil, "", "create", TrxUserAssignmentTable, int64(6), nil, sqlmock.AnyArg(), "", "", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "Later assignment is not replaced",
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_user_assignment\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "id_mst_user", "start_date"}).
+						AddRow(5, 2, time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)))
+				mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:    "Failed because insert method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_user_assignment\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_user_assignment\"").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			assignment := &model.TrxUserAssignment{
+				IDMstUser:       2,
+				IDMstDepartment: 3,
+				IDMstCostCentre: 4,
+				StartDate:       startDate,
+				CreatedBy:       sql.NullInt64{Int64: 1, Valid: true},
+			}
+			got, err := c.AssignOrganization(tenant.NewContext(context.Background(), 1), assignment)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.AssignOrganization() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantAssigned, got)
+		})
+	}
+}
+
+func Test_ListAssignments(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantLen int
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name:    "Successful",
+			wantLen: 2,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_user_assignment\" WHERE \\(id_mst_tenant = \\$1 AND id_mst_user = \\$2\\) ORDER BY start_date").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "id_mst_user"}).AddRow(5, 2).AddRow(6, 2))
+			},
+		},
+		{
+			name:    "Failed because find method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_user_assignment\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.ListAssignments(tenant.NewContext(context.Background(), 1), 2)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ListAssignments() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Len(t, got, tt.wantLen)
+		})
+	}
+}
+
+func Test_ListLabourCost(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	columns := []string{"group_id", "group_code", "group_name", "id_mst_user", "salary", "overtime_hours", "overtime_pay", "total_reimbursements", "total_cost"}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		groupBy string
+		want    []model.LabourCostRow
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name:    "Successful by department",
+			ctx:     tenant.NewContext(context.Background(), 1),
+			groupBy: constant.LabourCostGroupByDepartment,
+			want: []model.LabourCostRow{
+				{GroupID: 3, GroupCode: "ENG", GroupName: "Engineering", IDMstUser: 2, Salary: 100, OvertimeHours: 2, OvertimePay: 10, TotalReimbursements: 5, TotalCost: 115},
+				{IDMstUser: 9, Salary: 80, TotalCost: 80},
+			},
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM trx_user_payslip p\\s+JOIN mst_payroll_period pp .* LEFT JOIN trx_user_assignment a .* LEFT JOIN mst_department g ON g.id = a.id_mst_department\\s+WHERE p.id_mst_tenant = \\$1 .* GROUP BY g.id, g.code, g.name, p.id_mst_user").
+					WithArgs(1, "2025-07-01", "2025-09-30").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(3, "ENG", "Engineering", 2, 100, 2, 10, 5, 115).
+						AddRow(0, "", "", 9, 80, 0, 0, 0, 80))
+			},
+		},
+		{
+			name:    "Successful by cost centre",
+			ctx:     tenant.NewContext(context.Background(), 1),
+			groupBy: constant.LabourCostGroupByCostCentre,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* LEFT JOIN mst_cost_centre g ON g.id = a.id_mst_cost_centre").
+					WithArgs(1, "2025-07-01", "2025-09-30").
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			name:    "Failed because unknown group",
+			ctx:     tenant.NewContext(context.Background(), 1),
+			groupBy: "team",
+			wantErr: true,
+			patch:   func() {},
+		},
+		{
+			name:    "Failed because no tenant in context",
+			ctx:     context.Background(),
+			groupBy: constant.LabourCostGroupByDepartment,
+			wantErr: true,
+			patch:   func() {},
+		},
+		{
+			name:    "Failed because query method",
+			ctx:     tenant.NewContext(context.Background(), 1),
+			groupBy: constant.LabourCostGroupByDepartment,
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM trx_user_payslip").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.ListLabourCost(tt.ctx, model.LabourCostParams{
+				GroupBy:   tt.groupBy,
+				StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC),
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ListLabourCost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
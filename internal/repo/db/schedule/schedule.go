@@ -0,0 +1,357 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/internal/repo/db/audit"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/go-xorm/xorm"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+const (
+	MstShiftTable          = "mst_shift"
+	MstWorkPatternTable    = "mst_work_pattern"
+	DtlWorkPatternDayTable = "dtl_work_pattern_day"
+	TrxUserScheduleTable   = "trx_user_schedule"
+	TrxUserRosterTable     = "trx_user_roster"
+
+	dateFormat = "2006-01-02"
+)
+
+// errNotAssigned rolls an assignment back when a later schedule exists
+var errNotAssigned = errors.New("schedule not assigned")
+
+type Conn struct {
+	DB *xormlib.DBConnect
+}
+
+func New(conn *Conn) *Conn {
+	return conn
+}
+
+func (c *Conn) CreateShift(ctx context.Context, shift *model.MstShift) (err error) {
+	ctx, finish := c.DB.Operation(ctx, "schedule.Conn.CreateShift")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.CreateShift")
+	}
+	shift.IDMstTenant = tenantID
+
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		_, err := session.Table(MstShiftTable).InsertOne(shift)
+		if err != nil {
+			return nil, err
+		}
+		return []audit.Entry{{
+			Action:   constant.AuditActionCreate,
+			Entity:   MstShiftTable,
+			EntityID: shift.ID,
+			After:    shift,
+		}}, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "conn.CreateShift")
+	}
+	return nil
+}
+
+func (c *Conn) ListShifts(ctx context.Context) (res []model.MstShift, err error) {
+	ctx, finish := c.DB.Operation(ctx, "schedule.Conn.ListShifts")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListShifts")
+	}
+
+	err = c.DB.Reader(ctx).Table(MstShiftTable).Where("id_mst_tenant = ?", tenantID).OrderBy("id").Find(&res)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListShifts")
+	}
+	return res, nil
+}
+
+// CreateWorkPattern inserts the pattern with its working days
+func (c *Conn) CreateWorkPattern(ctx context.Context, pattern *model.MstWorkPattern, days []model.DtlWorkPatternDay) (err error) {
+	ctx, finish := c.DB.Operation(ctx, "schedule.Conn.CreateWorkPattern")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.CreateWorkPattern")
+	}
+	pattern.IDMstTenant = tenantID
+
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		_, err := session.Table(MstWorkPatternTable).InsertOne(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range days {
+			days[i].IDMstWorkPattern = pattern.ID
+		}
+		if len(days) > 0 {
+			_, err = session.Table(DtlWorkPatternDayTable).Insert(&days)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return []audit.Entry{{
+			Action:   constant.AuditActionCreate,
+			Entity:   MstWorkPatternTable,
+			EntityID: pattern.ID,
+			After:    workPatternState(*pattern, days),
+		}}, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "conn.CreateWorkPattern")
+	}
+	return nil
+}
+
+// ListWorkPatterns lists the patterns with the given ids, all of them when
+// ids is empty
+func (c *Conn) ListWorkPatterns(ctx context.Context, ids []int64) (res []model.MstWorkPattern, err error) {
+	ctx, finish := c.DB.Operation(ctx, "schedule.Conn.ListWorkPatterns")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListWorkPatterns")
+	}
+
+	session := c.DB.Reader(ctx).Table(MstWorkPatternTable).Where("id_mst_tenant = ?", tenantID)
+	if len(ids) > 0 {
+		session.Where("id = ANY(?)", pq.Array(ids))
+	}
+	err = session.OrderBy("id").Find(&res)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListWorkPatterns")
+	}
+	return res, nil
+}
+
+func (c *Conn) ListWorkPatternDays(ctx context.Context, patternIDs []int64) (res []model.DtlWorkPatternDay, err error) {
+	if len(patternIDs) == 0 {
+		return nil, nil
+	}
+
+	ctx, finish := c.DB.Operation(ctx, "schedule.Conn.ListWorkPatternDays")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListWorkPatternDays")
+	}
+
+	// the days carry no tenant, their pattern does
+	err = c.DB.Reader(ctx).Table(DtlWorkPatternDayTable).
+		Where("id_mst_work_pattern = ANY(?)", pq.Array(patternIDs)).
+		Where("id_mst_work_pattern IN (SELECT id FROM "+MstWorkPatternTable+" WHERE id_mst_tenant = ?)", tenantID).
+		OrderBy("id_mst_work_pattern, day_number").
+		Find(&res)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListWorkPatternDays")
+	}
+	return res, nil
+}
+
+// AssignSchedule moves the employee to the schedule from its start date,
+// ending the schedule they follow the day before. assigned is false and
+// nothing is stored when one of their schedules starts on or after it.
+func (c *Conn) AssignSchedule(ctx context.Context, schedule *model.TrxUserSchedule) (assigned bool, err error) {
+	ctx, finish := c.DB.Operation(ctx, "schedule.Conn.AssignSchedule")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "conn.AssignSchedule")
+	}
+	schedule.IDMstTenant = tenantID
+
+	startDate := schedule.StartDate.Format(dateFormat)
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		var current []model.TrxUserSchedule
+		err := session.Table(TrxUserScheduleTable).
+			Where("id_mst_user = ? AND id_mst_tenant = ?", schedule.IDMstUser, tenantID).
+			Where("(end_date IS NULL OR end_date >= ?)", startDate).
+			ForUpdate().
+			Find(&current)
+		if err != nil {
+			return nil, err
+		}
+
+		entries := []audit.Entry{}
+		for _, previous := range current {
+			if previous.StartDate.Format(dateFormat) >= startDate {
+				return nil, errNotAssigned
+			}
+
+			ended := previous
+			ended.EndDate = sql.NullTime{Time: schedule.StartDate.AddDate(0, 0, -1), Valid: true}
+			ended.UpdatedBy = schedule.CreatedBy
+			_, err = session.Table(TrxUserScheduleTable).
+				Where("id = ?", previous.ID).
+				Cols("end_date", "updated_by").
+				Update(&ended)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, audit.Entry{
+				Action:   constant.AuditActionUpdate,
+				Entity:   TrxUserScheduleTable,
+				EntityID: previous.ID,
+				Before:   previous,
+				After:    ended,
+			})
+		}
+
+		_, err = session.Table(TrxUserScheduleTable).InsertOne(schedule)
+		if err != nil {
+			return nil, err
+		}
+		return append(entries, audit.Entry{
+			Action:   constant.AuditActionCreate,
+			Entity:   TrxUserScheduleTable,
+			EntityID: schedule.ID,
+			After:    schedule,
+		}), nil
+	})
+	if errors.Is(err, errNotAssigned) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "conn.AssignSchedule")
+	}
+	return true, nil
+}
+
+// ListSchedules lists the schedules overlapping the dates, by employee and
+// start date
+func (c *Conn) ListSchedules(ctx context.Context, params model.ListScheduleParams) (res []model.TrxUserSchedule, err error) {
+	ctx, finish := c.DB.Operation(ctx, "schedule.Conn.ListSchedules")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListSchedules")
+	}
+
+	session := c.DB.Reader(ctx).Table(TrxUserScheduleTable).Where("id_mst_tenant = ?", tenantID)
+	if len(params.IDsMstUser) > 0 {
+		session.Where("id_mst_user = ANY(?)", pq.Array(params.IDsMstUser))
+	}
+	if !params.EndDate.IsZero() {
+		session.Where("start_date <= ?", params.EndDate.Format(dateFormat))
+	}
+	if !params.StartDate.IsZero() {
+		session.Where("(end_date IS NULL OR end_date >= ?)", params.StartDate.Format(dateFormat))
+	}
+	err = session.OrderBy("id_mst_user, start_date").Find(&res)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListSchedules")
+	}
+	return res, nil
+}
+
+// SetRoster sets the shift of each roster day, replacing the one already set
+// for the employee on that day
+func (c *Conn) SetRoster(ctx context.Context, rosters []model.TrxUserRoster) (err error) {
+	if len(rosters) == 0 {
+		return nil
+	}
+
+	ctx, finish := c.DB.Operation(ctx, "schedule.Conn.SetRoster")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.SetRoster")
+	}
+
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		entries := []audit.Entry{}
+		for _, roster := range rosters {
+			_, err := session.Exec(`INSERT INTO `+TrxUserRosterTable+`
+				(id_mst_tenant, id_mst_user, roster_date, id_mst_shift, created_by, updated_by)
+				VALUES (?, ?, ?, ?, ?, ?)
+				ON CONFLICT (id_mst_user, roster_date)
+				DO UPDATE SET id_mst_shift = EXCLUDED.id_mst_shift, updated_by = EXCLUDED.updated_by, updated_at = now()
+				WHERE `+TrxUserRosterTable+`.id_mst_tenant = EXCLUDED.id_mst_tenant`,
+				tenantID, roster.IDMstUser, roster.RosterDate.Format(dateFormat), roster.IDMstShift, roster.CreatedBy, roster.CreatedBy)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, audit.Entry{
+				Action: constant.AuditActionSetRoster,
+				Entity: TrxUserRosterTable,
+				After: map[string]interface{}{
+					"id_mst_user":  roster.IDMstUser,
+					"roster_date":  roster.RosterDate.Format(dateFormat),
+					"id_mst_shift": nullableID(roster.IDMstShift),
+				},
+			})
+		}
+		return entries, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "conn.SetRoster")
+	}
+	return nil
+}
+
+func (c *Conn) ListRosters(ctx context.Context, params model.ListScheduleParams) (res []model.TrxUserRoster, err error) {
+	ctx, finish := c.DB.Operation(ctx, "schedule.Conn.ListRosters")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListRosters")
+	}
+
+	session := c.DB.Reader(ctx).Table(TrxUserRosterTable).Where("id_mst_tenant = ?", tenantID)
+	if len(params.IDsMstUser) > 0 {
+		session.Where("id_mst_user = ANY(?)", pq.Array(params.IDsMstUser))
+	}
+	if !params.StartDate.IsZero() {
+		session.Where("roster_date >= ?", params.StartDate.Format(dateFormat))
+	}
+	if !params.EndDate.IsZero() {
+		session.Where("roster_date <= ?", params.EndDate.Format(dateFormat))
+	}
+	err = session.OrderBy("id_mst_user, roster_date").Find(&res)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListRosters")
+	}
+	return res, nil
+}
+
+func workPatternState(pattern model.MstWorkPattern, days []model.DtlWorkPatternDay) map[string]interface{} {
+	shifts := map[int]int64{}
+	for _, day := range days {
+		shifts[day.DayNumber] = day.IDMstShift
+	}
+	return map[string]interface{}{
+		"name":       pattern.Name,
+		"cycle_days": pattern.CycleDays,
+		"shifts":     shifts,
+	}
+}
+
+// nullableID records a missing id as null rather than as the NullInt64 struct
+func nullableID(id sql.NullInt64) interface{} {
+	if !id.Valid {
+		return nil
+	}
+	return id.Int64
+}
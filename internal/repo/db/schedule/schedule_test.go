@@ -0,0 +1,468 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateShift(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantID  int64
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name:   "Successful",
+			wantID: 2,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^INSERT INTO \"mst_shift\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WithArgs(int64(1), nil, "", "create", MstShiftTable, int64(2), nil, sqlmock.AnyArg(), "", "", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:    "Failed because insert method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^INSERT INTO \"mst_shift\"").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			shift := &model.MstShift{Name: "Night", StartTime: "22:00", EndTime: "06:00", WorkingHours: 8}
+			err := c.CreateShift(tenant.NewContext(context.Background(), 1), shift)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.CreateShift() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantID, shift.ID)
+		})
+	}
+}
+
+func Test_CreateWorkPattern(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^INSERT INTO \"mst_work_pattern\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				mockDB.ExpectExec("^INSERT INTO \"dtl_work_pattern_day\"").
+					WithArgs(4, 0, 2, 4, 1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WithArgs(int64(1), nil, "", "create", MstWorkPatternTable, int64(4), nil,
+						`{"cycle_days":4,"name":"Two on two off","shifts":{"0":2,"1":2}}`, "", "", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:    "Failed because insert days method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^INSERT INTO \"mst_work_pattern\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				mockDB.ExpectExec("^INSERT INTO \"dtl_work_pattern_day\"").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			pattern := &model.MstWorkPattern{Name: "Two on two off", CycleDays: 4}
+			days := []model.DtlWorkPatternDay{{DayNumber: 0, IDMstShift: 2}, {DayNumber: 1, IDMstShift: 2}}
+			err := c.CreateWorkPattern(tenant.NewContext(context.Background(), 1), pattern, days)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.CreateWorkPattern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ListWorkPatternDays(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name       string
+		patternIDs []int64
+		want       []model.DtlWorkPatternDay
+		wantErr    bool
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			patternIDs: []int64{4},
+			want:       []model.DtlWorkPatternDay{{ID: 1, IDMstWorkPattern: 4, DayNumber: 0, IDMstShift: 2}},
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"dtl_work_pattern_day\" WHERE \\(id_mst_work_pattern = ANY\\(\\$1\\)\\) AND \\(id_mst_work_pattern IN \\(SELECT id FROM mst_work_pattern WHERE id_mst_tenant = \\$2\\)\\) ORDER BY id_mst_work_pattern, day_number").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "id_mst_work_pattern", "day_number", "id_mst_shift"}).
+						AddRow(1, 4, 0, 2))
+			},
+		},
+		{
+			name:  "No pattern is not queried",
+			patch: func() {},
+		},
+		{
+			name:       "Failed because find method",
+			patternIDs: []int64{4},
+			wantErr:    true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"dtl_work_pattern_day\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.ListWorkPatternDays(tenant.NewContext(context.Background(), 1), tt.patternIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ListWorkPatternDays() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_AssignSchedule(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	startDate := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		wantAssigned bool
+		wantErr      bool
+		patch        func()
+	}{
+		{
+			name:         "Successful ending the current schedule",
+			wantAssigned: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_user_schedule\" WHERE \\(id_mst_user = \\$1 AND id_mst_tenant = \\$2\\) AND \\(\\(end_date IS NULL OR end_date >= \\$3\\)\\) FOR UPDATE").
+					WithArgs(2, 1, "2025-08-01").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "id_mst_user", "id_mst_work_pattern", "start_date"}).
+						AddRow(5, 2, 1, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+				mockDB.ExpectExec("^UPDATE \"trx_user_schedule\" SET \"end_date\" = \\$1, \"updated_by\" = \\$2").
+					WithArgs(time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC), 1, sqlmock.AnyArg(), 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_user_schedule\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(6))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WithArgs(int64(1), nil, "", "update", TrxUserScheduleTable, int64(5), sqlmock.AnyArg(), sqlmock.AnyArg(), "", "", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WithArgs(int64(1), nil, "", "create", TrxUserScheduleTable, int64(6), nil, sqlmock.AnyArg(), "", "", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "Later schedule is not replaced",
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_user_schedule\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "id_mst_user", "start_date"}).
+						AddRow(5, 2, time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)))
+				mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:    "Failed because insert method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_user_schedule\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_user_schedule\"").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			schedule := &model.TrxUserSchedule{
+				IDMstUser:        2,
+				IDMstWorkPattern: 4,
+				StartDate:        startDate,
+				CreatedBy:        sql.NullInt64{Int64: 1, Valid: true},
+			}
+			got, err := c.AssignSchedule(tenant.NewContext(context.Background(), 1), schedule)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.AssignSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantAssigned, got)
+		})
+	}
+}
+
+func Test_ListSchedules(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantLen int
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name:    "Successful",
+			wantLen: 1,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_user_schedule\" WHERE \\(id_mst_tenant = \\$1\\) AND \\(id_mst_user = ANY\\(\\$2\\)\\) AND \\(start_date <= \\$3\\) AND \\(\\(end_date IS NULL OR end_date >= \\$4\\)\\) ORDER BY id_mst_user, start_date").
+					WithArgs(1, sqlmock.AnyArg(), "2025-07-31", "2025-07-01").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "id_mst_user"}).AddRow(5, 2))
+			},
+		},
+		{
+			name:    "Failed because find method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_user_schedule\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.ListSchedules(tenant.NewContext(context.Background(), 1), model.ListScheduleParams{
+				IDsMstUser: []int64{2},
+				StartDate:  time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:    time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC),
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ListSchedules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Len(t, got, tt.wantLen)
+		})
+	}
+}
+
+func Test_SetRoster(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	rosters := []model.TrxUserRoster{
+		{
+			IDMstUser:  2,
+			RosterDate: time.Date(2025, 7, 26, 0, 0, 0, 0, time.UTC),
+			IDMstShift: sql.NullInt64{Int64: 3, Valid: true},
+			CreatedBy:  sql.NullInt64{Int64: 1, Valid: true},
+		},
+		{
+			IDMstUser:  2,
+			RosterDate: time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC),
+			CreatedBy:  sql.NullInt64{Int64: 1, Valid: true},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec("^INSERT INTO trx_user_roster.*ON CONFLICT \\(id_mst_user, roster_date\\)").
+					WithArgs(1, 2, "2025-07-26", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectExec("^INSERT INTO trx_user_roster").
+					WithArgs(1, 2, "2025-07-28", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WithArgs(int64(1), nil, "", "set_roster", TrxUserRosterTable, nil, nil,
+						`{"id_mst_shift":3,"id_mst_user":2,"roster_date":"2025-07-26"}`, "", "", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WithArgs(int64(1), nil, "", "set_roster", TrxUserRosterTable, nil, nil,
+						`{"id_mst_shift":null,"id_mst_user":2,"roster_date":"2025-07-28"}`, "", "", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:    "Failed because exec method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec("^INSERT INTO trx_user_roster").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			err := c.SetRoster(tenant.NewContext(context.Background(), 1), rosters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.SetRoster() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ListRosters(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantLen int
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name:    "Successful",
+			wantLen: 2,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_user_roster\" WHERE \\(id_mst_tenant = \\$1\\) AND \\(roster_date >= \\$2\\) AND \\(roster_date <= \\$3\\) ORDER BY id_mst_user, roster_date").
+					WithArgs(1, "2025-07-01", "2025-07-31").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "id_mst_user", "id_mst_shift"}).
+						AddRow(1, 2, 3).
+						AddRow(2, 2, nil))
+			},
+		},
+		{
+			name:    "Failed because find method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_user_roster\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.ListRosters(tenant.NewContext(context.Background(), 1), model.ListScheduleParams{
+				StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC),
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ListRosters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Len(t, got, tt.wantLen)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/internal/repo/db/audit"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/go-xorm/xorm"
+	"github.com/pkg/errors"
+)
+
+const (
+	MstTenantTable = "mst_tenant"
+)
+
+type Conn struct {
+	DB *xormlib.DBConnect
+}
+
+func New(conn *Conn) *Conn {
+	return conn
+}
+
+// ListTenants lists every tenant of the group. It is meant for the system
+// jobs that run once per tenant, never for a request.
+func (c *Conn) ListTenants(ctx context.Context) (res []model.MstTenant, err error) {
+	ctx, finish := c.DB.Operation(ctx, "tenant.Conn.ListTenants")
+	defer finish(&err)
+
+	err = c.DB.Reader(ctx).Table(MstTenantTable).OrderBy("id").Find(&res)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.ListTenants")
+	}
+	return res, nil
+}
+
+// GetTenant returns the tenant of ctx
+func (c *Conn) GetTenant(ctx context.Context) (res model.MstTenant, err error) {
+	ctx, finish := c.DB.Operation(ctx, "tenant.Conn.GetTenant")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.GetTenant")
+	}
+
+	_, err = c.DB.Reader(ctx).Table(MstTenantTable).Where("id = ?", tenantID).Get(&res)
+	if err != nil {
+		return res, errors.Wrap(err, "conn.GetTenant")
+	}
+	return res, nil
+}
+
+// UpdatePayrollPolicy sets the payroll policy of the tenant of ctx. The
+// tenant is returned as it was before, empty when it does not exist.
+func (c *Conn) UpdatePayrollPolicy(ctx context.Context, policy model.PayrollPolicyRequest, updatedBy int64) (before model.MstTenant, err error) {
+	ctx, finish := c.DB.Operation(ctx, "tenant.Conn.UpdatePayrollPolicy")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return before, errors.Wrap(err, "conn.UpdatePayrollPolicy")
+	}
+
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		found, err := session.Table(MstTenantTable).Where("id = ?", tenantID).ForUpdate().Get(&before)
+		if err != nil || !found {
+			return nil, err
+		}
+
+		_, err = session.Exec(`UPDATE `+MstTenantTable+`
+			SET overtime_multiplier = ?, max_overtime_hours = ?, updated_by = ?, updated_at = now()
+			WHERE id = ?`, policy.OvertimeMultiplier, policy.MaxOvertimeHours, updatedBy, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		return []audit.Entry{{
+			Action:   constant.AuditActionSetPayrollPolicy,
+			Entity:   MstTenantTable,
+			EntityID: tenantID,
+			Before:   payrollPolicyState(before.OvertimeMultiplier, before.MaxOvertimeHours),
+			After:    payrollPolicyState(policy.OvertimeMultiplier, policy.MaxOvertimeHours),
+		}}, nil
+	})
+	if err != nil {
+		return before, errors.Wrap(err, "conn.UpdatePayrollPolicy")
+	}
+	return before, nil
+}
+
+func payrollPolicyState(overtimeMultiplier, maxOvertimeHours int) map[string]interface{} {
+	return map[string]interface{}{
+		"overtime_multiplier": overtimeMultiplier,
+		"max_overtime_hours":  maxOvertimeHours,
+	}
+}
@@ -0,0 +1,204 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ListTenants(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		want    []model.MstTenant
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful without a tenant",
+			want: []model.MstTenant{{ID: 1, Code: "default"}, {ID: 2, Code: "retail"}},
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_tenant\" ORDER BY id").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code"}).
+						AddRow(1, "default").
+						AddRow(2, "retail"))
+			},
+		},
+		{
+			name:    "Failed because query method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_tenant\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.ListTenants(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ListTenants() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_GetTenant(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    model.MstTenant
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			ctx:  tenant.NewContext(context.Background(), 2),
+			want: model.MstTenant{ID: 2, Code: "retail", OvertimeMultiplier: 3, MaxOvertimeHours: 4},
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_tenant\" WHERE \\(id = \\$1\\) LIMIT 1").
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code", "overtime_multiplier", "max_overtime_hours"}).
+						AddRow(2, "retail", 3, 4))
+			},
+		},
+		{
+			name:    "Failed because no tenant in context",
+			ctx:     context.Background(),
+			wantErr: true,
+			patch:   func() {},
+		},
+		{
+			name:    "Failed because query method",
+			ctx:     tenant.NewContext(context.Background(), 2),
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_tenant\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.GetTenant(tt.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.GetTenant() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_UpdatePayrollPolicy(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		want    model.MstTenant
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			want: model.MstTenant{ID: 2, OvertimeMultiplier: 2, MaxOvertimeHours: 3},
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_tenant\" WHERE \\(id = \\$1\\) LIMIT 1 FOR UPDATE").
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "overtime_multiplier", "max_overtime_hours"}).AddRow(2, 2, 3))
+				mockDB.ExpectExec("^UPDATE mst_tenant\\s+SET overtime_multiplier = \\$1, max_overtime_hours = \\$2, updated_by = \\$3").
+					WithArgs(3, 4, 9, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WithArgs(int64(2), nil, "", "set_payroll_policy", MstTenantTable, int64(2),
+						`{"max_overtime_hours":3,"overtime_multiplier":2}`,
+						`{"max_overtime_hours":4,"overtime_multiplier":3}`, "", "", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "Missing tenant is neither updated nor recorded",
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_tenant\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:    "Failed because exec method",
+			want:    model.MstTenant{ID: 2},
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_tenant\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mockDB.ExpectExec("^UPDATE mst_tenant").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.UpdatePayrollPolicy(tenant.NewContext(context.Background(), 2), model.PayrollPolicyRequest{
+				OvertimeMultiplier: 3,
+				MaxOvertimeHours:   4,
+			}, 9)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.UpdatePayrollPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,14 +2,22 @@ package user
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/internal/repo/db/audit"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
 	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/go-xorm/xorm"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 )
 
 const (
-	MstUserTable = "mst_user"
+	MstUserTable         = "mst_user"
+	TrxLoginAttemptTable = "trx_login_attempt"
 
 	WrapMsgGetUser = "conn.GetUser"
 )
@@ -22,11 +30,38 @@ func New(conn *Conn) *Conn {
 	return conn
 }
 
-func (c *Conn) GetUser(ctx context.Context, params model.SignInRequest) (res model.MstUser, err error) {
-	session := c.DB.MasterDB.Table(MstUserTable)
+// GetUserByUsername looks the user up across all tenants, usernames are
+// unique in the whole group. It is only meant for signing in, before the
+// tenant of the user is known.
+func (c *Conn) GetUserByUsername(ctx context.Context, username string) (res model.MstUser, err error) {
+	ctx, finish := c.DB.Operation(ctx, "user.Conn.GetUserByUsername")
+	defer finish(&err)
+
+	session := c.DB.Reader(ctx).Table(MstUserTable)
+	_, err = session.
+		Where("username = ?", username).
+		Get(&res)
+	if err != nil {
+		err = errors.Wrap(err, WrapMsgGetUser)
+		return
+	}
+
+	return
+}
+
+func (c *Conn) GetUserByID(ctx context.Context, userID int64) (res model.MstUser, err error) {
+	ctx, finish := c.DB.Operation(ctx, "user.Conn.GetUserByID")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		err = errors.Wrap(err, WrapMsgGetUser)
+		return
+	}
+
+	session := c.DB.Reader(ctx).Table(MstUserTable)
 	_, err = session.
-		Where("username = ?", params.Username).
-		Where("password_hash = ?", params.Password).
+		Where("id = ? AND id_mst_tenant = ?", userID, tenantID).
 		Get(&res)
 	if err != nil {
 		err = errors.Wrap(err, WrapMsgGetUser)
@@ -36,12 +71,283 @@ func (c *Conn) GetUser(ctx context.Context, params model.SignInRequest) (res mod
 	return
 }
 
+func (c *Conn) GetUserByBadgeID(ctx context.Context, badgeID string) (res model.MstUser, err error) {
+	ctx, finish := c.DB.Operation(ctx, "user.Conn.GetUserByBadgeID")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		err = errors.Wrap(err, WrapMsgGetUser)
+		return
+	}
+
+	session := c.DB.Reader(ctx).Table(MstUserTable)
+	_, err = session.
+		Where("badge_id = ? AND id_mst_tenant = ?", badgeID, tenantID).
+		Get(&res)
+	if err != nil {
+		err = errors.Wrap(err, WrapMsgGetUser)
+		return
+	}
+
+	return
+}
+
+// ListUsersByBadgeIDs returns the users holding any of the badges
+func (c *Conn) ListUsersByBadgeIDs(ctx context.Context, badgeIDs []string) (res []model.MstUser, err error) {
+	ctx, finish := c.DB.Operation(ctx, "user.Conn.ListUsersByBadgeIDs")
+	defer finish(&err)
+
+	if len(badgeIDs) == 0 {
+		return
+	}
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		err = errors.Wrap(err, "conn.ListUsersByBadgeIDs")
+		return
+	}
+
+	session := c.DB.Reader(ctx).Table(MstUserTable)
+	err = session.
+		Where("badge_id = ANY(?) AND id_mst_tenant = ?", pq.Array(badgeIDs), tenantID).
+		Find(&res)
+	if err != nil {
+		err = errors.Wrap(err, "conn.ListUsersByBadgeIDs")
+		return
+	}
+	return
+}
+
 func (c *Conn) ListUser(ctx context.Context) (res []model.MstUser, err error) {
-	session := c.DB.MasterDB.Table(MstUserTable)
-	err = session.Find(&res)
+	ctx, finish := c.DB.Operation(ctx, "user.Conn.ListUser")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		err = errors.Wrap(err, "conn.ListUser")
+		return
+	}
+
+	session := c.DB.Reader(ctx).Table(MstUserTable)
+	err = session.Where("id_mst_tenant = ?", tenantID).Find(&res)
 	if err != nil {
 		err = errors.Wrap(err, "conn.ListUser")
 		return
 	}
 	return
 }
+
+func (c *Conn) RecordLoginAttempt(ctx context.Context, attempt *model.TrxLoginAttempt) (err error) {
+	ctx, finish := c.DB.Operation(ctx, "user.Conn.RecordLoginAttempt")
+	defer finish(&err)
+
+	session := c.DB.MasterDB.Context(ctx).Table(TrxLoginAttemptTable)
+	_, err = session.InsertOne(attempt)
+	if err != nil {
+		return errors.Wrap(err, "conn.RecordLoginAttempt")
+	}
+	return nil
+}
+
+// RegisterFailedLogin counts a failed sign in of the user. The failure that
+// reaches maxAttempts locks the account until lockUntil and starts the count
+// over. The lock, if any, is returned.
+func (c *Conn) RegisterFailedLogin(ctx context.Context, userID int64, maxAttempts int, lockUntil time.Time) (lockedUntil sql.NullTime, err error) {
+	ctx, finish := c.DB.Operation(ctx, "user.Conn.RegisterFailedLogin")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return lockedUntil, errors.Wrap(err, "conn.RegisterFailedLogin")
+	}
+
+	var user model.MstUser
+	_, err = c.DB.MasterDB.Context(ctx).SQL(`UPDATE `+MstUserTable+`
+		SET failed_login_attempts = CASE WHEN failed_login_attempts + 1 >= ? THEN 0 ELSE failed_login_attempts + 1 END,
+			locked_until = CASE WHEN failed_login_attempts + 1 >= ? THEN ? ELSE locked_until END
+		WHERE id = ? AND id_mst_tenant = ?
+		RETURNING failed_login_attempts, locked_until`, maxAttempts, maxAttempts, lockUntil, userID, tenantID).
+		Get(&user)
+	if err != nil {
+		return lockedUntil, errors.Wrap(err, "conn.RegisterFailedLogin")
+	}
+	return user.LockedUntil, nil
+}
+
+// ResetFailedLogins clears the failure count and lock after a successful sign in
+func (c *Conn) ResetFailedLogins(ctx context.Context, userID int64) (err error) {
+	ctx, finish := c.DB.Operation(ctx, "user.Conn.ResetFailedLogins")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.ResetFailedLogins")
+	}
+
+	_, err = c.DB.MasterDB.Context(ctx).Exec(`UPDATE `+MstUserTable+`
+		SET failed_login_attempts = 0, locked_until = NULL
+		WHERE id = ? AND id_mst_tenant = ? AND (failed_login_attempts > 0 OR locked_until IS NOT NULL)`, userID, tenantID)
+	if err != nil {
+		return errors.Wrap(err, "conn.ResetFailedLogins")
+	}
+	return nil
+}
+
+// UnlockUser lifts the lock of the user and clears the failure count. The
+// user is returned as it was before, empty when it does not exist.
+func (c *Conn) UnlockUser(ctx context.Context, userID int64) (user model.MstUser, err error) {
+	ctx, finish := c.DB.Operation(ctx, "user.Conn.UnlockUser")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return user, errors.Wrap(err, "conn.UnlockUser")
+	}
+
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		found, err := session.Table(MstUserTable).Where("id = ? AND id_mst_tenant = ?", userID, tenantID).ForUpdate().Get(&user)
+		if err != nil || !found {
+			return nil, err
+		}
+
+		_, err = session.Exec(`UPDATE `+MstUserTable+`
+			SET failed_login_attempts = 0, locked_until = NULL
+			WHERE id = ?`, userID)
+		if err != nil {
+			return nil, err
+		}
+		return []audit.Entry{{
+			Action:   constant.AuditActionUnlock,
+			Entity:   MstUserTable,
+			EntityID: userID,
+			Before:   lockState(user),
+			After:    lockState(model.MstUser{}),
+		}}, nil
+	})
+	if err != nil {
+		return user, errors.Wrap(err, "conn.UnlockUser")
+	}
+	return user, nil
+}
+
+// BindDevice binds the device to the user unless one is bound already.
+// bound tells whether this call bound it, so two first tap ins from
+// different devices cannot both win.
+func (c *Conn) BindDevice(ctx context.Context, userID int64, deviceID string) (bound bool, err error) {
+	ctx, finish := c.DB.Operation(ctx, "user.Conn.BindDevice")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "conn.BindDevice")
+	}
+
+	result, err := c.DB.MasterDB.Context(ctx).Exec(`UPDATE `+MstUserTable+`
+		SET device_id = ?
+		WHERE id = ? AND id_mst_tenant = ? AND device_id = ''`, deviceID, userID, tenantID)
+	if err != nil {
+		return false, errors.Wrap(err, "conn.BindDevice")
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "conn.BindDevice")
+	}
+	return affected > 0, nil
+}
+
+// ResetDevice unbinds the device of the user so the next tap in binds a new
+// one. The user is returned as it was before, empty when it does not exist.
+func (c *Conn) ResetDevice(ctx context.Context, userID int64) (user model.MstUser, err error) {
+	ctx, finish := c.DB.Operation(ctx, "user.Conn.ResetDevice")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return user, errors.Wrap(err, "conn.ResetDevice")
+	}
+
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		found, err := session.Table(MstUserTable).Where("id = ? AND id_mst_tenant = ?", userID, tenantID).ForUpdate().Get(&user)
+		if err != nil || !found {
+			return nil, err
+		}
+
+		_, err = session.Exec(`UPDATE `+MstUserTable+`
+			SET device_id = ''
+			WHERE id = ?`, userID)
+		if err != nil {
+			return nil, err
+		}
+		return []audit.Entry{{
+			Action:   constant.AuditActionResetDevice,
+			Entity:   MstUserTable,
+			EntityID: userID,
+			Before:   map[string]interface{}{"device_id": user.DeviceID},
+			After:    map[string]interface{}{"device_id": ""},
+		}}, nil
+	})
+	if err != nil {
+		return user, errors.Wrap(err, "conn.ResetDevice")
+	}
+	return user, nil
+}
+
+// SetManager sets the manager of the user, an invalid managerID removes it.
+// The user is empty when not found.
+func (c *Conn) SetManager(ctx context.Context, userID int64, managerID sql.NullInt64) (user model.MstUser, err error) {
+	ctx, finish := c.DB.Operation(ctx, "user.Conn.SetManager")
+	defer finish(&err)
+
+	tenantID, err := tenant.Require(ctx)
+	if err != nil {
+		return user, errors.Wrap(err, "conn.SetManager")
+	}
+
+	err = audit.Change(ctx, c.DB, func(session *xorm.Session) ([]audit.Entry, error) {
+		found, err := session.Table(MstUserTable).Where("id = ? AND id_mst_tenant = ?", userID, tenantID).ForUpdate().Get(&user)
+		if err != nil || !found {
+			return nil, err
+		}
+
+		_, err = session.Exec(`UPDATE `+MstUserTable+`
+			SET id_mst_manager = ?
+			WHERE id = ?`, managerID, userID)
+		if err != nil {
+			return nil, err
+		}
+		before := user.IDMstManager
+		user.IDMstManager = managerID
+		return []audit.Entry{{
+			Action:   constant.AuditActionSetManager,
+			Entity:   MstUserTable,
+			EntityID: userID,
+			Before:   map[string]interface{}{"id_mst_manager": nullableID(before)},
+			After:    map[string]interface{}{"id_mst_manager": nullableID(managerID)},
+		}}, nil
+	})
+	if err != nil {
+		return user, errors.Wrap(err, "conn.SetManager")
+	}
+	return user, nil
+}
+
+// nullableID records a missing id as null rather than as the NullInt64 struct
+func nullableID(id sql.NullInt64) interface{} {
+	if !id.Valid {
+		return nil
+	}
+	return id.Int64
+}
+
+// lockState keeps the password hash out of the audit trail
+func lockState(user model.MstUser) map[string]interface{} {
+	state := map[string]interface{}{
+		"failed_login_attempts": user.FailedLoginAttempts,
+		"locked_until":          nil,
+	}
+	if user.LockedUntil.Valid {
+		state["locked_until"] = user.LockedUntil.Time
+	}
+	return state
+}
@@ -0,0 +1,634 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RecordLoginAttempt(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			patch: func() {
+				mockDB.ExpectQuery("^INSERT INTO \"trx_login_attempt\"").
+					WithArgs("fooname", int64(1), false, "invalid_password", "10.0.0.1", "req-1", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+		},
+		{
+			name:    "Failed because insert method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^INSERT INTO \"trx_login_attempt\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			err := c.RecordLoginAttempt(tenant.NewContext(context.Background(), 1), &model.TrxLoginAttempt{
+				Username:      "fooname",
+				IDMstUser:     sql.NullInt64{Int64: 1, Valid: true},
+				FailureReason: "invalid_password",
+				IPAddress:     "10.0.0.1",
+				RequestID:     "req-1",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.RecordLoginAttempt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_RegisterFailedLogin(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	lockUntil := time.Date(2025, 7, 1, 9, 15, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		want    sql.NullTime
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful below the limit",
+			patch: func() {
+				mockDB.ExpectQuery("^UPDATE mst_user\\s+SET failed_login_attempts = CASE").
+					WithArgs(5, 5, lockUntil, 1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"failed_login_attempts", "locked_until"}).AddRow(2, nil))
+			},
+		},
+		{
+			name: "Successful reaching the limit",
+			want: sql.NullTime{Time: lockUntil, Valid: true},
+			patch: func() {
+				mockDB.ExpectQuery("^UPDATE mst_user").
+					WithArgs(5, 5, lockUntil, 1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"failed_login_attempts", "locked_until"}).AddRow(0, lockUntil))
+			},
+		},
+		{
+			name:    "Failed because query method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^UPDATE mst_user").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.RegisterFailedLogin(tenant.NewContext(context.Background(), 1), 1, 5, lockUntil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.RegisterFailedLogin() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want.Valid, got.Valid)
+			assert.True(t, tt.want.Time.Equal(got.Time))
+		})
+	}
+}
+
+func Test_ResetFailedLogins(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			patch: func() {
+				mockDB.ExpectExec("^UPDATE mst_user\\s+SET failed_login_attempts = 0, locked_until = NULL").
+					WithArgs(1, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:    "Failed because exec method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectExec("^UPDATE mst_user").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			err := c.ResetFailedLogins(tenant.NewContext(context.Background(), 1), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ResetFailedLogins() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_UnlockUser(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantID  int64
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name:   "Successful",
+			wantID: 2,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_user\" WHERE \\(id = \\$1 AND id_mst_tenant = \\$2\\) LIMIT 1 FOR UPDATE").
+					WithArgs(2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "failed_login_attempts"}).AddRow(2, "employee_001", 4))
+				mockDB.ExpectExec("^UPDATE mst_user\\s+SET failed_login_attempts = 0, locked_until = NULL").
+					WithArgs(2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "Missing user is neither updated nor recorded",
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_user\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:    "Failed because exec method",
+			wantID:  2,
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_user\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mockDB.ExpectExec("^UPDATE mst_user").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.UnlockUser(tenant.NewContext(context.Background(), 1), 2)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.UnlockUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantID, got.ID)
+		})
+	}
+}
+
+func Test_GetUserByID(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		want    model.MstUser
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name: "Successful",
+			want: model.MstUser{ID: 2, Username: "employee_001", WorkArrangement: "hybrid", DeviceID: "device-1"},
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_user\" WHERE \\(id = \\$1 AND id_mst_tenant = \\$2\\) LIMIT 1").
+					WithArgs(2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "work_arrangement", "device_id"}).
+						AddRow(2, "employee_001", "hybrid", "device-1"))
+			},
+		},
+		{
+			name:    "Failed because query method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_user\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.GetUserByID(tenant.NewContext(context.Background(), 1), 2)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.GetUserByID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_BindDevice(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name      string
+		wantBound bool
+		wantErr   bool
+		patch     func()
+	}{
+		{
+			name:      "Successful",
+			wantBound: true,
+			patch: func() {
+				mockDB.ExpectExec("^UPDATE mst_user\\s+SET device_id = \\$1\\s+WHERE id = \\$2 AND id_mst_tenant = \\$3 AND device_id = ''").
+					WithArgs("device-1", 2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "Another device is bound already",
+			patch: func() {
+				mockDB.ExpectExec("^UPDATE mst_user").
+					WithArgs("device-1", 2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name:    "Failed because exec method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectExec("^UPDATE mst_user").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.BindDevice(tenant.NewContext(context.Background(), 1), 2, "device-1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.BindDevice() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantBound, got)
+		})
+	}
+}
+
+func Test_ResetDevice(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantID  int64
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name:   "Successful",
+			wantID: 2,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_user\" WHERE \\(id = \\$1 AND id_mst_tenant = \\$2\\) LIMIT 1 FOR UPDATE").
+					WithArgs(2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "device_id"}).AddRow(2, "employee_001", "device-1"))
+				mockDB.ExpectExec("^UPDATE mst_user\\s+SET device_id = ''").
+					WithArgs(2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "Missing user is neither updated nor recorded",
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_user\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:    "Failed because exec method",
+			wantID:  2,
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_user\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mockDB.ExpectExec("^UPDATE mst_user").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.ResetDevice(tenant.NewContext(context.Background(), 1), 2)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ResetDevice() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantID, got.ID)
+		})
+	}
+}
+
+func Test_GetUserByBadgeID(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		wantID  int64
+		wantErr bool
+		patch   func()
+	}{
+		{
+			name:   "Successful",
+			wantID: 2,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_user\" WHERE \\(badge_id = \\$1 AND id_mst_tenant = \\$2\\) LIMIT 1").
+					WithArgs("B-0001", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "badge_id"}).AddRow(2, "employee_001", "B-0001"))
+			},
+		},
+		{
+			name: "Unknown badge",
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_user\"").
+					WithArgs("B-0001", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+		},
+		{
+			name:    "Failed because query method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_user\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.GetUserByBadgeID(tenant.NewContext(context.Background(), 1), "B-0001")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.GetUserByBadgeID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantID, got.ID)
+		})
+	}
+}
+
+func Test_ListUsersByBadgeIDs(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name     string
+		badgeIDs []string
+		wantIDs  []int64
+		wantErr  bool
+		patch    func()
+	}{
+		{
+			name:     "Successful",
+			badgeIDs: []string{"B-0001", "B-0002"},
+			wantIDs:  []int64{2, 3},
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_user\" WHERE \\(badge_id = ANY\\(\\$1\\) AND id_mst_tenant = \\$2\\)").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "badge_id"}).
+						AddRow(2, "employee_001", "B-0001").
+						AddRow(3, "employee_002", "B-0002"))
+			},
+		},
+		{
+			name:  "No badges",
+			patch: func() {},
+		},
+		{
+			name:     "Failed because query method",
+			badgeIDs: []string{"B-0001"},
+			wantErr:  true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_user\"").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.ListUsersByBadgeIDs(tenant.NewContext(context.Background(), 1), tt.badgeIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.ListUsersByBadgeIDs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var gotIDs []int64
+			for _, user := range got {
+				gotIDs = append(gotIDs, user.ID)
+			}
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+}
+
+func Test_SetManager(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	tests := []struct {
+		name          string
+		managerID     sql.NullInt64
+		wantID        int64
+		wantManagerID sql.NullInt64
+		wantErr       bool
+		patch         func()
+	}{
+		{
+			name:          "Successful",
+			managerID:     sql.NullInt64{Int64: 5, Valid: true},
+			wantID:        2,
+			wantManagerID: sql.NullInt64{Int64: 5, Valid: true},
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_user\" WHERE \\(id = \\$1 AND id_mst_tenant = \\$2\\) LIMIT 1 FOR UPDATE").
+					WithArgs(2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).AddRow(2, "employee_001"))
+				mockDB.ExpectExec("^UPDATE mst_user\\s+SET id_mst_manager = \\$1\\s+WHERE id = \\$2").
+					WithArgs(sql.NullInt64{Int64: 5, Valid: true}, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mockDB.ExpectQuery("^INSERT INTO \"trx_audit_log\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name: "Missing user is neither updated nor recorded",
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_user\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mockDB.ExpectCommit()
+			},
+		},
+		{
+			name:    "Failed because exec method",
+			wantID:  2,
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery("^SELECT .* FROM \"mst_user\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mockDB.ExpectExec("^UPDATE mst_user").
+					WillReturnError(errors.New("database error"))
+				mockDB.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Conn{
+				DB: &xormlib.DBConnect{
+					MasterDB: mockConn,
+				},
+			}
+			tt.patch()
+			got, err := c.SetManager(tenant.NewContext(context.Background(), 1), 2, tt.managerID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Conn.SetManager() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantID, got.ID)
+			if !tt.wantErr {
+				assert.Equal(t, tt.wantManagerID, got.IDMstManager)
+			}
+		})
+	}
+}
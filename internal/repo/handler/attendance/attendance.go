@@ -6,9 +6,15 @@ import (
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase"
 	"github.com/faisalhardin/employee-payroll-system/pkg/common/binding"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
 	commonwriter "github.com/faisalhardin/employee-payroll-system/pkg/common/writer"
 )
 
+const (
+	// maxImportSize caps an uploaded device log
+	maxImportSize = 10 << 20
+)
+
 var (
 	bindingBind = binding.Bind
 )
@@ -24,7 +30,85 @@ func New(attendanceHandler *AttendanceHandler) *AttendanceHandler {
 func (h *AttendanceHandler) TapIn(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	resp, err := h.AttendanceUsecase.TapIn(ctx, model.MstAttendance{})
+	// the location and device are optional, a bare POST still taps in
+	req := model.TapInRequest{}
+	if r.ContentLength != 0 {
+		err := bindingBind(r, &req)
+		if err != nil {
+			commonwriter.SetError(ctx, w, err)
+			return
+		}
+	}
+
+	resp, err := h.AttendanceUsecase.TapIn(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) TapInAtKiosk(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.KioskTapInRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.TapInAtKiosk(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) TapInWithBadge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.BadgeTapInRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.TapInWithBadge(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+// ImportAttendance takes a device log uploaded as the file field of a
+// multipart form
+func (h *AttendanceHandler) ImportAttendance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	req := model.ImportAttendanceRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, commonerr.SetNewBadRequest("invalid body", err.Error()))
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		commonwriter.SetError(ctx, w, commonerr.SetNewBadRequest("file_required", "upload the log as the file field"))
+		return
+	}
+	defer file.Close()
+	req.File = file
+
+	resp, err := h.AttendanceUsecase.ImportAttendance(ctx, req)
 	if err != nil {
 		commonwriter.SetError(ctx, w, err)
 		return
@@ -81,13 +165,31 @@ func (h *AttendanceHandler) GeneratePayroll(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	err = h.AttendanceUsecase.GeneratePayroll(ctx, req)
+	resp, err := h.AttendanceUsecase.EnqueuePayrollGeneration(ctx, req)
 	if err != nil {
 		commonwriter.SetError(ctx, w, err)
 		return
 	}
 
-	commonwriter.SetOKWithData(ctx, w, "OK")
+	commonwriter.SetAcceptedWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) GetPayrollJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := getIDFromURLParam(r)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.GetPayrollJob(ctx, id)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
 }
 
 func (h *AttendanceHandler) GetEmployeePayslip(w http.ResponseWriter, r *http.Request) {
@@ -2,9 +2,13 @@ package attendance
 
 import (
 	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -190,7 +194,7 @@ func Test_TapIn(t *testing.T) {
 				w: httptest.NewRecorder(),
 			},
 			patch: func() {
-				mockAttendanceUC.EXPECT().TapIn(gomock.Any(), model.MstAttendance{}).
+				mockAttendanceUC.EXPECT().TapIn(gomock.Any(), model.TapInRequest{}).
 					Return(mockSuccessfulResponse, nil).Times(1)
 			},
 		},
@@ -206,10 +210,45 @@ func Test_TapIn(t *testing.T) {
 				w: httptest.NewRecorder(),
 			},
 			patch: func() {
-				mockAttendanceUC.EXPECT().TapIn(gomock.Any(), model.MstAttendance{}).
+				mockAttendanceUC.EXPECT().TapIn(gomock.Any(), model.TapInRequest{}).
 					Return(model.TapInResponse{}, errFoo).Times(1)
 			},
 		},
+		{
+			name:       "Successful with location and device",
+			statusCode: http.StatusOK,
+			args: args{
+				r: func() *http.Request {
+					req := httptest.NewRequest(http.MethodPost, "/attendance/tap-in",
+						strings.NewReader(`{"latitude": -6.2, "longitude": 106.8, "device_id": "device-1"}`))
+					req.Header.Set("Content-Type", "application/json")
+					return req
+				}(),
+				w: httptest.NewRecorder(),
+			},
+			patch: func() {
+				latitude, longitude := -6.2, 106.8
+				mockAttendanceUC.EXPECT().TapIn(gomock.Any(), model.TapInRequest{
+					Latitude:  &latitude,
+					Longitude: &longitude,
+					DeviceID:  "device-1",
+				}).Return(mockSuccessfulResponse, nil).Times(1)
+			},
+		},
+		{
+			name:       "Invalid latitude",
+			statusCode: http.StatusBadRequest,
+			args: args{
+				r: func() *http.Request {
+					req := httptest.NewRequest(http.MethodPost, "/attendance/tap-in",
+						strings.NewReader(`{"latitude": -96.2, "longitude": 106.8}`))
+					req.Header.Set("Content-Type", "application/json")
+					return req
+				}(),
+				w: httptest.NewRecorder(),
+			},
+			patch: func() {},
+		},
 	}
 
 	for _, tt := range tests {
@@ -227,6 +266,181 @@ func Test_TapIn(t *testing.T) {
 	}
 }
 
+func Test_TapInAtKiosk(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/v1/tap-in/kiosk", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			body:       `{"nonce": "nonce"}`,
+			patch: func() {
+				mockAttendanceUC.EXPECT().TapInAtKiosk(gomock.Any(), model.KioskTapInRequest{Nonce: "nonce"}).
+					Return(model.TapInResponse{}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Missing nonce",
+			statusCode: http.StatusBadRequest,
+			body:       `{}`,
+			patch:      func() {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.TapInAtKiosk(w, newRequest(tt.body))
+			if w.Code != tt.statusCode {
+				t.Errorf("handler.TapInAtKiosk expected status %v, got %d", tt.statusCode, w.Code)
+			}
+		})
+	}
+}
+
+func Test_TapInWithBadge(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/kiosk/v1/tap-in", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			body:       `{"badge_id": "B-001"}`,
+			patch: func() {
+				mockAttendanceUC.EXPECT().TapInWithBadge(gomock.Any(), model.BadgeTapInRequest{BadgeID: "B-001"}).
+					Return(model.TapInResponse{Username: "employee_001"}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Missing badge",
+			statusCode: http.StatusBadRequest,
+			body:       `{}`,
+			patch:      func() {},
+		},
+		{
+			name:       "Failed",
+			statusCode: http.StatusInternalServerError,
+			body:       `{"badge_id": "B-001"}`,
+			patch: func() {
+				mockAttendanceUC.EXPECT().TapInWithBadge(gomock.Any(), gomock.Any()).
+					Return(model.TapInResponse{}, errFoo).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.TapInWithBadge(w, newRequest(tt.body))
+			if w.Code != tt.statusCode {
+				t.Errorf("handler.TapInWithBadge expected status %v, got %d", tt.statusCode, w.Code)
+			}
+		})
+	}
+}
+
+func Test_ImportAttendance(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	newRequest := func(target string, withFile bool) *http.Request {
+		body := &bytes.Buffer{}
+		form := multipart.NewWriter(body)
+		if withFile {
+			part, _ := form.CreateFormFile("file", "attlog.csv")
+			_, _ = part.Write([]byte("badge_id,timestamp\nB-001,2025-07-21 08:01:00\n"))
+		}
+		_ = form.Close()
+		req := httptest.NewRequest(http.MethodPost, target, body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		return req
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		r          *http.Request
+		patch      func()
+	}{
+		{
+			name:       "Successful preview",
+			statusCode: http.StatusOK,
+			r:          newRequest("/v1/attendance/import?preview=true", true),
+			patch: func() {
+				mockAttendanceUC.EXPECT().ImportAttendance(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req model.ImportAttendanceRequest) (model.ImportAttendanceResponse, error) {
+						content, _ := io.ReadAll(req.File)
+						if !req.Preview || !strings.Contains(string(content), "B-001") {
+							t.Errorf("unexpected request %+v", req)
+						}
+						return model.ImportAttendanceResponse{Preview: true, TotalLines: 1, Imported: 1}, nil
+					}).Times(1)
+			},
+		},
+		{
+			name:       "Missing file",
+			statusCode: http.StatusBadRequest,
+			r:          newRequest("/v1/attendance/import", false),
+			patch:      func() {},
+		},
+		{
+			name:       "Failed",
+			statusCode: http.StatusInternalServerError,
+			r:          newRequest("/v1/attendance/import", true),
+			patch: func() {
+				mockAttendanceUC.EXPECT().ImportAttendance(gomock.Any(), gomock.Any()).
+					Return(model.ImportAttendanceResponse{}, errFoo).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.ImportAttendance(w, tt.r)
+			if w.Code != tt.statusCode {
+				t.Errorf("handler.ImportAttendance expected status %v, got %d", tt.statusCode, w.Code)
+			}
+		})
+	}
+}
+
 func Test_SubmitOvertime(t *testing.T) {
 	ctrl := initMocks(t)
 	defer ctrl.Finish()
@@ -343,7 +557,7 @@ func Test_GeneratePayroll(t *testing.T) {
 	}{
 		{
 			name:       "Successful",
-			statusCode: http.StatusOK,
+			statusCode: http.StatusAccepted,
 			args: args{
 				r: func() *http.Request {
 					req := httptest.NewRequest(http.MethodPost, "/payroll/generate", bytes.NewBufferString(mockReqBody))
@@ -353,8 +567,8 @@ func Test_GeneratePayroll(t *testing.T) {
 				w: httptest.NewRecorder(),
 			},
 			patch: func() {
-				mockAttendanceUC.EXPECT().GeneratePayroll(gomock.Any(), gomock.Any()).
-					Return(nil).Times(1)
+				mockAttendanceUC.EXPECT().EnqueuePayrollGeneration(gomock.Any(), gomock.Any()).
+					Return(model.PayrollJobResponse{ID: 1, Status: "queued"}, nil).Times(1)
 			},
 		},
 		{
@@ -369,8 +583,8 @@ func Test_GeneratePayroll(t *testing.T) {
 				w: httptest.NewRecorder(),
 			},
 			patch: func() {
-				mockAttendanceUC.EXPECT().GeneratePayroll(gomock.Any(), gomock.Any()).
-					Return(errFoo).Times(1)
+				mockAttendanceUC.EXPECT().EnqueuePayrollGeneration(gomock.Any(), gomock.Any()).
+					Return(model.PayrollJobResponse{}, errFoo).Times(1)
 			},
 		},
 		{
@@ -616,3 +830,55 @@ func Test_GetPayroll(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetPayrollJob(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		id         string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			id:         "1",
+			patch: func() {
+				mockAttendanceUC.EXPECT().GetPayrollJob(gomock.Any(), int64(1)).
+					Return(model.PayrollJobResponse{ID: 1, Status: "running", Progress: 30}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at invalid id",
+			statusCode: http.StatusBadRequest,
+			id:         "abc",
+			patch:      func() {},
+		},
+		{
+			name:       "Failed at usecase",
+			statusCode: http.StatusInternalServerError,
+			id:         "1",
+			patch: func() {
+				mockAttendanceUC.EXPECT().GetPayrollJob(gomock.Any(), int64(1)).
+					Return(model.PayrollJobResponse{}, errFoo).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.GetPayrollJob(w, newRequestWithID(http.MethodGet, "/payroll/jobs/"+tt.id, tt.id, nil))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.GetPayrollJob expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
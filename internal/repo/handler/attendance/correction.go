@@ -0,0 +1,120 @@
+package attendance
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	commonwriter "github.com/faisalhardin/employee-payroll-system/pkg/common/writer"
+)
+
+func (h *AttendanceHandler) RequestAttendanceCorrection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.AttendanceCorrectionRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.RequestAttendanceCorrection(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) ListMyAttendanceCorrections(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.ListAttendanceCorrectionRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.ListMyAttendanceCorrections(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) ListAttendanceCorrections(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.ListAttendanceCorrectionRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.ListAttendanceCorrections(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) ApproveAttendanceCorrection(w http.ResponseWriter, r *http.Request) {
+	h.reviewAttendanceCorrection(w, r, h.AttendanceUsecase.ApproveAttendanceCorrection)
+}
+
+func (h *AttendanceHandler) RejectAttendanceCorrection(w http.ResponseWriter, r *http.Request) {
+	h.reviewAttendanceCorrection(w, r, h.AttendanceUsecase.RejectAttendanceCorrection)
+}
+
+func (h *AttendanceHandler) reviewAttendanceCorrection(w http.ResponseWriter, r *http.Request,
+	review func(ctx context.Context, request model.ReviewAttendanceCorrectionRequest) (model.AttendanceCorrectionResponse, error)) {
+	ctx := r.Context()
+
+	id, err := getIDFromURLParam(r)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	req := model.ReviewAttendanceCorrectionRequest{}
+	err = bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+	req.ID = id
+
+	resp, err := review(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) AdjustAttendance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.AdjustAttendanceRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.AdjustAttendance(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
@@ -0,0 +1,279 @@
+package attendance
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_RequestAttendanceCorrection(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			body:       `{"attendance_date": "2025-07-21", "reason": "forgot my phone"}`,
+			patch: func() {
+				mockAttendanceUC.EXPECT().RequestAttendanceCorrection(gomock.Any(), model.AttendanceCorrectionRequest{
+					AttendanceDate: "2025-07-21",
+					Reason:         "forgot my phone",
+				}).Return(model.AttendanceCorrectionResponse{ID: 1}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at missing reason",
+			statusCode: http.StatusBadRequest,
+			body:       `{"attendance_date": "2025-07-21"}`,
+			patch:      func() {},
+		},
+		{
+			name:       "Failed at invalid date",
+			statusCode: http.StatusBadRequest,
+			body:       `{"attendance_date": "21/07/2025", "reason": "forgot my phone"}`,
+			patch:      func() {},
+		},
+		{
+			name:       "Failed",
+			statusCode: http.StatusInternalServerError,
+			body:       `{"attendance_date": "2025-07-21", "reason": "forgot my phone"}`,
+			patch: func() {
+				mockAttendanceUC.EXPECT().RequestAttendanceCorrection(gomock.Any(), gomock.Any()).
+					Return(model.AttendanceCorrectionResponse{}, errFoo).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			req := httptest.NewRequest(http.MethodPost, "/attendance-corrections", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			h.RequestAttendanceCorrection(w, req)
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.RequestAttendanceCorrection expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_ListAttendanceCorrections(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		target     string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			target:     "/attendance-corrections?status=pending&limit=10",
+			patch: func() {
+				mockAttendanceUC.EXPECT().ListAttendanceCorrections(gomock.Any(), model.ListAttendanceCorrectionRequest{
+					CursorPagination: model.CursorPagination{Limit: 10},
+					Status:           constant.AttendanceCorrectionStatusPending,
+				}).Return(model.ListAttendanceCorrectionResponse{}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at invalid status",
+			statusCode: http.StatusBadRequest,
+			target:     "/attendance-corrections?status=done",
+			patch:      func() {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.ListAttendanceCorrections(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.ListAttendanceCorrections expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_ApproveAttendanceCorrection(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		id         string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			id:         "6",
+			patch: func() {
+				mockAttendanceUC.EXPECT().ApproveAttendanceCorrection(gomock.Any(), model.ReviewAttendanceCorrectionRequest{
+					ID:   6,
+					Note: "seen on cctv",
+				}).Return(model.AttendanceCorrectionResponse{ID: 6}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at invalid id",
+			statusCode: http.StatusBadRequest,
+			id:         "abc",
+			patch:      func() {},
+		},
+		{
+			name:       "Failed at conflict",
+			statusCode: http.StatusConflict,
+			id:         "6",
+			patch: func() {
+				mockAttendanceUC.EXPECT().ApproveAttendanceCorrection(gomock.Any(), gomock.Any()).
+					Return(model.AttendanceCorrectionResponse{}, commonerr.SetNewError(http.StatusConflict, "conflict", "changed meanwhile")).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.ApproveAttendanceCorrection(w, newRequestWithID(http.MethodPost, "/attendance-corrections/"+tt.id+"/approve", tt.id,
+				bytes.NewBufferString(`{"note": "seen on cctv"}`)))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.ApproveAttendanceCorrection expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_RejectAttendanceCorrection(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			patch: func() {
+				mockAttendanceUC.EXPECT().RejectAttendanceCorrection(gomock.Any(), model.ReviewAttendanceCorrectionRequest{
+					ID:   6,
+					Note: "was on leave",
+				}).Return(model.AttendanceCorrectionResponse{ID: 6}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Unauthorized",
+			statusCode: http.StatusUnauthorized,
+			patch: func() {
+				mockAttendanceUC.EXPECT().RejectAttendanceCorrection(gomock.Any(), gomock.Any()).
+					Return(model.AttendanceCorrectionResponse{}, commonerr.SetNewUnauthorizedAPICall()).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.RejectAttendanceCorrection(w, newRequestWithID(http.MethodPost, "/attendance-corrections/6/reject", "6",
+				bytes.NewBufferString(`{"note": "was on leave"}`)))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.RejectAttendanceCorrection expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_AdjustAttendance(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			body:       `{"user_id": 2, "attendance_date": "2025-07-21", "action": "remove", "reason": "on leave"}`,
+			patch: func() {
+				mockAttendanceUC.EXPECT().AdjustAttendance(gomock.Any(), model.AdjustAttendanceRequest{
+					UserID:         2,
+					AttendanceDate: "2025-07-21",
+					Action:         constant.AttendanceCorrectionRemove,
+					Reason:         "on leave",
+				}).Return(model.AttendanceCorrectionResponse{ID: 8}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at invalid action",
+			statusCode: http.StatusBadRequest,
+			body:       `{"user_id": 2, "attendance_date": "2025-07-21", "action": "move", "reason": "on leave"}`,
+			patch:      func() {},
+		},
+		{
+			name:       "Failed",
+			statusCode: http.StatusInternalServerError,
+			body:       `{"user_id": 2, "attendance_date": "2025-07-21", "action": "add", "reason": "on call"}`,
+			patch: func() {
+				mockAttendanceUC.EXPECT().AdjustAttendance(gomock.Any(), gomock.Any()).
+					Return(model.AttendanceCorrectionResponse{}, errFoo).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			req := httptest.NewRequest(http.MethodPost, "/attendance/adjustments", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			h.AdjustAttendance(w, req)
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.AdjustAttendance expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
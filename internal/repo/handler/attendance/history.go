@@ -0,0 +1,84 @@
+package attendance
+
+import (
+	"net/http"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	commonwriter "github.com/faisalhardin/employee-payroll-system/pkg/common/writer"
+)
+
+func (h *AttendanceHandler) ListMyPayslips(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.ListMyPayslipRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.ListMyPayslips(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) ListMyAttendance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.ListMyAttendanceRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.ListMyAttendance(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) ListMyOvertime(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.ListMyOvertimeRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.ListMyOvertime(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) ListMyReimbursements(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.ListMyReimbursementRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.ListMyReimbursements(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
@@ -0,0 +1,199 @@
+package attendance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_ListMyPayslips(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		target     string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			target:     "/me/payslips?cursor=10&limit=5",
+			patch: func() {
+				mockAttendanceUC.EXPECT().ListMyPayslips(gomock.Any(), model.ListMyPayslipRequest{
+					CursorPagination: model.CursorPagination{Cursor: 10, Limit: 5},
+				}).Return(model.ListMyPayslipResponse{}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed",
+			statusCode: http.StatusInternalServerError,
+			target:     "/me/payslips",
+			patch: func() {
+				mockAttendanceUC.EXPECT().ListMyPayslips(gomock.Any(), gomock.Any()).
+					Return(model.ListMyPayslipResponse{}, errFoo).Times(1)
+			},
+		},
+		{
+			name:       "Failed at validation",
+			statusCode: http.StatusBadRequest,
+			target:     "/me/payslips?limit=1000",
+			patch:      func() {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.ListMyPayslips(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.ListMyPayslips expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_ListMyAttendance(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		target     string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			target:     "/me/attendance?start_date=2025-07-01&end_date=2025-07-31",
+			patch: func() {
+				mockAttendanceUC.EXPECT().ListMyAttendance(gomock.Any(), model.ListMyAttendanceRequest{
+					StartDate: "2025-07-01",
+					EndDate:   "2025-07-31",
+				}).Return(model.ListMyAttendanceResponse{}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at validation",
+			statusCode: http.StatusBadRequest,
+			target:     "/me/attendance?start_date=01-07-2025&end_date=2025-07-31",
+			patch:      func() {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.ListMyAttendance(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.ListMyAttendance expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_ListMyOvertime(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		target     string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			target:     "/me/overtime?status=pending",
+			patch: func() {
+				mockAttendanceUC.EXPECT().ListMyOvertime(gomock.Any(), model.ListMyOvertimeRequest{
+					Status: "pending",
+				}).Return(model.ListMyOvertimeResponse{}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at validation",
+			statusCode: http.StatusBadRequest,
+			target:     "/me/overtime?status=unknown",
+			patch:      func() {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.ListMyOvertime(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.ListMyOvertime expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_ListMyReimbursements(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		target     string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			target:     "/me/reimbursements?status=paid",
+			patch: func() {
+				mockAttendanceUC.EXPECT().ListMyReimbursements(gomock.Any(), model.ListMyReimbursementRequest{
+					Status: "paid",
+				}).Return(model.ListMyReimbursementResponse{}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed",
+			statusCode: http.StatusInternalServerError,
+			target:     "/me/reimbursements",
+			patch: func() {
+				mockAttendanceUC.EXPECT().ListMyReimbursements(gomock.Any(), gomock.Any()).
+					Return(model.ListMyReimbursementResponse{}, errFoo).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.ListMyReimbursements(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.ListMyReimbursements expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package attendance
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	commonwriter "github.com/faisalhardin/employee-payroll-system/pkg/common/writer"
+)
+
+// labourCostCSVHeader are the columns of the labour cost export, the total
+// coming last as a row of its own
+var labourCostCSVHeader = []string{
+	"group_id", "code", "name", "headcount", "salary", "overtime_hours",
+	"overtime_pay", "total_reimbursements", "total_cost",
+}
+
+func (h *AttendanceHandler) CreateDepartment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.DepartmentRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.CreateDepartment(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) ListDepartments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := h.AttendanceUsecase.ListDepartments(ctx)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) CreateCostCentre(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.CostCentreRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.CreateCostCentre(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) ListCostCentres(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := h.AttendanceUsecase.ListCostCentres(ctx)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) AssignOrganization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.AssignOrganizationRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.AssignOrganization(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) ListAssignments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := getIDFromURLParam(r)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.ListAssignments(ctx, id)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+// GetLabourCost answers the report as JSON, or as a CSV file when the format
+// is csv
+func (h *AttendanceHandler) GetLabourCost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.LabourCostRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.GetLabourCost(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	if req.Format != constant.LabourCostFormatCSV {
+		commonwriter.SetOKWithData(ctx, w, resp)
+		return
+	}
+
+	b, err := labourCostCSV(resp)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="labour-cost-%s-%s-%s.csv"`,
+		resp.GroupBy, resp.StartDate.Format(time.DateOnly), resp.EndDate.Format(time.DateOnly)))
+	commonwriter.SetOKWithByte(ctx, w, b)
+}
+
+func labourCostCSV(report model.LabourCostResponse) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	records := [][]string{labourCostCSVHeader}
+	for _, group := range report.Groups {
+		records = append(records, labourCostCSVRecord(group))
+	}
+	total := labourCostCSVRecord(report.Total)
+	total[0], total[2] = "", "Total"
+	records = append(records, total)
+
+	err := writer.WriteAll(records)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func labourCostCSVRecord(group model.LabourCostGroupResponse) []string {
+	return []string{
+		strconv.FormatInt(group.ID, 10),
+		csvText(group.Code),
+		csvText(group.Name),
+		strconv.Itoa(group.Headcount),
+		strconv.FormatInt(group.Salary, 10),
+		strconv.Itoa(group.OvertimeHours),
+		strconv.FormatInt(group.OvertimePay, 10),
+		strconv.FormatInt(group.TotalReimbursements, 10),
+		strconv.FormatInt(group.TotalCost, 10),
+	}
+}
+
+// csvText keeps a text cell from being run as a formula by spreadsheets, the
+// names and codes are typed in by admins
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,291 @@
+package attendance
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateDepartment(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			body:       `{"code": "ENG", "name": "Engineering"}`,
+			patch: func() {
+				mockAttendanceUC.EXPECT().CreateDepartment(gomock.Any(), model.DepartmentRequest{
+					Code: "ENG",
+					Name: "Engineering",
+				}).Return(model.MstDepartment{ID: 3}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at missing code",
+			statusCode: http.StatusBadRequest,
+			body:       `{"name": "Engineering"}`,
+			patch:      func() {},
+		},
+		{
+			name:       "Unauthorized",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"code": "ENG", "name": "Engineering"}`,
+			patch: func() {
+				mockAttendanceUC.EXPECT().CreateDepartment(gomock.Any(), gomock.Any()).
+					Return(model.MstDepartment{}, commonerr.SetNewUnauthorizedAPICall()).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			req := httptest.NewRequest(http.MethodPost, "/departments", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			h.CreateDepartment(w, req)
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.CreateDepartment expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_AssignOrganization(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			body:       `{"user_id": 2, "department_id": 3, "cost_centre_id": 4, "start_date": "2025-08-01"}`,
+			patch: func() {
+				mockAttendanceUC.EXPECT().AssignOrganization(gomock.Any(), model.AssignOrganizationRequest{
+					UserID:       2,
+					DepartmentID: 3,
+					CostCentreID: 4,
+					StartDate:    "2025-08-01",
+				}).Return(model.TrxUserAssignment{ID: 5}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at missing cost centre",
+			statusCode: http.StatusBadRequest,
+			body:       `{"user_id": 2, "department_id": 3, "start_date": "2025-08-01"}`,
+			patch:      func() {},
+		},
+		{
+			name:       "Failed at invalid date",
+			statusCode: http.StatusBadRequest,
+			body:       `{"user_id": 2, "department_id": 3, "cost_centre_id": 4, "start_date": "01-08-2025"}`,
+			patch:      func() {},
+		},
+		{
+			name:       "Unauthorized",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"user_id": 2, "department_id": 3, "cost_centre_id": 4, "start_date": "2025-08-01"}`,
+			patch: func() {
+				mockAttendanceUC.EXPECT().AssignOrganization(gomock.Any(), gomock.Any()).
+					Return(model.TrxUserAssignment{}, commonerr.SetNewUnauthorizedAPICall()).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			req := httptest.NewRequest(http.MethodPost, "/assignments", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			h.AssignOrganization(w, req)
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.AssignOrganization expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_ListAssignments(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		id         string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			id:         "2",
+			patch: func() {
+				mockAttendanceUC.EXPECT().ListAssignments(gomock.Any(), int64(2)).
+					Return([]model.AssignmentResponse{{ID: 5, UserID: 2}}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at invalid id",
+			statusCode: http.StatusBadRequest,
+			id:         "abc",
+			patch:      func() {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.ListAssignments(w, newRequestWithID(http.MethodGet, "/users/"+tt.id+"/assignments", tt.id, nil))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.ListAssignments expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_GetLabourCost(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	report := model.LabourCostResponse{
+		GroupBy:   constant.LabourCostGroupByDepartment,
+		StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC),
+		Groups: []model.LabourCostGroupResponse{
+			{ID: 3, Code: "ENG", Name: "Engineering, platform", Headcount: 2, Salary: 300, OvertimeHours: 2, OvertimePay: 10, TotalReimbursements: 5, TotalCost: 315},
+		},
+		Total: model.LabourCostGroupResponse{Headcount: 2, Salary: 300, OvertimeHours: 2, OvertimePay: 10, TotalReimbursements: 5, TotalCost: 315},
+	}
+
+	tests := []struct {
+		name            string
+		statusCode      int
+		target          string
+		patch           func()
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:       "Successful as JSON",
+			statusCode: http.StatusOK,
+			target:     "/payroll/labour-cost?group_by=department&start_period_id=4&end_period_id=6",
+			patch: func() {
+				mockAttendanceUC.EXPECT().GetLabourCost(gomock.Any(), model.LabourCostRequest{
+					GroupBy:       constant.LabourCostGroupByDepartment,
+					StartPeriodID: 4,
+					EndPeriodID:   6,
+				}).Return(report, nil).Times(1)
+			},
+			wantContentType: "application/json",
+		},
+		{
+			name:       "Successful as CSV",
+			statusCode: http.StatusOK,
+			target:     "/payroll/labour-cost?group_by=department&start_period_id=4&end_period_id=6&format=csv",
+			patch: func() {
+				mockAttendanceUC.EXPECT().GetLabourCost(gomock.Any(), gomock.Any()).Return(report, nil).Times(1)
+			},
+			wantContentType: "text/csv",
+			wantBody: "group_id,code,name,headcount,salary,overtime_hours,overtime_pay,total_reimbursements,total_cost\n" +
+				"3,ENG,\"Engineering, platform\",2,300,2,10,5,315\n" +
+				",,Total,2,300,2,10,5,315\n",
+		},
+		{
+			name:       "Successful as CSV escapes formulas",
+			statusCode: http.StatusOK,
+			target:     "/payroll/labour-cost?group_by=department&start_period_id=4&format=csv",
+			patch: func() {
+				mockAttendanceUC.EXPECT().GetLabourCost(gomock.Any(), gomock.Any()).Return(model.LabourCostResponse{
+					Groups: []model.LabourCostGroupResponse{
+						{ID: 3, Code: "@SUM(A1)", Name: "=HYPERLINK(\"http://example.com\")", TotalCost: -5},
+						{ID: 4, Code: "+1", Name: "-Ops"},
+						{ID: 5, Code: "\tTAB", Name: "\rCR"},
+					},
+				}, nil).Times(1)
+			},
+			wantContentType: "text/csv",
+			wantBody: "group_id,code,name,headcount,salary,overtime_hours,overtime_pay,total_reimbursements,total_cost\n" +
+				"3,'@SUM(A1),\"'=HYPERLINK(\"\"http://example.com\"\")\",0,0,0,0,0,-5\n" +
+				"4,'+1,'-Ops,0,0,0,0,0,0\n" +
+				"5,'\tTAB,\"'\rCR\",0,0,0,0,0,0\n" +
+				",,Total,0,0,0,0,0,0\n",
+		},
+		{
+			name:       "Failed at unknown group",
+			statusCode: http.StatusBadRequest,
+			target:     "/payroll/labour-cost?group_by=team&start_period_id=4",
+			patch:      func() {},
+		},
+		{
+			name:       "Failed at missing period",
+			statusCode: http.StatusBadRequest,
+			target:     "/payroll/labour-cost?group_by=department",
+			patch:      func() {},
+		},
+		{
+			name:       "Unauthorized",
+			statusCode: http.StatusUnauthorized,
+			target:     "/payroll/labour-cost?group_by=cost_centre&start_period_id=4&format=csv",
+			patch: func() {
+				mockAttendanceUC.EXPECT().GetLabourCost(gomock.Any(), gomock.Any()).
+					Return(model.LabourCostResponse{}, commonerr.SetNewUnauthorizedAPICall()).Times(1)
+			},
+			wantContentType: "application/json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.GetLabourCost(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.GetLabourCost expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+			if tt.wantContentType != "" {
+				assert.Equal(t, tt.wantContentType, resp.Header.Get("Content-Type"))
+			}
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
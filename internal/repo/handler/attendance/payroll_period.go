@@ -0,0 +1,118 @@
+package attendance
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	commonwriter "github.com/faisalhardin/employee-payroll-system/pkg/common/writer"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *AttendanceHandler) ListPayrollPeriods(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.ListPayrollPeriodRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.ListPayrollPeriods(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) GetPayrollPeriod(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := getIDFromURLParam(r)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.GetPayrollPeriod(ctx, id)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) UpdatePayrollPeriod(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := getIDFromURLParam(r)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	req := model.PayrollPeriodRequest{}
+	err = bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.UpdatePayrollPeriod(ctx, id, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) DeletePayrollPeriod(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := getIDFromURLParam(r)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	err = h.AttendanceUsecase.DeletePayrollPeriod(ctx, id)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, "OK")
+}
+
+func getIDFromURLParam(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, commonerr.SetNewBadRequest("invalid", "id must be a positive number")
+	}
+	return id, nil
+}
+
+func (h *AttendanceHandler) ListPayrollScheduleRuns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.ListSchedulerRunRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.ListPayrollScheduleRuns(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
@@ -0,0 +1,280 @@
+package attendance
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+)
+
+func newRequestWithID(method, target, id string, body *bytes.Buffer) *http.Request {
+	var req *http.Request
+	if body == nil {
+		req = httptest.NewRequest(method, target, nil)
+	} else {
+		req = httptest.NewRequest(method, target, body)
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func Test_ListPayrollPeriods(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		target     string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			target:     "/payroll-period?status=open",
+			patch: func() {
+				mockAttendanceUC.EXPECT().ListPayrollPeriods(gomock.Any(), model.ListPayrollPeriodRequest{
+					Status: "open",
+				}).Return(model.ListPayrollPeriodResponse{}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at validation",
+			statusCode: http.StatusBadRequest,
+			target:     "/payroll-period?status=closed",
+			patch:      func() {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.ListPayrollPeriods(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.ListPayrollPeriods expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_GetPayrollPeriod(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		id         string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			id:         "1",
+			patch: func() {
+				mockAttendanceUC.EXPECT().GetPayrollPeriod(gomock.Any(), int64(1)).
+					Return(model.PayrollPeriodResponse{ID: 1}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at invalid id",
+			statusCode: http.StatusBadRequest,
+			id:         "abc",
+			patch:      func() {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.GetPayrollPeriod(w, newRequestWithID(http.MethodGet, "/payroll-period/"+tt.id, tt.id, nil))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.GetPayrollPeriod expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_UpdatePayrollPeriod(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	mockReqBody := `{
+		"start_date": "2024-01-01T00:00:00Z",
+		"end_date": "2024-01-31T00:00:00Z"
+	}`
+
+	tests := []struct {
+		name       string
+		statusCode int
+		id         string
+		body       string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			id:         "1",
+			body:       mockReqBody,
+			patch: func() {
+				mockAttendanceUC.EXPECT().UpdatePayrollPeriod(gomock.Any(), int64(1), gomock.Any()).
+					Return(model.PayrollPeriodResponse{ID: 1}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed",
+			statusCode: http.StatusInternalServerError,
+			id:         "1",
+			body:       mockReqBody,
+			patch: func() {
+				mockAttendanceUC.EXPECT().UpdatePayrollPeriod(gomock.Any(), int64(1), gomock.Any()).
+					Return(model.PayrollPeriodResponse{}, errFoo).Times(1)
+			},
+		},
+		{
+			name:       "Failed at validation",
+			statusCode: http.StatusBadRequest,
+			id:         "1",
+			body:       `{"start_date": "2024-01-31T00:00:00Z", "end_date": "2024-01-01T00:00:00Z"}`,
+			patch:      func() {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.UpdatePayrollPeriod(w, newRequestWithID(http.MethodPut, "/payroll-period/"+tt.id, tt.id, bytes.NewBufferString(tt.body)))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.UpdatePayrollPeriod expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_DeletePayrollPeriod(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		id         string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			id:         "3",
+			patch: func() {
+				mockAttendanceUC.EXPECT().DeletePayrollPeriod(gomock.Any(), int64(3)).
+					Return(nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed",
+			statusCode: http.StatusInternalServerError,
+			id:         "3",
+			patch: func() {
+				mockAttendanceUC.EXPECT().DeletePayrollPeriod(gomock.Any(), int64(3)).
+					Return(errFoo).Times(1)
+			},
+		},
+		{
+			name:       "Failed at invalid id",
+			statusCode: http.StatusBadRequest,
+			id:         "0",
+			patch:      func() {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.DeletePayrollPeriod(w, newRequestWithID(http.MethodDelete, "/payroll-period/"+tt.id, tt.id, nil))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.DeletePayrollPeriod expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_ListPayrollScheduleRuns(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		target     string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			target:     "/payroll-period/schedule-runs?status=failed&cursor=10",
+			patch: func() {
+				mockAttendanceUC.EXPECT().ListPayrollScheduleRuns(gomock.Any(), model.ListSchedulerRunRequest{
+					CursorPagination: model.CursorPagination{Cursor: 10},
+					Status:           "failed",
+				}).Return(model.ListSchedulerRunResponse{}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at validation",
+			statusCode: http.StatusBadRequest,
+			target:     "/payroll-period/schedule-runs?status=running",
+			patch:      func() {},
+		},
+		{
+			name:       "Failed at usecase",
+			statusCode: http.StatusInternalServerError,
+			target:     "/payroll-period/schedule-runs",
+			patch: func() {
+				mockAttendanceUC.EXPECT().ListPayrollScheduleRuns(gomock.Any(), gomock.Any()).
+					Return(model.ListSchedulerRunResponse{}, errFoo).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.ListPayrollScheduleRuns(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.ListPayrollScheduleRuns expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
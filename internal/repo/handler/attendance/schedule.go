@@ -0,0 +1,154 @@
+package attendance
+
+import (
+	"net/http"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	commonwriter "github.com/faisalhardin/employee-payroll-system/pkg/common/writer"
+)
+
+func (h *AttendanceHandler) CreateShift(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.ShiftRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.CreateShift(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) ListShifts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := h.AttendanceUsecase.ListShifts(ctx)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) CreateWorkPattern(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.WorkPatternRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.CreateWorkPattern(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) ListWorkPatterns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := h.AttendanceUsecase.ListWorkPatterns(ctx)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) AssignSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.AssignScheduleRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.AssignSchedule(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) SetRoster(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.RosterRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.SetRoster(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+// GetMySchedule lists the shifts of the signed in employee
+func (h *AttendanceHandler) GetMySchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.GetScheduleRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AttendanceUsecase.GetSchedule(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *AttendanceHandler) GetEmployeeSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := getIDFromURLParam(r)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	req := model.GetScheduleRequest{}
+	err = bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+	req.UserID = id
+
+	resp, err := h.AttendanceUsecase.GetSchedule(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
@@ -0,0 +1,342 @@
+package attendance
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/golang/mock/gomock"
+)
+
+func Test_CreateShift(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			body:       `{"name": "night", "start_time": "22:00", "end_time": "06:00", "working_hours": 8}`,
+			patch: func() {
+				mockAttendanceUC.EXPECT().CreateShift(gomock.Any(), model.ShiftRequest{
+					Name:         "night",
+					StartTime:    "22:00",
+					EndTime:      "06:00",
+					WorkingHours: 8,
+				}).Return(model.MstShift{ID: 4}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at invalid time",
+			statusCode: http.StatusBadRequest,
+			body:       `{"name": "night", "start_time": "10pm", "end_time": "06:00", "working_hours": 8}`,
+			patch:      func() {},
+		},
+		{
+			name:       "Failed at too many hours",
+			statusCode: http.StatusBadRequest,
+			body:       `{"name": "night", "start_time": "22:00", "end_time": "06:00", "working_hours": 25}`,
+			patch:      func() {},
+		},
+		{
+			name:       "Unauthorized",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"name": "night", "start_time": "22:00", "end_time": "06:00", "working_hours": 8}`,
+			patch: func() {
+				mockAttendanceUC.EXPECT().CreateShift(gomock.Any(), gomock.Any()).
+					Return(model.MstShift{}, commonerr.SetNewUnauthorizedAPICall()).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			req := httptest.NewRequest(http.MethodPost, "/shifts", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			h.CreateShift(w, req)
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.CreateShift expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_CreateWorkPattern(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			body:       `{"name": "two on two off", "shifts": [1, 1, 0, 0]}`,
+			patch: func() {
+				mockAttendanceUC.EXPECT().CreateWorkPattern(gomock.Any(), model.WorkPatternRequest{
+					Name:   "two on two off",
+					Shifts: []int64{1, 1, 0, 0},
+				}).Return(model.WorkPatternResponse{ID: 3}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at missing shifts",
+			statusCode: http.StatusBadRequest,
+			body:       `{"name": "two on two off", "shifts": []}`,
+			patch:      func() {},
+		},
+		{
+			name:       "Failed at negative shift",
+			statusCode: http.StatusBadRequest,
+			body:       `{"name": "two on two off", "shifts": [1, -1]}`,
+			patch:      func() {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			req := httptest.NewRequest(http.MethodPost, "/work-patterns", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			h.CreateWorkPattern(w, req)
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.CreateWorkPattern expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_AssignSchedule(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			body:       `{"user_id": 2, "work_pattern_id": 3, "start_date": "2025-08-01"}`,
+			patch: func() {
+				mockAttendanceUC.EXPECT().AssignSchedule(gomock.Any(), model.AssignScheduleRequest{
+					UserID:        2,
+					WorkPatternID: 3,
+					StartDate:     "2025-08-01",
+				}).Return(model.TrxUserSchedule{ID: 5}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at invalid date",
+			statusCode: http.StatusBadRequest,
+			body:       `{"user_id": 2, "work_pattern_id": 3, "start_date": "01/08/2025"}`,
+			patch:      func() {},
+		},
+		{
+			name:       "Failed",
+			statusCode: http.StatusInternalServerError,
+			body:       `{"user_id": 2, "work_pattern_id": 3, "start_date": "2025-08-01"}`,
+			patch: func() {
+				mockAttendanceUC.EXPECT().AssignSchedule(gomock.Any(), gomock.Any()).
+					Return(model.TrxUserSchedule{}, errFoo).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			req := httptest.NewRequest(http.MethodPost, "/schedules", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			h.AssignSchedule(w, req)
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.AssignSchedule expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_SetRoster(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			body:       `{"user_id": 2, "days": [{"date": "2025-07-26", "shift_id": 4}, {"date": "2025-07-28"}]}`,
+			patch: func() {
+				mockAttendanceUC.EXPECT().SetRoster(gomock.Any(), model.RosterRequest{
+					UserID: 2,
+					Days: []model.RosterDayRequest{
+						{Date: "2025-07-26", ShiftID: 4},
+						{Date: "2025-07-28"},
+					},
+				}).Return([]model.ScheduleDayResponse{}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at missing days",
+			statusCode: http.StatusBadRequest,
+			body:       `{"user_id": 2}`,
+			patch:      func() {},
+		},
+		{
+			name:       "Failed at invalid day",
+			statusCode: http.StatusBadRequest,
+			body:       `{"user_id": 2, "days": [{"date": "next saturday"}]}`,
+			patch:      func() {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			req := httptest.NewRequest(http.MethodPost, "/rosters", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			h.SetRoster(w, req)
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.SetRoster expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_GetMySchedule(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		target     string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			target:     "/me/schedule?start_date=2025-07-21&end_date=2025-07-27",
+			patch: func() {
+				mockAttendanceUC.EXPECT().GetSchedule(gomock.Any(), model.GetScheduleRequest{
+					StartDate: "2025-07-21",
+					EndDate:   "2025-07-27",
+				}).Return(model.GetScheduleResponse{UserID: 2}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at missing end date",
+			statusCode: http.StatusBadRequest,
+			target:     "/me/schedule?start_date=2025-07-21",
+			patch:      func() {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.GetMySchedule(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.GetMySchedule expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_GetEmployeeSchedule(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		id         string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			id:         "2",
+			patch: func() {
+				mockAttendanceUC.EXPECT().GetSchedule(gomock.Any(), model.GetScheduleRequest{
+					UserID:    2,
+					StartDate: "2025-07-21",
+					EndDate:   "2025-07-27",
+				}).Return(model.GetScheduleResponse{UserID: 2}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at invalid id",
+			statusCode: http.StatusBadRequest,
+			id:         "abc",
+			patch:      func() {},
+		},
+		{
+			name:       "Unauthorized",
+			statusCode: http.StatusUnauthorized,
+			id:         "3",
+			patch: func() {
+				mockAttendanceUC.EXPECT().GetSchedule(gomock.Any(), gomock.Any()).
+					Return(model.GetScheduleResponse{}, commonerr.SetNewUnauthorizedAPICall()).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AttendanceHandler{
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.GetEmployeeSchedule(w, newRequestWithID(http.MethodGet, "/users/"+tt.id+"/schedule?start_date=2025-07-21&end_date=2025-07-27", tt.id, nil))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.GetEmployeeSchedule expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"net/http"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/binding"
+	commonwriter "github.com/faisalhardin/employee-payroll-system/pkg/common/writer"
+)
+
+var (
+	bindingBind = binding.Bind
+)
+
+type AuditHandler struct {
+	AuditUsecase usecase.AuditUsecaseRepository
+}
+
+func New(auditHandler *AuditHandler) *AuditHandler {
+	return auditHandler
+}
+
+func (h *AuditHandler) ListAuditLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.ListAuditLogRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.AuditUsecase.ListAuditLogs(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
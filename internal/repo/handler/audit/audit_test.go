@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	mocksusecase "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/_mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+)
+
+var (
+	mockAuditUC *mocksusecase.MockAuditUsecaseRepository
+
+	errFoo = errors.New("err")
+)
+
+func initMocks(t *testing.T) *gomock.Controller {
+	ctrl := gomock.NewController(t)
+	mockAuditUC = mocksusecase.NewMockAuditUsecaseRepository(ctrl)
+
+	return ctrl
+}
+
+func Test_ListAuditLogs(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		target     string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			target:     "/audit-logs?entity=mst_attendance&entity_id=2&start_date=2025-07-01&end_date=2025-07-31&cursor=10",
+			patch: func() {
+				mockAuditUC.EXPECT().ListAuditLogs(gomock.Any(), model.ListAuditLogRequest{
+					CursorPagination: model.CursorPagination{Cursor: 10},
+					Entity:           "mst_attendance",
+					EntityID:         2,
+					StartDate:        "2025-07-01",
+					EndDate:          "2025-07-31",
+				}).Return(model.ListAuditLogResponse{}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed at validation",
+			statusCode: http.StatusBadRequest,
+			target:     "/audit-logs?start_date=21-07-2025",
+			patch:      func() {},
+		},
+		{
+			name:       "Failed at usecase",
+			statusCode: http.StatusInternalServerError,
+			target:     "/audit-logs",
+			patch: func() {
+				mockAuditUC.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).
+					Return(model.ListAuditLogResponse{}, errFoo).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := AuditHandler{
+				AuditUsecase: mockAuditUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.ListAuditLogs(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.ListAuditLogs expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
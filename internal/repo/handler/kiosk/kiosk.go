@@ -0,0 +1,105 @@
+package kiosk
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/binding"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	commonwriter "github.com/faisalhardin/employee-payroll-system/pkg/common/writer"
+	"github.com/go-chi/chi/v5"
+)
+
+var (
+	bindingBind = binding.Bind
+)
+
+type KioskHandler struct {
+	KioskUsecase usecase.KioskUsecaseRepository
+}
+
+func New(kioskHandler *KioskHandler) *KioskHandler {
+	return kioskHandler
+}
+
+func (h *KioskHandler) RegisterKiosk(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.RegisterKioskRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.KioskUsecase.RegisterKiosk(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *KioskHandler) ListKiosks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := h.KioskUsecase.ListKiosks(ctx)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *KioskHandler) DeactivateKiosk(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		commonwriter.SetError(ctx, w, commonerr.SetNewBadRequest("invalid", "id must be a positive number"))
+		return
+	}
+
+	resp, err := h.KioskUsecase.DeactivateKiosk(ctx, id)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *KioskHandler) SignIn(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.KioskSignInRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	token, err := h.KioskUsecase.SignIn(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, token)
+}
+
+func (h *KioskHandler) IssueNonce(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := h.KioskUsecase.IssueNonce(ctx)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
@@ -0,0 +1,227 @@
+package kiosk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	mocksusecase "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/_mocks"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+)
+
+var (
+	mockKioskUC *mocksusecase.MockKioskUsecaseRepository
+
+	errFoo = errors.New("err")
+)
+
+func initMocks(t *testing.T) *gomock.Controller {
+	ctrl := gomock.NewController(t)
+	mockKioskUC = mocksusecase.NewMockKioskUsecaseRepository(ctrl)
+
+	return ctrl
+}
+
+func newJSONRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func Test_RegisterKiosk(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			body:       `{"name": "Warehouse gate", "office_name": "HQ"}`,
+			patch: func() {
+				mockKioskUC.EXPECT().RegisterKiosk(gomock.Any(), model.RegisterKioskRequest{Name: "Warehouse gate", OfficeName: "HQ"}).
+					Return(model.RegisterKioskResponse{ID: 3, Name: "Warehouse gate", Secret: "secret"}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Missing name",
+			statusCode: http.StatusBadRequest,
+			body:       `{"office_name": "HQ"}`,
+			patch:      func() {},
+		},
+		{
+			name:       "Unauthorized",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"name": "Warehouse gate"}`,
+			patch: func() {
+				mockKioskUC.EXPECT().RegisterKiosk(gomock.Any(), gomock.Any()).
+					Return(model.RegisterKioskResponse{}, commonerr.SetNewUnauthorizedAPICall()).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := KioskHandler{
+				KioskUsecase: mockKioskUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.RegisterKiosk(w, newJSONRequest(http.MethodPost, "/v1/kiosks", tt.body))
+			if w.Code != tt.statusCode {
+				t.Errorf("handler.RegisterKiosk expected status %v, got %d", tt.statusCode, w.Code)
+			}
+		})
+	}
+}
+
+func Test_DeactivateKiosk(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	newRequest := func(id string) *http.Request {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/kiosks/"+id, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		id         string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			id:         "3",
+			patch: func() {
+				mockKioskUC.EXPECT().DeactivateKiosk(gomock.Any(), int64(3)).
+					Return(model.MstKiosk{ID: 3}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Invalid id",
+			statusCode: http.StatusBadRequest,
+			id:         "abc",
+			patch:      func() {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := KioskHandler{
+				KioskUsecase: mockKioskUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.DeactivateKiosk(w, newRequest(tt.id))
+			if w.Code != tt.statusCode {
+				t.Errorf("handler.DeactivateKiosk expected status %v, got %d", tt.statusCode, w.Code)
+			}
+		})
+	}
+}
+
+func Test_SignIn(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			body:       `{"id": 3, "secret": "secret"}`,
+			patch: func() {
+				mockKioskUC.EXPECT().SignIn(gomock.Any(), model.KioskSignInRequest{ID: 3, Secret: "secret"}).
+					Return("token", nil).Times(1)
+			},
+		},
+		{
+			name:       "Missing secret",
+			statusCode: http.StatusBadRequest,
+			body:       `{"id": 3}`,
+			patch:      func() {},
+		},
+		{
+			name:       "Failed",
+			statusCode: http.StatusInternalServerError,
+			body:       `{"id": 3, "secret": "secret"}`,
+			patch: func() {
+				mockKioskUC.EXPECT().SignIn(gomock.Any(), gomock.Any()).Return("", errFoo).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := KioskHandler{
+				KioskUsecase: mockKioskUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.SignIn(w, newJSONRequest(http.MethodPost, "/kiosk/login", tt.body))
+			if w.Code != tt.statusCode {
+				t.Errorf("handler.SignIn expected status %v, got %d", tt.statusCode, w.Code)
+			}
+		})
+	}
+}
+
+func Test_IssueNonce(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			patch: func() {
+				mockKioskUC.EXPECT().IssueNonce(gomock.Any()).
+					Return(model.KioskNonceResponse{Nonce: "nonce"}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed",
+			statusCode: http.StatusInternalServerError,
+			patch: func() {
+				mockKioskUC.EXPECT().IssueNonce(gomock.Any()).
+					Return(model.KioskNonceResponse{}, errFoo).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := KioskHandler{
+				KioskUsecase: mockKioskUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.IssueNonce(w, httptest.NewRequest(http.MethodGet, "/kiosk/v1/nonce", nil))
+			if w.Code != tt.statusCode {
+				t.Errorf("handler.IssueNonce expected status %v, got %d", tt.statusCode, w.Code)
+			}
+		})
+	}
+}
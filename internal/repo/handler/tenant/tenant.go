@@ -0,0 +1,53 @@
+package tenant
+
+import (
+	"net/http"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/binding"
+	commonwriter "github.com/faisalhardin/employee-payroll-system/pkg/common/writer"
+)
+
+var (
+	bindingBind = binding.Bind
+)
+
+type TenantHandler struct {
+	TenantUsecase usecase.TenantUsecaseRepository
+}
+
+func New(tenantHandler *TenantHandler) *TenantHandler {
+	return tenantHandler
+}
+
+func (h *TenantHandler) GetTenant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := h.TenantUsecase.GetTenant(ctx)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *TenantHandler) UpdatePayrollPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := model.PayrollPolicyRequest{}
+	err := bindingBind(r, &req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.TenantUsecase.UpdatePayrollPolicy(ctx, req)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
@@ -0,0 +1,122 @@
+package tenant
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	mocksusecase "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/_mocks"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+)
+
+var (
+	mockTenantUC *mocksusecase.MockTenantUsecaseRepository
+
+	errFoo = errors.New("err")
+)
+
+func initMocks(t *testing.T) *gomock.Controller {
+	ctrl := gomock.NewController(t)
+	mockTenantUC = mocksusecase.NewMockTenantUsecaseRepository(ctrl)
+
+	return ctrl
+}
+
+func Test_GetTenant(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			patch: func() {
+				mockTenantUC.EXPECT().GetTenant(gomock.Any()).
+					Return(model.MstTenant{ID: 2, Code: "retail"}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Failed",
+			statusCode: http.StatusInternalServerError,
+			patch: func() {
+				mockTenantUC.EXPECT().GetTenant(gomock.Any()).
+					Return(model.MstTenant{}, errFoo).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New(&TenantHandler{
+				TenantUsecase: mockTenantUC,
+			})
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.GetTenant(w, httptest.NewRequest(http.MethodGet, "/v1/tenant", nil))
+			if w.Code != tt.statusCode {
+				t.Errorf("handler.GetTenant expected status %v, got %d", tt.statusCode, w.Code)
+			}
+		})
+	}
+}
+
+func Test_UpdatePayrollPolicy(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			body:       `{"overtime_multiplier": 3, "max_overtime_hours": 4}`,
+			patch: func() {
+				mockTenantUC.EXPECT().UpdatePayrollPolicy(gomock.Any(), model.PayrollPolicyRequest{OvertimeMultiplier: 3, MaxOvertimeHours: 4}).
+					Return(model.MstTenant{ID: 2, OvertimeMultiplier: 3, MaxOvertimeHours: 4}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Overtime cap out of range",
+			statusCode: http.StatusBadRequest,
+			body:       `{"overtime_multiplier": 3, "max_overtime_hours": 25}`,
+			patch:      func() {},
+		},
+		{
+			name:       "Unauthorized",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"overtime_multiplier": 3, "max_overtime_hours": 4}`,
+			patch: func() {
+				mockTenantUC.EXPECT().UpdatePayrollPolicy(gomock.Any(), gomock.Any()).
+					Return(model.MstTenant{}, commonerr.SetNewUnauthorizedAPICall()).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New(&TenantHandler{
+				TenantUsecase: mockTenantUC,
+			})
+			tt.patch()
+			req := httptest.NewRequest(http.MethodPut, "/v1/tenant/payroll-policy", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			h.UpdatePayrollPolicy(w, req)
+			if w.Code != tt.statusCode {
+				t.Errorf("handler.UpdatePayrollPolicy expected status %v, got %d", tt.statusCode, w.Code)
+			}
+		})
+	}
+}
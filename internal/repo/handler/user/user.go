@@ -2,11 +2,14 @@ package user
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase"
 	"github.com/faisalhardin/employee-payroll-system/pkg/common/binding"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
 	commonwriter "github.com/faisalhardin/employee-payroll-system/pkg/common/writer"
+	"github.com/go-chi/chi/v5"
 )
 
 var (
@@ -38,3 +41,64 @@ func (h *UserHandler) SignIn(w http.ResponseWriter, r *http.Request) {
 
 	commonwriter.SetOKWithData(ctx, w, token)
 }
+
+func (h *UserHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		commonwriter.SetError(ctx, w, commonerr.SetNewBadRequest("invalid", "id must be a positive number"))
+		return
+	}
+
+	resp, err := h.UserUsecase.UnlockUser(ctx, id)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *UserHandler) ResetUserDevice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		commonwriter.SetError(ctx, w, commonerr.SetNewBadRequest("invalid", "id must be a positive number"))
+		return
+	}
+
+	resp, err := h.UserUsecase.ResetUserDevice(ctx, id)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
+
+func (h *UserHandler) SetManager(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		commonwriter.SetError(ctx, w, commonerr.SetNewBadRequest("invalid", "id must be a positive number"))
+		return
+	}
+
+	request := model.SetManagerRequest{}
+	err = bindingBind(r, &request)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	resp, err := h.UserUsecase.SetManager(ctx, id, request)
+	if err != nil {
+		commonwriter.SetError(ctx, w, err)
+		return
+	}
+
+	commonwriter.SetOKWithData(ctx, w, resp)
+}
@@ -2,13 +2,17 @@ package user
 
 import (
 	"bytes"
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"testing"
 
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
 	mocksusecase "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/_mocks"
 	"github.com/faisalhardin/employee-payroll-system/pkg/common/binding"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/go-chi/chi/v5"
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 )
@@ -151,3 +155,192 @@ func Test_SignIn(t *testing.T) {
 		})
 	}
 }
+
+func Test_UnlockUser(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	newRequest := func(id string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/v1/users/"+id+"/unlock", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		id         string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			id:         "2",
+			patch: func() {
+				mockUserUC.EXPECT().UnlockUser(gomock.Any(), int64(2)).
+					Return(model.UnlockUserResponse{ID: 2, Username: "employee_001"}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Invalid id",
+			statusCode: http.StatusBadRequest,
+			id:         "abc",
+			patch:      func() {},
+		},
+		{
+			name:       "Unauthorized",
+			statusCode: http.StatusUnauthorized,
+			id:         "2",
+			patch: func() {
+				mockUserUC.EXPECT().UnlockUser(gomock.Any(), int64(2)).
+					Return(model.UnlockUserResponse{}, commonerr.SetNewUnauthorizedAPICall()).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := UserHandler{
+				UserUsecase: mockUserUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.UnlockUser(w, newRequest(tt.id))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.UnlockUser expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_ResetUserDevice(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	newRequest := func(id string) *http.Request {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/users/"+id+"/device", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		id         string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			id:         "2",
+			patch: func() {
+				mockUserUC.EXPECT().ResetUserDevice(gomock.Any(), int64(2)).
+					Return(model.ResetUserDeviceResponse{ID: 2, Username: "employee_001"}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Invalid id",
+			statusCode: http.StatusBadRequest,
+			id:         "0",
+			patch:      func() {},
+		},
+		{
+			name:       "Unauthorized",
+			statusCode: http.StatusUnauthorized,
+			id:         "2",
+			patch: func() {
+				mockUserUC.EXPECT().ResetUserDevice(gomock.Any(), int64(2)).
+					Return(model.ResetUserDeviceResponse{}, commonerr.SetNewUnauthorizedAPICall()).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := UserHandler{
+				UserUsecase: mockUserUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.ResetUserDevice(w, newRequest(tt.id))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.ResetUserDevice expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func Test_SetManager(t *testing.T) {
+	ctrl := initMocks(t)
+	defer ctrl.Finish()
+
+	newRequest := func(id, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPut, "/v1/users/"+id+"/manager", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		id         string
+		body       string
+		patch      func()
+	}{
+		{
+			name:       "Successful",
+			statusCode: http.StatusOK,
+			id:         "2",
+			body:       `{"manager_id": 3}`,
+			patch: func() {
+				mockUserUC.EXPECT().SetManager(gomock.Any(), int64(2), model.SetManagerRequest{ManagerID: 3}).
+					Return(model.SetManagerResponse{ID: 2, Username: "employee_001", ManagerID: 3}, nil).Times(1)
+			},
+		},
+		{
+			name:       "Invalid id",
+			statusCode: http.StatusBadRequest,
+			id:         "abc",
+			body:       `{"manager_id": 3}`,
+			patch:      func() {},
+		},
+		{
+			name:       "Invalid manager id",
+			statusCode: http.StatusBadRequest,
+			id:         "2",
+			body:       `{"manager_id": -1}`,
+			patch:      func() {},
+		},
+		{
+			name:       "Unauthorized",
+			statusCode: http.StatusUnauthorized,
+			id:         "2",
+			body:       `{"manager_id": 3}`,
+			patch: func() {
+				mockUserUC.EXPECT().SetManager(gomock.Any(), int64(2), model.SetManagerRequest{ManagerID: 3}).
+					Return(model.SetManagerResponse{}, commonerr.SetNewUnauthorizedAPICall()).Times(1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := UserHandler{
+				UserUsecase: mockUserUC,
+			}
+			tt.patch()
+			w := httptest.NewRecorder()
+			h.SetManager(w, newRequest(tt.id, tt.body))
+			resp := w.Result()
+			if resp.StatusCode != tt.statusCode {
+				t.Errorf("handler.SetManager expected status %v, got %d", tt.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
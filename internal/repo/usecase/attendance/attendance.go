@@ -5,33 +5,52 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/faisalhardin/employee-payroll-system/internal/config"
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	authrepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/auth"
 	attendancerepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/attendance"
+	kioskrepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/kiosk"
+	organizationrepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/organization"
+	schedulerepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/schedule"
+	tenantrepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/tenant"
 	userdbrepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/user"
 	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
 	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
 	"github.com/pkg/errors"
 )
 
-const (
-	MaxOvertimeHours = 3
-)
-
 var (
-	authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+	authGetUserDetailFromCtx  = auth.GetUserDetailFromCtx
+	authGetKioskDetailFromCtx = auth.GetKioskDetailFromCtx
+	timeNow                   = time.Now
 )
 
 type Usecase struct {
-	AttendanceDB attendancerepo.AttendanceRepository
-	UserDB       userdbrepo.UserRepository
+	Cfg            config.Attendance
+	AttendanceDB   attendancerepo.AttendanceRepository
+	UserDB         userdbrepo.UserRepository
+	KioskDB        kioskrepo.KioskRepository
+	ScheduleDB     schedulerepo.ScheduleRepository
+	TenantDB       tenantrepo.TenantRepository
+	OrganizationDB organizationrepo.OrganizationRepository
+	AuthRepo       authrepo.Authenticator
 }
 
 func New(u Usecase) *Usecase {
 	return &u
 }
 
-func (u *Usecase) TapIn(ctx context.Context, tapInRequest model.MstAttendance) (resp model.TapInResponse, err error) {
+// TapIn records today's attendance of the employee. The location is checked
+// against the configured offices and, with device binding on, the device
+// against the one bound to the employee. Tapping in again returns the first
+// tap in of the day.
+func (u *Usecase) TapIn(ctx context.Context, tapInRequest model.TapInRequest) (resp model.TapInResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.TapIn")
+	defer func() { tracing.End(span, err) }()
+	ctx = xormlib.WithPrimary(ctx)
 
 	user, found := authGetUserDetailFromCtx(ctx)
 	if !found {
@@ -39,53 +58,103 @@ func (u *Usecase) TapIn(ctx context.Context, tapInRequest model.MstAttendance) (
 		return
 	}
 
-	if tapInRequest.AttendanceDate.IsZero() {
-		tapInRequest.AttendanceDate = time.Now()
+	if (tapInRequest.Latitude == nil) != (tapInRequest.Longitude == nil) {
+		err = commonerr.SetNewBadRequest("invalid", "latitude and longitude must be sent together")
+		return
 	}
+
 	mstAttendace := &model.MstAttendance{
 		IDMstUser:      user.ID,
-		AttendanceDate: tapInRequest.AttendanceDate,
+		AttendanceDate: timeNow(),
+		DeviceID:       tapInRequest.DeviceID,
+		Source:         constant.AttendanceSourceSelf,
 		CreatedBy: sql.NullInt64{
 			Int64: user.ID,
 			Valid: true,
 		},
 	}
 
-	if isWeekend(mstAttendace.AttendanceDate) {
-		err = commonerr.SetNewBadRequest("invalid", "cannot tap in on weekend")
+	return u.tapIn(ctx, mstAttendace, func() error {
+		employee, err := u.UserDB.GetUserByID(ctx, user.ID)
+		if err != nil {
+			return errors.Wrap(err, "Usecase.TapIn")
+		}
+		if employee.ID == 0 {
+			return errors.Wrap(errors.New("user not found"), "Usecase.TapIn")
+		}
+
+		err = u.checkDevice(ctx, employee, tapInRequest.DeviceID)
+		if err != nil {
+			return err
+		}
+		return u.locate(mstAttendace, employee.WorkArrangement, tapInRequest)
+	})
+}
+
+// tapIn records the attendance unless the employee already tapped in that
+// day, in which case the first tap in is returned. The attendance is dated on
+// the day of the shift, the day before for a night shift tapped in after
+// midnight. check runs right before recording and can still refuse the tap
+// in.
+func (u *Usecase) tapIn(ctx context.Context, attendance *model.MstAttendance, check func() error) (resp model.TapInResponse, err error) {
+	tappedAt := attendance.AttendanceDate
+	workday, working, err := u.scheduledWorkday(ctx, attendance.IDMstUser, tappedAt)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.TapIn")
+		return
+	}
+	if !working {
+		err = commonerr.SetNewBadRequest("invalid", "not scheduled to work on this day")
 		return
 	}
+	attendance.AttendanceDate = workday
 
-	existingAttendance, err := u.AttendanceDB.GetAttendance(ctx, *mstAttendace)
+	existingAttendance, err := u.AttendanceDB.GetAttendance(ctx, *attendance)
 	if err != nil {
 		err = errors.Wrap(err, "Usecase.TapIn")
 		return
 	}
 
 	if !existingAttendance.CreatedAt.IsZero() {
-		resp = model.TapInResponse{
-			AttendanceDate: existingAttendance.CreatedAt,
-		}
+		resp = toTapInResponse(existingAttendance.CreatedAt, existingAttendance)
 		return
 	}
-	err = u.AttendanceDB.RecordAttendance(ctx, mstAttendace)
+
+	if check != nil {
+		err = check()
+		if err != nil {
+			return
+		}
+	}
+
+	err = u.AttendanceDB.RecordAttendance(ctx, attendance)
 	if err != nil {
 		err = errors.Wrap(err, "Usecase.TapIn")
 		return
 	}
 
-	resp = model.TapInResponse{
-		AttendanceDate: mstAttendace.AttendanceDate,
-	}
+	resp = toTapInResponse(tappedAt, *attendance)
 	return
 }
 
+func toTapInResponse(attendanceDate time.Time, attendance model.MstAttendance) model.TapInResponse {
+	return model.TapInResponse{
+		AttendanceDate: attendanceDate,
+		LocationStatus: attendance.LocationStatus,
+		OfficeName:     attendance.OfficeName,
+		Flagged:        isFlagged(attendance.LocationStatus),
+	}
+}
+
 func isWeekend(t time.Time) bool {
 	weekday := t.Weekday()
 	return weekday == time.Saturday || weekday == time.Sunday
 }
 
 func (u *Usecase) CreatePayrollPeriod(ctx context.Context, payrollPeriodRequest model.PayrollPeriodRequest) (resp model.PayrollPeriodResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.CreatePayrollPeriod")
+	defer func() { tracing.End(span, err) }()
+	ctx = xormlib.WithPrimary(ctx)
 
 	user, found := authGetUserDetailFromCtx(ctx)
 	if !found {
@@ -107,30 +176,42 @@ func (u *Usecase) CreatePayrollPeriod(ctx context.Context, payrollPeriodRequest
 		},
 	}
 
-	err = u.AttendanceDB.CreatePayrollPeriod(ctx, mstPayrollPeriod)
+	err = u.checkPayrollPeriodSchedule(ctx, *mstPayrollPeriod)
 	if err != nil {
 		err = errors.Wrap(err, "Usecase.CreatePayrollPeriod")
 		return
 	}
 
-	resp = model.PayrollPeriodResponse{
-		ID:        mstPayrollPeriod.ID,
-		StartDate: mstPayrollPeriod.StartDate,
-		EndDate:   mstPayrollPeriod.EndDate,
+	err = u.AttendanceDB.CreatePayrollPeriod(ctx, mstPayrollPeriod)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.CreatePayrollPeriod")
+		return
 	}
+
+	resp = toPayrollPeriodResponse(*mstPayrollPeriod)
 	return
 }
 
 func (u *Usecase) SubmitOvertime(ctx context.Context, overtimeRequest model.SubmitOvertimeRequest) (resp model.SubmitOvertimeResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.SubmitOvertime")
+	defer func() { tracing.End(span, err) }()
+	ctx = xormlib.WithPrimary(ctx)
+
 	user, found := authGetUserDetailFromCtx(ctx)
 	if !found {
 		err = errors.Wrap(errors.New("forbidden"), "Usecase.CreatePayrollPeriod")
 		return
 	}
 
-	if !isWeekend(overtimeRequest.OvertimeDate) {
+	working, err := u.isScheduledToWork(ctx, user.ID, overtimeRequest.OvertimeDate)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.SubmitOvertime")
+		return
+	}
+
+	if working {
 
-		// check if user attended on the overtime date during business days
+		// check if user attended on the overtime date of a scheduled shift
 		mstAttendance, e := u.AttendanceDB.GetAttendance(ctx, model.MstAttendance{
 			IDMstUser:      user.ID,
 			AttendanceDate: overtimeRequest.OvertimeDate,
@@ -146,8 +227,15 @@ func (u *Usecase) SubmitOvertime(ctx context.Context, overtimeRequest model.Subm
 		}
 	}
 
-	if overtimeRequest.Hours > MaxOvertimeHours {
-		overtimeRequest.Hours = MaxOvertimeHours
+	// overtime is capped by the payroll policy of the tenant
+	policy, err := u.payrollPolicy(ctx)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.SubmitOvertime")
+		return
+	}
+
+	if overtimeRequest.Hours > policy.MaxOvertimeHours {
+		overtimeRequest.Hours = policy.MaxOvertimeHours
 	}
 
 	overtime := &model.TrxOvertime{
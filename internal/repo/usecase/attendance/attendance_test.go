@@ -6,9 +6,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/faisalhardin/employee-payroll-system/internal/config"
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	mockrepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/_mocks"
 	mockattendancedb "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/_mocks/attendance"
+	mockkioskdb "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/_mocks/kiosk"
+	mockorganizationdb "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/_mocks/organization"
+	mockscheduledb "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/_mocks/schedule"
+	mocktenantdb "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/_mocks/tenant"
 	mockuserdb "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/_mocks/user"
 	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
 	"github.com/golang/mock/gomock"
@@ -17,32 +23,71 @@ import (
 )
 
 var (
-	mockAttendanceRepo *mockattendancedb.MockAttendanceRepository
-	mockUserRepo       *mockuserdb.MockUserRepository
+	mockAttendanceRepo   *mockattendancedb.MockAttendanceRepository
+	mockUserRepo         *mockuserdb.MockUserRepository
+	mockKioskRepo        *mockkioskdb.MockKioskRepository
+	mockScheduleRepo     *mockscheduledb.MockScheduleRepository
+	mockTenantRepo       *mocktenantdb.MockTenantRepository
+	mockOrganizationRepo *mockorganizationdb.MockOrganizationRepository
+	mockAuthRepo         *mockrepo.MockAuthenticator
 
 	errFoo = errors.New("errFoo")
+
+	testPayrollPolicy = model.MstTenant{
+		ID:                 1,
+		Code:               "default",
+		OvertimeMultiplier: 2,
+		MaxOvertimeHours:   4,
+	}
 )
 
 func initMock(t *testing.T) *gomock.Controller {
 	ctrl := gomock.NewController(t)
 	mockAttendanceRepo = mockattendancedb.NewMockAttendanceRepository(ctrl)
 	mockUserRepo = mockuserdb.NewMockUserRepository(ctrl)
+	mockKioskRepo = mockkioskdb.NewMockKioskRepository(ctrl)
+	mockScheduleRepo = mockscheduledb.NewMockScheduleRepository(ctrl)
+	mockTenantRepo = mocktenantdb.NewMockTenantRepository(ctrl)
+	mockOrganizationRepo = mockorganizationdb.NewMockOrganizationRepository(ctrl)
+	mockAuthRepo = mockrepo.NewMockAuthenticator(ctrl)
 
 	return ctrl
 }
 
+// expectStandardSchedule expects the calendar of employees without a
+// schedule or roster day, who work the standard week
+func expectStandardSchedule() {
+	mockScheduleRepo.EXPECT().ListSchedules(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+	mockScheduleRepo.EXPECT().ListRosters(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+}
+
+// expectPayrollPolicy expects the payroll policy of the tenant to be loaded
+func expectPayrollPolicy() {
+	mockTenantRepo.EXPECT().GetTenant(gomock.Any()).Return(testPayrollPolicy, nil).Times(1)
+}
+
 func Test_TapIn(t *testing.T) {
 	ctrl := initMock(t)
 	defer ctrl.Finish()
 
 	mockNow, _ := time.Parse("2006-01-02", "2025-07-21")    //monday
 	mockSunday, _ := time.Parse("2006-01-02", "2025-07-20") //sunday
+	officeLatitude, officeLongitude := -6.175392, 106.827153
+	homeLatitude, homeLongitude := -6.194911, 106.823102
+	cfg := config.Attendance{
+		OfficeLocations: []config.OfficeLocation{
+			{Name: "HQ", Latitude: officeLatitude, Longitude: officeLongitude, RadiusInMeters: 150},
+		},
+		BindDevice: true,
+	}
 	type args struct {
 		ctx context.Context
-		req model.MstAttendance
+		now time.Time
+		req model.TapInRequest
 	}
 	tests := []struct {
 		name    string
+		cfg     config.Attendance
 		args    args
 		wantErr bool
 		want    model.TapInResponse
@@ -53,17 +98,18 @@ func Test_TapIn(t *testing.T) {
 			name: "Successful first tap in",
 			args: args{
 				ctx: context.Background(),
-				req: model.MstAttendance{
-					AttendanceDate: mockNow,
-				},
+				now: mockNow,
 			},
 			want: model.TapInResponse{
 				AttendanceDate: mockNow,
 			},
 			wantErr: false,
 			patch: func() {
+				expectStandardSchedule()
+
 				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
 					return auth.UserJWTPayload{
+						ID:       1,
 						Username: "username",
 					}, true
 				}
@@ -72,6 +118,10 @@ func Test_TapIn(t *testing.T) {
 					GetAttendance(gomock.Any(), gomock.Any()).
 					Return(model.MstAttendance{}, nil).Times(1)
 
+				mockUserRepo.EXPECT().
+					GetUserByID(gomock.Any(), int64(1)).
+					Return(model.MstUser{ID: 1, WorkArrangement: constant.WorkArrangementOffice}, nil).Times(1)
+
 				mockAttendanceRepo.EXPECT().
 					RecordAttendance(gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
@@ -82,22 +132,35 @@ func Test_TapIn(t *testing.T) {
 		},
 		{
 			name: "Successful second tap in",
+			cfg:  cfg,
 			args: args{
 				ctx: context.Background(),
-				req: model.MstAttendance{
-					AttendanceDate: mockSunday,
-				},
+				now: mockNow,
 			},
 			want: model.TapInResponse{
-				AttendanceDate: time.Time{},
+				AttendanceDate: mockNow.Add(8 * time.Hour),
+				LocationStatus: constant.LocationStatusOutside,
+				OfficeName:     "HQ",
+				Flagged:        true,
 			},
-			wantErr: true,
+			wantErr: false,
 			patch: func() {
+				expectStandardSchedule()
+
 				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
 					return auth.UserJWTPayload{
+						ID:       1,
 						Username: "username",
 					}, true
 				}
+
+				mockAttendanceRepo.EXPECT().
+					GetAttendance(gomock.Any(), gomock.Any()).
+					Return(model.MstAttendance{
+						CreatedAt:      mockNow.Add(8 * time.Hour),
+						LocationStatus: constant.LocationStatusOutside,
+						OfficeName:     "HQ",
+					}, nil).Times(1)
 			},
 			unpatch: func() {
 				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
@@ -107,25 +170,59 @@ func Test_TapIn(t *testing.T) {
 			name: "Tap in during weekend",
 			args: args{
 				ctx: context.Background(),
-				req: model.MstAttendance{
-					AttendanceDate: mockNow,
-				},
+				now: mockSunday,
 			},
 			want: model.TapInResponse{
-				AttendanceDate: mockNow,
+				AttendanceDate: time.Time{},
 			},
-			wantErr: false,
+			wantErr: true,
 			patch: func() {
+				expectStandardSchedule()
+
 				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
 					return auth.UserJWTPayload{
 						Username: "username",
 					}, true
 				}
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name: "Successful tap in on a rostered weekend",
+			args: args{
+				ctx: context.Background(),
+				now: mockSunday,
+			},
+			want: model.TapInResponse{
+				AttendanceDate: mockSunday,
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 1}, true
+				}
+
+				mockScheduleRepo.EXPECT().
+					ListSchedules(gomock.Any(), gomock.Any()).
+					Return(nil, nil).Times(1)
+
+				mockScheduleRepo.EXPECT().
+					ListRosters(gomock.Any(), model.ListScheduleParams{IDsMstUser: []int64{1}, StartDate: mockSunday.AddDate(0, 0, -1), EndDate: mockSunday}).
+					Return([]model.TrxUserRoster{{IDMstUser: 1, RosterDate: mockSunday, IDMstShift: sql.NullInt64{Int64: 4, Valid: true}}}, nil).Times(1)
+
+				mockScheduleRepo.EXPECT().
+					ListShifts(gomock.Any()).
+					Return([]model.MstShift{{ID: 4, Name: "weekend", WorkingHours: 8}}, nil).Times(1)
 
 				mockAttendanceRepo.EXPECT().
 					GetAttendance(gomock.Any(), gomock.Any()).
 					Return(model.MstAttendance{}, nil).Times(1)
 
+				mockUserRepo.EXPECT().
+					GetUserByID(gomock.Any(), int64(1)).
+					Return(model.MstUser{ID: 1, WorkArrangement: constant.WorkArrangementOffice}, nil).Times(1)
+
 				mockAttendanceRepo.EXPECT().
 					RecordAttendance(gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
@@ -135,18 +232,296 @@ func Test_TapIn(t *testing.T) {
 			},
 		},
 		{
-			name: "error during submission",
+			name: "Night shift tap in after midnight counts for the night before",
+			args: args{
+				ctx: context.Background(),
+				now: mockNow.Add(2 * time.Hour),
+			},
+			want: model.TapInResponse{
+				AttendanceDate: mockNow.Add(2 * time.Hour),
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 1}, true
+				}
+
+				mockScheduleRepo.EXPECT().
+					ListSchedules(gomock.Any(), gomock.Any()).
+					Return(nil, nil).Times(1)
+
+				mockScheduleRepo.EXPECT().
+					ListRosters(gomock.Any(), gomock.Any()).
+					Return([]model.TrxUserRoster{{IDMstUser: 1, RosterDate: mockSunday, IDMstShift: sql.NullInt64{Int64: 5, Valid: true}}}, nil).Times(1)
+
+				mockScheduleRepo.EXPECT().
+					ListShifts(gomock.Any()).
+					Return([]model.MstShift{{ID: 5, Name: "night", StartTime: "22:00", EndTime: "06:00", WorkingHours: 8}}, nil).Times(1)
+
+				mockAttendanceRepo.EXPECT().
+					GetAttendance(gomock.Any(), model.MstAttendance{
+						IDMstUser:      1,
+						AttendanceDate: mockSunday.Add(2 * time.Hour),
+						Source:         constant.AttendanceSourceSelf,
+						CreatedBy:      sql.NullInt64{Int64: 1, Valid: true},
+					}).
+					Return(model.MstAttendance{}, nil).Times(1)
+
+				mockUserRepo.EXPECT().
+					GetUserByID(gomock.Any(), int64(1)).
+					Return(model.MstUser{ID: 1, WorkArrangement: constant.WorkArrangementOffice}, nil).Times(1)
+
+				mockAttendanceRepo.EXPECT().
+					RecordAttendance(gomock.Any(), gomock.Any()).
+					Return(nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name: "Tap in on a day off of the work pattern",
 			args: args{
 				ctx: context.Background(),
-				req: model.MstAttendance{
-					AttendanceDate: mockNow,
+				now: mockNow,
+			},
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 1}, true
+				}
+
+				mockScheduleRepo.EXPECT().
+					ListSchedules(gomock.Any(), gomock.Any()).
+					Return([]model.TrxUserSchedule{{IDMstUser: 1, IDMstWorkPattern: 2, StartDate: mockNow}}, nil).Times(1)
+
+				mockScheduleRepo.EXPECT().
+					ListRosters(gomock.Any(), gomock.Any()).
+					Return(nil, nil).Times(1)
+
+				mockScheduleRepo.EXPECT().
+					ListWorkPatterns(gomock.Any(), []int64{2}).
+					Return([]model.MstWorkPattern{{ID: 2, CycleDays: 2}}, nil).Times(1)
+
+				mockScheduleRepo.EXPECT().
+					ListWorkPatternDays(gomock.Any(), []int64{2}).
+					Return([]model.DtlWorkPatternDay{{IDMstWorkPattern: 2, DayNumber: 1, IDMstShift: 4}}, nil).Times(1)
+
+				mockScheduleRepo.EXPECT().
+					ListShifts(gomock.Any()).
+					Return([]model.MstShift{{ID: 4, Name: "night", WorkingHours: 10}}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name: "error when loading the schedule",
+			args: args{
+				ctx: context.Background(),
+				now: mockNow,
+			},
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 1}, true
+				}
+
+				mockScheduleRepo.EXPECT().
+					ListSchedules(gomock.Any(), gomock.Any()).
+					Return(nil, errFoo).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name: "Successful tap in inside the office from the bound device",
+			cfg:  cfg,
+			args: args{
+				ctx: context.Background(),
+				now: mockNow,
+				req: model.TapInRequest{
+					Latitude:  &officeLatitude,
+					Longitude: &officeLongitude,
+					DeviceID:  "device-1",
+				},
+			},
+			want: model.TapInResponse{
+				AttendanceDate: mockNow,
+				LocationStatus: constant.LocationStatusInside,
+				OfficeName:     "HQ",
+			},
+			patch: func() {
+				expectStandardSchedule()
+
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 1}, true
+				}
+
+				mockAttendanceRepo.EXPECT().
+					GetAttendance(gomock.Any(), gomock.Any()).
+					Return(model.MstAttendance{}, nil).Times(1)
+
+				mockUserRepo.EXPECT().
+					GetUserByID(gomock.Any(), int64(1)).
+					Return(model.MstUser{ID: 1, WorkArrangement: constant.WorkArrangementOffice, DeviceID: "device-1"}, nil).Times(1)
+
+				mockAttendanceRepo.EXPECT().
+					RecordAttendance(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, attendance *model.MstAttendance) error {
+						assert.Equal(t, "device-1", attendance.DeviceID)
+						assert.Equal(t, officeLatitude, attendance.Latitude.Float64)
+						assert.Equal(t, int64(0), attendance.DistanceInMeters.Int64)
+						return nil
+					}).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name: "Successful hybrid tap in from home is flagged and binds the device",
+			cfg:  cfg,
+			args: args{
+				ctx: context.Background(),
+				now: mockNow,
+				req: model.TapInRequest{
+					Latitude:  &homeLatitude,
+					Longitude: &homeLongitude,
+					DeviceID:  "device-1",
+				},
+			},
+			want: model.TapInResponse{
+				AttendanceDate: mockNow,
+				LocationStatus: constant.LocationStatusOutside,
+				OfficeName:     "HQ",
+				Flagged:        true,
+			},
+			patch: func() {
+				expectStandardSchedule()
+
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 1}, true
+				}
+
+				mockAttendanceRepo.EXPECT().
+					GetAttendance(gomock.Any(), gomock.Any()).
+					Return(model.MstAttendance{}, nil).Times(1)
+
+				mockUserRepo.EXPECT().
+					GetUserByID(gomock.Any(), int64(1)).
+					Return(model.MstUser{ID: 1, WorkArrangement: constant.WorkArrangementHybrid}, nil).Times(1)
+
+				mockUserRepo.EXPECT().
+					BindDevice(gomock.Any(), int64(1), "device-1").
+					Return(true, nil).Times(1)
+
+				mockAttendanceRepo.EXPECT().
+					RecordAttendance(gomock.Any(), gomock.Any()).
+					Return(nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name: "error office tap in from home",
+			cfg:  cfg,
+			args: args{
+				ctx: context.Background(),
+				now: mockNow,
+				req: model.TapInRequest{
+					Latitude:  &homeLatitude,
+					Longitude: &homeLongitude,
+					DeviceID:  "device-1",
 				},
 			},
+			wantErr: true,
+			patch: func() {
+				expectStandardSchedule()
+
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 1}, true
+				}
+
+				mockAttendanceRepo.EXPECT().
+					GetAttendance(gomock.Any(), gomock.Any()).
+					Return(model.MstAttendance{}, nil).Times(1)
+
+				mockUserRepo.EXPECT().
+					GetUserByID(gomock.Any(), int64(1)).
+					Return(model.MstUser{ID: 1, WorkArrangement: constant.WorkArrangementOffice, DeviceID: "device-1"}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name: "error tap in from another device",
+			cfg:  cfg,
+			args: args{
+				ctx: context.Background(),
+				now: mockNow,
+				req: model.TapInRequest{
+					Latitude:  &officeLatitude,
+					Longitude: &officeLongitude,
+					DeviceID:  "device-2",
+				},
+			},
+			wantErr: true,
+			patch: func() {
+				expectStandardSchedule()
+
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 1}, true
+				}
+
+				mockAttendanceRepo.EXPECT().
+					GetAttendance(gomock.Any(), gomock.Any()).
+					Return(model.MstAttendance{}, nil).Times(1)
+
+				mockUserRepo.EXPECT().
+					GetUserByID(gomock.Any(), int64(1)).
+					Return(model.MstUser{ID: 1, WorkArrangement: constant.WorkArrangementOffice, DeviceID: "device-1"}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name: "error latitude without longitude",
+			cfg:  cfg,
+			args: args{
+				ctx: context.Background(),
+				now: mockNow,
+				req: model.TapInRequest{
+					Latitude: &officeLatitude,
+				},
+			},
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 1}, true
+				}
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name: "error during submission",
+			args: args{
+				ctx: context.Background(),
+				now: mockNow,
+			},
 			want:    model.TapInResponse{},
 			wantErr: true,
 			patch: func() {
+				expectStandardSchedule()
+
 				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
 					return auth.UserJWTPayload{
+						ID:       1,
 						Username: "username",
 					}, true
 				}
@@ -155,6 +530,10 @@ func Test_TapIn(t *testing.T) {
 					GetAttendance(gomock.Any(), gomock.Any()).
 					Return(model.MstAttendance{}, nil).Times(1)
 
+				mockUserRepo.EXPECT().
+					GetUserByID(gomock.Any(), int64(1)).
+					Return(model.MstUser{ID: 1}, nil).Times(1)
+
 				mockAttendanceRepo.EXPECT().
 					RecordAttendance(gomock.Any(), gomock.Any()).
 					Return(errFoo).Times(1)
@@ -167,13 +546,13 @@ func Test_TapIn(t *testing.T) {
 			name: "error when GetAttendance",
 			args: args{
 				ctx: context.Background(),
-				req: model.MstAttendance{
-					AttendanceDate: mockNow,
-				},
+				now: mockNow,
 			},
 			want:    model.TapInResponse{},
 			wantErr: true,
 			patch: func() {
+				expectStandardSchedule()
+
 				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
 					return auth.UserJWTPayload{
 						Username: "username",
@@ -188,15 +567,47 @@ func Test_TapIn(t *testing.T) {
 				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
 			},
 		},
+		{
+			name: "error when GetUserByID",
+			args: args{
+				ctx: context.Background(),
+				now: mockNow,
+			},
+			want:    model.TapInResponse{},
+			wantErr: true,
+			patch: func() {
+				expectStandardSchedule()
+
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 1}, true
+				}
+
+				mockAttendanceRepo.EXPECT().
+					GetAttendance(gomock.Any(), gomock.Any()).
+					Return(model.MstAttendance{}, nil).Times(1)
+
+				mockUserRepo.EXPECT().
+					GetUserByID(gomock.Any(), int64(1)).
+					Return(model.MstUser{}, errFoo).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			u := Usecase{
+				Cfg:          tt.cfg,
 				AttendanceDB: mockAttendanceRepo,
+				UserDB:       mockUserRepo,
+				ScheduleDB:   mockScheduleRepo,
 			}
 
 			tt.patch()
 			defer tt.unpatch()
+			timeNow = func() time.Time { return tt.args.now }
+			defer func() { timeNow = time.Now }()
 			got, err := u.TapIn(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("TapIn() error = %v, wantErr %v", err, tt.wantErr)
@@ -235,8 +646,19 @@ func Test_CreatePayrollPeriod(t *testing.T) {
 				ID:        1,
 				StartDate: time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC),
 				EndDate:   time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC),
+				Status:    constant.PayrollPeriodStatusOpen,
 			},
 			patch: func() {
+				mockAttendanceRepo.
+					EXPECT().ListPayrollPeriodByParams(gomock.Any(), gomock.Any()).
+					Return([]model.MstPayrollPeriod{
+						{
+							ID:        1,
+							StartDate: time.Date(2025, 5, 20, 0, 0, 0, 0, time.UTC),
+							EndDate:   time.Date(2025, 6, 19, 0, 0, 0, 0, time.UTC),
+						},
+					}, nil).Times(1)
+
 				mockAttendanceRepo.
 					EXPECT().CreatePayrollPeriod(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, period *model.MstPayrollPeriod) error {
@@ -318,6 +740,10 @@ func Test_CreatePayrollPeriod(t *testing.T) {
 					}, true
 				}
 
+				mockAttendanceRepo.EXPECT().
+					ListPayrollPeriodByParams(gomock.Any(), gomock.Any()).
+					Return([]model.MstPayrollPeriod{}, nil)
+
 				mockAttendanceRepo.EXPECT().
 					CreatePayrollPeriod(gomock.Any(), gomock.Any()).
 					Return(errFoo)
@@ -327,6 +753,72 @@ func Test_CreatePayrollPeriod(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error - overlaps existing period",
+			args: args{
+				ctx: context.Background(),
+				payrollPeriodRequest: model.PayrollPeriodRequest{
+					StartDate: time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC),
+					EndDate:   time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			want: model.PayrollPeriodResponse{},
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{
+						ID:   1,
+						Role: constant.UserRoleAdmin,
+					}, true
+				}
+
+				mockAttendanceRepo.EXPECT().
+					ListPayrollPeriodByParams(gomock.Any(), gomock.Any()).
+					Return([]model.MstPayrollPeriod{
+						{
+							ID:        1,
+							StartDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+							EndDate:   time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC),
+						},
+					}, nil)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+			wantErr: true,
+		},
+		{
+			name: "error - leaves a gap after the last period",
+			args: args{
+				ctx: context.Background(),
+				payrollPeriodRequest: model.PayrollPeriodRequest{
+					StartDate: time.Date(2025, 6, 25, 0, 0, 0, 0, time.UTC),
+					EndDate:   time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			want: model.PayrollPeriodResponse{},
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{
+						ID:   1,
+						Role: constant.UserRoleAdmin,
+					}, true
+				}
+
+				mockAttendanceRepo.EXPECT().
+					ListPayrollPeriodByParams(gomock.Any(), gomock.Any()).
+					Return([]model.MstPayrollPeriod{
+						{
+							ID:        1,
+							StartDate: time.Date(2025, 5, 20, 0, 0, 0, 0, time.UTC),
+							EndDate:   time.Date(2025, 6, 19, 0, 0, 0, 0, time.UTC),
+						},
+					}, nil)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -376,6 +868,8 @@ func Test_SubmitOvertime(t *testing.T) {
 				Hours:        2,
 			},
 			patch: func() {
+				expectStandardSchedule()
+
 				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
 					return auth.UserJWTPayload{
 						ID: 123,
@@ -387,6 +881,8 @@ func Test_SubmitOvertime(t *testing.T) {
 					Return(model.MstAttendance{ID: 1}, nil).
 					Times(1)
 
+				expectPayrollPolicy()
+
 				mockAttendanceRepo.
 					EXPECT().GetOvertime(gomock.Any(), gomock.Any()).
 					Return(model.TrxOvertime{}, nil).
@@ -416,12 +912,16 @@ func Test_SubmitOvertime(t *testing.T) {
 				Hours:        3,
 			},
 			patch: func() {
+				expectStandardSchedule()
+
 				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
 					return auth.UserJWTPayload{
 						ID: 123,
 					}, true
 				}
 
+				expectPayrollPolicy()
+
 				mockAttendanceRepo.
 					EXPECT().GetOvertime(gomock.Any(), gomock.Any()).
 					Return(model.TrxOvertime{}, nil).
@@ -468,6 +968,8 @@ func Test_SubmitOvertime(t *testing.T) {
 			},
 			want: model.SubmitOvertimeResponse{},
 			patch: func() {
+				expectStandardSchedule()
+
 				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
 					return auth.UserJWTPayload{
 						ID: 123,
@@ -495,12 +997,16 @@ func Test_SubmitOvertime(t *testing.T) {
 			},
 			want: model.SubmitOvertimeResponse{},
 			patch: func() {
+				expectStandardSchedule()
+
 				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
 					return auth.UserJWTPayload{
 						ID: 123,
 					}, true
 				}
 
+				expectPayrollPolicy()
+
 				mockAttendanceRepo.
 					EXPECT().GetOvertime(gomock.Any(), gomock.Any()).
 					Return(model.TrxOvertime{ID: 1}, nil).
@@ -522,6 +1028,8 @@ func Test_SubmitOvertime(t *testing.T) {
 			},
 			want: model.SubmitOvertimeResponse{},
 			patch: func() {
+				expectStandardSchedule()
+
 				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
 					return auth.UserJWTPayload{
 						ID: 123,
@@ -538,6 +1046,32 @@ func Test_SubmitOvertime(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error during load payroll policy",
+			args: args{
+				ctx: context.Background(),
+				overtimeRequest: model.SubmitOvertimeRequest{
+					OvertimeDate: time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC), // Sunday
+					Hours:        2,
+				},
+			},
+			want: model.SubmitOvertimeResponse{},
+			patch: func() {
+				expectStandardSchedule()
+
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{
+						ID: 123,
+					}, true
+				}
+
+				mockTenantRepo.EXPECT().GetTenant(gomock.Any()).Return(model.MstTenant{}, errFoo).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+			wantErr: true,
+		},
 		{
 			name: "error during get overtime",
 			args: args{
@@ -549,12 +1083,16 @@ func Test_SubmitOvertime(t *testing.T) {
 			},
 			want: model.SubmitOvertimeResponse{},
 			patch: func() {
+				expectStandardSchedule()
+
 				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
 					return auth.UserJWTPayload{
 						ID: 123,
 					}, true
 				}
 
+				expectPayrollPolicy()
+
 				mockAttendanceRepo.
 					EXPECT().GetOvertime(gomock.Any(), gomock.Any()).
 					Return(model.TrxOvertime{}, errFoo).
@@ -576,12 +1114,16 @@ func Test_SubmitOvertime(t *testing.T) {
 			},
 			want: model.SubmitOvertimeResponse{},
 			patch: func() {
+				expectStandardSchedule()
+
 				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
 					return auth.UserJWTPayload{
 						ID: 123,
 					}, true
 				}
 
+				expectPayrollPolicy()
+
 				mockAttendanceRepo.
 					EXPECT().GetOvertime(gomock.Any(), gomock.Any()).
 					Return(model.TrxOvertime{}, nil).
@@ -603,20 +1145,24 @@ func Test_SubmitOvertime(t *testing.T) {
 				ctx: context.Background(),
 				overtimeRequest: model.SubmitOvertimeRequest{
 					OvertimeDate: time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC), // Sunday
-					Hours:        MaxOvertimeHours + 2,
+					Hours:        6,
 				},
 			},
 			want: model.SubmitOvertimeResponse{
 				OvertimeDate: time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC), // Sunday
-				Hours:        MaxOvertimeHours,
+				Hours:        4,
 			},
 			patch: func() {
+				expectStandardSchedule()
+
 				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
 					return auth.UserJWTPayload{
 						ID: 123,
 					}, true
 				}
 
+				expectPayrollPolicy()
+
 				mockAttendanceRepo.
 					EXPECT().GetOvertime(gomock.Any(), gomock.Any()).
 					Return(model.TrxOvertime{}, nil).
@@ -626,7 +1172,7 @@ func Test_SubmitOvertime(t *testing.T) {
 					EXPECT().SubmitOvertime(gomock.Any(), &model.TrxOvertime{
 					UserID:       123,
 					OvertimeDate: time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC), // Sunday
-					Hours:        MaxOvertimeHours,
+					Hours:        4,
 					CreatedBy: sql.NullInt64{
 						Int64: 123,
 						Valid: true,
@@ -646,6 +1192,8 @@ func Test_SubmitOvertime(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			u := Usecase{
 				AttendanceDB: mockAttendanceRepo,
+				ScheduleDB:   mockScheduleRepo,
+				TenantDB:     mockTenantRepo,
 			}
 			tc.patch()
 			defer tc.unpatch()
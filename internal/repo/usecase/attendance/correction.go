@@ -0,0 +1,402 @@
+package attendance
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+)
+
+// RequestAttendanceCorrection asks for a past day the employee forgot to tap
+// in to be added. The request waits for the manager of the employee, or an
+// admin, to review it.
+func (u *Usecase) RequestAttendanceCorrection(ctx context.Context, request model.AttendanceCorrectionRequest) (resp model.AttendanceCorrectionResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.RequestAttendanceCorrection")
+	defer func() { tracing.End(span, err) }()
+	ctx = xormlib.WithPrimary(ctx)
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found {
+		err = errors.Wrap(errors.New("user not found"), "Usecase.RequestAttendanceCorrection")
+		return
+	}
+
+	currTime := timeNow()
+	day, err := time.ParseInLocation(dateFormat, request.AttendanceDate, currTime.Location())
+	if err != nil {
+		err = commonerr.SetNewBadRequest("invalid", "attendance_date must be a date")
+		return
+	}
+	if day.Format(dateFormat) >= currTime.Format(dateFormat) {
+		err = commonerr.SetNewBadRequest("invalid", "corrections are for past days, tap in for today")
+		return
+	}
+
+	working, err := u.isScheduledToWork(ctx, user.ID, day)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.RequestAttendanceCorrection")
+		return
+	}
+	if !working {
+		err = commonerr.SetNewBadRequest("invalid", "not scheduled to work on this day")
+		return
+	}
+
+	_, err = u.checkCorrection(ctx, user.ID, day, constant.AttendanceCorrectionAdd)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.RequestAttendanceCorrection")
+		return
+	}
+
+	pending, err := u.AttendanceDB.ListAttendanceCorrectionByParams(ctx, model.ListAttendanceCorrectionParams{
+		IDsMstUser:     []int64{user.ID},
+		AttendanceDate: day,
+		Status:         constant.AttendanceCorrectionStatusPending,
+	})
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.RequestAttendanceCorrection")
+		return
+	}
+	if len(pending) > 0 {
+		err = commonerr.SetNewBadRequest("already_requested", "a correction of this day is already waiting for review")
+		return
+	}
+
+	correction := &model.TrxAttendanceCorrection{
+		IDMstUser:      user.ID,
+		AttendanceDate: day,
+		Action:         constant.AttendanceCorrectionAdd,
+		Reason:         request.Reason,
+		Status:         constant.AttendanceCorrectionStatusPending,
+		CreatedBy: sql.NullInt64{
+			Int64: user.ID,
+			Valid: true,
+		},
+	}
+	err = u.AttendanceDB.CreateAttendanceCorrection(ctx, correction)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.RequestAttendanceCorrection")
+		return
+	}
+
+	return toAttendanceCorrectionResponse(*correction), nil
+}
+
+// ListMyAttendanceCorrections lists the correction requests of the employee
+func (u *Usecase) ListMyAttendanceCorrections(ctx context.Context, request model.ListAttendanceCorrectionRequest) (resp model.ListAttendanceCorrectionResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.ListMyAttendanceCorrections")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found {
+		err = errors.Wrap(errors.New("user not found"), "Usecase.ListMyAttendanceCorrections")
+		return
+	}
+
+	resp, err = u.listAttendanceCorrections(ctx, request, model.ListAttendanceCorrectionParams{
+		IDsMstUser: []int64{user.ID},
+	})
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListMyAttendanceCorrections")
+	}
+	return
+}
+
+// ListAttendanceCorrections lists the corrections the caller reviews, those
+// of their reports for a manager and all of them for an admin
+func (u *Usecase) ListAttendanceCorrections(ctx context.Context, request model.ListAttendanceCorrectionRequest) (resp model.ListAttendanceCorrectionResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.ListAttendanceCorrections")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found {
+		err = errors.Wrap(errors.New("user not found"), "Usecase.ListAttendanceCorrections")
+		return
+	}
+
+	params := model.ListAttendanceCorrectionParams{}
+	if user.Role != constant.UserRoleAdmin {
+		params.IDMstManager = user.ID
+	}
+	resp, err = u.listAttendanceCorrections(ctx, request, params)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListAttendanceCorrections")
+	}
+	return
+}
+
+func (u *Usecase) listAttendanceCorrections(ctx context.Context, request model.ListAttendanceCorrectionRequest, params model.ListAttendanceCorrectionParams) (resp model.ListAttendanceCorrectionResponse, err error) {
+	limit := request.GetLimit()
+	params.Status = request.Status
+	params.Cursor = request.Cursor
+	params.Limit = limit + 1
+
+	corrections, err := u.AttendanceDB.ListAttendanceCorrectionByParams(ctx, params)
+	if err != nil {
+		return
+	}
+
+	if len(corrections) > limit {
+		corrections = corrections[:limit]
+		resp.NextCursor = corrections[limit-1].ID
+	}
+
+	resp.Corrections = []model.AttendanceCorrectionResponse{}
+	for _, correction := range corrections {
+		resp.Corrections = append(resp.Corrections, toAttendanceCorrectionResponse(correction))
+	}
+	return
+}
+
+// ApproveAttendanceCorrection adds the requested day to the attendance of
+// the employee
+func (u *Usecase) ApproveAttendanceCorrection(ctx context.Context, request model.ReviewAttendanceCorrectionRequest) (resp model.AttendanceCorrectionResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.ApproveAttendanceCorrection")
+	defer func() { tracing.End(span, err) }()
+
+	resp, err = u.reviewAttendanceCorrection(ctx, request, constant.AttendanceCorrectionStatusApproved)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ApproveAttendanceCorrection")
+	}
+	return
+}
+
+func (u *Usecase) RejectAttendanceCorrection(ctx context.Context, request model.ReviewAttendanceCorrectionRequest) (resp model.AttendanceCorrectionResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.RejectAttendanceCorrection")
+	defer func() { tracing.End(span, err) }()
+
+	resp, err = u.reviewAttendanceCorrection(ctx, request, constant.AttendanceCorrectionStatusRejected)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.RejectAttendanceCorrection")
+	}
+	return
+}
+
+// reviewAttendanceCorrection settles a pending request. Only the manager of
+// the employee or an admin reviews it, never the employee.
+func (u *Usecase) reviewAttendanceCorrection(ctx context.Context, request model.ReviewAttendanceCorrectionRequest, status string) (resp model.AttendanceCorrectionResponse, err error) {
+	ctx = xormlib.WithPrimary(ctx)
+
+	reviewer, found := authGetUserDetailFromCtx(ctx)
+	if !found {
+		err = commonerr.SetNewUnauthorizedAPICall()
+		return
+	}
+
+	correction, err := u.AttendanceDB.GetAttendanceCorrection(ctx, request.ID)
+	if err != nil {
+		return
+	}
+	if correction.ID == 0 {
+		err = commonerr.SetNewError(http.StatusNotFound, "not found", "attendance correction not found")
+		return
+	}
+
+	employee, err := u.UserDB.GetUserByID(ctx, correction.IDMstUser)
+	if err != nil {
+		return
+	}
+	isManager := employee.IDMstManager.Valid && employee.IDMstManager.Int64 == reviewer.ID
+	if reviewer.ID == correction.IDMstUser || (reviewer.Role != constant.UserRoleAdmin && !isManager) {
+		err = commonerr.SetNewUnauthorizedAPICall()
+		return
+	}
+
+	if correction.Status != constant.AttendanceCorrectionStatusPending {
+		err = commonerr.SetNewBadRequest("already_reviewed", "the correction has already been reviewed")
+		return
+	}
+
+	if status == constant.AttendanceCorrectionStatusApproved {
+		period, errCheck := u.checkCorrection(ctx, correction.IDMstUser, correction.AttendanceDate, correction.Action)
+		if errCheck != nil {
+			err = errCheck
+			return
+		}
+		err = u.checkPayrollNotRunning(ctx, period)
+		if err != nil {
+			return
+		}
+	}
+
+	correction.Status = status
+	correction.ReviewNote = request.Note
+	correction.ReviewedBy = sql.NullInt64{Int64: reviewer.ID, Valid: true}
+	correction.ReviewedAt = sql.NullTime{Time: timeNow(), Valid: true}
+	correction.UpdatedBy = correction.ReviewedBy
+
+	err = u.resolveAttendanceCorrection(ctx, &correction)
+	if err != nil {
+		return
+	}
+	return toAttendanceCorrectionResponse(correction), nil
+}
+
+// AdjustAttendance adds or removes the attendance of any employee on a day of
+// an open payroll period, keeping the reason as an approved correction.
+// Admin only.
+func (u *Usecase) AdjustAttendance(ctx context.Context, request model.AdjustAttendanceRequest) (resp model.AttendanceCorrectionResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.AdjustAttendance")
+	defer func() { tracing.End(span, err) }()
+	ctx = xormlib.WithPrimary(ctx)
+
+	admin, found := authGetUserDetailFromCtx(ctx)
+	if !found || admin.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.AdjustAttendance")
+		return
+	}
+
+	currTime := timeNow()
+	day, err := time.ParseInLocation(dateFormat, request.AttendanceDate, currTime.Location())
+	if err != nil {
+		err = commonerr.SetNewBadRequest("invalid", "attendance_date must be a date")
+		return
+	}
+	if day.Format(dateFormat) > currTime.Format(dateFormat) {
+		err = commonerr.SetNewBadRequest("invalid", "cannot adjust the attendance of a future day")
+		return
+	}
+
+	employee, err := u.UserDB.GetUserByID(ctx, request.UserID)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.AdjustAttendance")
+		return
+	}
+	if employee.ID == 0 {
+		err = commonerr.SetNewError(http.StatusNotFound, "not found", "user not found")
+		return
+	}
+
+	if request.Action == constant.AttendanceCorrectionAdd {
+		working, errSchedule := u.isScheduledToWork(ctx, employee.ID, day)
+		if errSchedule != nil {
+			err = errors.Wrap(errSchedule, "Usecase.AdjustAttendance")
+			return
+		}
+		if !working {
+			err = commonerr.SetNewBadRequest("invalid", "not scheduled to work on this day")
+			return
+		}
+	}
+
+	period, err := u.checkCorrection(ctx, employee.ID, day, request.Action)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.AdjustAttendance")
+		return
+	}
+	if period.ID == 0 {
+		err = commonerr.SetNewBadRequest("period_not_open", "the day is not in an open payroll period")
+		return
+	}
+	err = u.checkPayrollNotRunning(ctx, period)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.AdjustAttendance")
+		return
+	}
+
+	reviewedBy := sql.NullInt64{Int64: admin.ID, Valid: true}
+	correction := &model.TrxAttendanceCorrection{
+		IDMstUser:      employee.ID,
+		AttendanceDate: day,
+		Action:         request.Action,
+		Reason:         request.Reason,
+		Status:         constant.AttendanceCorrectionStatusApproved,
+		ReviewedBy:     reviewedBy,
+		ReviewedAt:     sql.NullTime{Time: currTime, Valid: true},
+		CreatedBy:      reviewedBy,
+	}
+	err = u.resolveAttendanceCorrection(ctx, correction)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.AdjustAttendance")
+		return
+	}
+	return toAttendanceCorrectionResponse(*correction), nil
+}
+
+// checkCorrection refuses corrections of a processed payroll period, adding a
+// day already attended or removing one not attended. It returns the payroll
+// period of the day, empty when there is none yet.
+func (u *Usecase) checkCorrection(ctx context.Context, userID int64, day time.Time, action string) (period model.MstPayrollPeriod, err error) {
+	period, err = u.AttendanceDB.GetPayrollPeriodByDate(ctx, day)
+	if err != nil {
+		return
+	}
+	if period.PayrollProcessedDate.Valid {
+		err = commonerr.SetNewBadRequest("payroll_processed", "the payroll of this day is already processed")
+		return
+	}
+
+	attendance, err := u.AttendanceDB.GetAttendance(ctx, model.MstAttendance{
+		IDMstUser:      userID,
+		AttendanceDate: day,
+	})
+	if err != nil {
+		return
+	}
+	switch {
+	case action == constant.AttendanceCorrectionAdd && attendance.ID != 0:
+		err = commonerr.SetNewBadRequest("already_attended", "there is already attendance on this day")
+	case action == constant.AttendanceCorrectionRemove && attendance.ID == 0:
+		err = commonerr.SetNewError(http.StatusNotFound, "not found", "there is no attendance on this day")
+	}
+	return
+}
+
+// checkPayrollNotRunning refuses changing the attendance of a period whose
+// payroll is queued or being generated, the job would miss the change
+func (u *Usecase) checkPayrollNotRunning(ctx context.Context, period model.MstPayrollPeriod) error {
+	if period.ID == 0 {
+		return nil
+	}
+	activeJobs, err := u.AttendanceDB.ListPayrollJobByParams(ctx, model.ListPayrollJobParams{
+		IDMstPayrollPeriod: period.ID,
+		Statuses:           []string{constant.PayrollJobStatusQueued, constant.PayrollJobStatusRunning},
+	})
+	if err != nil {
+		return err
+	}
+	if len(activeJobs) > 0 {
+		return commonerr.SetNewBadRequest("payroll_running", "the payroll of this day is being generated, try again once it finishes")
+	}
+	return nil
+}
+
+// resolveAttendanceCorrection stores the correction, reporting a conflict
+// when the checks no longer hold by the time it is stored
+func (u *Usecase) resolveAttendanceCorrection(ctx context.Context, correction *model.TrxAttendanceCorrection) error {
+	resolved, err := u.AttendanceDB.ResolveAttendanceCorrection(ctx, correction)
+	if err != nil {
+		return err
+	}
+	if !resolved {
+		return commonerr.SetNewError(http.StatusConflict, "conflict", "the correction, the attendance or its payroll period changed meanwhile, reload and try again")
+	}
+	return nil
+}
+
+func toAttendanceCorrectionResponse(correction model.TrxAttendanceCorrection) model.AttendanceCorrectionResponse {
+	resp := model.AttendanceCorrectionResponse{
+		ID:             correction.ID,
+		UserID:         correction.IDMstUser,
+		AttendanceDate: correction.AttendanceDate.Format(dateFormat),
+		Action:         correction.Action,
+		Reason:         correction.Reason,
+		Status:         correction.Status,
+		AttendanceID:   correction.IDMstAttendance.Int64,
+		ReviewedBy:     correction.ReviewedBy.Int64,
+		ReviewNote:     correction.ReviewNote,
+		RequestedAt:    correction.CreatedAt,
+	}
+	if correction.ReviewedAt.Valid {
+		reviewedAt := correction.ReviewedAt.Time
+		resp.ReviewedAt = &reviewedAt
+	}
+	return resp
+}
@@ -0,0 +1,649 @@
+package attendance
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RequestAttendanceCorrection(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	mockNow := time.Date(2025, 7, 23, 18, 0, 0, 0, time.UTC)
+	day := time.Date(2025, 7, 21, 0, 0, 0, 0, time.UTC)
+	employeeCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 2, Role: constant.UserRoleEmployee})
+	request := model.AttendanceCorrectionRequest{AttendanceDate: "2025-07-21", Reason: "forgot my phone"}
+	expectChecks := func(period model.MstPayrollPeriod, attendance model.MstAttendance) {
+		expectStandardSchedule()
+		mockAttendanceRepo.EXPECT().GetPayrollPeriodByDate(gomock.Any(), day).Return(period, nil).Times(1)
+		mockAttendanceRepo.EXPECT().GetAttendance(gomock.Any(), model.MstAttendance{IDMstUser: 2, AttendanceDate: day}).
+			Return(attendance, nil).MaxTimes(1)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		request model.AttendanceCorrectionRequest
+		patch   func()
+		want    model.AttendanceCorrectionResponse
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			ctx:     employeeCtx,
+			request: request,
+			patch: func() {
+				expectChecks(model.MstPayrollPeriod{ID: 1}, model.MstAttendance{})
+				mockAttendanceRepo.EXPECT().ListAttendanceCorrectionByParams(gomock.Any(), model.ListAttendanceCorrectionParams{
+					IDsMstUser:     []int64{2},
+					AttendanceDate: day,
+					Status:         constant.AttendanceCorrectionStatusPending,
+				}).Return(nil, nil).Times(1)
+				mockAttendanceRepo.EXPECT().CreateAttendanceCorrection(gomock.Any(), &model.TrxAttendanceCorrection{
+					IDMstUser:      2,
+					AttendanceDate: day,
+					Action:         constant.AttendanceCorrectionAdd,
+					Reason:         "forgot my phone",
+					Status:         constant.AttendanceCorrectionStatusPending,
+					CreatedBy:      sql.NullInt64{Int64: 2, Valid: true},
+				}).DoAndReturn(func(_ context.Context, correction *model.TrxAttendanceCorrection) error {
+					correction.ID = 7
+					return nil
+				}).Times(1)
+			},
+			want: model.AttendanceCorrectionResponse{
+				ID:             7,
+				UserID:         2,
+				AttendanceDate: "2025-07-21",
+				Action:         constant.AttendanceCorrectionAdd,
+				Reason:         "forgot my phone",
+				Status:         constant.AttendanceCorrectionStatusPending,
+			},
+		},
+		{
+			name:    "error already requested",
+			ctx:     employeeCtx,
+			request: request,
+			patch: func() {
+				expectChecks(model.MstPayrollPeriod{}, model.MstAttendance{})
+				mockAttendanceRepo.EXPECT().ListAttendanceCorrectionByParams(gomock.Any(), gomock.Any()).
+					Return([]model.TrxAttendanceCorrection{{ID: 6}}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error already attended",
+			ctx:     employeeCtx,
+			request: request,
+			patch: func() {
+				expectChecks(model.MstPayrollPeriod{ID: 1}, model.MstAttendance{ID: 9})
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error payroll processed",
+			ctx:     employeeCtx,
+			request: request,
+			patch: func() {
+				expectChecks(model.MstPayrollPeriod{ID: 1, PayrollProcessedDate: sql.NullTime{Time: mockNow, Valid: true}}, model.MstAttendance{})
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error today",
+			ctx:     employeeCtx,
+			request: model.AttendanceCorrectionRequest{AttendanceDate: "2025-07-23", Reason: "forgot my phone"},
+			patch:   func() {},
+			wantErr: true,
+		},
+		{
+			name:    "error weekend",
+			ctx:     employeeCtx,
+			request: model.AttendanceCorrectionRequest{AttendanceDate: "2025-07-20", Reason: "forgot my phone"},
+			patch:   expectStandardSchedule,
+			wantErr: true,
+		},
+		{
+			name:    "error day off on the roster",
+			ctx:     employeeCtx,
+			request: request,
+			patch: func() {
+				mockScheduleRepo.EXPECT().ListSchedules(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				mockScheduleRepo.EXPECT().ListRosters(gomock.Any(), model.ListScheduleParams{
+					IDsMstUser: []int64{2},
+					StartDate:  day,
+					EndDate:    day,
+				}).Return([]model.TrxUserRoster{{IDMstUser: 2, RosterDate: day}}, nil).Times(1)
+				mockScheduleRepo.EXPECT().ListShifts(gomock.Any()).Return(nil, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error no user",
+			ctx:     context.Background(),
+			request: request,
+			patch:   func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+				ScheduleDB:   mockScheduleRepo,
+			}
+			timeNow = func() time.Time { return mockNow }
+			defer func() { timeNow = time.Now }()
+			tt.patch()
+			got, err := u.RequestAttendanceCorrection(tt.ctx, tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Usecase.RequestAttendanceCorrection() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ListAttendanceCorrections(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+	managerCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 3, Role: constant.UserRoleEmployee})
+	day := time.Date(2025, 7, 21, 0, 0, 0, 0, time.UTC)
+	request := model.ListAttendanceCorrectionRequest{
+		CursorPagination: model.CursorPagination{Limit: 1},
+		Status:           constant.AttendanceCorrectionStatusPending,
+	}
+	corrections := []model.TrxAttendanceCorrection{
+		{ID: 6, IDMstUser: 2, AttendanceDate: day, Action: constant.AttendanceCorrectionAdd, Status: constant.AttendanceCorrectionStatusPending},
+		{ID: 7, IDMstUser: 4, AttendanceDate: day, Action: constant.AttendanceCorrectionAdd, Status: constant.AttendanceCorrectionStatusPending},
+	}
+	want := model.ListAttendanceCorrectionResponse{
+		Corrections: []model.AttendanceCorrectionResponse{{
+			ID:             6,
+			UserID:         2,
+			AttendanceDate: "2025-07-21",
+			Action:         constant.AttendanceCorrectionAdd,
+			Status:         constant.AttendanceCorrectionStatusPending,
+		}},
+		NextCursor: 6,
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		patch   func()
+		want    model.ListAttendanceCorrectionResponse
+		wantErr bool
+	}{
+		{
+			name: "success admin sees all",
+			ctx:  adminCtx,
+			patch: func() {
+				mockAttendanceRepo.EXPECT().ListAttendanceCorrectionByParams(gomock.Any(), model.ListAttendanceCorrectionParams{
+					Status: constant.AttendanceCorrectionStatusPending,
+					Limit:  2,
+				}).Return(corrections, nil).Times(1)
+			},
+			want: want,
+		},
+		{
+			name: "success manager sees their reports",
+			ctx:  managerCtx,
+			patch: func() {
+				mockAttendanceRepo.EXPECT().ListAttendanceCorrectionByParams(gomock.Any(), model.ListAttendanceCorrectionParams{
+					Status:       constant.AttendanceCorrectionStatusPending,
+					IDMstManager: 3,
+					Limit:        2,
+				}).Return(corrections, nil).Times(1)
+			},
+			want: want,
+		},
+		{
+			name: "error listing",
+			ctx:  adminCtx,
+			patch: func() {
+				mockAttendanceRepo.EXPECT().ListAttendanceCorrectionByParams(gomock.Any(), gomock.Any()).Return(nil, errFoo).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error no user",
+			ctx:     context.Background(),
+			patch:   func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+			}
+			tt.patch()
+			got, err := u.ListAttendanceCorrections(tt.ctx, request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Usecase.ListAttendanceCorrections() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ApproveAttendanceCorrection(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	mockNow := time.Date(2025, 7, 23, 18, 0, 0, 0, time.UTC)
+	day := time.Date(2025, 7, 21, 0, 0, 0, 0, time.UTC)
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+	managerCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 3, Role: constant.UserRoleEmployee})
+	employeeCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 2, Role: constant.UserRoleEmployee})
+	request := model.ReviewAttendanceCorrectionRequest{ID: 6, Note: "seen on cctv"}
+	pending := model.TrxAttendanceCorrection{
+		ID:             6,
+		IDMstUser:      2,
+		AttendanceDate: day,
+		Action:         constant.AttendanceCorrectionAdd,
+		Reason:         "forgot my phone",
+		Status:         constant.AttendanceCorrectionStatusPending,
+	}
+	employee := model.MstUser{ID: 2, IDMstManager: sql.NullInt64{Int64: 3, Valid: true}}
+	expectLookups := func(correction model.TrxAttendanceCorrection) {
+		mockAttendanceRepo.EXPECT().GetAttendanceCorrection(gomock.Any(), int64(6)).Return(correction, nil).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), int64(2)).Return(employee, nil).Times(1)
+	}
+	expectChecks := func(activeJobs []model.TrxPayrollJob) {
+		mockAttendanceRepo.EXPECT().GetPayrollPeriodByDate(gomock.Any(), day).Return(model.MstPayrollPeriod{ID: 1}, nil).Times(1)
+		mockAttendanceRepo.EXPECT().GetAttendance(gomock.Any(), model.MstAttendance{IDMstUser: 2, AttendanceDate: day}).
+			Return(model.MstAttendance{}, nil).Times(1)
+		mockAttendanceRepo.EXPECT().ListPayrollJobByParams(gomock.Any(), model.ListPayrollJobParams{
+			IDMstPayrollPeriod: 1,
+			Statuses:           []string{constant.PayrollJobStatusQueued, constant.PayrollJobStatusRunning},
+		}).Return(activeJobs, nil).Times(1)
+	}
+	resolve := func(resolved bool) {
+		mockAttendanceRepo.EXPECT().ResolveAttendanceCorrection(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, correction *model.TrxAttendanceCorrection) (bool, error) {
+				correction.IDMstAttendance = sql.NullInt64{Int64: 12, Valid: true}
+				return resolved, nil
+			}).Times(1)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		patch   func()
+		want    model.AttendanceCorrectionResponse
+		wantErr bool
+	}{
+		{
+			name: "success by manager",
+			ctx:  managerCtx,
+			patch: func() {
+				expectLookups(pending)
+				expectChecks(nil)
+				resolve(true)
+			},
+			want: model.AttendanceCorrectionResponse{
+				ID:             6,
+				UserID:         2,
+				AttendanceDate: "2025-07-21",
+				Action:         constant.AttendanceCorrectionAdd,
+				Reason:         "forgot my phone",
+				Status:         constant.AttendanceCorrectionStatusApproved,
+				AttendanceID:   12,
+				ReviewedBy:     3,
+				ReviewNote:     "seen on cctv",
+				ReviewedAt:     &mockNow,
+			},
+		},
+		{
+			name: "success by admin",
+			ctx:  adminCtx,
+			patch: func() {
+				expectLookups(pending)
+				expectChecks(nil)
+				resolve(true)
+			},
+			want: model.AttendanceCorrectionResponse{
+				ID:             6,
+				UserID:         2,
+				AttendanceDate: "2025-07-21",
+				Action:         constant.AttendanceCorrectionAdd,
+				Reason:         "forgot my phone",
+				Status:         constant.AttendanceCorrectionStatusApproved,
+				AttendanceID:   12,
+				ReviewedBy:     1,
+				ReviewNote:     "seen on cctv",
+				ReviewedAt:     &mockNow,
+			},
+		},
+		{
+			name: "error payroll being generated",
+			ctx:  managerCtx,
+			patch: func() {
+				expectLookups(pending)
+				expectChecks([]model.TrxPayrollJob{{ID: 3, Status: constant.PayrollJobStatusRunning}})
+			},
+			wantErr: true,
+		},
+		{
+			name: "error changed meanwhile",
+			ctx:  managerCtx,
+			patch: func() {
+				expectLookups(pending)
+				expectChecks(nil)
+				resolve(false)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error already reviewed",
+			ctx:  managerCtx,
+			patch: func() {
+				reviewed := pending
+				reviewed.Status = constant.AttendanceCorrectionStatusRejected
+				expectLookups(reviewed)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error own correction",
+			ctx:  employeeCtx,
+			patch: func() {
+				expectLookups(pending)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error not found",
+			ctx:  managerCtx,
+			patch: func() {
+				mockAttendanceRepo.EXPECT().GetAttendanceCorrection(gomock.Any(), int64(6)).
+					Return(model.TrxAttendanceCorrection{}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+				UserDB:       mockUserRepo,
+			}
+			timeNow = func() time.Time { return mockNow }
+			defer func() { timeNow = time.Now }()
+			tt.patch()
+			got, err := u.ApproveAttendanceCorrection(tt.ctx, request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Usecase.ApproveAttendanceCorrection() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_RejectAttendanceCorrection(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	mockNow := time.Date(2025, 7, 23, 18, 0, 0, 0, time.UTC)
+	managerCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 3, Role: constant.UserRoleEmployee})
+	otherCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 4, Role: constant.UserRoleEmployee})
+	pending := model.TrxAttendanceCorrection{
+		ID:             6,
+		IDMstUser:      2,
+		AttendanceDate: time.Date(2025, 7, 21, 0, 0, 0, 0, time.UTC),
+		Action:         constant.AttendanceCorrectionAdd,
+		Status:         constant.AttendanceCorrectionStatusPending,
+	}
+	expectLookups := func() {
+		mockAttendanceRepo.EXPECT().GetAttendanceCorrection(gomock.Any(), int64(6)).Return(pending, nil).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), int64(2)).
+			Return(model.MstUser{ID: 2, IDMstManager: sql.NullInt64{Int64: 3, Valid: true}}, nil).Times(1)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		patch   func()
+		want    model.AttendanceCorrectionResponse
+		wantErr bool
+	}{
+		{
+			name: "success",
+			ctx:  managerCtx,
+			patch: func() {
+				expectLookups()
+				mockAttendanceRepo.EXPECT().ResolveAttendanceCorrection(gomock.Any(), &model.TrxAttendanceCorrection{
+					ID:             6,
+					IDMstUser:      2,
+					AttendanceDate: pending.AttendanceDate,
+					Action:         constant.AttendanceCorrectionAdd,
+					Status:         constant.AttendanceCorrectionStatusRejected,
+					ReviewedBy:     sql.NullInt64{Int64: 3, Valid: true},
+					ReviewNote:     "was on leave",
+					ReviewedAt:     sql.NullTime{Time: mockNow, Valid: true},
+					UpdatedBy:      sql.NullInt64{Int64: 3, Valid: true},
+				}).Return(true, nil).Times(1)
+			},
+			want: model.AttendanceCorrectionResponse{
+				ID:             6,
+				UserID:         2,
+				AttendanceDate: "2025-07-21",
+				Action:         constant.AttendanceCorrectionAdd,
+				Status:         constant.AttendanceCorrectionStatusRejected,
+				ReviewedBy:     3,
+				ReviewNote:     "was on leave",
+				ReviewedAt:     &mockNow,
+			},
+		},
+		{
+			name: "error not their manager",
+			ctx:  otherCtx,
+			patch: func() {
+				expectLookups()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error resolving",
+			ctx:  managerCtx,
+			patch: func() {
+				expectLookups()
+				mockAttendanceRepo.EXPECT().ResolveAttendanceCorrection(gomock.Any(), gomock.Any()).Return(false, errFoo).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+				UserDB:       mockUserRepo,
+			}
+			timeNow = func() time.Time { return mockNow }
+			defer func() { timeNow = time.Now }()
+			tt.patch()
+			got, err := u.RejectAttendanceCorrection(tt.ctx, model.ReviewAttendanceCorrectionRequest{ID: 6, Note: "was on leave"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Usecase.RejectAttendanceCorrection() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_AdjustAttendance(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	mockNow := time.Date(2025, 7, 23, 18, 0, 0, 0, time.UTC)
+	day := time.Date(2025, 7, 21, 0, 0, 0, 0, time.UTC)
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+	employeeCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 2, Role: constant.UserRoleEmployee})
+	remove := model.AdjustAttendanceRequest{
+		UserID:         2,
+		AttendanceDate: "2025-07-21",
+		Action:         constant.AttendanceCorrectionRemove,
+		Reason:         "tapped in from home while on leave",
+	}
+	expectChecks := func(period model.MstPayrollPeriod, attendance model.MstAttendance) {
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), int64(2)).Return(model.MstUser{ID: 2}, nil).Times(1)
+		mockAttendanceRepo.EXPECT().GetPayrollPeriodByDate(gomock.Any(), day).Return(period, nil).Times(1)
+		mockAttendanceRepo.EXPECT().GetAttendance(gomock.Any(), model.MstAttendance{IDMstUser: 2, AttendanceDate: day}).
+			Return(attendance, nil).MaxTimes(1)
+	}
+	expectActiveJobs := func(activeJobs []model.TrxPayrollJob) {
+		mockAttendanceRepo.EXPECT().ListPayrollJobByParams(gomock.Any(), model.ListPayrollJobParams{
+			IDMstPayrollPeriod: 1,
+			Statuses:           []string{constant.PayrollJobStatusQueued, constant.PayrollJobStatusRunning},
+		}).Return(activeJobs, nil).Times(1)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		request model.AdjustAttendanceRequest
+		patch   func()
+		want    model.AttendanceCorrectionResponse
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			ctx:     adminCtx,
+			request: remove,
+			patch: func() {
+				expectChecks(model.MstPayrollPeriod{ID: 1}, model.MstAttendance{ID: 9})
+				expectActiveJobs(nil)
+				mockAttendanceRepo.EXPECT().ResolveAttendanceCorrection(gomock.Any(), &model.TrxAttendanceCorrection{
+					IDMstUser:      2,
+					AttendanceDate: day,
+					Action:         constant.AttendanceCorrectionRemove,
+					Reason:         "tapped in from home while on leave",
+					Status:         constant.AttendanceCorrectionStatusApproved,
+					ReviewedBy:     sql.NullInt64{Int64: 1, Valid: true},
+					ReviewedAt:     sql.NullTime{Time: mockNow, Valid: true},
+					CreatedBy:      sql.NullInt64{Int64: 1, Valid: true},
+				}).DoAndReturn(func(_ context.Context, correction *model.TrxAttendanceCorrection) (bool, error) {
+					correction.ID = 8
+					correction.IDMstAttendance = sql.NullInt64{Int64: 9, Valid: true}
+					return true, nil
+				}).Times(1)
+			},
+			want: model.AttendanceCorrectionResponse{
+				ID:             8,
+				UserID:         2,
+				AttendanceDate: "2025-07-21",
+				Action:         constant.AttendanceCorrectionRemove,
+				Reason:         "tapped in from home while on leave",
+				Status:         constant.AttendanceCorrectionStatusApproved,
+				AttendanceID:   9,
+				ReviewedBy:     1,
+				ReviewedAt:     &mockNow,
+			},
+		},
+		{
+			name:    "error no attendance to remove",
+			ctx:     adminCtx,
+			request: remove,
+			patch: func() {
+				expectChecks(model.MstPayrollPeriod{ID: 1}, model.MstAttendance{})
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error payroll being generated",
+			ctx:     adminCtx,
+			request: remove,
+			patch: func() {
+				expectChecks(model.MstPayrollPeriod{ID: 1}, model.MstAttendance{ID: 9})
+				expectActiveJobs([]model.TrxPayrollJob{{ID: 3, Status: constant.PayrollJobStatusQueued}})
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error no open payroll period",
+			ctx:     adminCtx,
+			request: remove,
+			patch: func() {
+				expectChecks(model.MstPayrollPeriod{}, model.MstAttendance{ID: 9})
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error user not found",
+			ctx:     adminCtx,
+			request: remove,
+			patch: func() {
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), int64(2)).Return(model.MstUser{}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error adding on weekend",
+			ctx:  adminCtx,
+			request: model.AdjustAttendanceRequest{
+				UserID:         2,
+				AttendanceDate: "2025-07-20",
+				Action:         constant.AttendanceCorrectionAdd,
+				Reason:         "on call",
+			},
+			patch: func() {
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), int64(2)).Return(model.MstUser{ID: 2}, nil).Times(1)
+				expectStandardSchedule()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error future day",
+			ctx:  adminCtx,
+			request: model.AdjustAttendanceRequest{
+				UserID:         2,
+				AttendanceDate: "2025-07-24",
+				Action:         constant.AttendanceCorrectionAdd,
+				Reason:         "on call",
+			},
+			patch:   func() {},
+			wantErr: true,
+		},
+		{
+			name:    "error not admin",
+			ctx:     employeeCtx,
+			request: remove,
+			patch:   func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+				UserDB:       mockUserRepo,
+				ScheduleDB:   mockScheduleRepo,
+			}
+			timeNow = func() time.Time { return mockNow }
+			defer func() { timeNow = time.Now }()
+			tt.patch()
+			got, err := u.AdjustAttendance(tt.ctx, tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Usecase.AdjustAttendance() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package attendance
+
+import (
+	"context"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
+	"github.com/pkg/errors"
+)
+
+const (
+	OvertimeStatusPending   = "pending"
+	OvertimeStatusProcessed = "processed"
+
+	dateFormat = "2006-01-02"
+)
+
+func (u *Usecase) ListMyPayslips(ctx context.Context, request model.ListMyPayslipRequest) (resp model.ListMyPayslipResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.ListMyPayslips")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found {
+		err = errors.Wrap(errors.New("user not found"), "Usecase.ListMyPayslips")
+		return
+	}
+
+	limit := request.GetLimit()
+	payslips, err := u.AttendanceDB.GetPayslips(ctx, model.GetPayslipRequest{
+		UserID: user.ID,
+		Cursor: request.Cursor,
+		Limit:  limit + 1,
+	})
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListMyPayslips")
+		return
+	}
+
+	if len(payslips) > limit {
+		payslips = payslips[:limit]
+		resp.NextCursor = payslips[limit-1].ID
+	}
+
+	periodIDs := []int64{}
+	for _, payslip := range payslips {
+		periodIDs = append(periodIDs, payslip.IDMstPayrollPeriod)
+	}
+
+	mapOfPayrollPeriod := map[int64]model.MstPayrollPeriod{}
+	if len(periodIDs) > 0 {
+		payrollPeriods, e := u.AttendanceDB.ListPayrollPeriodByParams(ctx, model.ListPayrollPeriodParams{
+			IDs: periodIDs,
+		})
+		if e != nil {
+			err = errors.Wrap(e, "Usecase.ListMyPayslips")
+			return
+		}
+		for _, payrollPeriod := range payrollPeriods {
+			mapOfPayrollPeriod[payrollPeriod.ID] = payrollPeriod
+		}
+	}
+
+	resp.Payslips = []model.MyPayslipResponse{}
+	for _, payslip := range payslips {
+		payrollPeriod := mapOfPayrollPeriod[payslip.IDMstPayrollPeriod]
+		resp.Payslips = append(resp.Payslips, model.MyPayslipResponse{
+			ID:                  payslip.ID,
+			PayrollPeriodID:     payslip.IDMstPayrollPeriod,
+			StartDate:           payrollPeriod.StartDate,
+			EndDate:             payrollPeriod.EndDate,
+			WorkingDays:         payslip.WorkingDays,
+			WorkingHours:        payslip.WorkingHours,
+			AttendedDays:        payslip.AttendedDays,
+			ProratedSalary:      payslip.ProratedSalary,
+			OvertimeHours:       payslip.OvertimeHours,
+			OvertimePay:         payslip.OvertimePay,
+			TotalReimbursements: payslip.TotalReimbursements,
+			TotalTakeHomePay:    payslip.TotalTakeHome,
+		})
+	}
+
+	return
+}
+
+func (u *Usecase) ListMyAttendance(ctx context.Context, request model.ListMyAttendanceRequest) (resp model.ListMyAttendanceResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.ListMyAttendance")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found {
+		err = errors.Wrap(errors.New("user not found"), "Usecase.ListMyAttendance")
+		return
+	}
+
+	startDate, endDate, err := parseDateRange(request.StartDate, request.EndDate)
+	if err != nil {
+		return
+	}
+
+	limit := request.GetLimit()
+	listOfAttendance, err := u.AttendanceDB.ListAttendanceByParams(ctx, model.ListAttendanceParams{
+		IDsMstUser: []int64{user.ID},
+		StartDate:  startDate,
+		EndDate:    endDate,
+		Cursor:     request.Cursor,
+		Limit:      limit + 1,
+	})
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListMyAttendance")
+		return
+	}
+
+	if len(listOfAttendance) > limit {
+		listOfAttendance = listOfAttendance[:limit]
+		resp.NextCursor = listOfAttendance[limit-1].ID
+	}
+
+	resp.Attendances = []model.MyAttendanceResponse{}
+	for _, attendance := range listOfAttendance {
+		resp.Attendances = append(resp.Attendances, model.MyAttendanceResponse{
+			ID:              attendance.ID,
+			AttendanceDate:  attendance.AttendanceDate.Format(dateFormat),
+			PayrollPeriodID: attendance.IDMstPayrollPeriod.Int64,
+			LocationStatus:  attendance.LocationStatus,
+			Source:          attendance.Source,
+		})
+	}
+
+	return
+}
+
+func (u *Usecase) ListMyOvertime(ctx context.Context, request model.ListMyOvertimeRequest) (resp model.ListMyOvertimeResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.ListMyOvertime")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found {
+		err = errors.Wrap(errors.New("user not found"), "Usecase.ListMyOvertime")
+		return
+	}
+
+	startDate, endDate, err := parseDateRange(request.StartDate, request.EndDate)
+	if err != nil {
+		return
+	}
+
+	limit := request.GetLimit()
+	listOfOvertime, err := u.AttendanceDB.ListOvertimeByParams(ctx, model.ListOvertimeParams{
+		UserIDs:                []int64{user.ID},
+		StartDate:              startDate,
+		EndDate:                endDate,
+		IsForGeneratingPayroll: request.Status == OvertimeStatusPending,
+		IsProcessed:            request.Status == OvertimeStatusProcessed,
+		Cursor:                 request.Cursor,
+		Limit:                  limit + 1,
+	})
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListMyOvertime")
+		return
+	}
+
+	if len(listOfOvertime) > limit {
+		listOfOvertime = listOfOvertime[:limit]
+		resp.NextCursor = listOfOvertime[limit-1].ID
+	}
+
+	resp.Overtimes = []model.MyOvertimeResponse{}
+	for _, overtime := range listOfOvertime {
+		status := OvertimeStatusPending
+		if overtime.IDMstPayrollPeriod.Valid {
+			status = OvertimeStatusProcessed
+		}
+		resp.Overtimes = append(resp.Overtimes, model.MyOvertimeResponse{
+			ID:              overtime.ID,
+			OvertimeDate:    overtime.OvertimeDate,
+			Hours:           overtime.Hours,
+			PayrollPeriodID: overtime.IDMstPayrollPeriod.Int64,
+			Status:          status,
+		})
+	}
+
+	return
+}
+
+func (u *Usecase) ListMyReimbursements(ctx context.Context, request model.ListMyReimbursementRequest) (resp model.ListMyReimbursementResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.ListMyReimbursements")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found {
+		err = errors.Wrap(errors.New("user not found"), "Usecase.ListMyReimbursements")
+		return
+	}
+
+	limit := request.GetLimit()
+	listOfReimbursement, err := u.AttendanceDB.ListReimbursementByParams(ctx, model.ListReimbursementParams{
+		UserID: user.ID,
+		Status: request.Status,
+		Cursor: request.Cursor,
+		Limit:  limit + 1,
+	})
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListMyReimbursements")
+		return
+	}
+
+	if len(listOfReimbursement) > limit {
+		listOfReimbursement = listOfReimbursement[:limit]
+		resp.NextCursor = listOfReimbursement[limit-1].ID
+	}
+
+	resp.Reimbursements = []model.MyReimbursementResponse{}
+	for _, reimbursement := range listOfReimbursement {
+		resp.Reimbursements = append(resp.Reimbursements, model.MyReimbursementResponse{
+			ID:              reimbursement.ID,
+			Amount:          reimbursement.Amount,
+			Status:          reimbursement.Status,
+			Description:     reimbursement.Description,
+			PayrollPeriodID: reimbursement.IDMstPayrollPeriod.Int64,
+			SubmittedAt:     reimbursement.CreatedAt,
+		})
+	}
+
+	return
+}
+
+// parseDateRange parses an optional start_date/end_date pair. Both or neither
+// must be given, and the start date cannot be after the end date.
+func parseDateRange(start, end string) (startDate, endDate time.Time, err error) {
+	if start == "" && end == "" {
+		return
+	}
+	if start == "" || end == "" {
+		err = commonerr.SetNewBadRequest("invalid", "start_date and end_date must be provided together")
+		return
+	}
+
+	startDate, err = time.ParseInLocation(dateFormat, start, time.Local)
+	if err != nil {
+		err = commonerr.SetNewBadRequest("invalid", "start_date must be in YYYY-MM-DD format")
+		return
+	}
+	endDate, err = time.ParseInLocation(dateFormat, end, time.Local)
+	if err != nil {
+		err = commonerr.SetNewBadRequest("invalid", "end_date must be in YYYY-MM-DD format")
+		return
+	}
+
+	if startDate.After(endDate) {
+		err = commonerr.SetNewBadRequest("invalid", "start_date must not be after end_date")
+		return
+	}
+	return
+}
@@ -0,0 +1,449 @@
+package attendance
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ListMyPayslips(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	startDate := time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		request model.ListMyPayslipRequest
+		patch   func()
+		unpatch func()
+		wantErr bool
+		want    model.ListMyPayslipResponse
+	}{
+		{
+			name: "success with next cursor",
+			request: model.ListMyPayslipRequest{
+				CursorPagination: model.CursorPagination{Limit: 1},
+			},
+			want: model.ListMyPayslipResponse{
+				Payslips: []model.MyPayslipResponse{
+					{
+						ID:               10,
+						PayrollPeriodID:  1,
+						StartDate:        startDate,
+						EndDate:          endDate,
+						TotalTakeHomePay: 1000,
+					},
+				},
+				NextCursor: 10,
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 2}, true
+				}
+				mockAttendanceRepo.EXPECT().
+					GetPayslips(gomock.Any(), model.GetPayslipRequest{UserID: 2, Limit: 2}).
+					Return([]model.TrxUserPayslip{
+						{ID: 10, IDMstPayrollPeriod: 1, TotalTakeHome: 1000},
+						{ID: 11, IDMstPayrollPeriod: 2, TotalTakeHome: 2000},
+					}, nil).Times(1)
+				mockAttendanceRepo.EXPECT().
+					ListPayrollPeriodByParams(gomock.Any(), model.ListPayrollPeriodParams{IDs: []int64{1}}).
+					Return([]model.MstPayrollPeriod{
+						{ID: 1, StartDate: startDate, EndDate: endDate},
+					}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name: "success empty list",
+			want: model.ListMyPayslipResponse{
+				Payslips: []model.MyPayslipResponse{},
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 2}, true
+				}
+				mockAttendanceRepo.EXPECT().
+					GetPayslips(gomock.Any(), gomock.Any()).
+					Return([]model.TrxUserPayslip{}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - user not found in context",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{}, false
+				}
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - get payslips",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 2}, true
+				}
+				mockAttendanceRepo.EXPECT().
+					GetPayslips(gomock.Any(), gomock.Any()).
+					Return(nil, errFoo).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+			}
+			tt.patch()
+			defer tt.unpatch()
+
+			got, err := u.ListMyPayslips(context.Background(), tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListMyPayslips() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ListMyAttendance(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	attendanceDate := time.Date(2025, 7, 1, 0, 0, 0, 0, time.Local)
+
+	testCases := []struct {
+		name    string
+		request model.ListMyAttendanceRequest
+		patch   func()
+		unpatch func()
+		wantErr bool
+		want    model.ListMyAttendanceResponse
+	}{
+		{
+			name: "success",
+			request: model.ListMyAttendanceRequest{
+				StartDate: "2025-07-01",
+				EndDate:   "2025-07-31",
+			},
+			want: model.ListMyAttendanceResponse{
+				Attendances: []model.MyAttendanceResponse{
+					{ID: 1, AttendanceDate: "2025-07-01", PayrollPeriodID: 3},
+				},
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 2}, true
+				}
+				mockAttendanceRepo.EXPECT().
+					ListAttendanceByParams(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, params model.ListAttendanceParams) ([]model.MstAttendance, error) {
+						assert.Equal(t, []int64{2}, params.IDsMstUser)
+						assert.Equal(t, model.DefaultPaginationLimit+1, params.Limit)
+						assert.Equal(t, "2025-07-31", params.EndDate.Format(dateFormat))
+						return []model.MstAttendance{
+							{
+								ID:                 1,
+								AttendanceDate:     attendanceDate,
+								IDMstPayrollPeriod: sql.NullInt64{Int64: 3, Valid: true},
+							},
+						}, nil
+					}).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name: "error - only start date given",
+			request: model.ListMyAttendanceRequest{
+				StartDate: "2025-07-01",
+			},
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 2}, true
+				}
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name: "error - start date after end date",
+			request: model.ListMyAttendanceRequest{
+				StartDate: "2025-07-31",
+				EndDate:   "2025-07-01",
+			},
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 2}, true
+				}
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - list attendance",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 2}, true
+				}
+				mockAttendanceRepo.EXPECT().
+					ListAttendanceByParams(gomock.Any(), gomock.Any()).
+					Return(nil, errFoo).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+			}
+			tt.patch()
+			defer tt.unpatch()
+
+			got, err := u.ListMyAttendance(context.Background(), tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListMyAttendance() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ListMyOvertime(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	overtimeDate := time.Date(2025, 7, 5, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		request model.ListMyOvertimeRequest
+		patch   func()
+		unpatch func()
+		wantErr bool
+		want    model.ListMyOvertimeResponse
+	}{
+		{
+			name: "success pending only",
+			request: model.ListMyOvertimeRequest{
+				Status: OvertimeStatusPending,
+			},
+			want: model.ListMyOvertimeResponse{
+				Overtimes: []model.MyOvertimeResponse{
+					{ID: 4, OvertimeDate: overtimeDate, Hours: 2, Status: OvertimeStatusPending},
+				},
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 2}, true
+				}
+				mockAttendanceRepo.EXPECT().
+					ListOvertimeByParams(gomock.Any(), model.ListOvertimeParams{
+						UserIDs:                []int64{2},
+						IsForGeneratingPayroll: true,
+						Limit:                  model.DefaultPaginationLimit + 1,
+					}).
+					Return([]model.TrxOvertime{
+						{ID: 4, OvertimeDate: overtimeDate, Hours: 2},
+					}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name: "success processed",
+			request: model.ListMyOvertimeRequest{
+				Status: OvertimeStatusProcessed,
+			},
+			want: model.ListMyOvertimeResponse{
+				Overtimes: []model.MyOvertimeResponse{
+					{ID: 5, OvertimeDate: overtimeDate, Hours: 3, PayrollPeriodID: 1, Status: OvertimeStatusProcessed},
+				},
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 2}, true
+				}
+				mockAttendanceRepo.EXPECT().
+					ListOvertimeByParams(gomock.Any(), model.ListOvertimeParams{
+						UserIDs:     []int64{2},
+						IsProcessed: true,
+						Limit:       model.DefaultPaginationLimit + 1,
+					}).
+					Return([]model.TrxOvertime{
+						{ID: 5, OvertimeDate: overtimeDate, Hours: 3, IDMstPayrollPeriod: sql.NullInt64{Int64: 1, Valid: true}},
+					}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - list overtime",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 2}, true
+				}
+				mockAttendanceRepo.EXPECT().
+					ListOvertimeByParams(gomock.Any(), gomock.Any()).
+					Return(nil, errFoo).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+			}
+			tt.patch()
+			defer tt.unpatch()
+
+			got, err := u.ListMyOvertime(context.Background(), tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListMyOvertime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ListMyReimbursements(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	submittedAt := time.Date(2025, 7, 5, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		request model.ListMyReimbursementRequest
+		patch   func()
+		unpatch func()
+		wantErr bool
+		want    model.ListMyReimbursementResponse
+	}{
+		{
+			name: "success with cursor",
+			request: model.ListMyReimbursementRequest{
+				CursorPagination: model.CursorPagination{Cursor: 7, Limit: 5},
+				Status:           ReimbursementStatusPaid,
+			},
+			want: model.ListMyReimbursementResponse{
+				Reimbursements: []model.MyReimbursementResponse{
+					{
+						ID:              8,
+						Amount:          50000,
+						Status:          ReimbursementStatusPaid,
+						Description:     "Taxi",
+						PayrollPeriodID: 1,
+						SubmittedAt:     submittedAt,
+					},
+				},
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 2}, true
+				}
+				mockAttendanceRepo.EXPECT().
+					ListReimbursementByParams(gomock.Any(), model.ListReimbursementParams{
+						UserID: 2,
+						Status: ReimbursementStatusPaid,
+						Cursor: 7,
+						Limit:  6,
+					}).
+					Return([]model.TrxReimbursement{
+						{
+							ID:                 8,
+							Amount:             50000,
+							Status:             ReimbursementStatusPaid,
+							Description:        "Taxi",
+							IDMstPayrollPeriod: sql.NullInt64{Int64: 1, Valid: true},
+							CreatedAt:          submittedAt,
+						},
+					}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - user not found in context",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{}, false
+				}
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - list reimbursement",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{ID: 2}, true
+				}
+				mockAttendanceRepo.EXPECT().
+					ListReimbursementByParams(gomock.Any(), gomock.Any()).
+					Return(nil, errFoo).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+			}
+			tt.patch()
+			defer tt.unpatch()
+
+			got, err := u.ListMyReimbursements(context.Background(), tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListMyReimbursements() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
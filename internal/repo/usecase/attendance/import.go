@@ -0,0 +1,450 @@
+package attendance
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxImportLines caps one upload, longer logs have to be split
+	maxImportLines = 10000
+)
+
+var (
+	// header names used by device exports, in order of preference
+	importBadgeColumns = []string{"badge_id", "badge", "ac-no.", "ac-no", "enroll_number", "pin", "user_id"}
+	importTimeColumns  = []string{"timestamp", "datetime", "date_time", "time", "check_time", "punch_time"}
+
+	importTimeLayouts = []string{
+		time.RFC3339,
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04",
+		"2006/01/02 15:04:05",
+		"2006/01/02 15:04",
+	}
+)
+
+// punch is one line of a device log
+type punch struct {
+	line    int
+	badgeID string
+	time    time.Time
+	user    model.MstUser
+	// workday is the day the punch counts for, the day before for a night
+	// shift punched after midnight
+	workday time.Time
+}
+
+// ImportAttendance records the attendances in a biometric device log, the
+// first punch of an employee on a day being their tap in. Lines that cannot
+// be imported are reported and the rest are recorded together, or only
+// reported in a preview. Admin only.
+func (u *Usecase) ImportAttendance(ctx context.Context, request model.ImportAttendanceRequest) (resp model.ImportAttendanceResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.ImportAttendance")
+	defer func() { tracing.End(span, err) }()
+	// deduplication must see the latest tap ins
+	ctx = xormlib.WithPrimary(ctx)
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.ImportAttendance")
+		return
+	}
+
+	currTime := timeNow()
+	punches, issues, totalLines, err := readPunches(request.File, currTime.Location())
+	if err != nil {
+		return
+	}
+	if totalLines == 0 {
+		err = commonerr.SetNewBadRequest("empty_file", "the file has no attendance lines")
+		return
+	}
+
+	resp = model.ImportAttendanceResponse{
+		Preview:      request.Preview,
+		TotalLines:   totalLines,
+		Attendances:  []model.ImportedAttendance{},
+		SkippedLines: []model.ImportAttendanceIssue{},
+		Errors:       issues,
+	}
+
+	punches = rejectPunches(punches, &resp, func(p punch) string {
+		if p.time.After(currTime) {
+			return "the punch is in the future"
+		}
+		return ""
+	})
+
+	punches, err = u.resolveBadges(ctx, punches, &resp)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ImportAttendance")
+		return
+	}
+
+	punches, err = u.rejectUnscheduledPunches(ctx, punches, &resp)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ImportAttendance")
+		return
+	}
+
+	processed, err := u.AttendanceDB.ListPayrollPeriodByParams(ctx, model.ListPayrollPeriodParams{
+		Status: constant.PayrollPeriodStatusProcessed,
+	})
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ImportAttendance")
+		return
+	}
+	punches = rejectPunches(punches, &resp, func(p punch) string {
+		if inPayrollPeriods(p.workday, processed) {
+			return "the payroll of this date is already processed"
+		}
+		return ""
+	})
+
+	punches, err = u.dedupePunches(ctx, punches, &resp)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ImportAttendance")
+		return
+	}
+
+	attendances := make([]*model.MstAttendance, 0, len(punches))
+	for _, p := range punches {
+		attendances = append(attendances, &model.MstAttendance{
+			IDMstUser:      p.user.ID,
+			AttendanceDate: p.workday,
+			Source:         constant.AttendanceSourceImport,
+			CreatedBy: sql.NullInt64{
+				Int64: user.ID,
+				Valid: true,
+			},
+		})
+		resp.Attendances = append(resp.Attendances, model.ImportedAttendance{
+			Line:           p.line,
+			BadgeID:        p.badgeID,
+			Username:       p.user.Username,
+			AttendanceDate: p.time,
+		})
+	}
+
+	if !request.Preview {
+		err = u.AttendanceDB.RecordAttendances(ctx, attendances)
+		if err != nil {
+			err = errors.Wrap(err, "Usecase.ImportAttendance")
+			return
+		}
+	}
+
+	sortByLine(resp.Errors)
+	sortByLine(resp.SkippedLines)
+	sort.Slice(resp.Attendances, func(i, j int) bool { return resp.Attendances[i].Line < resp.Attendances[j].Line })
+	resp.Imported = len(resp.Attendances)
+	resp.Skipped = len(resp.SkippedLines)
+	resp.Failed = len(resp.Errors)
+	return
+}
+
+// resolveBadges finds the employee holding the badge of every punch
+func (u *Usecase) resolveBadges(ctx context.Context, punches []punch, resp *model.ImportAttendanceResponse) ([]punch, error) {
+	badgeIDs := []string{}
+	seen := map[string]bool{}
+	for _, p := range punches {
+		if !seen[p.badgeID] {
+			seen[p.badgeID] = true
+			badgeIDs = append(badgeIDs, p.badgeID)
+		}
+	}
+
+	users, err := u.UserDB.ListUsersByBadgeIDs(ctx, badgeIDs)
+	if err != nil {
+		return nil, err
+	}
+	usersByBadge := make(map[string]model.MstUser, len(users))
+	for _, user := range users {
+		usersByBadge[user.BadgeID.String] = user
+	}
+
+	resolved := punches[:0]
+	for _, p := range punches {
+		user, found := usersByBadge[p.badgeID]
+		if !found {
+			resp.Errors = append(resp.Errors, issueOf(p, "no employee holds this badge"))
+			continue
+		}
+		p.user = user
+		resolved = append(resolved, p)
+	}
+	return resolved, nil
+}
+
+// rejectUnscheduledPunches rejects the punches on days the employee is not
+// scheduled to work and dates the others on the day of their shift
+func (u *Usecase) rejectUnscheduledPunches(ctx context.Context, punches []punch, resp *model.ImportAttendanceResponse) ([]punch, error) {
+	if len(punches) == 0 {
+		return punches, nil
+	}
+
+	userIDs := []int64{}
+	seen := map[int64]bool{}
+	startDate, endDate := punches[0].time, punches[0].time
+	for _, p := range punches {
+		if !seen[p.user.ID] {
+			seen[p.user.ID] = true
+			userIDs = append(userIDs, p.user.ID)
+		}
+		if p.time.Before(startDate) {
+			startDate = p.time
+		}
+		if p.time.After(endDate) {
+			endDate = p.time
+		}
+	}
+
+	calendar, err := usecaseLoadWorkCalendar(u, ctx, userIDs, startDate.AddDate(0, 0, -1), endDate)
+	if err != nil {
+		return nil, err
+	}
+	scheduled := make(map[int]bool, len(punches))
+	for i, p := range punches {
+		punches[i].workday, scheduled[p.line] = calendar.workdayAt(p.user.ID, p.time)
+	}
+	return rejectPunches(punches, resp, func(p punch) string {
+		if !scheduled[p.line] {
+			return "not scheduled to work on this day"
+		}
+		return ""
+	}), nil
+}
+
+// dedupePunches keeps the first punch of an employee on a workday, unless
+// the employee already has an attendance that day
+func (u *Usecase) dedupePunches(ctx context.Context, punches []punch, resp *model.ImportAttendanceResponse) ([]punch, error) {
+	if len(punches) == 0 {
+		return punches, nil
+	}
+
+	sort.SliceStable(punches, func(i, j int) bool { return punches[i].time.Before(punches[j].time) })
+
+	userIDs := []int64{}
+	seenUsers := map[int64]bool{}
+	startDate, endDate := punches[0].workday, punches[0].workday
+	for _, p := range punches {
+		if !seenUsers[p.user.ID] {
+			seenUsers[p.user.ID] = true
+			userIDs = append(userIDs, p.user.ID)
+		}
+		if p.workday.Before(startDate) {
+			startDate = p.workday
+		}
+		if p.workday.After(endDate) {
+			endDate = p.workday
+		}
+	}
+
+	existing, err := u.AttendanceDB.ListAttendanceByParams(ctx, model.ListAttendanceParams{
+		IDsMstUser: userIDs,
+		StartDate:  startDate,
+		EndDate:    endDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	recorded := make(map[string]bool, len(existing))
+	for _, attendance := range existing {
+		recorded[attendanceKey(attendance.IDMstUser, attendance.AttendanceDate)] = true
+	}
+
+	firstLines := map[string]int{}
+	kept := []punch{}
+	for _, p := range punches {
+		key := attendanceKey(p.user.ID, p.workday)
+		switch {
+		case recorded[key]:
+			resp.SkippedLines = append(resp.SkippedLines, issueOf(p, "already tapped in on "+p.workday.Format(dateFormat)))
+		case firstLines[key] > 0:
+			resp.SkippedLines = append(resp.SkippedLines, issueOf(p, fmt.Sprintf("the punch on line %d is earlier", firstLines[key])))
+		default:
+			firstLines[key] = p.line
+			kept = append(kept, p)
+		}
+	}
+	return kept, nil
+}
+
+// readPunches reads a comma or tab separated device log. A first line naming
+// a badge and a time column is taken as the header, without one the badge is
+// the first column and the time the second as in ZKTeco attendance logs.
+// Times without an offset are read in loc.
+func readPunches(r io.Reader, loc *time.Location) (punches []punch, issues []model.ImportAttendanceIssue, totalLines int, err error) {
+	issues = []model.ImportAttendanceIssue{}
+	if r == nil {
+		return
+	}
+
+	buf := bufio.NewReader(r)
+	reader := csv.NewReader(buf)
+	reader.Comma = detectDelimiter(buf)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	badgeColumn, timeColumn := 0, 1
+	for first := true; ; first = false {
+		record, readErr := reader.Read()
+		if readErr == io.EOF {
+			break
+		}
+
+		var line int
+		if readErr != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(readErr, &parseErr) {
+				err = errors.Wrap(readErr, "readPunches")
+				return
+			}
+			line = parseErr.StartLine
+		} else {
+			line, _ = reader.FieldPos(0)
+			if first {
+				if badge, at, found := importColumns(record); found {
+					badgeColumn, timeColumn = badge, at
+					continue
+				}
+			}
+		}
+
+		totalLines++
+		if totalLines > maxImportLines {
+			err = commonerr.SetNewBadRequest("too_many_lines", fmt.Sprintf("the file has more than %d lines, split it", maxImportLines))
+			return
+		}
+		if readErr != nil {
+			issues = append(issues, model.ImportAttendanceIssue{Line: line, Message: "the line cannot be read"})
+			continue
+		}
+
+		p := punch{line: line, badgeID: field(record, badgeColumn)}
+		if p.badgeID == "" {
+			issues = append(issues, issueOf(p, "the badge id is missing"))
+			continue
+		}
+		rawTime := field(record, timeColumn)
+		at, parseErr := parsePunchTime(rawTime, loc)
+		if parseErr != nil {
+			issues = append(issues, issueOf(p, fmt.Sprintf("cannot read the time %q", rawTime)))
+			continue
+		}
+		p.time = at
+		punches = append(punches, p)
+	}
+	return
+}
+
+// detectDelimiter picks a tab when the first line has one
+func detectDelimiter(buf *bufio.Reader) rune {
+	head, _ := buf.Peek(4096)
+	if end := bytes.IndexByte(head, '\n'); end >= 0 {
+		head = head[:end]
+	}
+	if bytes.IndexByte(head, '\t') >= 0 {
+		return '\t'
+	}
+	return ','
+}
+
+// importColumns finds the badge and time columns of a header
+func importColumns(record []string) (badgeColumn, timeColumn int, found bool) {
+	names := make(map[string]int, len(record))
+	for i, name := range record {
+		name = strings.TrimPrefix(name, "\ufeff")
+		name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+		if _, taken := names[name]; !taken {
+			names[name] = i
+		}
+	}
+
+	badgeColumn, badgeFound := firstColumn(names, importBadgeColumns)
+	timeColumn, timeFound := firstColumn(names, importTimeColumns)
+	return badgeColumn, timeColumn, badgeFound && timeFound
+}
+
+func firstColumn(names map[string]int, aliases []string) (int, bool) {
+	for _, alias := range aliases {
+		if i, found := names[alias]; found {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func field(record []string, i int) string {
+	if i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func parsePunchTime(value string, loc *time.Location) (t time.Time, err error) {
+	for _, layout := range importTimeLayouts {
+		t, err = time.ParseInLocation(layout, value, loc)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return t, err
+}
+
+// rejectPunches reports the punches reject gives a reason for and keeps the
+// others
+func rejectPunches(punches []punch, resp *model.ImportAttendanceResponse, reject func(p punch) string) []punch {
+	kept := punches[:0]
+	for _, p := range punches {
+		if reason := reject(p); reason != "" {
+			resp.Errors = append(resp.Errors, issueOf(p, reason))
+			continue
+		}
+		kept = append(kept, p)
+	}
+	return kept
+}
+
+func inPayrollPeriods(t time.Time, periods []model.MstPayrollPeriod) bool {
+	date := t.Format(dateFormat)
+	for _, period := range periods {
+		if date >= period.StartDate.Format(dateFormat) && date <= period.EndDate.Format(dateFormat) {
+			return true
+		}
+	}
+	return false
+}
+
+func attendanceKey(userID int64, date time.Time) string {
+	return fmt.Sprintf("%d:%s", userID, date.Format(dateFormat))
+}
+
+func issueOf(p punch, message string) model.ImportAttendanceIssue {
+	return model.ImportAttendanceIssue{
+		Line:    p.line,
+		BadgeID: p.badgeID,
+		Message: message,
+	}
+}
+
+func sortByLine(issues []model.ImportAttendanceIssue) {
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+}
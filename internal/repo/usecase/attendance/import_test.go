@@ -0,0 +1,304 @@
+package attendance
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ImportAttendance(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	mockNow := time.Date(2025, 7, 23, 18, 0, 0, 0, time.UTC)
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+	employeeCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 2, Role: constant.UserRoleEmployee})
+	deviceLog := strings.Join([]string{
+		"badge_id,timestamp",
+		"B-001,2025-07-21 08:01:00",
+		"B-001,2025-07-21 17:30:00",
+		"B-002,2025-07-21 07:55:00",
+		"B-003,2025-07-22 08:00:00",
+		"B-001,2025-07-19 09:00:00",
+		"B-002,2025-07-24 08:00:00",
+		"B-002,not a time",
+		",2025-07-22 08:00:00",
+		"B-002,2025-07-22 08:10:00",
+		"B-001,2025-07-14 08:00:00",
+	}, "\n")
+	users := []model.MstUser{
+		{ID: 2, Username: "employee_001", BadgeID: sql.NullString{String: "B-001", Valid: true}},
+		{ID: 3, Username: "employee_002", BadgeID: sql.NullString{String: "B-002", Valid: true}},
+	}
+	processed := []model.MstPayrollPeriod{{
+		ID:        1,
+		StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC),
+	}}
+	existing := []model.MstAttendance{{ID: 9, IDMstUser: 3, AttendanceDate: time.Date(2025, 7, 21, 0, 0, 0, 0, time.UTC)}}
+	wantAttendances := []*model.MstAttendance{
+		{
+			IDMstUser:      2,
+			AttendanceDate: time.Date(2025, 7, 21, 8, 1, 0, 0, time.UTC),
+			Source:         constant.AttendanceSourceImport,
+			CreatedBy:      sql.NullInt64{Int64: 1, Valid: true},
+		},
+		{
+			IDMstUser:      3,
+			AttendanceDate: time.Date(2025, 7, 22, 8, 10, 0, 0, time.UTC),
+			Source:         constant.AttendanceSourceImport,
+			CreatedBy:      sql.NullInt64{Int64: 1, Valid: true},
+		},
+	}
+	wantReport := model.ImportAttendanceResponse{
+		TotalLines: 10,
+		Imported:   2,
+		Skipped:    2,
+		Failed:     6,
+		Attendances: []model.ImportedAttendance{
+			{Line: 2, BadgeID: "B-001", Username: "employee_001", AttendanceDate: time.Date(2025, 7, 21, 8, 1, 0, 0, time.UTC)},
+			{Line: 10, BadgeID: "B-002", Username: "employee_002", AttendanceDate: time.Date(2025, 7, 22, 8, 10, 0, 0, time.UTC)},
+		},
+		SkippedLines: []model.ImportAttendanceIssue{
+			{Line: 3, BadgeID: "B-001", Message: "the punch on line 2 is earlier"},
+			{Line: 4, BadgeID: "B-002", Message: "already tapped in on 2025-07-21"},
+		},
+		Errors: []model.ImportAttendanceIssue{
+			{Line: 5, BadgeID: "B-003", Message: "no employee holds this badge"},
+			{Line: 6, BadgeID: "B-001", Message: "not scheduled to work on this day"},
+			{Line: 7, BadgeID: "B-002", Message: "the punch is in the future"},
+			{Line: 8, BadgeID: "B-002", Message: `cannot read the time "not a time"`},
+			{Line: 9, Message: "the badge id is missing"},
+			{Line: 11, BadgeID: "B-001", Message: "the payroll of this date is already processed"},
+		},
+	}
+	expectLookups := func() {
+		mockUserRepo.EXPECT().ListUsersByBadgeIDs(gomock.Any(), []string{"B-001", "B-002", "B-003"}).Return(users, nil).Times(1)
+		scheduleParams := model.ListScheduleParams{
+			IDsMstUser: []int64{2, 3},
+			StartDate:  time.Date(2025, 7, 13, 8, 0, 0, 0, time.UTC),
+			EndDate:    time.Date(2025, 7, 22, 8, 10, 0, 0, time.UTC),
+		}
+		mockScheduleRepo.EXPECT().ListSchedules(gomock.Any(), scheduleParams).Return(nil, nil).Times(1)
+		mockScheduleRepo.EXPECT().ListRosters(gomock.Any(), scheduleParams).Return(nil, nil).Times(1)
+		mockAttendanceRepo.EXPECT().ListPayrollPeriodByParams(gomock.Any(), model.ListPayrollPeriodParams{
+			Status: constant.PayrollPeriodStatusProcessed,
+		}).Return(processed, nil).Times(1)
+		mockAttendanceRepo.EXPECT().ListAttendanceByParams(gomock.Any(), model.ListAttendanceParams{
+			IDsMstUser: []int64{3, 2},
+			StartDate:  time.Date(2025, 7, 21, 7, 55, 0, 0, time.UTC),
+			EndDate:    time.Date(2025, 7, 22, 8, 10, 0, 0, time.UTC),
+		}).Return(existing, nil).Times(1)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		request model.ImportAttendanceRequest
+		patch   func()
+		want    model.ImportAttendanceResponse
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			ctx:     adminCtx,
+			request: model.ImportAttendanceRequest{File: strings.NewReader(deviceLog)},
+			patch: func() {
+				expectLookups()
+				mockAttendanceRepo.EXPECT().RecordAttendances(gomock.Any(), wantAttendances).Return(nil).Times(1)
+			},
+			want: wantReport,
+		},
+		{
+			name:    "success preview records nothing",
+			ctx:     adminCtx,
+			request: model.ImportAttendanceRequest{File: strings.NewReader(deviceLog), Preview: true},
+			patch:   expectLookups,
+			want: func() model.ImportAttendanceResponse {
+				report := wantReport
+				report.Preview = true
+				return report
+			}(),
+		},
+		{
+			name: "success night shift punches count for the night they started",
+			ctx:  adminCtx,
+			request: model.ImportAttendanceRequest{File: strings.NewReader(strings.Join([]string{
+				"badge_id,timestamp",
+				"B-001,2025-07-21 22:01:00",
+				"B-001,2025-07-22 05:58:00",
+				"B-002,2025-07-22 02:00:00",
+			}, "\n"))},
+			patch: func() {
+				nightRoster := sql.NullInt64{Int64: 5, Valid: true}
+				mockUserRepo.EXPECT().ListUsersByBadgeIDs(gomock.Any(), []string{"B-001", "B-002"}).Return(users, nil).Times(1)
+				scheduleParams := model.ListScheduleParams{
+					IDsMstUser: []int64{2, 3},
+					StartDate:  time.Date(2025, 7, 20, 22, 1, 0, 0, time.UTC),
+					EndDate:    time.Date(2025, 7, 22, 5, 58, 0, 0, time.UTC),
+				}
+				mockScheduleRepo.EXPECT().ListSchedules(gomock.Any(), scheduleParams).Return(nil, nil).Times(1)
+				mockScheduleRepo.EXPECT().ListRosters(gomock.Any(), scheduleParams).Return([]model.TrxUserRoster{
+					{IDMstUser: 2, RosterDate: time.Date(2025, 7, 21, 0, 0, 0, 0, time.UTC), IDMstShift: nightRoster},
+					{IDMstUser: 3, RosterDate: time.Date(2025, 7, 21, 0, 0, 0, 0, time.UTC), IDMstShift: nightRoster},
+				}, nil).Times(1)
+				mockScheduleRepo.EXPECT().ListShifts(gomock.Any()).
+					Return([]model.MstShift{{ID: 5, Name: "night", StartTime: "22:00", EndTime: "06:00", WorkingHours: 8}}, nil).Times(1)
+				mockAttendanceRepo.EXPECT().ListPayrollPeriodByParams(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				mockAttendanceRepo.EXPECT().ListAttendanceByParams(gomock.Any(), model.ListAttendanceParams{
+					IDsMstUser: []int64{2, 3},
+					StartDate:  time.Date(2025, 7, 21, 2, 0, 0, 0, time.UTC),
+					EndDate:    time.Date(2025, 7, 21, 22, 1, 0, 0, time.UTC),
+				}).Return(nil, nil).Times(1)
+				mockAttendanceRepo.EXPECT().RecordAttendances(gomock.Any(), []*model.MstAttendance{
+					{
+						IDMstUser:      2,
+						AttendanceDate: time.Date(2025, 7, 21, 22, 1, 0, 0, time.UTC),
+						Source:         constant.AttendanceSourceImport,
+						CreatedBy:      sql.NullInt64{Int64: 1, Valid: true},
+					},
+					{
+						IDMstUser:      3,
+						AttendanceDate: time.Date(2025, 7, 21, 2, 0, 0, 0, time.UTC),
+						Source:         constant.AttendanceSourceImport,
+						CreatedBy:      sql.NullInt64{Int64: 1, Valid: true},
+					},
+				}).Return(nil).Times(1)
+			},
+			want: model.ImportAttendanceResponse{
+				TotalLines: 3,
+				Imported:   2,
+				Skipped:    1,
+				Attendances: []model.ImportedAttendance{
+					{Line: 2, BadgeID: "B-001", Username: "employee_001", AttendanceDate: time.Date(2025, 7, 21, 22, 1, 0, 0, time.UTC)},
+					{Line: 4, BadgeID: "B-002", Username: "employee_002", AttendanceDate: time.Date(2025, 7, 22, 2, 0, 0, 0, time.UTC)},
+				},
+				SkippedLines: []model.ImportAttendanceIssue{
+					{Line: 3, BadgeID: "B-001", Message: "the punch on line 2 is earlier"},
+				},
+				Errors: []model.ImportAttendanceIssue{},
+			},
+		},
+		{
+			name:    "error recording",
+			ctx:     adminCtx,
+			request: model.ImportAttendanceRequest{File: strings.NewReader(deviceLog)},
+			patch: func() {
+				expectLookups()
+				mockAttendanceRepo.EXPECT().RecordAttendances(gomock.Any(), gomock.Any()).Return(errFoo).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error listing badges",
+			ctx:     adminCtx,
+			request: model.ImportAttendanceRequest{File: strings.NewReader(deviceLog)},
+			patch: func() {
+				mockUserRepo.EXPECT().ListUsersByBadgeIDs(gomock.Any(), gomock.Any()).Return(nil, errFoo).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error empty file",
+			ctx:     adminCtx,
+			request: model.ImportAttendanceRequest{File: strings.NewReader("badge_id,timestamp\n")},
+			patch:   func() {},
+			wantErr: true,
+		},
+		{
+			name:    "error not admin",
+			ctx:     employeeCtx,
+			request: model.ImportAttendanceRequest{File: strings.NewReader(deviceLog)},
+			patch:   func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+				UserDB:       mockUserRepo,
+				ScheduleDB:   mockScheduleRepo,
+			}
+			timeNow = func() time.Time { return mockNow }
+			defer func() { timeNow = time.Now }()
+			tt.patch()
+			got, err := u.ImportAttendance(tt.ctx, tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Usecase.ImportAttendance() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_readPunches(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	tests := []struct {
+		name           string
+		deviceLog      string
+		wantPunches    []punch
+		wantIssues     []model.ImportAttendanceIssue
+		wantTotalLines int
+		wantErr        bool
+	}{
+		{
+			name:      "zkteco log without header",
+			deviceLog: "     1\t2025-07-21 08:01:00\t1\t0\t1\t0\n   102\t2025-07-21 08:05:00\t1\t0\t1\t0\n",
+			wantPunches: []punch{
+				{line: 1, badgeID: "1", time: time.Date(2025, 7, 21, 8, 1, 0, 0, jakarta)},
+				{line: 2, badgeID: "102", time: time.Date(2025, 7, 21, 8, 5, 0, 0, jakarta)},
+			},
+			wantIssues:     []model.ImportAttendanceIssue{},
+			wantTotalLines: 2,
+		},
+		{
+			name:      "header in other order",
+			deviceLog: "\ufeffName,Check Time,AC-No.\nBudi,2025-07-21T08:01:00Z,B-001\n\nSiti,2025/07/21 08:05,B-002\n",
+			wantPunches: []punch{
+				{line: 2, badgeID: "B-001", time: time.Date(2025, 7, 21, 8, 1, 0, 0, time.UTC)},
+				{line: 4, badgeID: "B-002", time: time.Date(2025, 7, 21, 8, 5, 0, 0, jakarta)},
+			},
+			wantIssues:     []model.ImportAttendanceIssue{},
+			wantTotalLines: 2,
+		},
+		{
+			name:           "short line",
+			deviceLog:      "badge_id,timestamp\nB-001\n",
+			wantIssues:     []model.ImportAttendanceIssue{{Line: 2, BadgeID: "B-001", Message: `cannot read the time ""`}},
+			wantTotalLines: 1,
+		},
+		{
+			name:      "too many lines",
+			deviceLog: strings.Repeat("B-001,2025-07-21 08:01:00\n", maxImportLines+1),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			punches, issues, totalLines, err := readPunches(strings.NewReader(tt.deviceLog), jakarta)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("readPunches() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			assert.Equal(t, tt.wantPunches, punches)
+			assert.Equal(t, tt.wantIssues, issues)
+			assert.Equal(t, tt.wantTotalLines, totalLines)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package attendance
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+)
+
+// TapInAtKiosk taps the employee in with the nonce scanned from the QR code
+// of a kiosk. Scanning a live code proves the employee stands at the kiosk,
+// so neither the location nor the device is checked. Each code taps in one
+// employee only, or a photo of it could be passed around until it expires.
+func (u *Usecase) TapInAtKiosk(ctx context.Context, request model.KioskTapInRequest) (resp model.TapInResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.TapInAtKiosk")
+	defer func() { tracing.End(span, err) }()
+	ctx = xormlib.WithPrimary(ctx)
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found {
+		err = errors.Wrap(errors.New("user not found"), "Usecase.TapInAtKiosk")
+		return
+	}
+
+	nonce, err := u.AuthRepo.VerifyKioskNonce(request.Nonce)
+	if err != nil {
+		err = commonerr.SetNewBadRequest("invalid_nonce", "the code is invalid or expired, scan it again")
+		return
+	}
+
+	// a kiosk of another company is not one the employee can tap in at
+	issuer := nonce.Kiosk
+	if issuer.TenantID != user.TenantID {
+		err = commonerr.SetNewBadRequest("invalid_nonce", "the code is invalid or expired, scan it again")
+		return
+	}
+
+	kiosk, err := u.activeKiosk(ctx, issuer.ID)
+	if err != nil {
+		return
+	}
+
+	claimed, err := u.KioskDB.ClaimNonce(ctx, &model.TrxKioskNonce{
+		ID:         nonce.ID,
+		IDMstKiosk: kiosk.ID,
+		IDMstUser:  user.ID,
+		ExpiresAt:  nonce.ExpiresAt,
+	})
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.TapInAtKiosk")
+		return
+	}
+	if !claimed {
+		err = commonerr.SetNewBadRequest("invalid_nonce", "the code was already used, scan the next one")
+		return
+	}
+
+	attendance := u.kioskAttendance(user.ID, kiosk, constant.AttendanceSourceKioskQR)
+	attendance.CreatedBy = sql.NullInt64{Int64: user.ID, Valid: true}
+	return u.tapIn(ctx, attendance, nil)
+}
+
+// TapInWithBadge taps in the employee holding the badge on behalf of the
+// signed in kiosk.
+func (u *Usecase) TapInWithBadge(ctx context.Context, request model.BadgeTapInRequest) (resp model.TapInResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.TapInWithBadge")
+	defer func() { tracing.End(span, err) }()
+	ctx = xormlib.WithPrimary(ctx)
+
+	signedIn, found := authGetKioskDetailFromCtx(ctx)
+	if !found {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.TapInWithBadge")
+		return
+	}
+
+	kiosk, err := u.activeKiosk(ctx, signedIn.ID)
+	if err != nil {
+		return
+	}
+
+	employee, err := u.UserDB.GetUserByBadgeID(ctx, request.BadgeID)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.TapInWithBadge")
+		return
+	}
+	if employee.ID == 0 {
+		err = commonerr.SetNewError(http.StatusNotFound, "unknown_badge", "no employee holds this badge")
+		return
+	}
+
+	resp, err = u.tapIn(ctx, u.kioskAttendance(employee.ID, kiosk, constant.AttendanceSourceKioskBadge), nil)
+	if err != nil {
+		return
+	}
+	resp.Username = employee.Username
+	return
+}
+
+// activeKiosk refuses kiosks deactivated since their token or nonce was
+// signed
+func (u *Usecase) activeKiosk(ctx context.Context, kioskID int64) (kiosk model.MstKiosk, err error) {
+	kiosk, err = u.KioskDB.GetKioskByID(ctx, kioskID)
+	if err != nil {
+		return kiosk, errors.Wrap(err, "Usecase.activeKiosk")
+	}
+	if kiosk.ID == 0 || !kiosk.Active {
+		return kiosk, commonerr.SetNewUnauthorizedError("kiosk_inactive", "the kiosk is not registered or has been deactivated")
+	}
+	return kiosk, nil
+}
+
+// kioskAttendance is made at the office of the kiosk, it counts as inside
+// when fencing is on
+func (u *Usecase) kioskAttendance(employeeID int64, kiosk model.MstKiosk, source string) *model.MstAttendance {
+	attendance := &model.MstAttendance{
+		IDMstUser:      employeeID,
+		AttendanceDate: timeNow(),
+		OfficeName:     kiosk.OfficeName,
+		Source:         source,
+		IDMstKiosk:     sql.NullInt64{Int64: kiosk.ID, Valid: true},
+	}
+	if len(u.Cfg.OfficeLocations) > 0 {
+		attendance.LocationStatus = constant.LocationStatusInside
+	}
+	return attendance
+}
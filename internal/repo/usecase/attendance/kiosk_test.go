@@ -0,0 +1,213 @@
+package attendance
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/config"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TapInAtKiosk(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	mockNow := time.Date(2025, 7, 21, 8, 0, 0, 0, time.UTC)
+	employeeCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 2, Role: constant.UserRoleEmployee, TenantID: 1})
+	kiosk := model.MstKiosk{ID: 3, Name: "Warehouse gate", OfficeName: "HQ", Active: true}
+	nonceExpiresAt := mockNow.Add(30 * time.Second)
+	kioskNonce := func(tenantID int64) auth.KioskNonce {
+		return auth.KioskNonce{ID: "abc", ExpiresAt: nonceExpiresAt, Kiosk: auth.KioskJWTPayload{ID: 3, TenantID: tenantID}}
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		patch   func()
+		want    model.TapInResponse
+		wantErr bool
+	}{
+		{
+			name: "success",
+			ctx:  employeeCtx,
+			patch: func() {
+				expectStandardSchedule()
+				mockAuthRepo.EXPECT().VerifyKioskNonce("nonce").Return(kioskNonce(1), nil).Times(1)
+				mockKioskRepo.EXPECT().GetKioskByID(gomock.Any(), int64(3)).Return(kiosk, nil).Times(1)
+				mockKioskRepo.EXPECT().ClaimNonce(gomock.Any(), &model.TrxKioskNonce{
+					ID:         "abc",
+					IDMstKiosk: 3,
+					IDMstUser:  2,
+					ExpiresAt:  nonceExpiresAt,
+				}).Return(true, nil).Times(1)
+				mockAttendanceRepo.EXPECT().GetAttendance(gomock.Any(), gomock.Any()).Return(model.MstAttendance{}, nil).Times(1)
+				mockAttendanceRepo.EXPECT().RecordAttendance(gomock.Any(), &model.MstAttendance{
+					IDMstUser:      2,
+					AttendanceDate: mockNow,
+					OfficeName:     "HQ",
+					LocationStatus: constant.LocationStatusInside,
+					Source:         constant.AttendanceSourceKioskQR,
+					IDMstKiosk:     sql.NullInt64{Int64: 3, Valid: true},
+					CreatedBy:      sql.NullInt64{Int64: 2, Valid: true},
+				}).Return(nil).Times(1)
+			},
+			want: model.TapInResponse{
+				AttendanceDate: mockNow,
+				LocationStatus: constant.LocationStatusInside,
+				OfficeName:     "HQ",
+			},
+		},
+		{
+			name: "error expired nonce",
+			ctx:  employeeCtx,
+			patch: func() {
+				mockAuthRepo.EXPECT().VerifyKioskNonce("nonce").Return(auth.KioskNonce{}, errFoo).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error nonce already used",
+			ctx:  employeeCtx,
+			patch: func() {
+				mockAuthRepo.EXPECT().VerifyKioskNonce("nonce").Return(kioskNonce(1), nil).Times(1)
+				mockKioskRepo.EXPECT().GetKioskByID(gomock.Any(), int64(3)).Return(kiosk, nil).Times(1)
+				mockKioskRepo.EXPECT().ClaimNonce(gomock.Any(), gomock.Any()).Return(false, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error claiming nonce",
+			ctx:  employeeCtx,
+			patch: func() {
+				mockAuthRepo.EXPECT().VerifyKioskNonce("nonce").Return(kioskNonce(1), nil).Times(1)
+				mockKioskRepo.EXPECT().GetKioskByID(gomock.Any(), int64(3)).Return(kiosk, nil).Times(1)
+				mockKioskRepo.EXPECT().ClaimNonce(gomock.Any(), gomock.Any()).Return(false, errFoo).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error kiosk of another tenant",
+			ctx:  employeeCtx,
+			patch: func() {
+				mockAuthRepo.EXPECT().VerifyKioskNonce("nonce").Return(kioskNonce(2), nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error deactivated kiosk",
+			ctx:  employeeCtx,
+			patch: func() {
+				mockAuthRepo.EXPECT().VerifyKioskNonce("nonce").Return(kioskNonce(1), nil).Times(1)
+				mockKioskRepo.EXPECT().GetKioskByID(gomock.Any(), int64(3)).Return(model.MstKiosk{ID: 3}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error without user",
+			ctx:     context.Background(),
+			patch:   func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				Cfg:          config.Attendance{OfficeLocations: []config.OfficeLocation{{Name: "HQ", RadiusInMeters: 100}}},
+				AttendanceDB: mockAttendanceRepo,
+				KioskDB:      mockKioskRepo,
+				ScheduleDB:   mockScheduleRepo,
+				AuthRepo:     mockAuthRepo,
+			}
+			timeNow = func() time.Time { return mockNow }
+			defer func() { timeNow = time.Now }()
+			tt.patch()
+			got, err := u.TapInAtKiosk(tt.ctx, model.KioskTapInRequest{Nonce: "nonce"})
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_TapInWithBadge(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	mockNow := time.Date(2025, 7, 21, 8, 0, 0, 0, time.UTC)
+	kioskCtx := auth.SetKioskDetailToCtx(context.Background(), auth.KioskJWTPayload{ID: 3, Name: "Warehouse gate"})
+	kiosk := model.MstKiosk{ID: 3, Name: "Warehouse gate", Active: true}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		patch   func()
+		want    model.TapInResponse
+		wantErr bool
+	}{
+		{
+			name: "success",
+			ctx:  kioskCtx,
+			patch: func() {
+				expectStandardSchedule()
+				mockKioskRepo.EXPECT().GetKioskByID(gomock.Any(), int64(3)).Return(kiosk, nil).Times(1)
+				mockUserRepo.EXPECT().GetUserByBadgeID(gomock.Any(), "B-0001").
+					Return(model.MstUser{ID: 2, Username: "employee_001"}, nil).Times(1)
+				mockAttendanceRepo.EXPECT().GetAttendance(gomock.Any(), gomock.Any()).Return(model.MstAttendance{}, nil).Times(1)
+				mockAttendanceRepo.EXPECT().RecordAttendance(gomock.Any(), &model.MstAttendance{
+					IDMstUser:      2,
+					AttendanceDate: mockNow,
+					Source:         constant.AttendanceSourceKioskBadge,
+					IDMstKiosk:     sql.NullInt64{Int64: 3, Valid: true},
+				}).Return(nil).Times(1)
+			},
+			want: model.TapInResponse{
+				AttendanceDate: mockNow,
+				Username:       "employee_001",
+			},
+		},
+		{
+			name: "error unknown badge",
+			ctx:  kioskCtx,
+			patch: func() {
+				mockKioskRepo.EXPECT().GetKioskByID(gomock.Any(), int64(3)).Return(kiosk, nil).Times(1)
+				mockUserRepo.EXPECT().GetUserByBadgeID(gomock.Any(), "B-0001").Return(model.MstUser{}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error user token",
+			ctx:     auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin}),
+			patch:   func() {},
+			wantErr: true,
+		},
+		{
+			name: "error during lookup",
+			ctx:  kioskCtx,
+			patch: func() {
+				mockKioskRepo.EXPECT().GetKioskByID(gomock.Any(), int64(3)).Return(model.MstKiosk{}, errFoo).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+				UserDB:       mockUserRepo,
+				KioskDB:      mockKioskRepo,
+				ScheduleDB:   mockScheduleRepo,
+			}
+			timeNow = func() time.Time { return mockNow }
+			defer func() { timeNow = time.Now }()
+			tt.patch()
+			got, err := u.TapInWithBadge(tt.ctx, model.BadgeTapInRequest{BadgeID: "B-0001"})
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package attendance
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/config"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/faisalhardin/employee-payroll-system/pkg/geo"
+	"github.com/pkg/errors"
+)
+
+// locate stores where the employee tapped in and checks it against the
+// offices. Office employees outside every office, or without coordinates,
+// are refused. Hybrid employees are let in and flagged instead and remote
+// employees are not checked.
+func (u *Usecase) locate(attendance *model.MstAttendance, workArrangement string, req model.TapInRequest) error {
+	if req.Latitude != nil && req.Longitude != nil {
+		attendance.Latitude = sql.NullFloat64{Float64: *req.Latitude, Valid: true}
+		attendance.Longitude = sql.NullFloat64{Float64: *req.Longitude, Valid: true}
+	}
+
+	offices := u.Cfg.OfficeLocations
+	if len(offices) == 0 {
+		return nil
+	}
+	if workArrangement == constant.WorkArrangementRemote {
+		attendance.LocationStatus = constant.LocationStatusRemote
+		return nil
+	}
+
+	if !attendance.Latitude.Valid {
+		if workArrangement == constant.WorkArrangementHybrid {
+			attendance.LocationStatus = constant.LocationStatusUnknown
+			return nil
+		}
+		return commonerr.SetNewBadRequest("location_required", "latitude and longitude are required to tap in")
+	}
+
+	office, distance, inside := nearestOffice(offices, geo.Point{
+		Latitude:  attendance.Latitude.Float64,
+		Longitude: attendance.Longitude.Float64,
+	})
+	attendance.OfficeName = office.Name
+	attendance.DistanceInMeters = sql.NullInt64{Int64: int64(math.Round(distance)), Valid: true}
+	if inside {
+		attendance.LocationStatus = constant.LocationStatusInside
+		return nil
+	}
+
+	if workArrangement == constant.WorkArrangementHybrid {
+		attendance.LocationStatus = constant.LocationStatusOutside
+		return nil
+	}
+	return commonerr.SetNewError(http.StatusForbidden, "outside_office",
+		fmt.Sprintf("tap in is %.0f meters from %s, outside its %.0f meters radius", distance, office.Name, office.RadiusInMeters))
+}
+
+// nearestOffice picks the closest office containing the point, or the
+// closest office when none does.
+func nearestOffice(offices []config.OfficeLocation, point geo.Point) (nearest config.OfficeLocation, distance float64, inside bool) {
+	distance = math.Inf(1)
+	for _, office := range offices {
+		d := geo.Distance(point, geo.Point{Latitude: office.Latitude, Longitude: office.Longitude})
+		in := d <= office.RadiusInMeters
+		if (in && !inside) || (in == inside && d < distance) {
+			nearest, distance, inside = office, d, in
+		}
+	}
+	return
+}
+
+// checkDevice makes sure the tap in comes from the device bound to the
+// employee, binding it on the first tap in. Nothing is checked unless
+// BindDevice is on.
+func (u *Usecase) checkDevice(ctx context.Context, employee model.MstUser, deviceID string) error {
+	if !u.Cfg.BindDevice {
+		return nil
+	}
+	if deviceID == "" {
+		return commonerr.SetNewBadRequest("device_required", "device_id is required to tap in")
+	}
+	if employee.DeviceID == deviceID {
+		return nil
+	}
+	if employee.DeviceID == "" {
+		bound, err := u.UserDB.BindDevice(ctx, employee.ID, deviceID)
+		if err != nil {
+			return errors.Wrap(err, "Usecase.checkDevice")
+		}
+		if bound {
+			return nil
+		}
+	}
+	return commonerr.SetNewError(http.StatusForbidden, "unknown_device", "tap in from a device that is not bound to the employee, ask an admin to reset it")
+}
+
+func isFlagged(locationStatus string) bool {
+	return locationStatus == constant.LocationStatusOutside || locationStatus == constant.LocationStatusUnknown
+}
@@ -0,0 +1,54 @@
+package attendance
+
+import (
+	"testing"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/config"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_locate(t *testing.T) {
+	// the branch office is closer to home but has a smaller radius
+	offices := []config.OfficeLocation{
+		{Name: "HQ", Latitude: -6.175392, Longitude: 106.827153, RadiusInMeters: 3000},
+		{Name: "Branch", Latitude: -6.200000, Longitude: 106.823000, RadiusInMeters: 100},
+	}
+	home := model.TapInRequest{Latitude: ptr(-6.194911), Longitude: ptr(106.823102)}
+	beach := model.TapInRequest{Latitude: ptr(-6.122), Longitude: ptr(106.833)}
+
+	tests := []struct {
+		name            string
+		offices         []config.OfficeLocation
+		workArrangement string
+		req             model.TapInRequest
+		wantStatus      string
+		wantOffice      string
+		wantErr         bool
+	}{
+		{name: "fencing off", workArrangement: constant.WorkArrangementOffice, req: beach},
+		{name: "inside a larger office further away", offices: offices, workArrangement: constant.WorkArrangementOffice, req: home, wantStatus: constant.LocationStatusInside, wantOffice: "HQ"},
+		{name: "office outside", offices: offices, workArrangement: constant.WorkArrangementOffice, req: beach, wantOffice: "HQ", wantErr: true},
+		{name: "office without location", offices: offices, workArrangement: constant.WorkArrangementOffice, wantErr: true},
+		{name: "unset arrangement is office", offices: offices, req: beach, wantOffice: "HQ", wantErr: true},
+		{name: "hybrid outside", offices: offices, workArrangement: constant.WorkArrangementHybrid, req: beach, wantStatus: constant.LocationStatusOutside, wantOffice: "HQ"},
+		{name: "hybrid without location", offices: offices, workArrangement: constant.WorkArrangementHybrid, wantStatus: constant.LocationStatusUnknown},
+		{name: "remote", offices: offices, workArrangement: constant.WorkArrangementRemote, req: beach, wantStatus: constant.LocationStatusRemote},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{Cfg: config.Attendance{OfficeLocations: tt.offices}}
+			attendance := model.MstAttendance{}
+			err := u.locate(&attendance, tt.workArrangement, tt.req)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantStatus, attendance.LocationStatus)
+			assert.Equal(t, tt.wantOffice, attendance.OfficeName)
+			assert.Equal(t, tt.req.Latitude != nil, attendance.Latitude.Valid)
+		})
+	}
+}
+
+func ptr(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,355 @@
+package attendance
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+)
+
+const (
+	// unassignedGroupName names the group of employees without an assignment
+	// in the labour cost report
+	unassignedGroupName = "Unassigned"
+)
+
+// CreateDepartment defines a department employees can be assigned to. Admin
+// only.
+func (u *Usecase) CreateDepartment(ctx context.Context, request model.DepartmentRequest) (resp model.MstDepartment, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.CreateDepartment")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.CreateDepartment")
+		return
+	}
+
+	department := &model.MstDepartment{
+		Code:      request.Code,
+		Name:      request.Name,
+		CreatedBy: sql.NullInt64{Int64: user.ID, Valid: true},
+	}
+	err = u.OrganizationDB.CreateDepartment(ctx, department)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.CreateDepartment")
+		return
+	}
+	return *department, nil
+}
+
+// ListDepartments lists every department. Admin only.
+func (u *Usecase) ListDepartments(ctx context.Context) (resp []model.MstDepartment, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.ListDepartments")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.ListDepartments")
+		return
+	}
+
+	resp, err = u.OrganizationDB.ListDepartments(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListDepartments")
+		return
+	}
+	if resp == nil {
+		resp = []model.MstDepartment{}
+	}
+	return
+}
+
+// CreateCostCentre defines a cost centre employees can be assigned to. Admin
+// only.
+func (u *Usecase) CreateCostCentre(ctx context.Context, request model.CostCentreRequest) (resp model.MstCostCentre, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.CreateCostCentre")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.CreateCostCentre")
+		return
+	}
+
+	costCentre := &model.MstCostCentre{
+		Code:      request.Code,
+		Name:      request.Name,
+		CreatedBy: sql.NullInt64{Int64: user.ID, Valid: true},
+	}
+	err = u.OrganizationDB.CreateCostCentre(ctx, costCentre)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.CreateCostCentre")
+		return
+	}
+	return *costCentre, nil
+}
+
+// ListCostCentres lists every cost centre. Admin only.
+func (u *Usecase) ListCostCentres(ctx context.Context) (resp []model.MstCostCentre, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.ListCostCentres")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.ListCostCentres")
+		return
+	}
+
+	resp, err = u.OrganizationDB.ListCostCentres(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListCostCentres")
+		return
+	}
+	if resp == nil {
+		resp = []model.MstCostCentre{}
+	}
+	return
+}
+
+// AssignOrganization moves an employee to a department and a cost centre
+// from the start date, ending the assignment they have the day before.
+// Admin only.
+func (u *Usecase) AssignOrganization(ctx context.Context, request model.AssignOrganizationRequest) (resp model.TrxUserAssignment, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.AssignOrganization")
+	defer func() { tracing.End(span, err) }()
+	ctx = xormlib.WithPrimary(ctx)
+
+	admin, found := authGetUserDetailFromCtx(ctx)
+	if !found || admin.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.AssignOrganization")
+		return
+	}
+
+	startDate, err := time.ParseInLocation(dateFormat, request.StartDate, timeNow().Location())
+	if err != nil {
+		err = commonerr.SetNewBadRequest("invalid", "start_date must be a date")
+		return
+	}
+
+	err = u.checkEmployee(ctx, request.UserID)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.AssignOrganization")
+		return
+	}
+
+	departments, err := u.OrganizationDB.ListDepartments(ctx, []int64{request.DepartmentID})
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.AssignOrganization")
+		return
+	}
+	if len(departments) == 0 {
+		err = commonerr.SetNewBadRequest("invalid", "department not found")
+		return
+	}
+
+	costCentres, err := u.OrganizationDB.ListCostCentres(ctx, []int64{request.CostCentreID})
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.AssignOrganization")
+		return
+	}
+	if len(costCentres) == 0 {
+		err = commonerr.SetNewBadRequest("invalid", "cost centre not found")
+		return
+	}
+
+	// processed payroll stays reported under the groups it was paid in
+	err = u.checkPayrollNotProcessed(ctx, startDate, time.Time{})
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.AssignOrganization")
+		return
+	}
+
+	assignment := &model.TrxUserAssignment{
+		IDMstUser:       request.UserID,
+		IDMstDepartment: request.DepartmentID,
+		IDMstCostCentre: request.CostCentreID,
+		StartDate:       startDate,
+		CreatedBy:       sql.NullInt64{Int64: admin.ID, Valid: true},
+	}
+	assigned, err := u.OrganizationDB.AssignOrganization(ctx, assignment)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.AssignOrganization")
+		return
+	}
+	if !assigned {
+		err = commonerr.SetNewBadRequest("invalid", "the employee has an assignment starting on or after start_date")
+		return
+	}
+	return *assignment, nil
+}
+
+// ListAssignments lists the departments and cost centres an employee has
+// been assigned to, oldest first. Admin only.
+func (u *Usecase) ListAssignments(ctx context.Context, userID int64) (resp []model.AssignmentResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.ListAssignments")
+	defer func() { tracing.End(span, err) }()
+
+	admin, found := authGetUserDetailFromCtx(ctx)
+	if !found || admin.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.ListAssignments")
+		return
+	}
+
+	err = u.checkEmployee(ctx, userID)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListAssignments")
+		return
+	}
+
+	assignments, err := u.OrganizationDB.ListAssignments(ctx, userID)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListAssignments")
+		return
+	}
+	resp = []model.AssignmentResponse{}
+	if len(assignments) == 0 {
+		return
+	}
+
+	departmentIDs, costCentreIDs := []int64{}, []int64{}
+	for _, assignment := range assignments {
+		departmentIDs = append(departmentIDs, assignment.IDMstDepartment)
+		costCentreIDs = append(costCentreIDs, assignment.IDMstCostCentre)
+	}
+	departments, err := u.OrganizationDB.ListDepartments(ctx, departmentIDs)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListAssignments")
+		return
+	}
+	costCentres, err := u.OrganizationDB.ListCostCentres(ctx, costCentreIDs)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListAssignments")
+		return
+	}
+	departmentNames := make(map[int64]string, len(departments))
+	for _, department := range departments {
+		departmentNames[department.ID] = department.Name
+	}
+	costCentreNames := make(map[int64]string, len(costCentres))
+	for _, costCentre := range costCentres {
+		costCentreNames[costCentre.ID] = costCentre.Name
+	}
+
+	for _, assignment := range assignments {
+		resp = append(resp, model.AssignmentResponse{
+			ID:             assignment.ID,
+			UserID:         assignment.IDMstUser,
+			DepartmentID:   assignment.IDMstDepartment,
+			DepartmentName: departmentNames[assignment.IDMstDepartment],
+			CostCentreID:   assignment.IDMstCostCentre,
+			CostCentreName: costCentreNames[assignment.IDMstCostCentre],
+			StartDate:      assignment.StartDate,
+			EndDate:        assignment.EndDate,
+		})
+	}
+	return
+}
+
+// GetLabourCost reports the salary, overtime, reimbursements and headcount
+// of every department or cost centre over one payroll period, or over every
+// period from the start period to the end period. Only processed periods
+// count. Admin only.
+func (u *Usecase) GetLabourCost(ctx context.Context, request model.LabourCostRequest) (resp model.LabourCostResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.GetLabourCost")
+	defer func() { tracing.End(span, err) }()
+
+	admin, found := authGetUserDetailFromCtx(ctx)
+	if !found || admin.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.GetLabourCost")
+		return
+	}
+
+	startPeriod, err := u.AttendanceDB.GetPayrollPeriod(ctx, request.StartPeriodID)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.GetLabourCost")
+		return
+	}
+	if startPeriod.ID == 0 {
+		err = commonerr.SetNewError(http.StatusNotFound, "not found", "payroll period not found")
+		return
+	}
+
+	endPeriod := startPeriod
+	if request.EndPeriodID != 0 && request.EndPeriodID != request.StartPeriodID {
+		endPeriod, err = u.AttendanceDB.GetPayrollPeriod(ctx, request.EndPeriodID)
+		if err != nil {
+			err = errors.Wrap(err, "Usecase.GetLabourCost")
+			return
+		}
+		if endPeriod.ID == 0 {
+			err = commonerr.SetNewError(http.StatusNotFound, "not found", "payroll period not found")
+			return
+		}
+		if endPeriod.StartDate.Before(startPeriod.StartDate) {
+			err = commonerr.SetNewBadRequest("invalid", "end_period_id must not come before start_period_id")
+			return
+		}
+	}
+
+	rows, err := u.OrganizationDB.ListLabourCost(ctx, model.LabourCostParams{
+		GroupBy:   request.GroupBy,
+		StartDate: startPeriod.StartDate,
+		EndDate:   endPeriod.EndDate,
+	})
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.GetLabourCost")
+		return
+	}
+
+	resp = sumLabourCost(rows)
+	resp.GroupBy = request.GroupBy
+	resp.StartDate = startPeriod.StartDate
+	resp.EndDate = endPeriod.EndDate
+	return
+}
+
+// sumLabourCost sums the rows of every employee into their groups, keeping
+// the order of the rows. An employee counts once in the headcount of every
+// group they were paid in and once in the total.
+func sumLabourCost(rows []model.LabourCostRow) (resp model.LabourCostResponse) {
+	resp.Groups = []model.LabourCostGroupResponse{}
+	groupIndex := map[int64]int{}
+	employees := map[int64]bool{}
+	for _, row := range rows {
+		index, found := groupIndex[row.GroupID]
+		if !found {
+			name := row.GroupName
+			if row.GroupID == 0 {
+				name = unassignedGroupName
+			}
+			index = len(resp.Groups)
+			groupIndex[row.GroupID] = index
+			resp.Groups = append(resp.Groups, model.LabourCostGroupResponse{
+				ID:   row.GroupID,
+				Code: row.GroupCode,
+				Name: name,
+			})
+		}
+
+		// the rows of a group are one per employee
+		resp.Groups[index].Headcount++
+		addLabourCost(&resp.Groups[index], row)
+		addLabourCost(&resp.Total, row)
+		if !employees[row.IDMstUser] {
+			employees[row.IDMstUser] = true
+			resp.Total.Headcount++
+		}
+	}
+	return
+}
+
+func addLabourCost(group *model.LabourCostGroupResponse, row model.LabourCostRow) {
+	group.Salary += row.Salary
+	group.OvertimeHours += row.OvertimeHours
+	group.OvertimePay += row.OvertimePay
+	group.TotalReimbursements += row.TotalReimbursements
+	group.TotalCost += row.TotalCost
+}
@@ -0,0 +1,492 @@
+package attendance
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateDepartment(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+	employeeCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 2, Role: constant.UserRoleEmployee})
+	request := model.DepartmentRequest{Code: "ENG", Name: "Engineering"}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		patch   func()
+		want    model.MstDepartment
+		wantErr bool
+	}{
+		{
+			name: "success",
+			ctx:  adminCtx,
+			patch: func() {
+				mockOrganizationRepo.EXPECT().CreateDepartment(gomock.Any(), &model.MstDepartment{
+					Code:      "ENG",
+					Name:      "Engineering",
+					CreatedBy: sql.NullInt64{Int64: 1, Valid: true},
+				}).DoAndReturn(func(_ context.Context, department *model.MstDepartment) error {
+					department.ID = 3
+					return nil
+				}).Times(1)
+			},
+			want: model.MstDepartment{
+				ID:        3,
+				Code:      "ENG",
+				Name:      "Engineering",
+				CreatedBy: sql.NullInt64{Int64: 1, Valid: true},
+			},
+		},
+		{
+			name: "error creating",
+			ctx:  adminCtx,
+			patch: func() {
+				mockOrganizationRepo.EXPECT().CreateDepartment(gomock.Any(), gomock.Any()).Return(errFoo).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error not admin",
+			ctx:     employeeCtx,
+			patch:   func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				OrganizationDB: mockOrganizationRepo,
+			}
+			tt.patch()
+			got, err := u.CreateDepartment(tt.ctx, request)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_CreateCostCentre(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+	employeeCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 2, Role: constant.UserRoleEmployee})
+	request := model.CostCentreRequest{Code: "CC-100", Name: "Head office"}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		patch   func()
+		want    model.MstCostCentre
+		wantErr bool
+	}{
+		{
+			name: "success",
+			ctx:  adminCtx,
+			patch: func() {
+				mockOrganizationRepo.EXPECT().CreateCostCentre(gomock.Any(), &model.MstCostCentre{
+					Code:      "CC-100",
+					Name:      "Head office",
+					CreatedBy: sql.NullInt64{Int64: 1, Valid: true},
+				}).DoAndReturn(func(_ context.Context, costCentre *model.MstCostCentre) error {
+					costCentre.ID = 7
+					return nil
+				}).Times(1)
+			},
+			want: model.MstCostCentre{
+				ID:        7,
+				Code:      "CC-100",
+				Name:      "Head office",
+				CreatedBy: sql.NullInt64{Int64: 1, Valid: true},
+			},
+		},
+		{
+			name: "error creating",
+			ctx:  adminCtx,
+			patch: func() {
+				mockOrganizationRepo.EXPECT().CreateCostCentre(gomock.Any(), gomock.Any()).Return(errFoo).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error not admin",
+			ctx:     employeeCtx,
+			patch:   func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				OrganizationDB: mockOrganizationRepo,
+			}
+			tt.patch()
+			got, err := u.CreateCostCentre(tt.ctx, request)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_AssignOrganization(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	mockNow := time.Date(2025, 7, 23, 18, 0, 0, 0, time.UTC)
+	startDate := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+	request := model.AssignOrganizationRequest{UserID: 2, DepartmentID: 3, CostCentreID: 4, StartDate: "2025-08-01"}
+	processed := []model.MstPayrollPeriod{{
+		ID:        1,
+		StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC),
+	}}
+	expectChecks := func() {
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), int64(2)).Return(model.MstUser{ID: 2}, nil).Times(1)
+		mockOrganizationRepo.EXPECT().ListDepartments(gomock.Any(), []int64{3}).Return([]model.MstDepartment{{ID: 3}}, nil).Times(1)
+		mockOrganizationRepo.EXPECT().ListCostCentres(gomock.Any(), []int64{4}).Return([]model.MstCostCentre{{ID: 4}}, nil).Times(1)
+		mockAttendanceRepo.EXPECT().ListPayrollPeriodByParams(gomock.Any(), model.ListPayrollPeriodParams{
+			Status: constant.PayrollPeriodStatusProcessed,
+		}).Return(processed, nil).Times(1)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		request model.AssignOrganizationRequest
+		patch   func()
+		want    model.TrxUserAssignment
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			ctx:     adminCtx,
+			request: request,
+			patch: func() {
+				expectChecks()
+				mockOrganizationRepo.EXPECT().AssignOrganization(gomock.Any(), &model.TrxUserAssignment{
+					IDMstUser:       2,
+					IDMstDepartment: 3,
+					IDMstCostCentre: 4,
+					StartDate:       startDate,
+					CreatedBy:       sql.NullInt64{Int64: 1, Valid: true},
+				}).DoAndReturn(func(_ context.Context, assignment *model.TrxUserAssignment) (bool, error) {
+					assignment.ID = 5
+					return true, nil
+				}).Times(1)
+			},
+			want: model.TrxUserAssignment{
+				ID:              5,
+				IDMstUser:       2,
+				IDMstDepartment: 3,
+				IDMstCostCentre: 4,
+				StartDate:       startDate,
+				CreatedBy:       sql.NullInt64{Int64: 1, Valid: true},
+			},
+		},
+		{
+			name:    "error later assignment exists",
+			ctx:     adminCtx,
+			request: request,
+			patch: func() {
+				expectChecks()
+				mockOrganizationRepo.EXPECT().AssignOrganization(gomock.Any(), gomock.Any()).Return(false, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error payroll processed",
+			ctx:     adminCtx,
+			request: model.AssignOrganizationRequest{UserID: 2, DepartmentID: 3, CostCentreID: 4, StartDate: "2025-07-21"},
+			patch: func() {
+				expectChecks()
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error unknown cost centre",
+			ctx:     adminCtx,
+			request: request,
+			patch: func() {
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), int64(2)).Return(model.MstUser{ID: 2}, nil).Times(1)
+				mockOrganizationRepo.EXPECT().ListDepartments(gomock.Any(), []int64{3}).Return([]model.MstDepartment{{ID: 3}}, nil).Times(1)
+				mockOrganizationRepo.EXPECT().ListCostCentres(gomock.Any(), []int64{4}).Return(nil, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error unknown department",
+			ctx:     adminCtx,
+			request: request,
+			patch: func() {
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), int64(2)).Return(model.MstUser{ID: 2}, nil).Times(1)
+				mockOrganizationRepo.EXPECT().ListDepartments(gomock.Any(), []int64{3}).Return(nil, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error user not found",
+			ctx:     adminCtx,
+			request: request,
+			patch: func() {
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), int64(2)).Return(model.MstUser{}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error invalid start date",
+			ctx:     adminCtx,
+			request: model.AssignOrganizationRequest{UserID: 2, DepartmentID: 3, CostCentreID: 4, StartDate: "2025-13-01"},
+			patch:   func() {},
+			wantErr: true,
+		},
+		{
+			name:    "error not admin",
+			ctx:     auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 2, Role: constant.UserRoleEmployee}),
+			request: request,
+			patch:   func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB:   mockAttendanceRepo,
+				UserDB:         mockUserRepo,
+				OrganizationDB: mockOrganizationRepo,
+			}
+			timeNow = func() time.Time { return mockNow }
+			defer func() { timeNow = time.Now }()
+			tt.patch()
+			got, err := u.AssignOrganization(tt.ctx, tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Usecase.AssignOrganization() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ListAssignments(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+	firstStart := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	secondStart := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	firstEnd := sql.NullTime{Time: time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC), Valid: true}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		patch   func()
+		want    []model.AssignmentResponse
+		wantErr bool
+	}{
+		{
+			name: "success",
+			ctx:  adminCtx,
+			patch: func() {
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), int64(2)).Return(model.MstUser{ID: 2}, nil).Times(1)
+				mockOrganizationRepo.EXPECT().ListAssignments(gomock.Any(), int64(2)).Return([]model.TrxUserAssignment{
+					{ID: 5, IDMstUser: 2, IDMstDepartment: 3, IDMstCostCentre: 4, StartDate: firstStart, EndDate: firstEnd},
+					{ID: 6, IDMstUser: 2, IDMstDepartment: 8, IDMstCostCentre: 4, StartDate: secondStart},
+				}, nil).Times(1)
+				mockOrganizationRepo.EXPECT().ListDepartments(gomock.Any(), []int64{3, 8}).
+					Return([]model.MstDepartment{{ID: 3, Name: "Engineering"}, {ID: 8, Name: "Finance"}}, nil).Times(1)
+				mockOrganizationRepo.EXPECT().ListCostCentres(gomock.Any(), []int64{4, 4}).
+					Return([]model.MstCostCentre{{ID: 4, Name: "Head office"}}, nil).Times(1)
+			},
+			want: []model.AssignmentResponse{
+				{ID: 5, UserID: 2, DepartmentID: 3, DepartmentName: "Engineering", CostCentreID: 4, CostCentreName: "Head office", StartDate: firstStart, EndDate: firstEnd},
+				{ID: 6, UserID: 2, DepartmentID: 8, DepartmentName: "Finance", CostCentreID: 4, CostCentreName: "Head office", StartDate: secondStart},
+			},
+		},
+		{
+			name: "success without assignments",
+			ctx:  adminCtx,
+			patch: func() {
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), int64(2)).Return(model.MstUser{ID: 2}, nil).Times(1)
+				mockOrganizationRepo.EXPECT().ListAssignments(gomock.Any(), int64(2)).Return(nil, nil).Times(1)
+			},
+			want: []model.AssignmentResponse{},
+		},
+		{
+			name: "error listing assignments",
+			ctx:  adminCtx,
+			patch: func() {
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), int64(2)).Return(model.MstUser{ID: 2}, nil).Times(1)
+				mockOrganizationRepo.EXPECT().ListAssignments(gomock.Any(), int64(2)).Return(nil, errFoo).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error not admin",
+			ctx:     auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 2, Role: constant.UserRoleEmployee}),
+			patch:   func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				UserDB:         mockUserRepo,
+				OrganizationDB: mockOrganizationRepo,
+			}
+			tt.patch()
+			got, err := u.ListAssignments(tt.ctx, 2)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_GetLabourCost(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+	july := model.MstPayrollPeriod{
+		ID:        4,
+		StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC),
+	}
+	september := model.MstPayrollPeriod{
+		ID:        6,
+		StartDate: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC),
+	}
+	rows := []model.LabourCostRow{
+		{GroupID: 3, GroupCode: "ENG", GroupName: "Engineering", IDMstUser: 2, Salary: 100, OvertimeHours: 2, OvertimePay: 10, TotalReimbursements: 5, TotalCost: 115},
+		{GroupID: 3, GroupCode: "ENG", GroupName: "Engineering", IDMstUser: 3, Salary: 200, TotalCost: 200},
+		{GroupID: 8, GroupCode: "FIN", GroupName: "Finance", IDMstUser: 2, Salary: 50, TotalCost: 50},
+		{IDMstUser: 9, Salary: 80, TotalReimbursements: 20, TotalCost: 100},
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		request model.LabourCostRequest
+		patch   func()
+		want    model.LabourCostResponse
+		wantErr bool
+	}{
+		{
+			name:    "success over a range of periods",
+			ctx:     adminCtx,
+			request: model.LabourCostRequest{GroupBy: constant.LabourCostGroupByDepartment, StartPeriodID: 4, EndPeriodID: 6},
+			patch: func() {
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(4)).Return(july, nil).Times(1)
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(6)).Return(september, nil).Times(1)
+				mockOrganizationRepo.EXPECT().ListLabourCost(gomock.Any(), model.LabourCostParams{
+					GroupBy:   constant.LabourCostGroupByDepartment,
+					StartDate: july.StartDate,
+					EndDate:   september.EndDate,
+				}).Return(rows, nil).Times(1)
+			},
+			want: model.LabourCostResponse{
+				GroupBy:   constant.LabourCostGroupByDepartment,
+				StartDate: july.StartDate,
+				EndDate:   september.EndDate,
+				Groups: []model.LabourCostGroupResponse{
+					{ID: 3, Code: "ENG", Name: "Engineering", Headcount: 2, Salary: 300, OvertimeHours: 2, OvertimePay: 10, TotalReimbursements: 5, TotalCost: 315},
+					{ID: 8, Code: "FIN", Name: "Finance", Headcount: 1, Salary: 50, TotalCost: 50},
+					{Name: unassignedGroupName, Headcount: 1, Salary: 80, TotalReimbursements: 20, TotalCost: 100},
+				},
+				Total: model.LabourCostGroupResponse{Headcount: 3, Salary: 430, OvertimeHours: 2, OvertimePay: 10, TotalReimbursements: 25, TotalCost: 465},
+			},
+		},
+		{
+			name:    "success for one period without payslips",
+			ctx:     adminCtx,
+			request: model.LabourCostRequest{GroupBy: constant.LabourCostGroupByCostCentre, StartPeriodID: 4},
+			patch: func() {
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(4)).Return(july, nil).Times(1)
+				mockOrganizationRepo.EXPECT().ListLabourCost(gomock.Any(), model.LabourCostParams{
+					GroupBy:   constant.LabourCostGroupByCostCentre,
+					StartDate: july.StartDate,
+					EndDate:   july.EndDate,
+				}).Return(nil, nil).Times(1)
+			},
+			want: model.LabourCostResponse{
+				GroupBy:   constant.LabourCostGroupByCostCentre,
+				StartDate: july.StartDate,
+				EndDate:   july.EndDate,
+				Groups:    []model.LabourCostGroupResponse{},
+			},
+		},
+		{
+			name:    "error end period before start period",
+			ctx:     adminCtx,
+			request: model.LabourCostRequest{GroupBy: constant.LabourCostGroupByDepartment, StartPeriodID: 6, EndPeriodID: 4},
+			patch: func() {
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(6)).Return(september, nil).Times(1)
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(4)).Return(july, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error end period not found",
+			ctx:     adminCtx,
+			request: model.LabourCostRequest{GroupBy: constant.LabourCostGroupByDepartment, StartPeriodID: 4, EndPeriodID: 6},
+			patch: func() {
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(4)).Return(july, nil).Times(1)
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(6)).Return(model.MstPayrollPeriod{}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error start period not found",
+			ctx:     adminCtx,
+			request: model.LabourCostRequest{GroupBy: constant.LabourCostGroupByDepartment, StartPeriodID: 4},
+			patch: func() {
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(4)).Return(model.MstPayrollPeriod{}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error listing labour cost",
+			ctx:     adminCtx,
+			request: model.LabourCostRequest{GroupBy: constant.LabourCostGroupByDepartment, StartPeriodID: 4},
+			patch: func() {
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(4)).Return(july, nil).Times(1)
+				mockOrganizationRepo.EXPECT().ListLabourCost(gomock.Any(), gomock.Any()).Return(nil, errFoo).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error not admin",
+			ctx:     auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 2, Role: constant.UserRoleEmployee}),
+			request: model.LabourCostRequest{GroupBy: constant.LabourCostGroupByDepartment, StartPeriodID: 4},
+			patch:   func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB:   mockAttendanceRepo,
+				OrganizationDB: mockOrganizationRepo,
+			}
+			tt.patch()
+			got, err := u.GetLabourCost(tt.ctx, tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Usecase.GetLabourCost() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -8,13 +8,15 @@ import (
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
 	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/faisalhardin/employee-payroll-system/pkg/metrics"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 )
 
 const (
-	OvertimeMultiplier = int64(2)
-	WorkingHours       = int64(8)
+	WorkingHours = int64(8)
 )
 
 var (
@@ -29,9 +31,32 @@ var (
 	usecaseUpdateAttendanceInBulk             = (*Usecase).updateAttendanceInBulk
 	usecaseUpdatePayrollPeriod                = (*Usecase).updatePayrollPeriod
 	usecaseGetMapOfPayslipSummary             = (*Usecase).getMapOfPayslipSummary
+	usecaseGeneratePayroll                    = (*Usecase).generatePayroll
+
+	metricsObservePayrollGeneration = metrics.ObservePayrollGeneration
 )
 
-func (u *Usecase) GeneratePayroll(ctx context.Context, request model.GeneratePayrollRequest) (err error) {
+// payrollPolicy loads the payroll policy of the tenant in the context.
+func (u *Usecase) payrollPolicy(ctx context.Context) (policy model.MstTenant, err error) {
+	policy, err = u.TenantDB.GetTenant(ctx)
+	if err != nil {
+		return policy, errors.Wrap(err, "Usecase.payrollPolicy")
+	}
+
+	if policy.ID == 0 {
+		return policy, errors.Wrap(errors.New("tenant not found"), "Usecase.payrollPolicy")
+	}
+
+	return policy, nil
+}
+
+// generatePayroll calculates and stores the payroll of a period, calling
+// reportProgress whenever a stage starts.
+func (u *Usecase) generatePayroll(ctx context.Context, request model.GeneratePayrollRequest, reportProgress func(stage string, progress int)) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.generatePayroll")
+	defer func() { tracing.End(span, err) }()
+	ctx = xormlib.WithPrimary(ctx)
+
 	user, found := authGetUserDetailFromCtx(ctx)
 	if !found {
 		err = errors.Wrap(errors.New("user not found"), "Usecase.GeneratePayroll")
@@ -51,16 +76,35 @@ func (u *Usecase) GeneratePayroll(ctx context.Context, request model.GeneratePay
 		return commonerr.SetNewBadRequest("invalid", "payroll has been processed")
 	}
 
-	numberOfWorkingDays := usecaseGetNumberOfWorkingDays(u, payrollPeriod.StartDate, payrollPeriod.EndDate)
+	var (
+		startedAt        = time.Now()
+		totalTakeHomePay int64
+		payslipSummary   map[int64]model.TrxUserPayslip
+	)
+	defer func() {
+		metricsObservePayrollGeneration(time.Since(startedAt), len(payslipSummary), totalTakeHomePay, err != nil)
+	}()
 
+	policy, err := u.payrollPolicy(ctx)
+	if err != nil {
+		return errors.Wrap(err, "Usecase.GeneratePayroll")
+	}
+
+	reportProgress("loading employees", 5)
 	employees, err := u.UserDB.ListUser(ctx)
 	if err != nil {
 		return errors.Wrap(err, "Usecase.GeneratePayroll")
 	}
 
-	payslipSummary := usecaseGetMapOfPayslipSummary(u, employees, numberOfWorkingDays, payrollPeriod.ID)
+	calendar, err := usecaseLoadWorkCalendar(u, ctx, nil, payrollPeriod.StartDate, payrollPeriod.EndDate)
+	if err != nil {
+		return errors.Wrap(err, "Usecase.GeneratePayroll")
+	}
+
+	payslipSummary = usecaseGetMapOfPayslipSummary(u, employees, calendar, payrollPeriod.StartDate, payrollPeriod.EndDate, payrollPeriod.ID)
 
 	// START attendance calculation
+	reportProgress("calculating attendance", 15)
 	payslipSummary, listOfAttendance, err := usecaseAttendanceCalculation(
 		u,
 		ctx,
@@ -75,6 +119,7 @@ func (u *Usecase) GeneratePayroll(ctx context.Context, request model.GeneratePay
 	// END attendance calculation
 
 	// START overtime calculation
+	reportProgress("calculating overtime", 30)
 	payslipSummary, listOfOvertime, err := usecaseOvertimeCalculation(
 		u,
 		ctx,
@@ -89,6 +134,7 @@ func (u *Usecase) GeneratePayroll(ctx context.Context, request model.GeneratePay
 	// END overtime calculation
 
 	//  START reimbursement calculation
+	reportProgress("calculating reimbursements", 45)
 	payslipSummary, listOfReimbursement, err := usecaseReimbursementCalculation(
 		u,
 		ctx,
@@ -102,14 +148,16 @@ func (u *Usecase) GeneratePayroll(ctx context.Context, request model.GeneratePay
 	}
 	// END reimbursement calculation
 
-	payslipSummary, totalTakeHomePay := usecaseCalculatePayslipSummaryTotalSalary(u, payslipSummary, numberOfWorkingDays)
+	reportProgress("calculating salaries", 55)
+	payslipSummary, totalTakeHomePay = usecaseCalculatePayslipSummaryTotalSalary(u, payslipSummary, int64(policy.OvertimeMultiplier))
 	payrollSummary := model.DtlPayroll{
 		IDMstPayrollPeriod: payrollPeriod.ID,
-		CreatedBy:          user.ID,
+		CreatedBy:          actorID(user),
 		TotalTakeHome:      totalTakeHomePay,
 	}
 
 	// store payroll summary
+	reportProgress("storing payslips", 65)
 	err = u.AttendanceDB.SubmitPayroll(ctx, payrollSummary)
 	if err != nil {
 		return errors.Wrap(err, "Usecase.GeneratePayroll")
@@ -122,24 +170,28 @@ func (u *Usecase) GeneratePayroll(ctx context.Context, request model.GeneratePay
 	}
 
 	// update attendance
+	reportProgress("updating attendance", 75)
 	err = usecaseUpdateAttendanceInBulk(u, ctx, listOfAttendance)
 	if err != nil {
 		return errors.Wrap(err, "Usecase.GeneratePayroll")
 	}
 
 	// update reimbursement
+	reportProgress("updating reimbursements", 85)
 	err = usecaseUpdateReimbursementInBulk(u, ctx, listOfReimbursement)
 	if err != nil {
 		return errors.Wrap(err, "Usecase.GeneratePayroll")
 	}
 
 	// update overtime
+	reportProgress("updating overtime", 90)
 	err = usecaseUpdateOvertimeInBulk(u, ctx, listOfOvertime)
 	if err != nil {
 		return errors.Wrap(err, "Usecase.GeneratePayroll")
 	}
 
 	// update payroll period
+	reportProgress("closing payroll period", 95)
 	err = usecaseUpdatePayrollPeriod(u, ctx, &payrollPeriod, user.ID)
 	if err != nil {
 		return errors.Wrap(err, "Usecase.GeneratePayroll")
@@ -148,27 +200,31 @@ func (u *Usecase) GeneratePayroll(ctx context.Context, request model.GeneratePay
 	return
 }
 
-func (u *Usecase) getNumberOfWorkingDays(startDate, endDate time.Time) int {
-	workingDays := 0
+// getNumberOfWorkingDays counts the days the employee is scheduled to work
+// between the dates and the hours of their shifts
+func (u *Usecase) getNumberOfWorkingDays(calendar workCalendar, userID int64, startDate, endDate time.Time) (workingDays, workingHours int) {
 	attendanceDate := startDate
 	for attendanceDate.Before(endDate) || attendanceDate.Equal(endDate) {
-		if attendanceDate.Weekday() != time.Saturday && attendanceDate.Weekday() != time.Sunday {
+		if shift, working := calendar.shiftOn(userID, attendanceDate); working {
 			workingDays++
+			workingHours += shift.WorkingHours
 		}
 		attendanceDate = attendanceDate.AddDate(0, 0, 1)
 	}
-	return workingDays
+	return workingDays, workingHours
 }
 
-func (u *Usecase) getMapOfPayslipSummary(employees []model.MstUser, workingDays int, payrollPeriodID int64) map[int64]model.TrxUserPayslip {
+func (u *Usecase) getMapOfPayslipSummary(employees []model.MstUser, calendar workCalendar, startDate, endDate time.Time, payrollPeriodID int64) map[int64]model.TrxUserPayslip {
 	payslipSummary := map[int64]model.TrxUserPayslip{}
 
 	for _, employee := range employees {
+		workingDays, workingHours := usecaseGetNumberOfWorkingDays(u, calendar, employee.ID, startDate, endDate)
 		payslipSummary[employee.ID] = model.TrxUserPayslip{
 			UserID:             employee.ID,
 			Username:           employee.Username,
 			BaseSalary:         employee.Salary,
 			WorkingDays:        workingDays,
+			WorkingHours:       workingHours,
 			IDMstPayrollPeriod: payrollPeriodID,
 		}
 	}
@@ -176,25 +232,37 @@ func (u *Usecase) getMapOfPayslipSummary(employees []model.MstUser, workingDays
 	return payslipSummary
 }
 
-func (u *Usecase) updatePayrollPeriod(ctx context.Context, payrollPeriod *model.MstPayrollPeriod, userID int64) error {
+func (u *Usecase) updatePayrollPeriod(ctx context.Context, payrollPeriod *model.MstPayrollPeriod, userID int64) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.updatePayrollPeriod")
+	defer func() { tracing.End(span, err) }()
+
 	payrollPeriod.PayrollProcessedDate = sql.NullTime{
 		Time:  time.Now(),
 		Valid: true,
 	}
 	payrollPeriod.UpdatedBy = sql.NullInt64{
 		Int64: userID,
-		Valid: true,
+		Valid: userID > 0,
 	}
-	err := u.AttendanceDB.UpdatePayrollPeriod(ctx, payrollPeriod)
+	err = u.AttendanceDB.UpdatePayrollPeriod(ctx, payrollPeriod)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// updateAttendanceInBulk tags the attendance with its payroll period using one
+// UPDATE per distinct period and updater instead of one per row
 func (u *Usecase) updateAttendanceInBulk(ctx context.Context, attendances []model.MstAttendance) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.updateAttendanceInBulk")
+	defer func() { tracing.End(span, err) }()
+
+	groups := payrollPeriodAssignments{}
 	for _, attendance := range attendances {
-		err = u.AttendanceDB.UpdateAttendance(ctx, &attendance)
+		groups.add(attendance.ID, attendance.IDMstPayrollPeriod, attendance.UpdatedBy, "")
+	}
+	for _, params := range groups.params {
+		err = u.AttendanceDB.AssignAttendancePayrollPeriod(ctx, params)
 		if err != nil {
 			return err
 		}
@@ -203,8 +271,15 @@ func (u *Usecase) updateAttendanceInBulk(ctx context.Context, attendances []mode
 }
 
 func (u *Usecase) updateOvertimeInBulk(ctx context.Context, overtimes []model.TrxOvertime) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.updateOvertimeInBulk")
+	defer func() { tracing.End(span, err) }()
+
+	groups := payrollPeriodAssignments{}
 	for _, overtime := range overtimes {
-		err = u.AttendanceDB.UpdateOvertime(ctx, &overtime)
+		groups.add(overtime.ID, overtime.IDMstPayrollPeriod, overtime.UpdatedBy, "")
+	}
+	for _, params := range groups.params {
+		err = u.AttendanceDB.AssignOvertimePayrollPeriod(ctx, params)
 		if err != nil {
 			return err
 		}
@@ -213,8 +288,15 @@ func (u *Usecase) updateOvertimeInBulk(ctx context.Context, overtimes []model.Tr
 }
 
 func (u *Usecase) updateReimbursementInBulk(ctx context.Context, reimbursements []model.TrxReimbursement) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.updateReimbursementInBulk")
+	defer func() { tracing.End(span, err) }()
+
+	groups := payrollPeriodAssignments{}
 	for _, reimbursement := range reimbursements {
-		err = u.AttendanceDB.UpdateReimbursement(ctx, &reimbursement)
+		groups.add(reimbursement.ID, reimbursement.IDMstPayrollPeriod, reimbursement.UpdatedBy, reimbursement.Status)
+	}
+	for _, params := range groups.params {
+		err = u.AttendanceDB.AssignReimbursementPayrollPeriod(ctx, params)
 		if err != nil {
 			return err
 		}
@@ -222,7 +304,45 @@ func (u *Usecase) updateReimbursementInBulk(ctx context.Context, reimbursements
 	return nil
 }
 
+// payrollPeriodAssignments groups row ids sharing the same values to set, in
+// the order the groups are first seen
+type payrollPeriodAssignments struct {
+	params []model.AssignPayrollPeriodParams
+	index  map[payrollPeriodAssignmentKey]int
+}
+
+type payrollPeriodAssignmentKey struct {
+	payrollPeriodID int64
+	updatedBy       sql.NullInt64
+	status          string
+}
+
+func (a *payrollPeriodAssignments) add(id int64, payrollPeriodID, updatedBy sql.NullInt64, status string) {
+	key := payrollPeriodAssignmentKey{
+		payrollPeriodID: payrollPeriodID.Int64,
+		updatedBy:       updatedBy,
+		status:          status,
+	}
+	if a.index == nil {
+		a.index = map[payrollPeriodAssignmentKey]int{}
+	}
+	i, ok := a.index[key]
+	if !ok {
+		i = len(a.params)
+		a.index[key] = i
+		a.params = append(a.params, model.AssignPayrollPeriodParams{
+			IDMstPayrollPeriod: key.payrollPeriodID,
+			UpdatedBy:          key.updatedBy,
+			Status:             key.status,
+		})
+	}
+	a.params[i].IDs = append(a.params[i].IDs, id)
+}
+
 func (u *Usecase) submitPayslips(ctx context.Context, mapOfPayslips map[int64]model.TrxUserPayslip) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.submitPayslips")
+	defer func() { tracing.End(span, err) }()
+
 	payslips := []model.TrxUserPayslip{}
 
 	for _, payslip := range mapOfPayslips {
@@ -242,7 +362,9 @@ func (u *Usecase) attendanceCalculation(
 	payslipSummary map[int64]model.TrxUserPayslip,
 	payrollPeriodID int64,
 	userID int64,
-) (map[int64]model.TrxUserPayslip, []model.MstAttendance, error) {
+) (summary map[int64]model.TrxUserPayslip, listOfAttendance []model.MstAttendance, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.attendanceCalculation")
+	defer func() { tracing.End(span, err) }()
 
 	listAttendanceParams := model.ListAttendanceParams{
 		StartDate:              startDate,
@@ -250,7 +372,7 @@ func (u *Usecase) attendanceCalculation(
 		IsForGeneratingPayroll: true,
 	}
 
-	listOfAttendance, err := u.AttendanceDB.ListAttendanceByParams(ctx, listAttendanceParams)
+	listOfAttendance, err = u.AttendanceDB.ListAttendanceByParams(ctx, listAttendanceParams)
 	if err != nil {
 		return payslipSummary, []model.MstAttendance{}, err
 	}
@@ -266,7 +388,7 @@ func (u *Usecase) attendanceCalculation(
 		}
 		attendance.UpdatedBy = sql.NullInt64{
 			Int64: userID,
-			Valid: true,
+			Valid: userID > 0,
 		}
 		listOfAttendance[i] = attendance
 	}
@@ -280,13 +402,16 @@ func (u *Usecase) overtimeCalculation(
 	payslipSummary map[int64]model.TrxUserPayslip,
 	payrollPeriodID int64,
 	userID int64,
-) (map[int64]model.TrxUserPayslip, []model.TrxOvertime, error) {
+) (summary map[int64]model.TrxUserPayslip, listOfOvertime []model.TrxOvertime, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.overtimeCalculation")
+	defer func() { tracing.End(span, err) }()
+
 	listOvertimeParams := model.ListOvertimeParams{
 		StartDate: startDate,
 		EndDate:   endDate,
 	}
 
-	listOfOvertime, err := u.AttendanceDB.ListOvertimeByParams(ctx, listOvertimeParams)
+	listOfOvertime, err = u.AttendanceDB.ListOvertimeByParams(ctx, listOvertimeParams)
 	if err != nil {
 		return payslipSummary, []model.TrxOvertime{}, err
 	}
@@ -302,7 +427,7 @@ func (u *Usecase) overtimeCalculation(
 		}
 		overtime.UpdatedBy = sql.NullInt64{
 			Int64: userID,
-			Valid: true,
+			Valid: userID > 0,
 		}
 		listOfOvertime[i] = overtime
 	}
@@ -315,14 +440,17 @@ func (u *Usecase) reimbursementCalculation(ctx context.Context,
 	payslipSummary map[int64]model.TrxUserPayslip,
 	payrollPeriodID int64,
 	userID int64,
-) (map[int64]model.TrxUserPayslip, []model.TrxReimbursement, error) {
+) (summary map[int64]model.TrxUserPayslip, listOfReimbursement []model.TrxReimbursement, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.reimbursementCalculation")
+	defer func() { tracing.End(span, err) }()
+
 	listReimbursementParams := model.ListReimbursementParams{
 		StartDate: startDate,
 		EndDate:   endDate,
 		Status:    ReimbursementStatusPending,
 	}
 
-	listOfReimbursement, err := u.AttendanceDB.ListReimbursementByParams(ctx, listReimbursementParams)
+	listOfReimbursement, err = u.AttendanceDB.ListReimbursementByParams(ctx, listReimbursementParams)
 	if err != nil {
 		return payslipSummary, []model.TrxReimbursement{}, err
 	}
@@ -338,7 +466,7 @@ func (u *Usecase) reimbursementCalculation(ctx context.Context,
 		}
 		reimbursement.UpdatedBy = sql.NullInt64{
 			Int64: userID,
-			Valid: true,
+			Valid: userID > 0,
 		}
 		reimbursement.Status = ReimbursementStatusPaid
 		listOfReimbursement[i] = reimbursement
@@ -347,29 +475,40 @@ func (u *Usecase) reimbursementCalculation(ctx context.Context,
 	return payslipSummary, listOfReimbursement, err
 }
 
+// calculatePayslipSummaryTotalSalary prorates the salary of every employee
+// by the days they attended out of their scheduled ones and pays overtime
+// at overtimeMultiplier times their hourly pay, the salary over their
+// scheduled hours
 func (*Usecase) calculatePayslipSummaryTotalSalary(
 	payslipSummary map[int64]model.TrxUserPayslip,
-	numberOfWorkingDays int,
+	overtimeMultiplier int64,
 ) (
 	modifiedPayslipSummary map[int64]model.TrxUserPayslip,
 	totalTakeHomePay int64,
 ) {
 	totalTakeHomePay = 0
 	for i, employeeSummary := range payslipSummary {
+		// an employee without a scheduled day in the period earns no salary
+		// nor overtime
+		proratedSalary, overtimePay := decimal.Zero, decimal.Zero
+
 		// attendance calculation
-		proratedSalary := decimal.NewFromInt(int64(employeeSummary.AttendedDays)).
-			Div(decimal.NewFromInt(int64(numberOfWorkingDays))).
-			Mul(decimal.NewFromInt(int64(employeeSummary.BaseSalary)))
+		if employeeSummary.WorkingDays > 0 {
+			proratedSalary = decimal.NewFromInt(int64(employeeSummary.AttendedDays)).
+				Div(decimal.NewFromInt(int64(employeeSummary.WorkingDays))).
+				Mul(decimal.NewFromInt(int64(employeeSummary.BaseSalary)))
+		}
 		employeeSummary.ProratedSalary = proratedSalary.IntPart()
 
 		// overtime calculation
-		hourlyPay := decimal.NewFromInt(int64(employeeSummary.BaseSalary)).
-			Div(decimal.NewFromInt(int64(numberOfWorkingDays))).
-			Div(decimal.NewFromInt(WorkingHours))
+		if employeeSummary.WorkingHours > 0 {
+			hourlyPay := decimal.NewFromInt(int64(employeeSummary.BaseSalary)).
+				Div(decimal.NewFromInt(int64(employeeSummary.WorkingHours)))
 
-		overtimePay := decimal.NewFromInt(int64(employeeSummary.OvertimeHours)).
-			Mul(hourlyPay).
-			Mul(decimal.NewFromInt(OvertimeMultiplier))
+			overtimePay = decimal.NewFromInt(int64(employeeSummary.OvertimeHours)).
+				Mul(hourlyPay).
+				Mul(decimal.NewFromInt(overtimeMultiplier))
+		}
 
 		employeeSummary.OvertimePay = overtimePay.IntPart()
 
@@ -386,6 +525,9 @@ func (*Usecase) calculatePayslipSummaryTotalSalary(
 }
 
 func (u *Usecase) GetEmployeePayslip(ctx context.Context, request model.GetPayslipRequest) (payslip model.GetPayslipResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.GetEmployeePayslip")
+	defer func() { tracing.End(span, err) }()
+
 	user, found := authGetUserDetailFromCtx(ctx)
 	if !found {
 		err = errors.Wrap(errors.New("user not found"), "Usecase.GetEmployeePayslip")
@@ -458,6 +600,7 @@ func (u *Usecase) GetEmployeePayslip(ctx context.Context, request model.GetPaysl
 		TotalTakeHomePay:    employeePayslip.TotalTakeHome,
 		AttendanceDate:      attendanceDate,
 		WorkingDays:         employeePayslip.WorkingDays,
+		WorkingHours:        employeePayslip.WorkingHours,
 		AttendedDays:        employeePayslip.AttendedDays,
 		ProratedSalary:      employeePayslip.ProratedSalary,
 		OvertimeHours:       employeePayslip.OvertimeHours,
@@ -471,6 +614,9 @@ func (u *Usecase) GetEmployeePayslip(ctx context.Context, request model.GetPaysl
 }
 
 func (u *Usecase) GetPayroll(ctx context.Context, request model.GetPayrollRequest) (payrollSummary model.GetPayrollResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.GetPayroll")
+	defer func() { tracing.End(span, err) }()
+
 	user, found := authGetUserDetailFromCtx(ctx)
 	if !found {
 		err = errors.Wrap(errors.New("user not found"), "Usecase.GetEmployeePayslip")
@@ -0,0 +1,207 @@
+package attendance
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	liblog "github.com/faisalhardin/employee-payroll-system/pkg/common/log"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+)
+
+// EnqueuePayrollGeneration validates the period and queues a background job
+// for it. When the period already has an unfinished job that job is returned,
+// so retrying the request never queues the same period twice.
+func (u *Usecase) EnqueuePayrollGeneration(ctx context.Context, request model.GeneratePayrollRequest) (resp model.PayrollJobResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.EnqueuePayrollGeneration")
+	defer func() { tracing.End(span, err) }()
+	ctx = xormlib.WithPrimary(ctx)
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.EnqueuePayrollGeneration")
+		return
+	}
+
+	payrollPeriod, err := u.AttendanceDB.GetPayrollPeriod(ctx, int64(request.IDMstPayrollPeriod))
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.EnqueuePayrollGeneration")
+		return
+	}
+	if payrollPeriod.ID == 0 {
+		err = commonerr.SetNewBadRequest("invalid", "payroll period not found")
+		return
+	}
+	if payrollPeriod.PayrollProcessedDate.Valid {
+		err = commonerr.SetNewBadRequest("invalid", "payroll has been processed")
+		return
+	}
+
+	activeJobs, err := u.AttendanceDB.ListPayrollJobByParams(ctx, model.ListPayrollJobParams{
+		IDMstPayrollPeriod: payrollPeriod.ID,
+		Statuses:           []string{constant.PayrollJobStatusQueued, constant.PayrollJobStatusRunning},
+	})
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.EnqueuePayrollGeneration")
+		return
+	}
+	if len(activeJobs) > 0 {
+		return toPayrollJobResponse(activeJobs[0]), nil
+	}
+
+	job := model.TrxPayrollJob{
+		IDMstPayrollPeriod: payrollPeriod.ID,
+		Status:             constant.PayrollJobStatusQueued,
+		Stage:              "waiting for a worker",
+		CreatedBy:          actorID(user),
+	}
+	err = u.AttendanceDB.CreatePayrollJob(ctx, &job)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.EnqueuePayrollGeneration")
+		return
+	}
+
+	return toPayrollJobResponse(job), nil
+}
+
+func (u *Usecase) GetPayrollJob(ctx context.Context, id int64) (resp model.PayrollJobResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.GetPayrollJob")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.GetPayrollJob")
+		return
+	}
+
+	job, err := u.AttendanceDB.GetPayrollJob(ctx, id)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.GetPayrollJob")
+		return
+	}
+	if job.ID == 0 {
+		err = commonerr.SetNewBadRequest("not found", "payroll job not found")
+		return
+	}
+
+	return toPayrollJobResponse(job), nil
+}
+
+// RunPayrollJob generates the payroll of a claimed job and records its
+// progress. Every attempt first clears whatever an earlier failed or
+// interrupted run left behind for the period, and a job whose period turns
+// out to be processed already is simply marked completed.
+func (u *Usecase) RunPayrollJob(ctx context.Context, job model.TrxPayrollJob) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.RunPayrollJob")
+	defer func() { tracing.End(span, err) }()
+
+	ctx = tenant.NewContext(ctx, job.IDMstTenant)
+	actor := auth.UserJWTPayload{
+		ID:       job.CreatedBy.Int64,
+		TenantID: job.IDMstTenant,
+	}
+	if !job.CreatedBy.Valid {
+		// queued by the scheduler, which acts as no user
+		actor.Username = constant.SystemActorScheduler
+	}
+	ctx = auth.SetUserDetailToCtx(ctx, actor)
+
+	updateJob := func(stage string, progress int) {
+		job.Stage = stage
+		job.Progress = progress
+		if e := u.AttendanceDB.UpdatePayrollJob(ctx, &job); e != nil {
+			liblog.Errorf("failed to update progress of payroll job %d: %v", job.ID, e)
+		}
+	}
+
+	err = u.runPayrollJob(ctx, &job, updateJob)
+	job.FinishedAt = sql.NullTime{
+		Time:  time.Now(),
+		Valid: true,
+	}
+	if err != nil {
+		job.Status = constant.PayrollJobStatusFailed
+		job.ErrorMessage = payrollJobErrorMessage(err)
+	} else {
+		job.Status = constant.PayrollJobStatusCompleted
+		job.Stage = "completed"
+		job.Progress = 100
+	}
+
+	updateErr := u.AttendanceDB.UpdatePayrollJob(ctx, &job)
+	if updateErr != nil {
+		return errors.Wrap(updateErr, "Usecase.RunPayrollJob")
+	}
+	if err != nil {
+		return errors.Wrap(err, "Usecase.RunPayrollJob")
+	}
+	return nil
+}
+
+func (u *Usecase) runPayrollJob(ctx context.Context, job *model.TrxPayrollJob, updateJob func(stage string, progress int)) error {
+	payrollPeriod, err := u.AttendanceDB.GetPayrollPeriod(ctx, job.IDMstPayrollPeriod)
+	if err != nil {
+		return err
+	}
+	if payrollPeriod.PayrollProcessedDate.Valid {
+		// the previous attempt finished the payroll but not the job
+		return nil
+	}
+
+	// a failed job may have been queued again as a new job with its first
+	// attempt, so the period is cleared whatever the attempt count says
+	updateJob("cleaning up previous runs", 0)
+	err = u.AttendanceDB.ResetPayrollPeriodResults(ctx, job.IDMstPayrollPeriod, ReimbursementStatusPending)
+	if err != nil {
+		return err
+	}
+
+	return usecaseGeneratePayroll(u, ctx, model.GeneratePayrollRequest{
+		IDMstPayrollPeriod: int(job.IDMstPayrollPeriod),
+	}, updateJob)
+}
+
+// actorID is the user behind a change, empty for a system actor such as the
+// scheduler
+func actorID(user auth.UserJWTPayload) sql.NullInt64 {
+	return sql.NullInt64{Int64: user.ID, Valid: user.ID > 0}
+}
+
+// payrollJobErrorMessage keeps the readable description of request errors
+// instead of their JSON encoding.
+func payrollJobErrorMessage(err error) string {
+	errMessage, ok := errors.Cause(err).(*commonerr.ErrorMessage)
+	if ok && len(errMessage.ErrorList) > 0 {
+		return errMessage.ErrorList[0].ErrorDescription
+	}
+	return err.Error()
+}
+
+func toPayrollJobResponse(job model.TrxPayrollJob) model.PayrollJobResponse {
+	resp := model.PayrollJobResponse{
+		ID:              job.ID,
+		PayrollPeriodID: job.IDMstPayrollPeriod,
+		Status:          job.Status,
+		Progress:        job.Progress,
+		Stage:           job.Stage,
+		Error:           job.ErrorMessage,
+		Attempts:        job.Attempts,
+		CreatedAt:       job.CreatedAt,
+	}
+	if job.StartedAt.Valid {
+		startedAt := job.StartedAt.Time
+		resp.StartedAt = &startedAt
+	}
+	if job.FinishedAt.Valid {
+		finishedAt := job.FinishedAt.Time
+		resp.FinishedAt = &finishedAt
+	}
+	return resp
+}
@@ -0,0 +1,432 @@
+package attendance
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EnqueuePayrollGeneration(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	activeStatuses := []string{constant.PayrollJobStatusQueued, constant.PayrollJobStatusRunning}
+
+	testCases := []struct {
+		name    string
+		request model.GeneratePayrollRequest
+		patch   func()
+		unpatch func()
+		wantErr bool
+		want    model.PayrollJobResponse
+	}{
+		{
+			name:    "success - queue a new job",
+			request: model.GeneratePayrollRequest{IDMstPayrollPeriod: 5},
+			want: model.PayrollJobResponse{
+				ID:              9,
+				PayrollPeriodID: 5,
+				Status:          constant.PayrollJobStatusQueued,
+				Stage:           "waiting for a worker",
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(5)).
+					Return(model.MstPayrollPeriod{ID: 5}, nil).Times(1)
+				mockAttendanceRepo.EXPECT().ListPayrollJobByParams(gomock.Any(), model.ListPayrollJobParams{
+					IDMstPayrollPeriod: 5,
+					Statuses:           activeStatuses,
+				}).Return(nil, nil).Times(1)
+				mockAttendanceRepo.EXPECT().CreatePayrollJob(gomock.Any(), &model.TrxPayrollJob{
+					IDMstPayrollPeriod: 5,
+					Status:             constant.PayrollJobStatusQueued,
+					Stage:              "waiting for a worker",
+					CreatedBy:          sql.NullInt64{Int64: 1, Valid: true},
+				}).DoAndReturn(func(ctx context.Context, job *model.TrxPayrollJob) error {
+					job.ID = 9
+					return nil
+				}).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "success - return the unfinished job",
+			request: model.GeneratePayrollRequest{IDMstPayrollPeriod: 5},
+			want: model.PayrollJobResponse{
+				ID:              8,
+				PayrollPeriodID: 5,
+				Status:          constant.PayrollJobStatusRunning,
+				Progress:        30,
+				Attempts:        1,
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(5)).
+					Return(model.MstPayrollPeriod{ID: 5}, nil).Times(1)
+				mockAttendanceRepo.EXPECT().ListPayrollJobByParams(gomock.Any(), gomock.Any()).
+					Return([]model.TrxPayrollJob{
+						{ID: 8, IDMstPayrollPeriod: 5, Status: constant.PayrollJobStatusRunning, Progress: 30, Attempts: 1},
+					}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - user not found",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = func(ctx context.Context) (auth.UserJWTPayload, bool) {
+					return auth.UserJWTPayload{}, false
+				}
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - not an admin",
+			request: model.GeneratePayrollRequest{IDMstPayrollPeriod: 5},
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockEmployeeUser
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - payroll period not found",
+			request: model.GeneratePayrollRequest{IDMstPayrollPeriod: 5},
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(5)).
+					Return(model.MstPayrollPeriod{}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - payroll already processed",
+			request: model.GeneratePayrollRequest{IDMstPayrollPeriod: 5},
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(5)).
+					Return(model.MstPayrollPeriod{
+						ID:                   5,
+						PayrollProcessedDate: sql.NullTime{Time: time.Now(), Valid: true},
+					}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - create payroll job",
+			request: model.GeneratePayrollRequest{IDMstPayrollPeriod: 5},
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(5)).
+					Return(model.MstPayrollPeriod{ID: 5}, nil).Times(1)
+				mockAttendanceRepo.EXPECT().ListPayrollJobByParams(gomock.Any(), gomock.Any()).
+					Return(nil, nil).Times(1)
+				mockAttendanceRepo.EXPECT().CreatePayrollJob(gomock.Any(), gomock.Any()).
+					Return(errFoo).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+			}
+			tt.patch()
+			defer tt.unpatch()
+
+			got, err := u.EnqueuePayrollGeneration(context.Background(), tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EnqueuePayrollGeneration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_GetPayrollJob(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	finishedAt := time.Date(2025, 7, 21, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		patch   func()
+		unpatch func()
+		wantErr bool
+		want    model.PayrollJobResponse
+	}{
+		{
+			name: "success",
+			want: model.PayrollJobResponse{
+				ID:              3,
+				PayrollPeriodID: 5,
+				Status:          constant.PayrollJobStatusFailed,
+				Progress:        65,
+				Stage:           "storing payslips",
+				Error:           "database error",
+				Attempts:        1,
+				FinishedAt:      &finishedAt,
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().GetPayrollJob(gomock.Any(), int64(3)).
+					Return(model.TrxPayrollJob{
+						ID:                 3,
+						IDMstPayrollPeriod: 5,
+						Status:             constant.PayrollJobStatusFailed,
+						Progress:           65,
+						Stage:              "storing payslips",
+						ErrorMessage:       "database error",
+						Attempts:           1,
+						FinishedAt:         sql.NullTime{Time: finishedAt, Valid: true},
+						CreatedBy:          sql.NullInt64{Int64: 2, Valid: true},
+					}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - not found",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().GetPayrollJob(gomock.Any(), int64(3)).
+					Return(model.TrxPayrollJob{}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - not an admin",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockEmployeeUser
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - get payroll job",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().GetPayrollJob(gomock.Any(), int64(3)).
+					Return(model.TrxPayrollJob{}, errFoo).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+			}
+			tt.patch()
+			defer tt.unpatch()
+
+			got, err := u.GetPayrollJob(context.Background(), 3)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetPayrollJob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_RunPayrollJob(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	// captures the final state written for the job
+	var finalJob model.TrxPayrollJob
+	captureUpdates := func() {
+		mockAttendanceRepo.EXPECT().UpdatePayrollJob(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, job *model.TrxPayrollJob) error {
+				finalJob = *job
+				return nil
+			}).AnyTimes()
+	}
+
+	testCases := []struct {
+		name       string
+		job        model.TrxPayrollJob
+		patch      func()
+		unpatch    func()
+		wantErr    bool
+		wantStatus string
+		wantError  string
+	}{
+		{
+			name:       "success - first attempt",
+			job:        model.TrxPayrollJob{ID: 1, IDMstTenant: 2, IDMstPayrollPeriod: 5, Attempts: 1, CreatedBy: sql.NullInt64{Int64: 1, Valid: true}},
+			wantStatus: constant.PayrollJobStatusCompleted,
+			patch: func() {
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(5)).
+					Return(model.MstPayrollPeriod{ID: 5}, nil).Times(1)
+				captureUpdates()
+				mockAttendanceRepo.EXPECT().ResetPayrollPeriodResults(gomock.Any(), int64(5), ReimbursementStatusPending).
+					Return(nil).Times(1)
+				usecaseGeneratePayroll = func(u *Usecase, ctx context.Context, request model.GeneratePayrollRequest, reportProgress func(stage string, progress int)) error {
+					user, found := auth.GetUserDetailFromCtx(ctx)
+					assert.True(t, found)
+					assert.Equal(t, int64(1), user.ID)
+					tenantID, found := tenant.FromContext(ctx)
+					assert.True(t, found)
+					assert.Equal(t, int64(2), tenantID)
+					assert.Equal(t, 5, request.IDMstPayrollPeriod)
+					reportProgress("calculating attendance", 15)
+					return nil
+				}
+			},
+			unpatch: func() {
+				usecaseGeneratePayroll = (*Usecase).generatePayroll
+			},
+		},
+		{
+			name:       "success - job queued by the scheduler runs as the system actor",
+			job:        model.TrxPayrollJob{ID: 1, IDMstTenant: 2, IDMstPayrollPeriod: 5, Attempts: 1},
+			wantStatus: constant.PayrollJobStatusCompleted,
+			patch: func() {
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(5)).
+					Return(model.MstPayrollPeriod{ID: 5}, nil).Times(1)
+				captureUpdates()
+				mockAttendanceRepo.EXPECT().ResetPayrollPeriodResults(gomock.Any(), int64(5), ReimbursementStatusPending).
+					Return(nil).Times(1)
+				usecaseGeneratePayroll = func(u *Usecase, ctx context.Context, request model.GeneratePayrollRequest, reportProgress func(stage string, progress int)) error {
+					user, found := auth.GetUserDetailFromCtx(ctx)
+					assert.True(t, found)
+					assert.Equal(t, int64(0), user.ID)
+					assert.Equal(t, constant.SystemActorScheduler, user.Username)
+					return nil
+				}
+			},
+			unpatch: func() {
+				usecaseGeneratePayroll = (*Usecase).generatePayroll
+			},
+		},
+		{
+			name:       "success - resumed attempt clears previous results",
+			job:        model.TrxPayrollJob{ID: 1, IDMstPayrollPeriod: 5, Attempts: 2, CreatedBy: sql.NullInt64{Int64: 1, Valid: true}},
+			wantStatus: constant.PayrollJobStatusCompleted,
+			patch: func() {
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(5)).
+					Return(model.MstPayrollPeriod{ID: 5}, nil).Times(1)
+				captureUpdates()
+				mockAttendanceRepo.EXPECT().ResetPayrollPeriodResults(gomock.Any(), int64(5), ReimbursementStatusPending).
+					Return(nil).Times(1)
+				usecaseGeneratePayroll = func(u *Usecase, ctx context.Context, request model.GeneratePayrollRequest, reportProgress func(stage string, progress int)) error {
+					return nil
+				}
+			},
+			unpatch: func() {
+				usecaseGeneratePayroll = (*Usecase).generatePayroll
+			},
+		},
+		{
+			name:       "success - period processed by the interrupted attempt",
+			job:        model.TrxPayrollJob{ID: 1, IDMstPayrollPeriod: 5, Attempts: 2, CreatedBy: sql.NullInt64{Int64: 1, Valid: true}},
+			wantStatus: constant.PayrollJobStatusCompleted,
+			patch: func() {
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(5)).
+					Return(model.MstPayrollPeriod{
+						ID:                   5,
+						PayrollProcessedDate: sql.NullTime{Time: time.Now(), Valid: true},
+					}, nil).Times(1)
+				captureUpdates()
+			},
+			unpatch: func() {},
+		},
+		{
+			name:       "error - generate payroll",
+			job:        model.TrxPayrollJob{ID: 1, IDMstPayrollPeriod: 5, Attempts: 1, CreatedBy: sql.NullInt64{Int64: 1, Valid: true}},
+			wantErr:    true,
+			wantStatus: constant.PayrollJobStatusFailed,
+			wantError:  "no employees",
+			patch: func() {
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(5)).
+					Return(model.MstPayrollPeriod{ID: 5}, nil).Times(1)
+				captureUpdates()
+				mockAttendanceRepo.EXPECT().ResetPayrollPeriodResults(gomock.Any(), int64(5), ReimbursementStatusPending).
+					Return(nil).Times(1)
+				usecaseGeneratePayroll = func(u *Usecase, ctx context.Context, request model.GeneratePayrollRequest, reportProgress func(stage string, progress int)) error {
+					return commonerr.SetNewBadRequest("invalid", "no employees")
+				}
+			},
+			unpatch: func() {
+				usecaseGeneratePayroll = (*Usecase).generatePayroll
+			},
+		},
+		{
+			name:       "error - reset previous results",
+			job:        model.TrxPayrollJob{ID: 1, IDMstPayrollPeriod: 5, Attempts: 1, CreatedBy: sql.NullInt64{Int64: 1, Valid: true}},
+			wantErr:    true,
+			wantStatus: constant.PayrollJobStatusFailed,
+			wantError:  "errFoo",
+			patch: func() {
+				mockAttendanceRepo.EXPECT().GetPayrollPeriod(gomock.Any(), int64(5)).
+					Return(model.MstPayrollPeriod{ID: 5}, nil).Times(1)
+				captureUpdates()
+				mockAttendanceRepo.EXPECT().ResetPayrollPeriodResults(gomock.Any(), int64(5), ReimbursementStatusPending).
+					Return(errFoo).Times(1)
+			},
+			unpatch: func() {},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+			}
+			finalJob = model.TrxPayrollJob{}
+			tt.patch()
+			defer tt.unpatch()
+
+			err := u.RunPayrollJob(context.Background(), tt.job)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RunPayrollJob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantStatus, finalJob.Status)
+			assert.Equal(t, tt.wantError, finalJob.ErrorMessage)
+			assert.True(t, finalJob.FinishedAt.Valid)
+			if tt.wantStatus == constant.PayrollJobStatusCompleted {
+				assert.Equal(t, 100, finalJob.Progress)
+			}
+		})
+	}
+}
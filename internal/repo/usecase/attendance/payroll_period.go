@@ -0,0 +1,277 @@
+package attendance
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+)
+
+func (u *Usecase) ListPayrollPeriods(ctx context.Context, request model.ListPayrollPeriodRequest) (resp model.ListPayrollPeriodResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.ListPayrollPeriods")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.ListPayrollPeriods")
+		return
+	}
+
+	limit := request.GetLimit()
+	payrollPeriods, err := u.AttendanceDB.ListPayrollPeriodByParams(ctx, model.ListPayrollPeriodParams{
+		Status: request.Status,
+		Cursor: request.Cursor,
+		Limit:  limit + 1,
+	})
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListPayrollPeriods")
+		return
+	}
+
+	if len(payrollPeriods) > limit {
+		payrollPeriods = payrollPeriods[:limit]
+		resp.NextCursor = payrollPeriods[limit-1].ID
+	}
+
+	resp.PayrollPeriods = []model.PayrollPeriodResponse{}
+	for _, payrollPeriod := range payrollPeriods {
+		resp.PayrollPeriods = append(resp.PayrollPeriods, toPayrollPeriodResponse(payrollPeriod))
+	}
+	return
+}
+
+func (u *Usecase) GetPayrollPeriod(ctx context.Context, id int64) (resp model.PayrollPeriodResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.GetPayrollPeriod")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.GetPayrollPeriod")
+		return
+	}
+
+	payrollPeriod, err := u.getExistingPayrollPeriod(ctx, id)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.GetPayrollPeriod")
+		return
+	}
+
+	return toPayrollPeriodResponse(payrollPeriod), nil
+}
+
+func (u *Usecase) UpdatePayrollPeriod(ctx context.Context, id int64, payrollPeriodRequest model.PayrollPeriodRequest) (resp model.PayrollPeriodResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.UpdatePayrollPeriod")
+	defer func() { tracing.End(span, err) }()
+	ctx = xormlib.WithPrimary(ctx)
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.UpdatePayrollPeriod")
+		return
+	}
+
+	payrollPeriod, err := u.getExistingPayrollPeriod(ctx, id)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.UpdatePayrollPeriod")
+		return
+	}
+
+	if payrollPeriod.PayrollProcessedDate.Valid {
+		err = commonerr.SetNewBadRequest("invalid", "payroll has been processed")
+		return
+	}
+
+	payrollPeriod.StartDate = payrollPeriodRequest.StartDate
+	payrollPeriod.EndDate = payrollPeriodRequest.EndDate
+	payrollPeriod.UpdatedBy = sql.NullInt64{
+		Int64: user.ID,
+		Valid: true,
+	}
+
+	err = u.checkPayrollPeriodSchedule(ctx, payrollPeriod)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.UpdatePayrollPeriod")
+		return
+	}
+
+	err = u.AttendanceDB.UpdatePayrollPeriod(ctx, &payrollPeriod)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.UpdatePayrollPeriod")
+		return
+	}
+
+	return toPayrollPeriodResponse(payrollPeriod), nil
+}
+
+func (u *Usecase) DeletePayrollPeriod(ctx context.Context, id int64) (err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.DeletePayrollPeriod")
+	defer func() { tracing.End(span, err) }()
+	ctx = xormlib.WithPrimary(ctx)
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		return errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.DeletePayrollPeriod")
+	}
+
+	payrollPeriod, err := u.getExistingPayrollPeriod(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "Usecase.DeletePayrollPeriod")
+	}
+
+	if payrollPeriod.PayrollProcessedDate.Valid {
+		return commonerr.SetNewBadRequest("invalid", "payroll has been processed")
+	}
+
+	payrollPeriods, err := u.AttendanceDB.ListPayrollPeriodByParams(ctx, model.ListPayrollPeriodParams{})
+	if err != nil {
+		return errors.Wrap(err, "Usecase.DeletePayrollPeriod")
+	}
+
+	// removing a period from the middle of the schedule would leave a gap
+	hasPrevious, hasNext := false, false
+	for _, existing := range payrollPeriods {
+		if existing.ID == payrollPeriod.ID {
+			continue
+		}
+		if toDate(existing.EndDate).Before(toDate(payrollPeriod.StartDate)) {
+			hasPrevious = true
+		}
+		if toDate(existing.StartDate).After(toDate(payrollPeriod.EndDate)) {
+			hasNext = true
+		}
+	}
+	if hasPrevious && hasNext {
+		return commonerr.SetNewBadRequest("invalid", "only the first or the last payroll period can be deleted")
+	}
+
+	err = u.AttendanceDB.DeletePayrollPeriod(ctx, payrollPeriod.ID)
+	if err != nil {
+		return errors.Wrap(err, "Usecase.DeletePayrollPeriod")
+	}
+	return nil
+}
+
+func (u *Usecase) getExistingPayrollPeriod(ctx context.Context, id int64) (payrollPeriod model.MstPayrollPeriod, err error) {
+	payrollPeriod, err = u.AttendanceDB.GetPayrollPeriod(ctx, id)
+	if err != nil {
+		return
+	}
+	if payrollPeriod.ID == 0 {
+		err = commonerr.SetNewBadRequest("not found", "payroll period not found")
+		return
+	}
+	return
+}
+
+// checkPayrollPeriodSchedule verifies the candidate fits into the existing
+// schedule: it must not overlap any period and must start right after the
+// previous period and end right before the next one.
+func (u *Usecase) checkPayrollPeriodSchedule(ctx context.Context, candidate model.MstPayrollPeriod) error {
+	payrollPeriods, err := u.AttendanceDB.ListPayrollPeriodByParams(ctx, model.ListPayrollPeriodParams{})
+	if err != nil {
+		return err
+	}
+	return validatePayrollPeriodSchedule(payrollPeriods, candidate)
+}
+
+func validatePayrollPeriodSchedule(payrollPeriods []model.MstPayrollPeriod, candidate model.MstPayrollPeriod) error {
+	startDate, endDate := toDate(candidate.StartDate), toDate(candidate.EndDate)
+
+	var previous, next *model.MstPayrollPeriod
+	for i, existing := range payrollPeriods {
+		if candidate.ID != 0 && existing.ID == candidate.ID {
+			continue
+		}
+		existingStart, existingEnd := toDate(existing.StartDate), toDate(existing.EndDate)
+
+		if !startDate.After(existingEnd) && !existingStart.After(endDate) {
+			return commonerr.SetNewBadRequest("invalid", fmt.Sprintf(
+				"payroll period overlaps with period %d (%s - %s)",
+				existing.ID, existingStart.Format(dateFormat), existingEnd.Format(dateFormat),
+			))
+		}
+
+		if existingEnd.Before(startDate) && (previous == nil || existingEnd.After(toDate(previous.EndDate))) {
+			previous = &payrollPeriods[i]
+		}
+		if existingStart.After(endDate) && (next == nil || existingStart.Before(toDate(next.StartDate))) {
+			next = &payrollPeriods[i]
+		}
+	}
+
+	if previous != nil && !toDate(previous.EndDate).AddDate(0, 0, 1).Equal(startDate) {
+		return commonerr.SetNewBadRequest("invalid", fmt.Sprintf(
+			"payroll period must start on %s to follow period %d",
+			toDate(previous.EndDate).AddDate(0, 0, 1).Format(dateFormat), previous.ID,
+		))
+	}
+	if next != nil && !endDate.AddDate(0, 0, 1).Equal(toDate(next.StartDate)) {
+		return commonerr.SetNewBadRequest("invalid", fmt.Sprintf(
+			"payroll period must end on %s to precede period %d",
+			toDate(next.StartDate).AddDate(0, 0, -1).Format(dateFormat), next.ID,
+		))
+	}
+	return nil
+}
+
+// toDate drops the time and location so dates read from DATE columns compare
+// equal to dates sent by clients in any timezone.
+func toDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func toPayrollPeriodResponse(payrollPeriod model.MstPayrollPeriod) model.PayrollPeriodResponse {
+	resp := model.PayrollPeriodResponse{
+		ID:        payrollPeriod.ID,
+		StartDate: payrollPeriod.StartDate,
+		EndDate:   payrollPeriod.EndDate,
+		Status:    constant.PayrollPeriodStatusOpen,
+	}
+	if payrollPeriod.PayrollProcessedDate.Valid {
+		processedDate := payrollPeriod.PayrollProcessedDate.Time
+		resp.Status = constant.PayrollPeriodStatusProcessed
+		resp.PayrollProcessedDate = &processedDate
+	}
+	return resp
+}
+
+func (u *Usecase) ListPayrollScheduleRuns(ctx context.Context, request model.ListSchedulerRunRequest) (resp model.ListSchedulerRunResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.ListPayrollScheduleRuns")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.ListPayrollScheduleRuns")
+		return
+	}
+
+	limit := request.GetLimit()
+	runs, err := u.AttendanceDB.ListSchedulerRunByParams(ctx, model.ListSchedulerRunParams{
+		JobName: constant.SchedulerJobPayrollPeriod,
+		Status:  request.Status,
+		Cursor:  request.Cursor,
+		Limit:   limit + 1,
+	})
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListPayrollScheduleRuns")
+		return
+	}
+
+	if len(runs) > limit {
+		runs = runs[:limit]
+		resp.NextCursor = runs[limit-1].ID
+	}
+
+	resp.Runs = runs
+	if resp.Runs == nil {
+		resp.Runs = []model.TrxSchedulerRun{}
+	}
+	return
+}
@@ -0,0 +1,525 @@
+package attendance
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockAdminUser(ctx context.Context) (auth.UserJWTPayload, bool) {
+	return auth.UserJWTPayload{
+		ID:   1,
+		Role: constant.UserRoleAdmin,
+	}, true
+}
+
+func mockEmployeeUser(ctx context.Context) (auth.UserJWTPayload, bool) {
+	return auth.UserJWTPayload{
+		ID:   2,
+		Role: constant.UserRoleEmployee,
+	}, true
+}
+
+func Test_validatePayrollPeriodSchedule(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	existing := []model.MstPayrollPeriod{
+		{ID: 1, StartDate: date(5, 20), EndDate: date(6, 19)},
+		{ID: 2, StartDate: date(6, 20), EndDate: date(7, 19)},
+	}
+
+	tests := []struct {
+		name      string
+		candidate model.MstPayrollPeriod
+		wantErr   bool
+	}{
+		{
+			name:      "continues after the last period",
+			candidate: model.MstPayrollPeriod{StartDate: date(7, 20), EndDate: date(8, 19)},
+		},
+		{
+			name:      "precedes the first period",
+			candidate: model.MstPayrollPeriod{StartDate: date(4, 20), EndDate: date(5, 19)},
+		},
+		{
+			name:      "dates in another timezone",
+			candidate: model.MstPayrollPeriod{StartDate: time.Date(2025, 7, 20, 0, 0, 0, 0, time.FixedZone("WIB", 7*3600)), EndDate: date(8, 19)},
+		},
+		{
+			name:      "overlaps the last period",
+			candidate: model.MstPayrollPeriod{StartDate: date(7, 19), EndDate: date(8, 19)},
+			wantErr:   true,
+		},
+		{
+			name:      "leaves a gap after the last period",
+			candidate: model.MstPayrollPeriod{StartDate: date(7, 21), EndDate: date(8, 19)},
+			wantErr:   true,
+		},
+		{
+			name:      "leaves a gap before the first period",
+			candidate: model.MstPayrollPeriod{StartDate: date(4, 20), EndDate: date(5, 18)},
+			wantErr:   true,
+		},
+		{
+			name:      "updating a period in place",
+			candidate: model.MstPayrollPeriod{ID: 2, StartDate: date(6, 20), EndDate: date(7, 19)},
+		},
+		{
+			name:      "shrinking the last period",
+			candidate: model.MstPayrollPeriod{ID: 2, StartDate: date(6, 20), EndDate: date(7, 10)},
+		},
+		{
+			name:      "moving the last period away from the previous one",
+			candidate: model.MstPayrollPeriod{ID: 2, StartDate: date(6, 21), EndDate: date(7, 19)},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePayrollPeriodSchedule(existing, tt.candidate)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validatePayrollPeriodSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ListPayrollPeriods(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	processedDate := time.Date(2025, 7, 21, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		request model.ListPayrollPeriodRequest
+		patch   func()
+		unpatch func()
+		wantErr bool
+		want    model.ListPayrollPeriodResponse
+	}{
+		{
+			name: "success",
+			request: model.ListPayrollPeriodRequest{
+				Status: constant.PayrollPeriodStatusProcessed,
+			},
+			want: model.ListPayrollPeriodResponse{
+				PayrollPeriods: []model.PayrollPeriodResponse{
+					{
+						ID:                   1,
+						Status:               constant.PayrollPeriodStatusProcessed,
+						PayrollProcessedDate: &processedDate,
+					},
+				},
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().
+					ListPayrollPeriodByParams(gomock.Any(), model.ListPayrollPeriodParams{
+						Status: constant.PayrollPeriodStatusProcessed,
+						Limit:  model.DefaultPaginationLimit + 1,
+					}).
+					Return([]model.MstPayrollPeriod{
+						{ID: 1, PayrollProcessedDate: sql.NullTime{Time: processedDate, Valid: true}},
+					}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - not admin",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockEmployeeUser
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - list payroll period",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().
+					ListPayrollPeriodByParams(gomock.Any(), gomock.Any()).
+					Return(nil, errFoo).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+			}
+			tt.patch()
+			defer tt.unpatch()
+
+			got, err := u.ListPayrollPeriods(context.Background(), tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListPayrollPeriods() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_GetPayrollPeriod(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name    string
+		patch   func()
+		unpatch func()
+		wantErr bool
+		want    model.PayrollPeriodResponse
+	}{
+		{
+			name: "success",
+			want: model.PayrollPeriodResponse{
+				ID:     1,
+				Status: constant.PayrollPeriodStatusOpen,
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().
+					GetPayrollPeriod(gomock.Any(), int64(1)).
+					Return(model.MstPayrollPeriod{ID: 1}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - not found",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().
+					GetPayrollPeriod(gomock.Any(), int64(1)).
+					Return(model.MstPayrollPeriod{}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+			}
+			tt.patch()
+			defer tt.unpatch()
+
+			got, err := u.GetPayrollPeriod(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetPayrollPeriod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_UpdatePayrollPeriod(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	request := model.PayrollPeriodRequest{
+		StartDate: date(6, 20),
+		EndDate:   date(7, 10),
+	}
+
+	testCases := []struct {
+		name    string
+		patch   func()
+		unpatch func()
+		wantErr bool
+		want    model.PayrollPeriodResponse
+	}{
+		{
+			name: "success",
+			want: model.PayrollPeriodResponse{
+				ID:        2,
+				StartDate: date(6, 20),
+				EndDate:   date(7, 10),
+				Status:    constant.PayrollPeriodStatusOpen,
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().
+					GetPayrollPeriod(gomock.Any(), int64(2)).
+					Return(model.MstPayrollPeriod{ID: 2, StartDate: date(6, 20), EndDate: date(7, 19)}, nil).Times(1)
+				mockAttendanceRepo.EXPECT().
+					ListPayrollPeriodByParams(gomock.Any(), gomock.Any()).
+					Return([]model.MstPayrollPeriod{
+						{ID: 1, StartDate: date(5, 20), EndDate: date(6, 19)},
+						{ID: 2, StartDate: date(6, 20), EndDate: date(7, 19)},
+					}, nil).Times(1)
+				mockAttendanceRepo.EXPECT().
+					UpdatePayrollPeriod(gomock.Any(), gomock.Any()).
+					Return(nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - already processed",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().
+					GetPayrollPeriod(gomock.Any(), int64(2)).
+					Return(model.MstPayrollPeriod{
+						ID:                   2,
+						PayrollProcessedDate: sql.NullTime{Time: date(7, 21), Valid: true},
+					}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - update",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().
+					GetPayrollPeriod(gomock.Any(), int64(2)).
+					Return(model.MstPayrollPeriod{ID: 2}, nil).Times(1)
+				mockAttendanceRepo.EXPECT().
+					ListPayrollPeriodByParams(gomock.Any(), gomock.Any()).
+					Return([]model.MstPayrollPeriod{}, nil).Times(1)
+				mockAttendanceRepo.EXPECT().
+					UpdatePayrollPeriod(gomock.Any(), gomock.Any()).
+					Return(errFoo).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+			}
+			tt.patch()
+			defer tt.unpatch()
+
+			got, err := u.UpdatePayrollPeriod(context.Background(), 2, request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdatePayrollPeriod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_DeletePayrollPeriod(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	schedule := []model.MstPayrollPeriod{
+		{ID: 1, StartDate: date(5, 20), EndDate: date(6, 19)},
+		{ID: 2, StartDate: date(6, 20), EndDate: date(7, 19)},
+		{ID: 3, StartDate: date(7, 20), EndDate: date(8, 19)},
+	}
+
+	testCases := []struct {
+		name    string
+		id      int64
+		patch   func()
+		unpatch func()
+		wantErr bool
+	}{
+		{
+			name: "success - last period",
+			id:   3,
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().
+					GetPayrollPeriod(gomock.Any(), int64(3)).
+					Return(schedule[2], nil).Times(1)
+				mockAttendanceRepo.EXPECT().
+					ListPayrollPeriodByParams(gomock.Any(), gomock.Any()).
+					Return(schedule, nil).Times(1)
+				mockAttendanceRepo.EXPECT().
+					DeletePayrollPeriod(gomock.Any(), int64(3)).
+					Return(nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - middle period",
+			id:      2,
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().
+					GetPayrollPeriod(gomock.Any(), int64(2)).
+					Return(schedule[1], nil).Times(1)
+				mockAttendanceRepo.EXPECT().
+					ListPayrollPeriodByParams(gomock.Any(), gomock.Any()).
+					Return(schedule, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - not admin",
+			id:      3,
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockEmployeeUser
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+			}
+			tt.patch()
+			defer tt.unpatch()
+
+			err := u.DeletePayrollPeriod(context.Background(), tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeletePayrollPeriod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ListPayrollScheduleRuns(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name    string
+		request model.ListSchedulerRunRequest
+		patch   func()
+		unpatch func()
+		wantErr bool
+		want    model.ListSchedulerRunResponse
+	}{
+		{
+			name: "success with next cursor",
+			request: model.ListSchedulerRunRequest{
+				CursorPagination: model.CursorPagination{Limit: 2},
+				Status:           constant.SchedulerRunStatusFailed,
+			},
+			want: model.ListSchedulerRunResponse{
+				Runs: []model.TrxSchedulerRun{
+					{ID: 9, Status: constant.SchedulerRunStatusFailed},
+					{ID: 7, Status: constant.SchedulerRunStatusFailed},
+				},
+				NextCursor: 7,
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().
+					ListSchedulerRunByParams(gomock.Any(), model.ListSchedulerRunParams{
+						JobName: constant.SchedulerJobPayrollPeriod,
+						Status:  constant.SchedulerRunStatusFailed,
+						Limit:   3,
+					}).
+					Return([]model.TrxSchedulerRun{
+						{ID: 9, Status: constant.SchedulerRunStatusFailed},
+						{ID: 7, Status: constant.SchedulerRunStatusFailed},
+						{ID: 4, Status: constant.SchedulerRunStatusFailed},
+					}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name: "success without runs",
+			want: model.ListSchedulerRunResponse{
+				Runs: []model.TrxSchedulerRun{},
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().
+					ListSchedulerRunByParams(gomock.Any(), gomock.Any()).
+					Return(nil, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - not admin",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockEmployeeUser
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - list scheduler run",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.EXPECT().
+					ListSchedulerRunByParams(gomock.Any(), gomock.Any()).
+					Return(nil, errFoo).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+			}
+			tt.patch()
+			defer tt.unpatch()
+
+			got, err := u.ListPayrollScheduleRuns(context.Background(), tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListPayrollScheduleRuns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
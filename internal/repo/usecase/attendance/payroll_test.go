@@ -8,6 +8,7 @@ import (
 
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/metrics"
 	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
 	"github.com/golang/mock/gomock"
 	"github.com/shopspring/decimal"
@@ -18,16 +19,30 @@ func Test_GeneratePayroll(t *testing.T) {
 	ctrl := initMock(t)
 	defer ctrl.Finish()
 
+	type observedGeneration struct {
+		employees     int
+		totalTakeHome int64
+		failed        bool
+	}
+	var observed *observedGeneration
+	metricsObservePayrollGeneration = func(duration time.Duration, employees int, totalTakeHome int64, failed bool) {
+		observed = &observedGeneration{employees: employees, totalTakeHome: totalTakeHome, failed: failed}
+	}
+	defer func() {
+		metricsObservePayrollGeneration = metrics.ObservePayrollGeneration
+	}()
+
 	type args struct {
 		ctx     context.Context
 		request model.GeneratePayrollRequest
 	}
 	testCases := []struct {
-		name    string
-		args    args
-		patch   func()
-		unpatch func()
-		wantErr bool
+		name         string
+		args         args
+		patch        func()
+		unpatch      func()
+		wantErr      bool
+		wantObserved *observedGeneration
 	}{
 		{
 			name: "success - complete payroll generation",
@@ -57,6 +72,8 @@ func Test_GeneratePayroll(t *testing.T) {
 					}, nil).
 					Times(1)
 
+				expectPayrollPolicy()
+
 				mockUserRepo.
 					EXPECT().ListUser(gomock.Any()).
 					Return([]model.MstUser{
@@ -73,11 +90,11 @@ func Test_GeneratePayroll(t *testing.T) {
 					}, nil).
 					Times(1)
 
-				usecaseGetNumberOfWorkingDays = func(u *Usecase, startDate, endDate time.Time) int {
-					return 22
+				usecaseLoadWorkCalendar = func(u *Usecase, ctx context.Context, userIDs []int64, startDate, endDate time.Time) (workCalendar, error) {
+					return workCalendar{}, nil
 				}
 
-				usecaseGetMapOfPayslipSummary = func(u *Usecase, employees []model.MstUser, workingDays int, payrollPeriodID int64) map[int64]model.TrxUserPayslip {
+				usecaseGetMapOfPayslipSummary = func(u *Usecase, employees []model.MstUser, calendar workCalendar, startDate, endDate time.Time, payrollPeriodID int64) map[int64]model.TrxUserPayslip {
 					return map[int64]model.TrxUserPayslip{
 						123: {
 							UserID:             123,
@@ -130,7 +147,7 @@ func Test_GeneratePayroll(t *testing.T) {
 					return payslipSummary, []model.TrxReimbursement{{ID: 1}}, nil
 				}
 
-				usecaseCalculatePayslipSummaryTotalSalary = func(u *Usecase, payslipSummary map[int64]model.TrxUserPayslip, numberOfWorkingDays int) (map[int64]model.TrxUserPayslip, int64) {
+				usecaseCalculatePayslipSummaryTotalSalary = func(u *Usecase, payslipSummary map[int64]model.TrxUserPayslip, overtimeMultiplier int64) (map[int64]model.TrxUserPayslip, int64) {
 					payslipSummary[123] = model.TrxUserPayslip{
 						UserID:              123,
 						Username:            "john.doe",
@@ -183,7 +200,7 @@ func Test_GeneratePayroll(t *testing.T) {
 			},
 			unpatch: func() {
 				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
-				usecaseGetNumberOfWorkingDays = (*Usecase).getNumberOfWorkingDays
+				usecaseLoadWorkCalendar = (*Usecase).loadWorkCalendar
 				usecaseGetMapOfPayslipSummary = (*Usecase).getMapOfPayslipSummary
 				usecaseAttendanceCalculation = (*Usecase).attendanceCalculation
 				usecaseOvertimeCalculation = (*Usecase).overtimeCalculation
@@ -195,7 +212,80 @@ func Test_GeneratePayroll(t *testing.T) {
 				usecaseUpdateOvertimeInBulk = (*Usecase).updateOvertimeInBulk
 				usecaseUpdatePayrollPeriod = (*Usecase).updatePayrollPeriod
 			},
-			wantErr: false,
+			wantErr:      false,
+			wantObserved: &observedGeneration{employees: 2, totalTakeHome: 2509090},
+		},
+		{
+			name: "failed - payroll already processed is not observed",
+			args: args{
+				ctx: context.Background(),
+				request: model.GeneratePayrollRequest{
+					IDMstPayrollPeriod: 1,
+				},
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.
+					EXPECT().GetPayrollPeriod(gomock.Any(), int64(1)).
+					Return(model.MstPayrollPeriod{
+						ID:                   1,
+						PayrollProcessedDate: sql.NullTime{Time: time.Now(), Valid: true},
+					}, nil).
+					Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed - payroll policy of the tenant not found",
+			args: args{
+				ctx: context.Background(),
+				request: model.GeneratePayrollRequest{
+					IDMstPayrollPeriod: 1,
+				},
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.
+					EXPECT().GetPayrollPeriod(gomock.Any(), int64(1)).
+					Return(model.MstPayrollPeriod{ID: 1}, nil).
+					Times(1)
+				mockTenantRepo.EXPECT().GetTenant(gomock.Any()).Return(model.MstTenant{}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+			wantErr:      true,
+			wantObserved: &observedGeneration{failed: true},
+		},
+		{
+			name: "failed - error while storing is observed as failed",
+			args: args{
+				ctx: context.Background(),
+				request: model.GeneratePayrollRequest{
+					IDMstPayrollPeriod: 1,
+				},
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAttendanceRepo.
+					EXPECT().GetPayrollPeriod(gomock.Any(), int64(1)).
+					Return(model.MstPayrollPeriod{ID: 1}, nil).
+					Times(1)
+				expectPayrollPolicy()
+
+				mockUserRepo.
+					EXPECT().ListUser(gomock.Any()).
+					Return(nil, errFoo).
+					Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+			wantErr:      true,
+			wantObserved: &observedGeneration{failed: true},
 		},
 	}
 
@@ -204,21 +294,24 @@ func Test_GeneratePayroll(t *testing.T) {
 			u := Usecase{
 				AttendanceDB: mockAttendanceRepo,
 				UserDB:       mockUserRepo,
+				TenantDB:     mockTenantRepo,
 			}
+			observed = nil
 			tc.patch()
 			defer tc.unpatch()
 
-			err := u.GeneratePayroll(tc.args.ctx, tc.args.request)
+			err := u.generatePayroll(tc.args.ctx, tc.args.request, func(stage string, progress int) {})
 
 			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Equal(t, tc.wantObserved, observed)
 		})
 	}
 }
 
 func Test_calculatePayslipSummaryTotalSalary(t *testing.T) {
 	type args struct {
-		payslipSummary      map[int64]model.TrxUserPayslip
-		numberOfWorkingDays int
+		payslipSummary     map[int64]model.TrxUserPayslip
+		overtimeMultiplier int64
 	}
 	testCases := []struct {
 		name                       string
@@ -231,26 +324,30 @@ func Test_calculatePayslipSummaryTotalSalary(t *testing.T) {
 		{
 			name: "success - single employee with full attendance",
 			args: args{
+				overtimeMultiplier: 2,
 				payslipSummary: map[int64]model.TrxUserPayslip{
 					1: {
 						UserID:              1,
 						BaseSalary:          1000000,
+						WorkingDays:         20,
+						WorkingHours:        160,
 						AttendedDays:        20,
 						OvertimeHours:       4,
 						TotalReimbursements: 50000,
 					},
 				},
-				numberOfWorkingDays: 20,
 			},
 			wantModifiedPayslipSummary: map[int64]model.TrxUserPayslip{
 				1: {
 					UserID:              1,
 					BaseSalary:          1000000,
+					WorkingDays:         20,
+					WorkingHours:        160,
 					AttendedDays:        20,
 					OvertimeHours:       4,
 					TotalReimbursements: 50000,
 					ProratedSalary:      1000000, // (20/20) * 1000000
-					OvertimePay:         50000,   // (1000000/20/8) * 4 * 2
+					OvertimePay:         50000,   // (1000000/160) * 4 * 2
 					TotalTakeHome:       1100000, // 1000000 + 50000 + 50000
 				},
 			},
@@ -261,26 +358,30 @@ func Test_calculatePayslipSummaryTotalSalary(t *testing.T) {
 		{
 			name: "success - single employee with partial attendance",
 			args: args{
+				overtimeMultiplier: 2,
 				payslipSummary: map[int64]model.TrxUserPayslip{
 					1: {
 						UserID:              1,
 						BaseSalary:          2000000,
+						WorkingDays:         20,
+						WorkingHours:        160,
 						AttendedDays:        15,
 						OvertimeHours:       2,
 						TotalReimbursements: 100000,
 					},
 				},
-				numberOfWorkingDays: 20,
 			},
 			wantModifiedPayslipSummary: map[int64]model.TrxUserPayslip{
 				1: {
 					UserID:              1,
 					BaseSalary:          2000000,
+					WorkingDays:         20,
+					WorkingHours:        160,
 					AttendedDays:        15,
 					OvertimeHours:       2,
 					TotalReimbursements: 100000,
 					ProratedSalary:      1500000, // (15/20) * 2000000
-					OvertimePay:         50000,   // (2000000/20/8) * 2 * 2
+					OvertimePay:         50000,   // (2000000/160) * 2 * 2
 					TotalTakeHome:       1650000, // 1500000 + 62500 + 100000
 				},
 			},
@@ -291,10 +392,13 @@ func Test_calculatePayslipSummaryTotalSalary(t *testing.T) {
 		{
 			name: "success - multiple employees",
 			args: args{
+				overtimeMultiplier: 2,
 				payslipSummary: map[int64]model.TrxUserPayslip{
 					1: {
 						UserID:              1,
 						BaseSalary:          1000000,
+						WorkingDays:         20,
+						WorkingHours:        160,
 						AttendedDays:        20,
 						OvertimeHours:       4,
 						TotalReimbursements: 50000,
@@ -302,32 +406,37 @@ func Test_calculatePayslipSummaryTotalSalary(t *testing.T) {
 					2: {
 						UserID:              2,
 						BaseSalary:          1500000,
+						WorkingDays:         20,
+						WorkingHours:        160,
 						AttendedDays:        18,
 						OvertimeHours:       2,
 						TotalReimbursements: 75000,
 					},
 				},
-				numberOfWorkingDays: 20,
 			},
 			wantModifiedPayslipSummary: map[int64]model.TrxUserPayslip{
 				1: {
 					UserID:              1,
 					BaseSalary:          1000000,
+					WorkingDays:         20,
+					WorkingHours:        160,
 					AttendedDays:        20,
 					OvertimeHours:       4,
 					TotalReimbursements: 50000,
 					ProratedSalary:      1000000, // (20/20) * 1000000
-					OvertimePay:         50000,   // (1000000/20/8) * 4 * 2
+					OvertimePay:         50000,   // (1000000/160) * 4 * 2
 					TotalTakeHome:       1100000, // 1000000 + 50000 + 50000
 				},
 				2: {
 					UserID:              2,
 					BaseSalary:          1500000,
+					WorkingDays:         20,
+					WorkingHours:        160,
 					AttendedDays:        18,
 					OvertimeHours:       2,
 					TotalReimbursements: 75000,
 					ProratedSalary:      1350000, // (18/20) * 1500000
-					OvertimePay:         37500,   // (1500000/20/8) * 2 * 2
+					OvertimePay:         37500,   // (1500000/160) * 2 * 2
 					TotalTakeHome:       1462500, // 1350000 + 37500 + 75000
 				},
 			},
@@ -335,6 +444,98 @@ func Test_calculatePayslipSummaryTotalSalary(t *testing.T) {
 			patch:                func() {},
 			unpatch:              func() {},
 		},
+		{
+			name: "success - employee on twelve hour shifts",
+			args: args{
+				overtimeMultiplier: 2,
+				payslipSummary: map[int64]model.TrxUserPayslip{
+					1: {
+						UserID:              1,
+						BaseSalary:          1800000,
+						WorkingDays:         15,
+						WorkingHours:        180,
+						AttendedDays:        12,
+						OvertimeHours:       3,
+						TotalReimbursements: 0,
+					},
+				},
+			},
+			wantModifiedPayslipSummary: map[int64]model.TrxUserPayslip{
+				1: {
+					UserID:              1,
+					BaseSalary:          1800000,
+					WorkingDays:         15,
+					WorkingHours:        180,
+					AttendedDays:        12,
+					OvertimeHours:       3,
+					TotalReimbursements: 0,
+					ProratedSalary:      1440000, // (12/15) * 1800000
+					OvertimePay:         60000,   // (1800000/180) * 3 * 2
+					TotalTakeHome:       1500000, // 1440000 + 60000
+				},
+			},
+			wantTotalTakeHomePay: 1500000,
+			patch:                func() {},
+			unpatch:              func() {},
+		},
+		{
+			name: "success - employee without scheduled days is paid reimbursements only",
+			args: args{
+				overtimeMultiplier: 2,
+				payslipSummary: map[int64]model.TrxUserPayslip{
+					1: {
+						UserID:              1,
+						BaseSalary:          1000000,
+						OvertimeHours:       2,
+						TotalReimbursements: 40000,
+					},
+				},
+			},
+			wantModifiedPayslipSummary: map[int64]model.TrxUserPayslip{
+				1: {
+					UserID:              1,
+					BaseSalary:          1000000,
+					OvertimeHours:       2,
+					TotalReimbursements: 40000,
+					TotalTakeHome:       40000,
+				},
+			},
+			wantTotalTakeHomePay: 40000,
+			patch:                func() {},
+			unpatch:              func() {},
+		},
+		{
+			name: "success - overtime paid at the multiplier of the tenant",
+			args: args{
+				overtimeMultiplier: 3,
+				payslipSummary: map[int64]model.TrxUserPayslip{
+					1: {
+						UserID:        1,
+						BaseSalary:    1000000,
+						WorkingDays:   20,
+						WorkingHours:  160,
+						AttendedDays:  20,
+						OvertimeHours: 4,
+					},
+				},
+			},
+			wantModifiedPayslipSummary: map[int64]model.TrxUserPayslip{
+				1: {
+					UserID:         1,
+					BaseSalary:     1000000,
+					WorkingDays:    20,
+					WorkingHours:   160,
+					AttendedDays:   20,
+					OvertimeHours:  4,
+					ProratedSalary: 1000000,
+					OvertimePay:    75000, // (1000000/160) * 4 * 3
+					TotalTakeHome:  1075000,
+				},
+			},
+			wantTotalTakeHomePay: 1075000,
+			patch:                func() {},
+			unpatch:              func() {},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -343,7 +544,7 @@ func Test_calculatePayslipSummaryTotalSalary(t *testing.T) {
 			tc.patch()
 			defer tc.unpatch()
 
-			gotModifiedPayslipSummary, gotTotalTakeHomePay := u.calculatePayslipSummaryTotalSalary(tc.args.payslipSummary, tc.args.numberOfWorkingDays)
+			gotModifiedPayslipSummary, gotTotalTakeHomePay := u.calculatePayslipSummaryTotalSalary(tc.args.payslipSummary, tc.args.overtimeMultiplier)
 
 			assert.Equal(t, tc.wantTotalTakeHomePay, gotTotalTakeHomePay)
 			assert.Equal(t, tc.wantModifiedPayslipSummary, gotModifiedPayslipSummary)
@@ -359,14 +560,14 @@ func Test_calculatePayslipSummaryTotalSalary_DecimalPrecision(t *testing.T) {
 		1: {
 			UserID:              1,
 			BaseSalary:          1000001, // Odd number to test precision
-			AttendedDays:        13,      // Partial attendance
-			OvertimeHours:       3,       // Odd overtime hours
-			TotalReimbursements: 33333,   // Odd reimbursement
+			WorkingDays:         21,      // Odd working days
+			WorkingHours:        168,
+			AttendedDays:        13,    // Partial attendance
+			OvertimeHours:       3,     // Odd overtime hours
+			TotalReimbursements: 33333, // Odd reimbursement
 		},
 	}
-	numberOfWorkingDays := 21 // Odd working days
-
-	gotModifiedPayslipSummary, gotTotalTakeHomePay := u.calculatePayslipSummaryTotalSalary(payslipSummary, numberOfWorkingDays)
+	gotModifiedPayslipSummary, gotTotalTakeHomePay := u.calculatePayslipSummaryTotalSalary(payslipSummary, 2)
 
 	employee := gotModifiedPayslipSummary[1]
 
@@ -376,8 +577,7 @@ func Test_calculatePayslipSummaryTotalSalary_DecimalPrecision(t *testing.T) {
 		Mul(decimal.NewFromInt(1000001)).IntPart()
 
 	expectedHourlyPay := decimal.NewFromInt(1000001).
-		Div(decimal.NewFromInt(21)).
-		Div(decimal.NewFromInt(8))
+		Div(decimal.NewFromInt(168))
 
 	expectedOvertimePay := decimal.NewFromInt(3).
 		Mul(expectedHourlyPay).
@@ -916,17 +1116,46 @@ func Test_updateReimbursementInBulk(t *testing.T) {
 							Valid: true,
 						},
 					},
+					{
+						ID:                 2,
+						UserID:             124,
+						Amount:             50000,
+						Status:             ReimbursementStatusPaid,
+						IDMstPayrollPeriod: sql.NullInt64{Int64: 1, Valid: true},
+						UpdatedBy:          sql.NullInt64{Int64: 456, Valid: true},
+					},
 				},
 			},
 			patch: func() {
 				mockAttendanceRepo.
-					EXPECT().UpdateReimbursement(gomock.Any(), gomock.Any()).
+					EXPECT().AssignReimbursementPayrollPeriod(gomock.Any(), model.AssignPayrollPeriodParams{
+					IDs:                []int64{1, 2},
+					IDMstPayrollPeriod: 1,
+					UpdatedBy:          sql.NullInt64{Int64: 456, Valid: true},
+					Status:             ReimbursementStatusPaid,
+				}).
 					Return(nil).
 					Times(1)
 			},
 			unpatch: func() {},
 			wantErr: false,
 		},
+		{
+			name: "failed to assign payroll period",
+			args: args{
+				ctx: context.Background(),
+				reimbursements: []model.TrxReimbursement{
+					{ID: 1, Status: ReimbursementStatusPaid},
+				},
+			},
+			patch: func() {
+				mockAttendanceRepo.
+					EXPECT().AssignReimbursementPayrollPeriod(gomock.Any(), gomock.Any()).
+					Return(errFoo)
+			},
+			unpatch: func() {},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -982,13 +1211,26 @@ func Test_updateOvertimeInBulk(t *testing.T) {
 			},
 			patch: func() {
 				mockAttendanceRepo.
-					EXPECT().UpdateOvertime(gomock.Any(), gomock.Any()).
+					EXPECT().AssignOvertimePayrollPeriod(gomock.Any(), model.AssignPayrollPeriodParams{
+					IDs:                []int64{1},
+					IDMstPayrollPeriod: 1,
+					UpdatedBy:          sql.NullInt64{Int64: 456, Valid: true},
+				}).
 					Return(nil).
 					Times(1)
 			},
 			unpatch: func() {},
 			wantErr: false,
 		},
+		{
+			name: "empty list",
+			args: args{
+				ctx: context.Background(),
+			},
+			patch:   func() {},
+			unpatch: func() {},
+			wantErr: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1044,13 +1286,33 @@ func Test_updateAttendanceInBulk(t *testing.T) {
 			},
 			patch: func() {
 				mockAttendanceRepo.
-					EXPECT().UpdateAttendance(gomock.Any(), gomock.Any()).
+					EXPECT().AssignAttendancePayrollPeriod(gomock.Any(), model.AssignPayrollPeriodParams{
+					IDs:                []int64{1},
+					IDMstPayrollPeriod: 1,
+					UpdatedBy:          sql.NullInt64{Int64: 456, Valid: true},
+				}).
 					Return(nil).
 					Times(1)
 			},
 			unpatch: func() {},
 			wantErr: false,
 		},
+		{
+			name: "failed to assign payroll period",
+			args: args{
+				ctx: context.Background(),
+				attendances: []model.MstAttendance{
+					{ID: 1},
+				},
+			},
+			patch: func() {
+				mockAttendanceRepo.
+					EXPECT().AssignAttendancePayrollPeriod(gomock.Any(), gomock.Any()).
+					Return(errFoo)
+			},
+			unpatch: func() {},
+			wantErr: true,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1136,7 +1398,9 @@ func Test_updatePayrollPeriod(t *testing.T) {
 func Test_getMapOfPayslipSummary(t *testing.T) {
 	type args struct {
 		employees       []model.MstUser
-		workingDays     int
+		calendar        workCalendar
+		startDate       time.Time
+		endDate         time.Time
 		payrollPeriodID int64
 	}
 	testCases := []struct {
@@ -1166,7 +1430,27 @@ func Test_getMapOfPayslipSummary(t *testing.T) {
 						Salary:   800000,
 					},
 				},
-				workingDays:     22,
+				calendar: workCalendar{
+					shifts: map[int64]model.MstShift{
+						1: {ID: 1, Name: "day", WorkingHours: 8},
+						2: {ID: 2, Name: "long day", WorkingHours: 12},
+					},
+					patterns: map[int64]workPattern{
+						// two days on, two days off
+						1: {cycleDays: 4, shifts: map[int]int64{0: 2, 1: 2}},
+					},
+					schedules: map[int64][]model.TrxUserSchedule{
+						456: {{IDMstUser: 456, IDMstWorkPattern: 1, StartDate: time.Date(2025, 7, 14, 0, 0, 0, 0, time.UTC)}},
+					},
+					rosters: map[int64]map[string]sql.NullInt64{
+						789: {
+							"2025-07-19": {Int64: 1, Valid: true},
+							"2025-07-21": {},
+						},
+					},
+				},
+				startDate:       time.Date(2025, 7, 14, 0, 0, 0, 0, time.UTC), // Monday
+				endDate:         time.Date(2025, 7, 27, 0, 0, 0, 0, time.UTC), // Sunday
 				payrollPeriodID: 5,
 			},
 			want: map[int64]model.TrxUserPayslip{
@@ -1174,21 +1458,24 @@ func Test_getMapOfPayslipSummary(t *testing.T) {
 					UserID:             123,
 					Username:           "john.doe",
 					BaseSalary:         1000000,
-					WorkingDays:        22,
+					WorkingDays:        10,
+					WorkingHours:       80,
 					IDMstPayrollPeriod: 5,
 				},
 				456: {
 					UserID:             456,
 					Username:           "jane.smith",
 					BaseSalary:         1500000,
-					WorkingDays:        22,
+					WorkingDays:        8,
+					WorkingHours:       96,
 					IDMstPayrollPeriod: 5,
 				},
 				789: {
 					UserID:             789,
 					Username:           "bob.wilson",
 					BaseSalary:         800000,
-					WorkingDays:        22,
+					WorkingDays:        10,
+					WorkingHours:       80,
 					IDMstPayrollPeriod: 5,
 				},
 			},
@@ -1203,7 +1490,7 @@ func Test_getMapOfPayslipSummary(t *testing.T) {
 			tc.patch()
 			defer tc.unpatch()
 
-			got := u.getMapOfPayslipSummary(tc.args.employees, tc.args.workingDays, tc.args.payrollPeriodID)
+			got := u.getMapOfPayslipSummary(tc.args.employees, tc.args.calendar, tc.args.startDate, tc.args.endDate, tc.args.payrollPeriodID)
 
 			assert.Equal(t, tc.want, got)
 		})
@@ -1212,25 +1499,81 @@ func Test_getMapOfPayslipSummary(t *testing.T) {
 
 func Test_getNumberOfWorkingDays(t *testing.T) {
 	type args struct {
+		calendar  workCalendar
+		userID    int64
 		startDate time.Time
 		endDate   time.Time
 	}
 	testCases := []struct {
-		name    string
-		args    args
-		patch   func()
-		unpatch func()
-		want    int
+		name             string
+		args             args
+		patch            func()
+		unpatch          func()
+		wantWorkingDays  int
+		wantWorkingHours int
 	}{
 		{
 			name: "success - full month with weekends",
 			args: args{
+				userID:    1,
 				startDate: time.Date(2025, 7, 13, 0, 0, 0, 0, time.UTC), // Monday
 				endDate:   time.Date(2025, 7, 19, 0, 0, 0, 0, time.UTC), // Sunday
 			},
-			want:    5, // 7 days - 2 weekend days (Saturdays + Sundays)
-			patch:   func() {},
-			unpatch: func() {},
+			wantWorkingDays:  5, // 7 days - 2 weekend days (Saturdays + Sundays)
+			wantWorkingHours: 40,
+			patch:            func() {},
+			unpatch:          func() {},
+		},
+		{
+			name: "success - pattern started before the period",
+			args: args{
+				calendar: workCalendar{
+					shifts: map[int64]model.MstShift{
+						2: {ID: 2, Name: "long day", WorkingHours: 12},
+					},
+					patterns: map[int64]workPattern{
+						1: {cycleDays: 4, shifts: map[int]int64{0: 2, 1: 2}},
+					},
+					schedules: map[int64][]model.TrxUserSchedule{
+						1: {{IDMstUser: 1, IDMstWorkPattern: 1, StartDate: time.Date(2025, 7, 12, 0, 0, 0, 0, time.UTC)}},
+					},
+				},
+				userID:    1,
+				startDate: time.Date(2025, 7, 13, 0, 0, 0, 0, time.UTC),
+				endDate:   time.Date(2025, 7, 19, 0, 0, 0, 0, time.UTC),
+			},
+			wantWorkingDays:  3, // 13, 16 and 17 July
+			wantWorkingHours: 36,
+			patch:            func() {},
+			unpatch:          func() {},
+		},
+		{
+			name: "success - back to the standard week after the schedule ends",
+			args: args{
+				calendar: workCalendar{
+					shifts: map[int64]model.MstShift{
+						3: {ID: 3, Name: "night", WorkingHours: 10},
+					},
+					patterns: map[int64]workPattern{
+						1: {cycleDays: 1, shifts: map[int]int64{0: 3}},
+					},
+					schedules: map[int64][]model.TrxUserSchedule{
+						1: {{
+							IDMstUser:        1,
+							IDMstWorkPattern: 1,
+							StartDate:        time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+							EndDate:          sql.NullTime{Time: time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC), Valid: true},
+						}},
+					},
+				},
+				userID:    1,
+				startDate: time.Date(2025, 7, 13, 0, 0, 0, 0, time.UTC),
+				endDate:   time.Date(2025, 7, 19, 0, 0, 0, 0, time.UTC),
+			},
+			wantWorkingDays:  6, // 13 to 15 July on nights, 16 to 18 July standard
+			wantWorkingHours: 54,
+			patch:            func() {},
+			unpatch:          func() {},
 		},
 	}
 
@@ -1240,9 +1583,10 @@ func Test_getNumberOfWorkingDays(t *testing.T) {
 			tc.patch()
 			defer tc.unpatch()
 
-			got := u.getNumberOfWorkingDays(tc.args.startDate, tc.args.endDate)
+			gotWorkingDays, gotWorkingHours := u.getNumberOfWorkingDays(tc.args.calendar, tc.args.userID, tc.args.startDate, tc.args.endDate)
 
-			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantWorkingDays, gotWorkingDays)
+			assert.Equal(t, tc.wantWorkingHours, gotWorkingHours)
 		})
 	}
 }
@@ -1540,7 +1884,7 @@ func Test_GetPayroll(t *testing.T) {
 					Return(model.DtlPayroll{
 						IDMstPayrollPeriod: 1,
 						TotalTakeHome:      5500000,
-						CreatedBy:          999,
+						CreatedBy:          sql.NullInt64{Int64: 999, Valid: true},
 					}, nil).
 					Times(1)
 			},
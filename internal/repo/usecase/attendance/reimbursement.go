@@ -5,6 +5,8 @@ import (
 	"database/sql"
 
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
 	"github.com/pkg/errors"
 )
 
@@ -14,6 +16,9 @@ const (
 )
 
 func (u *Usecase) SubmitReimbursement(ctx context.Context, submitReimbursementRequest model.SubmitReimbursementRequest) (resp model.SubmitReimbursementResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.SubmitReimbursement")
+	defer func() { tracing.End(span, err) }()
+	ctx = xormlib.WithPrimary(ctx)
 
 	user, found := authGetUserDetailFromCtx(ctx)
 	if !found {
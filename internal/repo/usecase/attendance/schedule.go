@@ -0,0 +1,608 @@
+package attendance
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxScheduleDays caps the days of one schedule lookup
+	maxScheduleDays = 62
+
+	// clockFormat is the format of the start and end time of shifts
+	clockFormat = "15:04"
+)
+
+var (
+	usecaseLoadWorkCalendar = (*Usecase).loadWorkCalendar
+
+	// standardShift is worked Monday to Friday by employees without a
+	// schedule
+	standardShift = model.MstShift{
+		Name:         "standard",
+		WorkingHours: int(WorkingHours),
+	}
+)
+
+// workCalendar tells the shift of an employee on a day: their roster day,
+// else the pattern of their schedule, else the standard week
+type workCalendar struct {
+	shifts    map[int64]model.MstShift
+	patterns  map[int64]workPattern
+	schedules map[int64][]model.TrxUserSchedule
+	rosters   map[int64]map[string]sql.NullInt64
+}
+
+type workPattern struct {
+	cycleDays int
+	shifts    map[int]int64
+}
+
+// loadWorkCalendar loads the schedules and roster days of the employees
+// between the dates, of every employee when userIDs is empty
+func (u *Usecase) loadWorkCalendar(ctx context.Context, userIDs []int64, startDate, endDate time.Time) (calendar workCalendar, err error) {
+	calendar = workCalendar{
+		shifts:    map[int64]model.MstShift{},
+		patterns:  map[int64]workPattern{},
+		schedules: map[int64][]model.TrxUserSchedule{},
+		rosters:   map[int64]map[string]sql.NullInt64{},
+	}
+	params := model.ListScheduleParams{
+		IDsMstUser: userIDs,
+		StartDate:  startDate,
+		EndDate:    endDate,
+	}
+
+	schedules, err := u.ScheduleDB.ListSchedules(ctx, params)
+	if err != nil {
+		return
+	}
+	rosters, err := u.ScheduleDB.ListRosters(ctx, params)
+	if err != nil {
+		return
+	}
+	if len(schedules) == 0 && len(rosters) == 0 {
+		return calendar, nil
+	}
+
+	patternIDs := []int64{}
+	for _, schedule := range schedules {
+		if _, found := calendar.patterns[schedule.IDMstWorkPattern]; !found {
+			calendar.patterns[schedule.IDMstWorkPattern] = workPattern{shifts: map[int]int64{}}
+			patternIDs = append(patternIDs, schedule.IDMstWorkPattern)
+		}
+		calendar.schedules[schedule.IDMstUser] = append(calendar.schedules[schedule.IDMstUser], schedule)
+	}
+	for _, roster := range rosters {
+		if calendar.rosters[roster.IDMstUser] == nil {
+			calendar.rosters[roster.IDMstUser] = map[string]sql.NullInt64{}
+		}
+		calendar.rosters[roster.IDMstUser][roster.RosterDate.Format(dateFormat)] = roster.IDMstShift
+	}
+
+	if len(patternIDs) > 0 {
+		patterns, errList := u.ScheduleDB.ListWorkPatterns(ctx, patternIDs)
+		if errList != nil {
+			return calendar, errList
+		}
+		for _, pattern := range patterns {
+			calendar.patterns[pattern.ID] = workPattern{cycleDays: pattern.CycleDays, shifts: map[int]int64{}}
+		}
+
+		days, errList := u.ScheduleDB.ListWorkPatternDays(ctx, patternIDs)
+		if errList != nil {
+			return calendar, errList
+		}
+		for _, day := range days {
+			calendar.patterns[day.IDMstWorkPattern].shifts[day.DayNumber] = day.IDMstShift
+		}
+	}
+
+	shifts, err := u.ScheduleDB.ListShifts(ctx)
+	if err != nil {
+		return
+	}
+	for _, shift := range shifts {
+		calendar.shifts[shift.ID] = shift
+	}
+	return calendar, nil
+}
+
+// shiftOn returns the shift of the employee on the day, working is false on
+// a day off
+func (c workCalendar) shiftOn(userID int64, day time.Time) (shift model.MstShift, working bool) {
+	date := day.Format(dateFormat)
+	if shiftID, found := c.rosters[userID][date]; found {
+		if !shiftID.Valid {
+			return model.MstShift{}, false
+		}
+		return c.shifts[shiftID.Int64], true
+	}
+
+	for _, schedule := range c.schedules[userID] {
+		if date < schedule.StartDate.Format(dateFormat) ||
+			(schedule.EndDate.Valid && date > schedule.EndDate.Time.Format(dateFormat)) {
+			continue
+		}
+		pattern := c.patterns[schedule.IDMstWorkPattern]
+		if pattern.cycleDays <= 0 {
+			return model.MstShift{}, false
+		}
+		shiftID, found := pattern.shifts[daysBetween(schedule.StartDate, day)%pattern.cycleDays]
+		if !found {
+			return model.MstShift{}, false
+		}
+		return c.shifts[shiftID], true
+	}
+
+	if isWeekend(day) {
+		return model.MstShift{}, false
+	}
+	return standardShift, true
+}
+
+// workdayAt returns the day a tap in at the time counts for. A shift that
+// crosses midnight belongs to the day it starts, so a tap in before the end of
+// the night shift of the day before counts for that day.
+func (c workCalendar) workdayAt(userID int64, at time.Time) (day time.Time, working bool) {
+	previousDay := at.AddDate(0, 0, -1)
+	if shift, working := c.shiftOn(userID, previousDay); working && crossesMidnight(shift) && at.Format(clockFormat) < shift.EndTime {
+		return previousDay, true
+	}
+	_, working = c.shiftOn(userID, at)
+	return at, working
+}
+
+// crossesMidnight tells whether the shift ends on the day after it starts
+func crossesMidnight(shift model.MstShift) bool {
+	return shift.StartTime != "" && shift.EndTime != "" && shift.EndTime < shift.StartTime
+}
+
+// daysBetween counts the calendar days from one date to a later one,
+// whatever their time of day
+func daysBetween(from, to time.Time) int {
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
+
+// isScheduledToWork tells whether the employee has a shift on the day
+func (u *Usecase) isScheduledToWork(ctx context.Context, userID int64, day time.Time) (bool, error) {
+	calendar, err := usecaseLoadWorkCalendar(u, ctx, []int64{userID}, day, day)
+	if err != nil {
+		return false, err
+	}
+	_, working := calendar.shiftOn(userID, day)
+	return working, nil
+}
+
+// scheduledWorkday returns the day a tap in at the time counts for and
+// whether the employee works that day
+func (u *Usecase) scheduledWorkday(ctx context.Context, userID int64, at time.Time) (day time.Time, working bool, err error) {
+	calendar, err := usecaseLoadWorkCalendar(u, ctx, []int64{userID}, at.AddDate(0, 0, -1), at)
+	if err != nil {
+		return
+	}
+	day, working = calendar.workdayAt(userID, at)
+	return day, working, nil
+}
+
+// CreateShift defines a shift employees can be scheduled on. Admin only.
+func (u *Usecase) CreateShift(ctx context.Context, request model.ShiftRequest) (resp model.MstShift, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.CreateShift")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.CreateShift")
+		return
+	}
+
+	shift := &model.MstShift{
+		Name:         request.Name,
+		StartTime:    request.StartTime,
+		EndTime:      request.EndTime,
+		WorkingHours: request.WorkingHours,
+		CreatedBy:    sql.NullInt64{Int64: user.ID, Valid: true},
+	}
+	err = u.ScheduleDB.CreateShift(ctx, shift)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.CreateShift")
+		return
+	}
+	return *shift, nil
+}
+
+// ListShifts lists every shift. Admin only.
+func (u *Usecase) ListShifts(ctx context.Context) (resp []model.MstShift, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.ListShifts")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.ListShifts")
+		return
+	}
+
+	resp, err = u.ScheduleDB.ListShifts(ctx)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListShifts")
+		return
+	}
+	if resp == nil {
+		resp = []model.MstShift{}
+	}
+	return
+}
+
+// CreateWorkPattern defines a cycle of shifts and days off, such as Monday
+// to Friday or four days on and four days off. Admin only.
+func (u *Usecase) CreateWorkPattern(ctx context.Context, request model.WorkPatternRequest) (resp model.WorkPatternResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.CreateWorkPattern")
+	defer func() { tracing.End(span, err) }()
+	ctx = xormlib.WithPrimary(ctx)
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.CreateWorkPattern")
+		return
+	}
+
+	shifts, err := u.listShiftsByID(ctx)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.CreateWorkPattern")
+		return
+	}
+
+	days := []model.DtlWorkPatternDay{}
+	for dayNumber, shiftID := range request.Shifts {
+		if shiftID == 0 {
+			continue
+		}
+		if _, found := shifts[shiftID]; !found {
+			err = commonerr.SetNewBadRequest("invalid", "shift not found")
+			return
+		}
+		days = append(days, model.DtlWorkPatternDay{
+			DayNumber:  dayNumber,
+			IDMstShift: shiftID,
+		})
+	}
+	if len(days) == 0 {
+		err = commonerr.SetNewBadRequest("invalid", "the pattern has no working day")
+		return
+	}
+
+	pattern := &model.MstWorkPattern{
+		Name:      request.Name,
+		CycleDays: len(request.Shifts),
+		CreatedBy: sql.NullInt64{Int64: user.ID, Valid: true},
+	}
+	err = u.ScheduleDB.CreateWorkPattern(ctx, pattern, days)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.CreateWorkPattern")
+		return
+	}
+
+	return toWorkPatternResponse(*pattern, days), nil
+}
+
+// ListWorkPatterns lists every pattern with its shifts. Admin only.
+func (u *Usecase) ListWorkPatterns(ctx context.Context) (resp []model.WorkPatternResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.ListWorkPatterns")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.ListWorkPatterns")
+		return
+	}
+
+	patterns, err := u.ScheduleDB.ListWorkPatterns(ctx, nil)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListWorkPatterns")
+		return
+	}
+
+	patternIDs := []int64{}
+	for _, pattern := range patterns {
+		patternIDs = append(patternIDs, pattern.ID)
+	}
+	days, err := u.ScheduleDB.ListWorkPatternDays(ctx, patternIDs)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListWorkPatterns")
+		return
+	}
+	daysByPattern := map[int64][]model.DtlWorkPatternDay{}
+	for _, day := range days {
+		daysByPattern[day.IDMstWorkPattern] = append(daysByPattern[day.IDMstWorkPattern], day)
+	}
+
+	resp = []model.WorkPatternResponse{}
+	for _, pattern := range patterns {
+		resp = append(resp, toWorkPatternResponse(pattern, daysByPattern[pattern.ID]))
+	}
+	return
+}
+
+// AssignSchedule moves an employee to a pattern from the start date, ending
+// the schedule they follow the day before. Admin only.
+func (u *Usecase) AssignSchedule(ctx context.Context, request model.AssignScheduleRequest) (resp model.TrxUserSchedule, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.AssignSchedule")
+	defer func() { tracing.End(span, err) }()
+	ctx = xormlib.WithPrimary(ctx)
+
+	admin, found := authGetUserDetailFromCtx(ctx)
+	if !found || admin.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.AssignSchedule")
+		return
+	}
+
+	startDate, err := time.ParseInLocation(dateFormat, request.StartDate, timeNow().Location())
+	if err != nil {
+		err = commonerr.SetNewBadRequest("invalid", "start_date must be a date")
+		return
+	}
+
+	err = u.checkEmployee(ctx, request.UserID)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.AssignSchedule")
+		return
+	}
+
+	patterns, err := u.ScheduleDB.ListWorkPatterns(ctx, []int64{request.WorkPatternID})
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.AssignSchedule")
+		return
+	}
+	if len(patterns) == 0 {
+		err = commonerr.SetNewBadRequest("invalid", "work pattern not found")
+		return
+	}
+
+	err = u.checkPayrollNotProcessed(ctx, startDate, time.Time{})
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.AssignSchedule")
+		return
+	}
+
+	schedule := &model.TrxUserSchedule{
+		IDMstUser:        request.UserID,
+		IDMstWorkPattern: request.WorkPatternID,
+		StartDate:        startDate,
+		CreatedBy:        sql.NullInt64{Int64: admin.ID, Valid: true},
+	}
+	assigned, err := u.ScheduleDB.AssignSchedule(ctx, schedule)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.AssignSchedule")
+		return
+	}
+	if !assigned {
+		err = commonerr.SetNewBadRequest("invalid", "the employee has a schedule starting on or after start_date")
+		return
+	}
+	return *schedule, nil
+}
+
+// SetRoster sets the shift of an employee on single days, overriding their
+// pattern. Admin only.
+func (u *Usecase) SetRoster(ctx context.Context, request model.RosterRequest) (resp []model.ScheduleDayResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.SetRoster")
+	defer func() { tracing.End(span, err) }()
+	ctx = xormlib.WithPrimary(ctx)
+
+	admin, found := authGetUserDetailFromCtx(ctx)
+	if !found || admin.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.SetRoster")
+		return
+	}
+
+	err = u.checkEmployee(ctx, request.UserID)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.SetRoster")
+		return
+	}
+
+	shifts, err := u.listShiftsByID(ctx)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.SetRoster")
+		return
+	}
+
+	var (
+		location = timeNow().Location()
+		rosters  = make([]model.TrxUserRoster, 0, len(request.Days))
+		days     = make([]model.ScheduleDayResponse, 0, len(request.Days))
+		seen     = map[string]bool{}
+		from, to time.Time
+	)
+	for _, day := range request.Days {
+		rosterDate, errParse := time.ParseInLocation(dateFormat, day.Date, location)
+		if errParse != nil {
+			err = commonerr.SetNewBadRequest("invalid", "date must be a date")
+			return
+		}
+		if seen[day.Date] {
+			err = commonerr.SetNewBadRequest("invalid", "a date is rostered twice")
+			return
+		}
+		seen[day.Date] = true
+
+		roster := model.TrxUserRoster{
+			IDMstUser:  request.UserID,
+			RosterDate: rosterDate,
+			CreatedBy:  sql.NullInt64{Int64: admin.ID, Valid: true},
+		}
+		dayResp := model.ScheduleDayResponse{Date: day.Date}
+		if day.ShiftID != 0 {
+			shift, found := shifts[day.ShiftID]
+			if !found {
+				err = commonerr.SetNewBadRequest("invalid", "shift not found")
+				return
+			}
+			roster.IDMstShift = sql.NullInt64{Int64: shift.ID, Valid: true}
+			dayResp = toScheduleDayResponse(rosterDate, shift, true)
+		}
+		rosters = append(rosters, roster)
+		days = append(days, dayResp)
+
+		if from.IsZero() || rosterDate.Before(from) {
+			from = rosterDate
+		}
+		if rosterDate.After(to) {
+			to = rosterDate
+		}
+	}
+
+	err = u.checkPayrollNotProcessed(ctx, from, to)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.SetRoster")
+		return
+	}
+
+	err = u.ScheduleDB.SetRoster(ctx, rosters)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.SetRoster")
+		return
+	}
+	return days, nil
+}
+
+// GetSchedule lists the shift of every day between the dates, of the caller
+// unless an admin asks for another employee
+func (u *Usecase) GetSchedule(ctx context.Context, request model.GetScheduleRequest) (resp model.GetScheduleResponse, err error) {
+	ctx, span := tracing.Start(ctx, "attendance.Usecase.GetSchedule")
+	defer func() { tracing.End(span, err) }()
+
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found {
+		err = errors.Wrap(errors.New("user not found"), "Usecase.GetSchedule")
+		return
+	}
+	if request.UserID == 0 {
+		request.UserID = user.ID
+	}
+	if request.UserID != user.ID && user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.GetSchedule")
+		return
+	}
+
+	location := timeNow().Location()
+	startDate, err := time.ParseInLocation(dateFormat, request.StartDate, location)
+	if err != nil {
+		err = commonerr.SetNewBadRequest("invalid", "start_date must be a date")
+		return
+	}
+	endDate, err := time.ParseInLocation(dateFormat, request.EndDate, location)
+	if err != nil {
+		err = commonerr.SetNewBadRequest("invalid", "end_date must be a date")
+		return
+	}
+	if endDate.Before(startDate) || daysBetween(startDate, endDate) >= maxScheduleDays {
+		err = commonerr.SetNewBadRequest("invalid", "end_date must be on or after start_date and within 62 days of it")
+		return
+	}
+
+	calendar, err := usecaseLoadWorkCalendar(u, ctx, []int64{request.UserID}, startDate, endDate)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.GetSchedule")
+		return
+	}
+
+	resp = model.GetScheduleResponse{
+		UserID: request.UserID,
+		Days:   []model.ScheduleDayResponse{},
+	}
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		shift, working := calendar.shiftOn(request.UserID, day)
+		if working {
+			resp.WorkingDays++
+			resp.WorkingHours += shift.WorkingHours
+		}
+		resp.Days = append(resp.Days, toScheduleDayResponse(day, shift, working))
+	}
+	return
+}
+
+// checkEmployee refuses an employee that does not exist
+func (u *Usecase) checkEmployee(ctx context.Context, userID int64) error {
+	employee, err := u.UserDB.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if employee.ID == 0 {
+		return commonerr.SetNewError(http.StatusNotFound, "not found", "user not found")
+	}
+	return nil
+}
+
+// checkPayrollNotProcessed refuses changing the schedule of days already
+// paid, from one date to another or onwards when to is zero
+func (u *Usecase) checkPayrollNotProcessed(ctx context.Context, from, to time.Time) error {
+	processed, err := u.AttendanceDB.ListPayrollPeriodByParams(ctx, model.ListPayrollPeriodParams{
+		Status: constant.PayrollPeriodStatusProcessed,
+	})
+	if err != nil {
+		return err
+	}
+	for _, period := range processed {
+		if period.EndDate.Format(dateFormat) < from.Format(dateFormat) {
+			continue
+		}
+		if !to.IsZero() && period.StartDate.Format(dateFormat) > to.Format(dateFormat) {
+			continue
+		}
+		return commonerr.SetNewBadRequest("payroll_processed", "the payroll of these days is already processed")
+	}
+	return nil
+}
+
+func (u *Usecase) listShiftsByID(ctx context.Context) (map[int64]model.MstShift, error) {
+	shifts, err := u.ScheduleDB.ListShifts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shiftsByID := make(map[int64]model.MstShift, len(shifts))
+	for _, shift := range shifts {
+		shiftsByID[shift.ID] = shift
+	}
+	return shiftsByID, nil
+}
+
+func toWorkPatternResponse(pattern model.MstWorkPattern, days []model.DtlWorkPatternDay) model.WorkPatternResponse {
+	resp := model.WorkPatternResponse{
+		ID:     pattern.ID,
+		Name:   pattern.Name,
+		Shifts: make([]int64, pattern.CycleDays),
+	}
+	for _, day := range days {
+		if day.DayNumber >= 0 && day.DayNumber < pattern.CycleDays {
+			resp.Shifts[day.DayNumber] = day.IDMstShift
+		}
+	}
+	return resp
+}
+
+func toScheduleDayResponse(day time.Time, shift model.MstShift, working bool) model.ScheduleDayResponse {
+	resp := model.ScheduleDayResponse{
+		Date:    day.Format(dateFormat),
+		Working: working,
+	}
+	if working {
+		resp.ShiftID = shift.ID
+		resp.ShiftName = shift.Name
+		resp.StartTime = shift.StartTime
+		resp.EndTime = shift.EndTime
+		resp.WorkingHours = shift.WorkingHours
+	}
+	return resp
+}
@@ -0,0 +1,735 @@
+package attendance
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_loadWorkCalendar(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	startDate := time.Date(2025, 7, 14, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 7, 27, 0, 0, 0, 0, time.UTC)
+	params := model.ListScheduleParams{IDsMstUser: []int64{2, 3}, StartDate: startDate, EndDate: endDate}
+	schedule := model.TrxUserSchedule{IDMstUser: 2, IDMstWorkPattern: 1, StartDate: startDate}
+	roster := model.TrxUserRoster{IDMstUser: 3, RosterDate: time.Date(2025, 7, 19, 0, 0, 0, 0, time.UTC), IDMstShift: sql.NullInt64{Int64: 2, Valid: true}}
+
+	tests := []struct {
+		name    string
+		patch   func()
+		want    workCalendar
+		wantErr bool
+	}{
+		{
+			name: "success",
+			patch: func() {
+				mockScheduleRepo.EXPECT().ListSchedules(gomock.Any(), params).Return([]model.TrxUserSchedule{schedule}, nil).Times(1)
+				mockScheduleRepo.EXPECT().ListRosters(gomock.Any(), params).Return([]model.TrxUserRoster{roster}, nil).Times(1)
+				mockScheduleRepo.EXPECT().ListWorkPatterns(gomock.Any(), []int64{1}).
+					Return([]model.MstWorkPattern{{ID: 1, CycleDays: 4}}, nil).Times(1)
+				mockScheduleRepo.EXPECT().ListWorkPatternDays(gomock.Any(), []int64{1}).
+					Return([]model.DtlWorkPatternDay{
+						{IDMstWorkPattern: 1, DayNumber: 0, IDMstShift: 1},
+						{IDMstWorkPattern: 1, DayNumber: 1, IDMstShift: 1},
+					}, nil).Times(1)
+				mockScheduleRepo.EXPECT().ListShifts(gomock.Any()).
+					Return([]model.MstShift{{ID: 1, WorkingHours: 12}, {ID: 2, WorkingHours: 8}}, nil).Times(1)
+			},
+			want: workCalendar{
+				shifts:    map[int64]model.MstShift{1: {ID: 1, WorkingHours: 12}, 2: {ID: 2, WorkingHours: 8}},
+				patterns:  map[int64]workPattern{1: {cycleDays: 4, shifts: map[int]int64{0: 1, 1: 1}}},
+				schedules: map[int64][]model.TrxUserSchedule{2: {schedule}},
+				rosters:   map[int64]map[string]sql.NullInt64{3: {"2025-07-19": {Int64: 2, Valid: true}}},
+			},
+		},
+		{
+			name: "success without schedules skips the patterns",
+			patch: func() {
+				mockScheduleRepo.EXPECT().ListSchedules(gomock.Any(), params).Return(nil, nil).Times(1)
+				mockScheduleRepo.EXPECT().ListRosters(gomock.Any(), params).Return(nil, nil).Times(1)
+			},
+			want: workCalendar{
+				shifts:    map[int64]model.MstShift{},
+				patterns:  map[int64]workPattern{},
+				schedules: map[int64][]model.TrxUserSchedule{},
+				rosters:   map[int64]map[string]sql.NullInt64{},
+			},
+		},
+		{
+			name: "error listing rosters",
+			patch: func() {
+				mockScheduleRepo.EXPECT().ListSchedules(gomock.Any(), params).Return(nil, nil).Times(1)
+				mockScheduleRepo.EXPECT().ListRosters(gomock.Any(), params).Return(nil, errFoo).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				ScheduleDB: mockScheduleRepo,
+			}
+			tt.patch()
+			got, err := u.loadWorkCalendar(context.Background(), []int64{2, 3}, startDate, endDate)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_workCalendar_shiftOn(t *testing.T) {
+	dayShift := model.MstShift{ID: 1, Name: "day", WorkingHours: 8}
+	nightShift := model.MstShift{ID: 2, Name: "night", StartTime: "22:00", EndTime: "06:00", WorkingHours: 8}
+	calendar := workCalendar{
+		shifts: map[int64]model.MstShift{1: dayShift, 2: nightShift},
+		patterns: map[int64]workPattern{
+			// three nights on, three days off
+			1: {cycleDays: 6, shifts: map[int]int64{0: 2, 1: 2, 2: 2}},
+		},
+		schedules: map[int64][]model.TrxUserSchedule{
+			2: {{
+				IDMstUser:        2,
+				IDMstWorkPattern: 1,
+				StartDate:        time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:          sql.NullTime{Time: time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC), Valid: true},
+			}},
+		},
+		rosters: map[int64]map[string]sql.NullInt64{
+			2: {"2025-07-08": {Int64: 1, Valid: true}},
+			3: {"2025-07-21": {}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		userID      int64
+		day         time.Time
+		wantShift   model.MstShift
+		wantWorking bool
+	}{
+		{
+			name:        "standard weekday",
+			userID:      1,
+			day:         time.Date(2025, 7, 21, 9, 0, 0, 0, time.UTC),
+			wantShift:   standardShift,
+			wantWorking: true,
+		},
+		{
+			name:   "standard weekend",
+			userID: 1,
+			day:    time.Date(2025, 7, 20, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "night of the pattern",
+			userID:      2,
+			day:         time.Date(2025, 7, 13, 23, 0, 0, 0, time.UTC),
+			wantShift:   nightShift,
+			wantWorking: true,
+		},
+		{
+			name:   "day off of the pattern",
+			userID: 2,
+			day:    time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "roster overrides the pattern",
+			userID:      2,
+			day:         time.Date(2025, 7, 8, 9, 0, 0, 0, time.UTC),
+			wantShift:   dayShift,
+			wantWorking: true,
+		},
+		{
+			name:        "standard week after the schedule ends",
+			userID:      2,
+			day:         time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC),
+			wantShift:   standardShift,
+			wantWorking: true,
+		},
+		{
+			name:   "roster day off",
+			userID: 3,
+			day:    time.Date(2025, 7, 21, 9, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotShift, gotWorking := calendar.shiftOn(tt.userID, tt.day)
+			assert.Equal(t, tt.wantShift, gotShift)
+			assert.Equal(t, tt.wantWorking, gotWorking)
+		})
+	}
+}
+
+func Test_workCalendar_workdayAt(t *testing.T) {
+	nightShift := model.MstShift{ID: 2, Name: "night", StartTime: "22:00", EndTime: "06:00", WorkingHours: 8}
+	calendar := workCalendar{
+		shifts: map[int64]model.MstShift{2: nightShift},
+		patterns: map[int64]workPattern{
+			// three nights on, three days off
+			1: {cycleDays: 6, shifts: map[int]int64{0: 2, 1: 2, 2: 2}},
+		},
+		schedules: map[int64][]model.TrxUserSchedule{
+			2: {{
+				IDMstUser:        2,
+				IDMstWorkPattern: 1,
+				StartDate:        time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		userID      int64
+		at          time.Time
+		wantDay     time.Time
+		wantWorking bool
+	}{
+		{
+			name:        "standard shift stays on the day",
+			userID:      1,
+			at:          time.Date(2025, 7, 22, 1, 0, 0, 0, time.UTC),
+			wantDay:     time.Date(2025, 7, 22, 1, 0, 0, 0, time.UTC),
+			wantWorking: true,
+		},
+		{
+			name:        "after midnight of the last night",
+			userID:      2,
+			at:          time.Date(2025, 7, 16, 3, 0, 0, 0, time.UTC),
+			wantDay:     time.Date(2025, 7, 15, 3, 0, 0, 0, time.UTC),
+			wantWorking: true,
+		},
+		{
+			name:        "start of the night",
+			userID:      2,
+			at:          time.Date(2025, 7, 14, 21, 45, 0, 0, time.UTC),
+			wantDay:     time.Date(2025, 7, 14, 21, 45, 0, 0, time.UTC),
+			wantWorking: true,
+		},
+		{
+			name:    "after the night ended",
+			userID:  2,
+			at:      time.Date(2025, 7, 16, 6, 0, 0, 0, time.UTC),
+			wantDay: time.Date(2025, 7, 16, 6, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotDay, gotWorking := calendar.workdayAt(tt.userID, tt.at)
+			assert.Equal(t, tt.wantDay, gotDay)
+			assert.Equal(t, tt.wantWorking, gotWorking)
+		})
+	}
+}
+
+func Test_CreateShift(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+	employeeCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 2, Role: constant.UserRoleEmployee})
+	request := model.ShiftRequest{Name: "night", StartTime: "22:00", EndTime: "06:00", WorkingHours: 8}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		patch   func()
+		want    model.MstShift
+		wantErr bool
+	}{
+		{
+			name: "success",
+			ctx:  adminCtx,
+			patch: func() {
+				mockScheduleRepo.EXPECT().CreateShift(gomock.Any(), &model.MstShift{
+					Name:         "night",
+					StartTime:    "22:00",
+					EndTime:      "06:00",
+					WorkingHours: 8,
+					CreatedBy:    sql.NullInt64{Int64: 1, Valid: true},
+				}).DoAndReturn(func(_ context.Context, shift *model.MstShift) error {
+					shift.ID = 4
+					return nil
+				}).Times(1)
+			},
+			want: model.MstShift{
+				ID:           4,
+				Name:         "night",
+				StartTime:    "22:00",
+				EndTime:      "06:00",
+				WorkingHours: 8,
+				CreatedBy:    sql.NullInt64{Int64: 1, Valid: true},
+			},
+		},
+		{
+			name: "error creating",
+			ctx:  adminCtx,
+			patch: func() {
+				mockScheduleRepo.EXPECT().CreateShift(gomock.Any(), gomock.Any()).Return(errFoo).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error not admin",
+			ctx:     employeeCtx,
+			patch:   func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				ScheduleDB: mockScheduleRepo,
+			}
+			tt.patch()
+			got, err := u.CreateShift(tt.ctx, request)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_CreateWorkPattern(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+	shifts := []model.MstShift{{ID: 1, Name: "day"}, {ID: 2, Name: "night"}}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		request model.WorkPatternRequest
+		patch   func()
+		want    model.WorkPatternResponse
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			ctx:     adminCtx,
+			request: model.WorkPatternRequest{Name: "four on four off", Shifts: []int64{1, 1, 2, 2, 0, 0, 0, 0}},
+			patch: func() {
+				mockScheduleRepo.EXPECT().ListShifts(gomock.Any()).Return(shifts, nil).Times(1)
+				mockScheduleRepo.EXPECT().CreateWorkPattern(gomock.Any(), &model.MstWorkPattern{
+					Name:      "four on four off",
+					CycleDays: 8,
+					CreatedBy: sql.NullInt64{Int64: 1, Valid: true},
+				}, []model.DtlWorkPatternDay{
+					{DayNumber: 0, IDMstShift: 1},
+					{DayNumber: 1, IDMstShift: 1},
+					{DayNumber: 2, IDMstShift: 2},
+					{DayNumber: 3, IDMstShift: 2},
+				}).DoAndReturn(func(_ context.Context, pattern *model.MstWorkPattern, _ []model.DtlWorkPatternDay) error {
+					pattern.ID = 3
+					return nil
+				}).Times(1)
+			},
+			want: model.WorkPatternResponse{ID: 3, Name: "four on four off", Shifts: []int64{1, 1, 2, 2, 0, 0, 0, 0}},
+		},
+		{
+			name:    "error unknown shift",
+			ctx:     adminCtx,
+			request: model.WorkPatternRequest{Name: "weekly", Shifts: []int64{1, 9}},
+			patch: func() {
+				mockScheduleRepo.EXPECT().ListShifts(gomock.Any()).Return(shifts, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error without working day",
+			ctx:     adminCtx,
+			request: model.WorkPatternRequest{Name: "never", Shifts: []int64{0, 0}},
+			patch: func() {
+				mockScheduleRepo.EXPECT().ListShifts(gomock.Any()).Return(shifts, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error not admin",
+			ctx:     context.Background(),
+			request: model.WorkPatternRequest{Name: "weekly", Shifts: []int64{1}},
+			patch:   func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				ScheduleDB: mockScheduleRepo,
+			}
+			tt.patch()
+			got, err := u.CreateWorkPattern(tt.ctx, tt.request)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ListWorkPatterns(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+
+	mockScheduleRepo.EXPECT().ListWorkPatterns(gomock.Any(), nil).
+		Return([]model.MstWorkPattern{{ID: 1, Name: "weekly", CycleDays: 7}, {ID: 2, Name: "nights", CycleDays: 2}}, nil).Times(1)
+	mockScheduleRepo.EXPECT().ListWorkPatternDays(gomock.Any(), []int64{1, 2}).
+		Return([]model.DtlWorkPatternDay{
+			{IDMstWorkPattern: 1, DayNumber: 0, IDMstShift: 1},
+			{IDMstWorkPattern: 1, DayNumber: 4, IDMstShift: 1},
+			{IDMstWorkPattern: 2, DayNumber: 0, IDMstShift: 2},
+		}, nil).Times(1)
+
+	u := Usecase{
+		ScheduleDB: mockScheduleRepo,
+	}
+	got, err := u.ListWorkPatterns(adminCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.WorkPatternResponse{
+		{ID: 1, Name: "weekly", Shifts: []int64{1, 0, 0, 0, 1, 0, 0}},
+		{ID: 2, Name: "nights", Shifts: []int64{2, 0}},
+	}, got)
+}
+
+func Test_AssignSchedule(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	mockNow := time.Date(2025, 7, 23, 18, 0, 0, 0, time.UTC)
+	startDate := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+	request := model.AssignScheduleRequest{UserID: 2, WorkPatternID: 3, StartDate: "2025-08-01"}
+	processed := []model.MstPayrollPeriod{{
+		ID:        1,
+		StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC),
+	}}
+	expectChecks := func(processed []model.MstPayrollPeriod) {
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), int64(2)).Return(model.MstUser{ID: 2}, nil).Times(1)
+		mockScheduleRepo.EXPECT().ListWorkPatterns(gomock.Any(), []int64{3}).Return([]model.MstWorkPattern{{ID: 3}}, nil).Times(1)
+		mockAttendanceRepo.EXPECT().ListPayrollPeriodByParams(gomock.Any(), model.ListPayrollPeriodParams{
+			Status: constant.PayrollPeriodStatusProcessed,
+		}).Return(processed, nil).Times(1)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		request model.AssignScheduleRequest
+		patch   func()
+		want    model.TrxUserSchedule
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			ctx:     adminCtx,
+			request: request,
+			patch: func() {
+				expectChecks(processed)
+				mockScheduleRepo.EXPECT().AssignSchedule(gomock.Any(), &model.TrxUserSchedule{
+					IDMstUser:        2,
+					IDMstWorkPattern: 3,
+					StartDate:        startDate,
+					CreatedBy:        sql.NullInt64{Int64: 1, Valid: true},
+				}).DoAndReturn(func(_ context.Context, schedule *model.TrxUserSchedule) (bool, error) {
+					schedule.ID = 5
+					return true, nil
+				}).Times(1)
+			},
+			want: model.TrxUserSchedule{
+				ID:               5,
+				IDMstUser:        2,
+				IDMstWorkPattern: 3,
+				StartDate:        startDate,
+				CreatedBy:        sql.NullInt64{Int64: 1, Valid: true},
+			},
+		},
+		{
+			name:    "error later schedule exists",
+			ctx:     adminCtx,
+			request: request,
+			patch: func() {
+				expectChecks(processed)
+				mockScheduleRepo.EXPECT().AssignSchedule(gomock.Any(), gomock.Any()).Return(false, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error payroll processed",
+			ctx:     adminCtx,
+			request: model.AssignScheduleRequest{UserID: 2, WorkPatternID: 3, StartDate: "2025-07-21"},
+			patch: func() {
+				expectChecks(processed)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error unknown pattern",
+			ctx:     adminCtx,
+			request: request,
+			patch: func() {
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), int64(2)).Return(model.MstUser{ID: 2}, nil).Times(1)
+				mockScheduleRepo.EXPECT().ListWorkPatterns(gomock.Any(), []int64{3}).Return(nil, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error user not found",
+			ctx:     adminCtx,
+			request: request,
+			patch: func() {
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), int64(2)).Return(model.MstUser{}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error not admin",
+			ctx:     auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 2, Role: constant.UserRoleEmployee}),
+			request: request,
+			patch:   func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+				UserDB:       mockUserRepo,
+				ScheduleDB:   mockScheduleRepo,
+			}
+			timeNow = func() time.Time { return mockNow }
+			defer func() { timeNow = time.Now }()
+			tt.patch()
+			got, err := u.AssignSchedule(tt.ctx, tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Usecase.AssignSchedule() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_SetRoster(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	mockNow := time.Date(2025, 7, 23, 18, 0, 0, 0, time.UTC)
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+	request := model.RosterRequest{
+		UserID: 2,
+		Days: []model.RosterDayRequest{
+			{Date: "2025-07-26", ShiftID: 4},
+			{Date: "2025-07-28"},
+		},
+	}
+	expectChecks := func() {
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), int64(2)).Return(model.MstUser{ID: 2}, nil).Times(1)
+		mockScheduleRepo.EXPECT().ListShifts(gomock.Any()).
+			Return([]model.MstShift{{ID: 4, Name: "weekend", StartTime: "09:00", EndTime: "15:00", WorkingHours: 6}}, nil).Times(1)
+	}
+
+	tests := []struct {
+		name    string
+		request model.RosterRequest
+		patch   func()
+		want    []model.ScheduleDayResponse
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			request: request,
+			patch: func() {
+				expectChecks()
+				mockAttendanceRepo.EXPECT().ListPayrollPeriodByParams(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				mockScheduleRepo.EXPECT().SetRoster(gomock.Any(), []model.TrxUserRoster{
+					{
+						IDMstUser:  2,
+						RosterDate: time.Date(2025, 7, 26, 0, 0, 0, 0, time.UTC),
+						IDMstShift: sql.NullInt64{Int64: 4, Valid: true},
+						CreatedBy:  sql.NullInt64{Int64: 1, Valid: true},
+					},
+					{
+						IDMstUser:  2,
+						RosterDate: time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC),
+						CreatedBy:  sql.NullInt64{Int64: 1, Valid: true},
+					},
+				}).Return(nil).Times(1)
+			},
+			want: []model.ScheduleDayResponse{
+				{Date: "2025-07-26", Working: true, ShiftID: 4, ShiftName: "weekend", StartTime: "09:00", EndTime: "15:00", WorkingHours: 6},
+				{Date: "2025-07-28"},
+			},
+		},
+		{
+			name: "error payroll processed",
+			request: model.RosterRequest{
+				UserID: 2,
+				Days:   []model.RosterDayRequest{{Date: "2025-07-12"}},
+			},
+			patch: func() {
+				expectChecks()
+				mockAttendanceRepo.EXPECT().ListPayrollPeriodByParams(gomock.Any(), gomock.Any()).
+					Return([]model.MstPayrollPeriod{{
+						ID:        1,
+						StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+						EndDate:   time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC),
+					}}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error date rostered twice",
+			request: model.RosterRequest{
+				UserID: 2,
+				Days:   []model.RosterDayRequest{{Date: "2025-07-26"}, {Date: "2025-07-26", ShiftID: 4}},
+			},
+			patch:   expectChecks,
+			wantErr: true,
+		},
+		{
+			name: "error unknown shift",
+			request: model.RosterRequest{
+				UserID: 2,
+				Days:   []model.RosterDayRequest{{Date: "2025-07-26", ShiftID: 9}},
+			},
+			patch:   expectChecks,
+			wantErr: true,
+		},
+		{
+			name:    "error storing",
+			request: request,
+			patch: func() {
+				expectChecks()
+				mockAttendanceRepo.EXPECT().ListPayrollPeriodByParams(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				mockScheduleRepo.EXPECT().SetRoster(gomock.Any(), gomock.Any()).Return(errFoo).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				AttendanceDB: mockAttendanceRepo,
+				UserDB:       mockUserRepo,
+				ScheduleDB:   mockScheduleRepo,
+			}
+			timeNow = func() time.Time { return mockNow }
+			defer func() { timeNow = time.Now }()
+			tt.patch()
+			got, err := u.SetRoster(adminCtx, tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Usecase.SetRoster() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_GetSchedule(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	mockNow := time.Date(2025, 7, 23, 18, 0, 0, 0, time.UTC)
+	employeeCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 2, Role: constant.UserRoleEmployee})
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		request model.GetScheduleRequest
+		patch   func()
+		want    model.GetScheduleResponse
+		wantErr bool
+	}{
+		{
+			name:    "success own schedule",
+			ctx:     employeeCtx,
+			request: model.GetScheduleRequest{StartDate: "2025-07-25", EndDate: "2025-07-28"},
+			patch: func() {
+				mockScheduleRepo.EXPECT().ListSchedules(gomock.Any(), model.ListScheduleParams{
+					IDsMstUser: []int64{2},
+					StartDate:  time.Date(2025, 7, 25, 0, 0, 0, 0, time.UTC),
+					EndDate:    time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC),
+				}).Return(nil, nil).Times(1)
+				mockScheduleRepo.EXPECT().ListRosters(gomock.Any(), gomock.Any()).
+					Return([]model.TrxUserRoster{{
+						IDMstUser:  2,
+						RosterDate: time.Date(2025, 7, 26, 0, 0, 0, 0, time.UTC),
+						IDMstShift: sql.NullInt64{Int64: 4, Valid: true},
+					}}, nil).Times(1)
+				mockScheduleRepo.EXPECT().ListShifts(gomock.Any()).
+					Return([]model.MstShift{{ID: 4, Name: "weekend", StartTime: "09:00", EndTime: "15:00", WorkingHours: 6}}, nil).Times(1)
+			},
+			want: model.GetScheduleResponse{
+				UserID:       2,
+				WorkingDays:  3,
+				WorkingHours: 22,
+				Days: []model.ScheduleDayResponse{
+					{Date: "2025-07-25", Working: true, ShiftName: "standard", WorkingHours: 8},
+					{Date: "2025-07-26", Working: true, ShiftID: 4, ShiftName: "weekend", StartTime: "09:00", EndTime: "15:00", WorkingHours: 6},
+					{Date: "2025-07-27"},
+					{Date: "2025-07-28", Working: true, ShiftName: "standard", WorkingHours: 8},
+				},
+			},
+		},
+		{
+			name:    "success admin reads another employee",
+			ctx:     adminCtx,
+			request: model.GetScheduleRequest{UserID: 2, StartDate: "2025-07-27", EndDate: "2025-07-27"},
+			patch:   expectStandardSchedule,
+			want: model.GetScheduleResponse{
+				UserID: 2,
+				Days:   []model.ScheduleDayResponse{{Date: "2025-07-27"}},
+			},
+		},
+		{
+			name:    "error another employee",
+			ctx:     employeeCtx,
+			request: model.GetScheduleRequest{UserID: 3, StartDate: "2025-07-25", EndDate: "2025-07-28"},
+			patch:   func() {},
+			wantErr: true,
+		},
+		{
+			name:    "error range too long",
+			ctx:     employeeCtx,
+			request: model.GetScheduleRequest{StartDate: "2025-07-01", EndDate: "2025-09-30"},
+			patch:   func() {},
+			wantErr: true,
+		},
+		{
+			name:    "error end before start",
+			ctx:     employeeCtx,
+			request: model.GetScheduleRequest{StartDate: "2025-07-28", EndDate: "2025-07-25"},
+			patch:   func() {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				ScheduleDB: mockScheduleRepo,
+			}
+			timeNow = func() time.Time { return mockNow }
+			defer func() { timeNow = time.Now }()
+			tt.patch()
+			got, err := u.GetSchedule(tt.ctx, tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Usecase.GetSchedule() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
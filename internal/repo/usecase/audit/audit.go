@@ -0,0 +1,123 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/pkg/errors"
+
+	auditdbrepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/audit"
+)
+
+const (
+	dateFormat = "2006-01-02"
+)
+
+var (
+	authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+)
+
+type Usecase struct {
+	AuditDB auditdbrepo.AuditRepository
+}
+
+func New(opt *Usecase) *Usecase {
+	return opt
+}
+
+// ListAuditLogs lets admins page through the audit trail, newest first. The
+// date range covers whole days, end date included.
+func (u *Usecase) ListAuditLogs(ctx context.Context, request model.ListAuditLogRequest) (resp model.ListAuditLogResponse, err error) {
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.ListAuditLogs")
+		return
+	}
+
+	params := model.ListAuditLogParams{
+		ActorID:   request.ActorID,
+		Action:    request.Action,
+		Entity:    request.Entity,
+		EntityID:  request.EntityID,
+		RequestID: request.RequestID,
+		Cursor:    request.Cursor,
+	}
+	if request.StartDate != "" || request.EndDate != "" {
+		startDate, endDate, e := parseDateRange(request.StartDate, request.EndDate)
+		if e != nil {
+			err = errors.Wrap(e, "Usecase.ListAuditLogs")
+			return
+		}
+		params.CreatedFrom = startDate
+		params.CreatedBefore = endDate.AddDate(0, 0, 1)
+	}
+
+	limit := request.GetLimit()
+	params.Limit = limit + 1
+	auditLogs, err := u.AuditDB.ListAuditLogByParams(ctx, params)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListAuditLogs")
+		return
+	}
+
+	if len(auditLogs) > limit {
+		auditLogs = auditLogs[:limit]
+		resp.NextCursor = auditLogs[limit-1].ID
+	}
+
+	resp.AuditLogs = []model.AuditLogResponse{}
+	for _, auditLog := range auditLogs {
+		resp.AuditLogs = append(resp.AuditLogs, toAuditLogResponse(auditLog))
+	}
+	return
+}
+
+func toAuditLogResponse(auditLog model.TrxAuditLog) model.AuditLogResponse {
+	resp := model.AuditLogResponse{
+		ID:            auditLog.ID,
+		ActorID:       auditLog.ActorID.Int64,
+		ActorUsername: auditLog.ActorUsername,
+		Action:        auditLog.Action,
+		Entity:        auditLog.Entity,
+		EntityID:      auditLog.EntityID.Int64,
+		RequestID:     auditLog.RequestID,
+		IPAddress:     auditLog.IPAddress,
+		CreatedAt:     auditLog.CreatedAt,
+	}
+	if auditLog.Before.Valid {
+		resp.Before = json.RawMessage(auditLog.Before.String)
+	}
+	if auditLog.After.Valid {
+		resp.After = json.RawMessage(auditLog.After.String)
+	}
+	return resp
+}
+
+func parseDateRange(start, end string) (startDate, endDate time.Time, err error) {
+	if start == "" || end == "" {
+		err = commonerr.SetNewBadRequest("invalid", "start_date and end_date must be provided together")
+		return
+	}
+
+	startDate, err = time.ParseInLocation(dateFormat, start, time.Local)
+	if err != nil {
+		err = commonerr.SetNewBadRequest("invalid", "start_date must be in YYYY-MM-DD format")
+		return
+	}
+	endDate, err = time.ParseInLocation(dateFormat, end, time.Local)
+	if err != nil {
+		err = commonerr.SetNewBadRequest("invalid", "end_date must be in YYYY-MM-DD format")
+		return
+	}
+
+	if startDate.After(endDate) {
+		err = commonerr.SetNewBadRequest("invalid", "start_date must not be after end_date")
+		return
+	}
+	return
+}
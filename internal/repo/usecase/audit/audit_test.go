@@ -0,0 +1,178 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	mockauditdb "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/_mocks/audit"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	mockAuditRepo *mockauditdb.MockAuditRepository
+
+	errFoo = errors.New("errFoo")
+)
+
+func initMock(t *testing.T) *gomock.Controller {
+	ctrl := gomock.NewController(t)
+	mockAuditRepo = mockauditdb.NewMockAuditRepository(ctrl)
+
+	return ctrl
+}
+
+func mockAdminUser(ctx context.Context) (auth.UserJWTPayload, bool) {
+	return auth.UserJWTPayload{ID: 1, Username: "admin", Role: constant.UserRoleAdmin}, true
+}
+
+func mockEmployeeUser(ctx context.Context) (auth.UserJWTPayload, bool) {
+	return auth.UserJWTPayload{ID: 2, Username: "employee", Role: constant.UserRoleEmployee}, true
+}
+
+func Test_ListAuditLogs(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	createdAt := time.Date(2025, 7, 21, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		request model.ListAuditLogRequest
+		patch   func()
+		unpatch func()
+		wantErr bool
+		want    model.ListAuditLogResponse
+	}{
+		{
+			name: "success with next cursor",
+			request: model.ListAuditLogRequest{
+				CursorPagination: model.CursorPagination{Limit: 1},
+				Entity:           "mst_attendance",
+				EntityID:         2,
+				StartDate:        "2025-07-01",
+				EndDate:          "2025-07-31",
+			},
+			want: model.ListAuditLogResponse{
+				AuditLogs: []model.AuditLogResponse{
+					{
+						ID:            9,
+						ActorID:       1,
+						ActorUsername: "admin",
+						Action:        constant.AuditActionUpdate,
+						Entity:        "mst_attendance",
+						EntityID:      2,
+						Before:        json.RawMessage(`{"id":2}`),
+						After:         json.RawMessage(`{"id":2}`),
+						RequestID:     "req-1",
+						CreatedAt:     createdAt,
+					},
+				},
+				NextCursor: 9,
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAuditRepo.EXPECT().
+					ListAuditLogByParams(gomock.Any(), model.ListAuditLogParams{
+						Entity:        "mst_attendance",
+						EntityID:      2,
+						CreatedFrom:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.Local),
+						CreatedBefore: time.Date(2025, 8, 1, 0, 0, 0, 0, time.Local),
+						Limit:         2,
+					}).
+					Return([]model.TrxAuditLog{
+						{
+							ID:            9,
+							ActorID:       sql.NullInt64{Int64: 1, Valid: true},
+							ActorUsername: "admin",
+							Action:        constant.AuditActionUpdate,
+							Entity:        "mst_attendance",
+							EntityID:      sql.NullInt64{Int64: 2, Valid: true},
+							Before:        sql.NullString{String: `{"id":2}`, Valid: true},
+							After:         sql.NullString{String: `{"id":2}`, Valid: true},
+							RequestID:     "req-1",
+							CreatedAt:     createdAt,
+						},
+						{ID: 4},
+					}, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name: "success without logs",
+			want: model.ListAuditLogResponse{
+				AuditLogs: []model.AuditLogResponse{},
+			},
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAuditRepo.EXPECT().
+					ListAuditLogByParams(gomock.Any(), gomock.Any()).
+					Return(nil, nil).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - not admin",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockEmployeeUser
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name: "error - only start date",
+			request: model.ListAuditLogRequest{
+				StartDate: "2025-07-01",
+			},
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+		{
+			name:    "error - list audit log",
+			wantErr: true,
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockAuditRepo.EXPECT().
+					ListAuditLogByParams(gomock.Any(), gomock.Any()).
+					Return(nil, errFoo).Times(1)
+			},
+			unpatch: func() {
+				authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &Usecase{
+				AuditDB: mockAuditRepo,
+			}
+			tt.patch()
+			defer tt.unpatch()
+
+			got, err := u.ListAuditLogs(context.Background(), tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListAuditLogs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
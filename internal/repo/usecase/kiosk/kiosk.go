@@ -0,0 +1,217 @@
+package kiosk
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/config"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	authrepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/auth"
+	kioskrepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/kiosk"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultTokenDurationInHours = 12
+	defaultNonceTTLInSeconds    = 30
+
+	// secretLength is the number of random bytes in a kiosk secret
+	secretLength = 32
+)
+
+var (
+	authGetUserDetailFromCtx  = auth.GetUserDetailFromCtx
+	authGetKioskDetailFromCtx = auth.GetKioskDetailFromCtx
+	timeNow                   = time.Now
+	randRead                  = rand.Read
+)
+
+type Usecase struct {
+	Cfg      *config.Config
+	KioskDB  kioskrepo.KioskRepository
+	AuthRepo authrepo.Authenticator
+}
+
+func New(opt *Usecase) *Usecase {
+	return opt
+}
+
+// RegisterKiosk registers a kiosk and returns its secret, which is not kept
+// and cannot be shown again. Admin only.
+func (u *Usecase) RegisterKiosk(ctx context.Context, request model.RegisterKioskRequest) (resp model.RegisterKioskResponse, err error) {
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.RegisterKiosk")
+		return
+	}
+
+	if !u.isOffice(request.OfficeName) {
+		err = commonerr.SetNewBadRequest("invalid", "office_name is not one of attendance.office_locations")
+		return
+	}
+
+	secret := make([]byte, secretLength)
+	_, err = randRead(secret)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.RegisterKiosk")
+		return
+	}
+	resp.Secret = hex.EncodeToString(secret)
+
+	kiosk := &model.MstKiosk{
+		Name:       request.Name,
+		OfficeName: request.OfficeName,
+		SecretHash: hashSecret(resp.Secret),
+		Active:     true,
+		CreatedBy:  sql.NullInt64{Int64: user.ID, Valid: true},
+	}
+	err = u.KioskDB.RegisterKiosk(ctx, kiosk)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.RegisterKiosk")
+		return model.RegisterKioskResponse{}, err
+	}
+
+	resp.ID = kiosk.ID
+	resp.Name = kiosk.Name
+	resp.OfficeName = kiosk.OfficeName
+	return
+}
+
+// isOffice accepts any office name while fencing is off
+func (u *Usecase) isOffice(name string) bool {
+	offices := u.Cfg.Attendance.OfficeLocations
+	if len(offices) == 0 || name == "" {
+		return true
+	}
+	for _, office := range offices {
+		if office.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ListKiosks lists every kiosk, deactivated ones included. Admin only.
+func (u *Usecase) ListKiosks(ctx context.Context) (resp []model.MstKiosk, err error) {
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.ListKiosks")
+		return
+	}
+
+	resp, err = u.KioskDB.ListKiosks(ctx)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ListKiosks")
+		return
+	}
+	if resp == nil {
+		resp = []model.MstKiosk{}
+	}
+	return
+}
+
+// DeactivateKiosk retires a kiosk. Its token and nonces stop working right
+// away and it can no longer sign in. Admin only.
+func (u *Usecase) DeactivateKiosk(ctx context.Context, kioskID int64) (resp model.MstKiosk, err error) {
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.DeactivateKiosk")
+		return
+	}
+
+	resp, err = u.KioskDB.DeactivateKiosk(ctx, kioskID, user.ID)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.DeactivateKiosk")
+		return
+	}
+	if resp.ID == 0 {
+		err = commonerr.SetNewError(http.StatusNotFound, "not found", "kiosk not found")
+		return
+	}
+	resp.Active = false
+	return
+}
+
+// SignIn issues a kiosk token for a registered, active kiosk and its secret
+func (u *Usecase) SignIn(ctx context.Context, request model.KioskSignInRequest) (token string, err error) {
+	// a kiosk deactivated a moment ago must not sign in from a lagging replica
+	ctx = xormlib.WithPrimary(ctx)
+	kiosk, err := u.KioskDB.GetKioskForSignIn(ctx, request.ID)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.SignIn")
+		return
+	}
+
+	valid := subtle.ConstantTimeCompare([]byte(kiosk.SecretHash), []byte(hashSecret(request.Secret))) == 1
+	if kiosk.ID == 0 || !kiosk.Active || !valid {
+		err = commonerr.SetNewUnauthorizedError("authorization", "invalid kiosk credentials")
+		return
+	}
+
+	durationInHours := u.Cfg.Kiosk.TokenDurationInHours
+	if durationInHours <= 0 {
+		durationInHours = defaultTokenDurationInHours
+	}
+	currTime := timeNow()
+	return u.AuthRepo.CreateKioskToken(ctx, auth.KioskJWTPayload{
+		ID:       kiosk.ID,
+		Name:     kiosk.Name,
+		TenantID: kiosk.IDMstTenant,
+	}, currTime, currTime.Add(time.Duration(durationInHours)*time.Hour))
+}
+
+// IssueNonce signs a fresh nonce for the signed in kiosk to show as a QR
+// code. Kiosks ask for a new one before ExpiresAt.
+func (u *Usecase) IssueNonce(ctx context.Context) (resp model.KioskNonceResponse, err error) {
+	signedIn, found := authGetKioskDetailFromCtx(ctx)
+	if !found {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.IssueNonce")
+		return
+	}
+
+	kiosk, err := u.KioskDB.GetKioskByID(ctx, signedIn.ID)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.IssueNonce")
+		return
+	}
+	if kiosk.ID == 0 || !kiosk.Active {
+		err = commonerr.SetNewUnauthorizedError("kiosk_inactive", "the kiosk is not registered or has been deactivated")
+		return
+	}
+
+	ttlInSeconds := u.Cfg.Kiosk.NonceTTLInSeconds
+	if ttlInSeconds <= 0 {
+		ttlInSeconds = defaultNonceTTLInSeconds
+	}
+	currTime := timeNow()
+	expiresAt := currTime.Add(time.Duration(ttlInSeconds) * time.Second)
+	nonce, err := u.AuthRepo.CreateKioskNonce(ctx, auth.KioskJWTPayload{
+		ID:       kiosk.ID,
+		Name:     kiosk.Name,
+		TenantID: kiosk.IDMstTenant,
+	}, currTime, expiresAt)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.IssueNonce")
+		return
+	}
+
+	return model.KioskNonceResponse{
+		Nonce:     nonce,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,294 @@
+package kiosk
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/config"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	mockrepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/_mocks"
+	mockkioskdb "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/_mocks/kiosk"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	mockKioskDB  *mockkioskdb.MockKioskRepository
+	mockAuthRepo *mockrepo.MockAuthenticator
+
+	errFoo = errors.New("errFoo")
+
+	adminCtx    = auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+	employeeCtx = auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 2, Role: constant.UserRoleEmployee})
+	kioskCtx    = auth.SetKioskDetailToCtx(context.Background(), auth.KioskJWTPayload{ID: 3, Name: "Warehouse gate"})
+)
+
+func initMock(t *testing.T) *gomock.Controller {
+	ctrl := gomock.NewController(t)
+	mockKioskDB = mockkioskdb.NewMockKioskRepository(ctrl)
+	mockAuthRepo = mockrepo.NewMockAuthenticator(ctrl)
+	return ctrl
+}
+
+func Test_RegisterKiosk(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{}
+	cfg.Attendance.OfficeLocations = []config.OfficeLocation{{Name: "HQ", RadiusInMeters: 100}}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		request model.RegisterKioskRequest
+		patch   func()
+		want    model.RegisterKioskResponse
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			ctx:     adminCtx,
+			request: model.RegisterKioskRequest{Name: "Warehouse gate", OfficeName: "HQ"},
+			patch: func() {
+				mockKioskDB.EXPECT().RegisterKiosk(gomock.Any(), &model.MstKiosk{
+					Name:       "Warehouse gate",
+					OfficeName: "HQ",
+					SecretHash: hashSecret("0101010101010101010101010101010101010101010101010101010101010101"),
+					Active:     true,
+					CreatedBy:  sql.NullInt64{Int64: 1, Valid: true},
+				}).DoAndReturn(func(ctx context.Context, kiosk *model.MstKiosk) error {
+					kiosk.ID = 3
+					return nil
+				}).Times(1)
+			},
+			want: model.RegisterKioskResponse{
+				ID:         3,
+				Name:       "Warehouse gate",
+				OfficeName: "HQ",
+				Secret:     "0101010101010101010101010101010101010101010101010101010101010101",
+			},
+		},
+		{
+			name:    "error not admin",
+			ctx:     employeeCtx,
+			request: model.RegisterKioskRequest{Name: "Warehouse gate"},
+			patch:   func() {},
+			wantErr: true,
+		},
+		{
+			name:    "error unknown office",
+			ctx:     adminCtx,
+			request: model.RegisterKioskRequest{Name: "Warehouse gate", OfficeName: "Branch"},
+			patch:   func() {},
+			wantErr: true,
+		},
+		{
+			name:    "error during insert",
+			ctx:     adminCtx,
+			request: model.RegisterKioskRequest{Name: "Warehouse gate"},
+			patch: func() {
+				mockKioskDB.EXPECT().RegisterKiosk(gomock.Any(), gomock.Any()).Return(errFoo).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				Cfg:     cfg,
+				KioskDB: mockKioskDB,
+			}
+			randRead = func(b []byte) (int, error) {
+				for i := range b {
+					b[i] = 1
+				}
+				return len(b), nil
+			}
+			defer func() { randRead = rand.Read }()
+			tt.patch()
+			got, err := u.RegisterKiosk(tt.ctx, tt.request)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_DeactivateKiosk(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		patch   func()
+		want    model.MstKiosk
+		wantErr bool
+	}{
+		{
+			name: "success",
+			ctx:  adminCtx,
+			patch: func() {
+				mockKioskDB.EXPECT().DeactivateKiosk(gomock.Any(), int64(3), int64(1)).
+					Return(model.MstKiosk{ID: 3, Name: "Warehouse gate", Active: true}, nil).Times(1)
+			},
+			want: model.MstKiosk{ID: 3, Name: "Warehouse gate"},
+		},
+		{
+			name:    "error not admin",
+			ctx:     employeeCtx,
+			patch:   func() {},
+			wantErr: true,
+		},
+		{
+			name: "error kiosk not found",
+			ctx:  adminCtx,
+			patch: func() {
+				mockKioskDB.EXPECT().DeactivateKiosk(gomock.Any(), int64(3), int64(1)).
+					Return(model.MstKiosk{}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				KioskDB: mockKioskDB,
+			}
+			tt.patch()
+			got, err := u.DeactivateKiosk(tt.ctx, 3)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_SignIn(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 7, 21, 8, 0, 0, 0, time.UTC)
+	kiosk := model.MstKiosk{ID: 3, IDMstTenant: 2, Name: "Warehouse gate", SecretHash: hashSecret("secret"), Active: true}
+
+	tests := []struct {
+		name    string
+		request model.KioskSignInRequest
+		patch   func()
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			request: model.KioskSignInRequest{ID: 3, Secret: "secret"},
+			patch: func() {
+				mockKioskDB.EXPECT().GetKioskForSignIn(gomock.Any(), int64(3)).Return(kiosk, nil).Times(1)
+				mockAuthRepo.EXPECT().CreateKioskToken(gomock.Any(), auth.KioskJWTPayload{ID: 3, Name: "Warehouse gate", TenantID: 2}, now, now.Add(12*time.Hour)).
+					Return("token", nil).Times(1)
+			},
+			want: "token",
+		},
+		{
+			name:    "error wrong secret",
+			request: model.KioskSignInRequest{ID: 3, Secret: "guess"},
+			patch: func() {
+				mockKioskDB.EXPECT().GetKioskForSignIn(gomock.Any(), int64(3)).Return(kiosk, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error deactivated",
+			request: model.KioskSignInRequest{ID: 3, Secret: "secret"},
+			patch: func() {
+				deactivated := kiosk
+				deactivated.Active = false
+				mockKioskDB.EXPECT().GetKioskForSignIn(gomock.Any(), int64(3)).Return(deactivated, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error unknown kiosk",
+			request: model.KioskSignInRequest{ID: 3, Secret: ""},
+			patch: func() {
+				mockKioskDB.EXPECT().GetKioskForSignIn(gomock.Any(), int64(3)).Return(model.MstKiosk{}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				Cfg:      &config.Config{},
+				KioskDB:  mockKioskDB,
+				AuthRepo: mockAuthRepo,
+			}
+			timeNow = func() time.Time { return now }
+			defer func() { timeNow = time.Now }()
+			tt.patch()
+			got, err := u.SignIn(context.Background(), tt.request)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_IssueNonce(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 7, 21, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		patch   func()
+		want    model.KioskNonceResponse
+		wantErr bool
+	}{
+		{
+			name: "success",
+			ctx:  kioskCtx,
+			patch: func() {
+				mockKioskDB.EXPECT().GetKioskByID(gomock.Any(), int64(3)).
+					Return(model.MstKiosk{ID: 3, IDMstTenant: 2, Name: "Warehouse gate", Active: true}, nil).Times(1)
+				mockAuthRepo.EXPECT().CreateKioskNonce(gomock.Any(), auth.KioskJWTPayload{ID: 3, Name: "Warehouse gate", TenantID: 2}, now, now.Add(30*time.Second)).
+					Return("nonce", nil).Times(1)
+			},
+			want: model.KioskNonceResponse{Nonce: "nonce", ExpiresAt: now.Add(30 * time.Second)},
+		},
+		{
+			name:    "error user token",
+			ctx:     adminCtx,
+			patch:   func() {},
+			wantErr: true,
+		},
+		{
+			name: "error deactivated",
+			ctx:  kioskCtx,
+			patch: func() {
+				mockKioskDB.EXPECT().GetKioskByID(gomock.Any(), int64(3)).
+					Return(model.MstKiosk{ID: 3}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				Cfg:      &config.Config{},
+				KioskDB:  mockKioskDB,
+				AuthRepo: mockAuthRepo,
+			}
+			timeNow = func() time.Time { return now }
+			defer func() { timeNow = time.Now }()
+			tt.patch()
+			got, err := u.IssueNonce(tt.ctx)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package payrolljob
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/config"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	attendancerepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/attendance"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase"
+	liblog "github.com/faisalhardin/employee-payroll-system/pkg/common/log"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultWorkers               = 1
+	defaultPollIntervalInSeconds = 5
+	defaultStaleAfterInMinutes   = 5
+	defaultMaxAttempts           = 3
+)
+
+var (
+	timeNow = time.Now
+)
+
+type Usecase struct {
+	Cfg               config.PayrollJob
+	AttendanceDB      attendancerepo.AttendanceRepository
+	AttendanceUsecase usecase.AttendanceUsecaseRepository
+}
+
+func New(u Usecase) *Usecase {
+	if u.Cfg.Workers <= 0 {
+		u.Cfg.Workers = defaultWorkers
+	}
+	if u.Cfg.PollIntervalInSeconds <= 0 {
+		u.Cfg.PollIntervalInSeconds = defaultPollIntervalInSeconds
+	}
+	if u.Cfg.StaleAfterInMinutes <= 0 {
+		u.Cfg.StaleAfterInMinutes = defaultStaleAfterInMinutes
+	}
+	if u.Cfg.MaxAttempts <= 0 {
+		u.Cfg.MaxAttempts = defaultMaxAttempts
+	}
+	return &u
+}
+
+// Run starts the workers and keeps requeueing stale jobs until ctx is done.
+func (u *Usecase) Run(ctx context.Context) {
+	wg := sync.WaitGroup{}
+	for i := 0; i < u.Cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			u.work(ctx)
+		}()
+	}
+
+	staleAfter := time.Duration(u.Cfg.StaleAfterInMinutes) * time.Minute
+	ticker := time.NewTicker(staleAfter)
+	defer ticker.Stop()
+
+	for {
+		u.RequeueStaleJobs(ctx)
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (u *Usecase) work(ctx context.Context) {
+	pollInterval := time.Duration(u.Cfg.PollIntervalInSeconds) * time.Second
+	for {
+		processed, err := u.ProcessNextJob(ctx)
+		if err != nil {
+			liblog.Errorf("payroll job failed: %v", err)
+		}
+		if processed {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// RequeueStaleJobs queues again the jobs whose worker stopped sending
+// heartbeats, typically because the server restarted mid-job.
+func (u *Usecase) RequeueStaleJobs(ctx context.Context) {
+	staleBefore := timeNow().Add(-time.Duration(u.Cfg.StaleAfterInMinutes) * time.Minute)
+	requeued, err := u.AttendanceDB.RequeueStalePayrollJobs(ctx, staleBefore)
+	if err != nil {
+		liblog.Errorf("failed to requeue stale payroll jobs: %v", err)
+		return
+	}
+	if requeued > 0 {
+		liblog.Infof("requeued %d interrupted payroll jobs", requeued)
+	}
+}
+
+// ProcessNextJob claims the oldest queued job and runs it, returning false
+// when the queue is empty.
+func (u *Usecase) ProcessNextJob(ctx context.Context) (processed bool, err error) {
+	job, found, err := u.AttendanceDB.ClaimPayrollJob(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "Usecase.ProcessNextJob")
+	}
+	if !found {
+		return false, nil
+	}
+	// the queue is shared, the job runs in the tenant that queued it
+	ctx = tenant.NewContext(ctx, job.IDMstTenant)
+
+	if job.Attempts > u.Cfg.MaxAttempts {
+		job.Status = constant.PayrollJobStatusFailed
+		job.ErrorMessage = fmt.Sprintf("gave up after %d interrupted attempts", u.Cfg.MaxAttempts)
+		job.FinishedAt = sql.NullTime{
+			Time:  timeNow(),
+			Valid: true,
+		}
+		err = u.AttendanceDB.UpdatePayrollJob(ctx, &job)
+		if err != nil {
+			return true, errors.Wrap(err, "Usecase.ProcessNextJob")
+		}
+		return true, nil
+	}
+
+	stopHeartbeat := u.startHeartbeat(ctx, job)
+	defer stopHeartbeat()
+
+	err = u.AttendanceUsecase.RunPayrollJob(ctx, job)
+	if err != nil {
+		return true, errors.Wrap(err, "Usecase.ProcessNextJob")
+	}
+	return true, nil
+}
+
+// startHeartbeat keeps the job from being seen as stale while a long stage
+// runs without reporting progress.
+func (u *Usecase) startHeartbeat(ctx context.Context, job model.TrxPayrollJob) (stop func()) {
+	heartbeatCtx, cancel := context.WithCancel(ctx)
+	interval := time.Duration(u.Cfg.StaleAfterInMinutes) * time.Minute / 3
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-heartbeatCtx.Done():
+				return
+			case <-ticker.C:
+				if err := u.AttendanceDB.TouchPayrollJob(heartbeatCtx, job.ID); err != nil {
+					liblog.Errorf("failed to send heartbeat of payroll job %d: %v", job.ID, err)
+				}
+			}
+		}
+	}()
+
+	return cancel
+}
@@ -0,0 +1,161 @@
+package payrolljob
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/config"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	mockusecase "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/_mocks"
+	mockattendancedb "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/_mocks/attendance"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	mockAttendanceRepo *mockattendancedb.MockAttendanceRepository
+	mockAttendanceUC   *mockusecase.MockAttendanceUsecaseRepository
+
+	errFoo = errors.New("errFoo")
+)
+
+func initMock(t *testing.T) *gomock.Controller {
+	ctrl := gomock.NewController(t)
+	mockAttendanceRepo = mockattendancedb.NewMockAttendanceRepository(ctrl)
+	mockAttendanceUC = mockusecase.NewMockAttendanceUsecaseRepository(ctrl)
+
+	return ctrl
+}
+
+func Test_New(t *testing.T) {
+	u := New(Usecase{})
+	assert.Equal(t, config.PayrollJob{
+		Workers:               defaultWorkers,
+		PollIntervalInSeconds: defaultPollIntervalInSeconds,
+		StaleAfterInMinutes:   defaultStaleAfterInMinutes,
+		MaxAttempts:           defaultMaxAttempts,
+	}, u.Cfg)
+
+	u = New(Usecase{Cfg: config.PayrollJob{Workers: 4, MaxAttempts: 1}})
+	assert.Equal(t, 4, u.Cfg.Workers)
+	assert.Equal(t, 1, u.Cfg.MaxAttempts)
+}
+
+func Test_ProcessNextJob(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	cfg := config.PayrollJob{
+		StaleAfterInMinutes: 5,
+		MaxAttempts:         3,
+	}
+
+	tests := []struct {
+		name          string
+		wantProcessed bool
+		wantErr       bool
+		patch         func()
+	}{
+		{
+			name:          "runs the claimed job",
+			wantProcessed: true,
+			patch: func() {
+				job := model.TrxPayrollJob{ID: 1, IDMstPayrollPeriod: 5, Status: constant.PayrollJobStatusRunning, Attempts: 1}
+				mockAttendanceRepo.EXPECT().ClaimPayrollJob(gomock.Any()).Return(job, true, nil)
+				mockAttendanceUC.EXPECT().RunPayrollJob(gomock.Any(), job).Return(nil)
+			},
+		},
+		{
+			name:          "runs the claimed job in its tenant",
+			wantProcessed: true,
+			patch: func() {
+				job := model.TrxPayrollJob{ID: 2, IDMstTenant: 3, IDMstPayrollPeriod: 5, Status: constant.PayrollJobStatusRunning, Attempts: 1}
+				mockAttendanceRepo.EXPECT().ClaimPayrollJob(gomock.Any()).Return(job, true, nil)
+				mockAttendanceUC.EXPECT().RunPayrollJob(gomock.Any(), job).
+					DoAndReturn(func(ctx context.Context, job model.TrxPayrollJob) error {
+						tenantID, found := tenant.FromContext(ctx)
+						assert.True(t, found)
+						assert.Equal(t, int64(3), tenantID)
+						return nil
+					})
+			},
+		},
+		{
+			name: "empty queue",
+			patch: func() {
+				mockAttendanceRepo.EXPECT().ClaimPayrollJob(gomock.Any()).Return(model.TrxPayrollJob{}, false, nil)
+			},
+		},
+		{
+			name:          "gives up after too many attempts",
+			wantProcessed: true,
+			patch: func() {
+				mockAttendanceRepo.EXPECT().ClaimPayrollJob(gomock.Any()).
+					Return(model.TrxPayrollJob{ID: 1, Status: constant.PayrollJobStatusRunning, Attempts: 4}, true, nil)
+				mockAttendanceRepo.EXPECT().UpdatePayrollJob(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, job *model.TrxPayrollJob) error {
+						assert.Equal(t, constant.PayrollJobStatusFailed, job.Status)
+						assert.Equal(t, "gave up after 3 interrupted attempts", job.ErrorMessage)
+						assert.True(t, job.FinishedAt.Valid)
+						return nil
+					})
+			},
+		},
+		{
+			name:          "job failed",
+			wantProcessed: true,
+			wantErr:       true,
+			patch: func() {
+				mockAttendanceRepo.EXPECT().ClaimPayrollJob(gomock.Any()).
+					Return(model.TrxPayrollJob{ID: 1, Attempts: 1}, true, nil)
+				mockAttendanceUC.EXPECT().RunPayrollJob(gomock.Any(), gomock.Any()).Return(errFoo)
+			},
+		},
+		{
+			name:    "failed to claim",
+			wantErr: true,
+			patch: func() {
+				mockAttendanceRepo.EXPECT().ClaimPayrollJob(gomock.Any()).Return(model.TrxPayrollJob{}, false, errFoo)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.patch()
+
+			u := New(Usecase{
+				Cfg:               cfg,
+				AttendanceDB:      mockAttendanceRepo,
+				AttendanceUsecase: mockAttendanceUC,
+			})
+			processed, err := u.ProcessNextJob(context.Background())
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantProcessed, processed)
+		})
+	}
+}
+
+func Test_RequeueStaleJobs(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	mockNow := time.Date(2025, 7, 21, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return mockNow }
+	defer func() {
+		timeNow = time.Now
+	}()
+
+	mockAttendanceRepo.EXPECT().RequeueStalePayrollJobs(gomock.Any(), mockNow.Add(-5*time.Minute)).Return(int64(1), nil)
+	mockAttendanceRepo.EXPECT().RequeueStalePayrollJobs(gomock.Any(), gomock.Any()).Return(int64(0), errFoo)
+
+	u := New(Usecase{
+		Cfg:          config.PayrollJob{StaleAfterInMinutes: 5},
+		AttendanceDB: mockAttendanceRepo,
+	})
+	u.RequeueStaleJobs(context.Background())
+	u.RequeueStaleJobs(context.Background())
+}
@@ -0,0 +1,318 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/config"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	attendancerepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/attendance"
+	tenantrepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/tenant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/repo/usecase"
+	liblog "github.com/faisalhardin/employee-payroll-system/pkg/common/log"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxPeriodsPerRun bounds how far a single run catches up after a long outage
+	maxPeriodsPerRun = 24
+
+	defaultIntervalInMinutes = 60
+)
+
+var (
+	timeNow = time.Now
+)
+
+type Usecase struct {
+	Cfg               config.Scheduler
+	AttendanceDB      attendancerepo.AttendanceRepository
+	TenantDB          tenantrepo.TenantRepository
+	AttendanceUsecase usecase.AttendanceUsecaseRepository
+}
+
+func New(u Usecase) (*Usecase, error) {
+	if err := validateConfig(u.Cfg); err != nil {
+		return nil, errors.Wrap(err, "scheduler.New")
+	}
+	return &u, nil
+}
+
+func validateConfig(cfg config.Scheduler) error {
+	switch cfg.Frequency {
+	case constant.ScheduleFrequencyMonthly:
+		if len(cfg.CutoffDays) != 1 {
+			return errors.New("monthly schedule needs exactly one cut-off day")
+		}
+	case constant.ScheduleFrequencySemiMonthly:
+		if len(cfg.CutoffDays) != 2 {
+			return errors.New("semi-monthly schedule needs exactly two cut-off days")
+		}
+	default:
+		return fmt.Errorf("unknown schedule frequency %q", cfg.Frequency)
+	}
+
+	for _, day := range cfg.CutoffDays {
+		if day < 1 || day > 31 {
+			return fmt.Errorf("cut-off day %d must be between 1 and 31", day)
+		}
+	}
+	if cfg.PeriodsAhead < 0 || cfg.GenerateAfterDays < 0 {
+		return errors.New("periods_ahead and generate_after_days cannot be negative")
+	}
+	return nil
+}
+
+// Run executes the schedule of every tenant immediately and then on every
+// interval until ctx is done.
+func (u *Usecase) Run(ctx context.Context) {
+	interval := time.Duration(u.Cfg.IntervalInMinutes) * time.Minute
+	if interval <= 0 {
+		interval = defaultIntervalInMinutes * time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		u.runTenantSchedules(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runTenantSchedules runs the schedule once for every tenant, a failing
+// tenant does not hold back the others.
+func (u *Usecase) runTenantSchedules(ctx context.Context) {
+	tenants, err := u.TenantDB.ListTenants(ctx)
+	if err != nil {
+		liblog.Errorf("payroll schedule could not list tenants: %v", err)
+		return
+	}
+
+	for _, t := range tenants {
+		run, err := u.RunPayrollSchedule(tenant.NewContext(ctx, t.ID))
+		if err != nil {
+			liblog.Errorf("payroll schedule run of tenant %s failed: %v", t.Code, err)
+		} else {
+			liblog.Infof("payroll schedule run of tenant %s %s: %s", t.Code, run.Status, run.Message)
+		}
+	}
+}
+
+// RunPayrollSchedule creates the periods needed to cover today plus the
+// configured number of periods ahead, optionally queues payroll generation for
+// open periods past their cut-off, and records the outcome. It works on the
+// tenant in ctx.
+func (u *Usecase) RunPayrollSchedule(ctx context.Context) (run model.TrxSchedulerRun, err error) {
+	// periods created in earlier runs must be seen, or they are created twice
+	ctx = xormlib.WithPrimary(ctx)
+	run = model.TrxSchedulerRun{
+		JobName:   constant.SchedulerJobPayrollPeriod,
+		StartedAt: timeNow(),
+	}
+
+	messages := []string{}
+	failures := 0
+
+	createdPeriods, err := u.createUpcomingPeriods(ctx)
+	run.CreatedPeriods = len(createdPeriods)
+	if err != nil {
+		failures++
+		messages = append(messages, fmt.Sprintf("create periods: %v", err))
+	}
+	for _, payrollPeriod := range createdPeriods {
+		messages = append(messages, fmt.Sprintf("created period %d (%s - %s)",
+			payrollPeriod.ID, payrollPeriod.StartDate.Format(time.DateOnly), payrollPeriod.EndDate.Format(time.DateOnly)))
+	}
+
+	if u.Cfg.AutoGenerate {
+		queued, queueErrors := u.queueDuePayrolls(ctx)
+		run.GeneratedPeriods = len(queued)
+		for _, job := range queued {
+			messages = append(messages, fmt.Sprintf("payroll job %d %s for period %d", job.ID, job.Status, job.PayrollPeriodID))
+		}
+		for _, queueErr := range queueErrors {
+			failures++
+			messages = append(messages, queueErr.Error())
+		}
+	}
+
+	switch {
+	case failures == 0:
+		run.Status = constant.SchedulerRunStatusSuccess
+	case run.CreatedPeriods > 0 || run.GeneratedPeriods > 0:
+		run.Status = constant.SchedulerRunStatusPartial
+	default:
+		run.Status = constant.SchedulerRunStatusFailed
+	}
+	if len(messages) == 0 {
+		messages = append(messages, "nothing to do")
+	}
+	run.Message = strings.Join(messages, "; ")
+	run.FinishedAt = timeNow()
+
+	err = u.AttendanceDB.CreateSchedulerRun(ctx, &run)
+	if err != nil {
+		return run, errors.Wrap(err, "Usecase.RunPayrollSchedule")
+	}
+	return run, nil
+}
+
+func (u *Usecase) createUpcomingPeriods(ctx context.Context) (created []model.MstPayrollPeriod, err error) {
+	payrollPeriods, err := u.AttendanceDB.ListPayrollPeriodByParams(ctx, model.ListPayrollPeriodParams{})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(payrollPeriods, func(i, j int) bool {
+		return payrollPeriods[i].StartDate.Before(payrollPeriods[j].StartDate)
+	})
+
+	today := toLocalDate(timeNow())
+	upcoming := 0
+	var lastEnd time.Time
+	for _, payrollPeriod := range payrollPeriods {
+		if !toLocalDate(payrollPeriod.EndDate).Before(today) {
+			upcoming++
+		}
+		lastEnd = toLocalDate(payrollPeriod.EndDate)
+	}
+
+	var startDate time.Time
+	if lastEnd.IsZero() {
+		startDate = periodStartContaining(today, u.Cfg.CutoffDays)
+	} else {
+		startDate = lastEnd.AddDate(0, 0, 1)
+	}
+
+	// keep the schedule contiguous from the last period, then top up until
+	// today and the periods ahead are covered
+	for i := 0; i < maxPeriodsPerRun && (startDate.Before(today) || upcoming <= u.Cfg.PeriodsAhead); i++ {
+		payrollPeriod := model.MstPayrollPeriod{
+			StartDate: startDate,
+			EndDate:   nextCutoff(startDate, u.Cfg.CutoffDays),
+		}
+
+		err = u.AttendanceDB.CreatePayrollPeriod(ctx, &payrollPeriod)
+		if err != nil {
+			return created, err
+		}
+		created = append(created, payrollPeriod)
+
+		if !payrollPeriod.EndDate.Before(today) {
+			upcoming++
+		}
+		startDate = payrollPeriod.EndDate.AddDate(0, 0, 1)
+	}
+	return created, nil
+}
+
+// queueDuePayrolls queues a payroll job for every open period past its grace
+// days. Periods that already have an unfinished job get that job back.
+func (u *Usecase) queueDuePayrolls(ctx context.Context) (queued []model.PayrollJobResponse, errs []error) {
+	payrollPeriods, err := u.AttendanceDB.ListPayrollPeriodByParams(ctx, model.ListPayrollPeriodParams{
+		Status: constant.PayrollPeriodStatusOpen,
+	})
+	if err != nil {
+		return nil, []error{fmt.Errorf("list open periods: %w", err)}
+	}
+
+	today := toLocalDate(timeNow())
+	tenantID, _ := tenant.FromContext(ctx)
+	// the scheduler queues as a system actor with no user, so no user of one
+	// tenant is recorded as acting in another
+	ctx = auth.SetUserDetailToCtx(ctx, auth.UserJWTPayload{
+		Username: constant.SystemActorScheduler,
+		Role:     constant.UserRoleAdmin,
+		TenantID: tenantID,
+	})
+
+	for _, payrollPeriod := range payrollPeriods {
+		endDate := toLocalDate(payrollPeriod.EndDate)
+		if !today.After(endDate) || today.Before(endDate.AddDate(0, 0, u.Cfg.GenerateAfterDays)) {
+			continue
+		}
+
+		job, err := u.AttendanceUsecase.EnqueuePayrollGeneration(ctx, model.GeneratePayrollRequest{
+			IDMstPayrollPeriod: int(payrollPeriod.ID),
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("queue payroll for period %d: %w", payrollPeriod.ID, err))
+			continue
+		}
+		queued = append(queued, job)
+	}
+	return queued, errs
+}
+
+// nextCutoff returns the first cut-off date on or after date
+func nextCutoff(date time.Time, cutoffDays []int) time.Time {
+	days := append([]int{}, cutoffDays...)
+	sort.Ints(days)
+
+	year, month := date.Year(), date.Month()
+	for {
+		for _, day := range days {
+			cutoff := cutoffDate(year, month, day)
+			if !cutoff.Before(date) {
+				return cutoff
+			}
+		}
+		year, month = nextMonth(year, month)
+	}
+}
+
+// periodStartContaining returns the first day of the period that date falls into
+func periodStartContaining(date time.Time, cutoffDays []int) time.Time {
+	days := append([]int{}, cutoffDays...)
+	sort.Sort(sort.Reverse(sort.IntSlice(days)))
+
+	year, month := date.Year(), date.Month()
+	for {
+		for _, day := range days {
+			cutoff := cutoffDate(year, month, day)
+			if cutoff.Before(date) {
+				return cutoff.AddDate(0, 0, 1)
+			}
+		}
+		year, month = previousMonth(year, month)
+	}
+}
+
+// cutoffDate clamps day to the last day of the month
+func cutoffDate(year int, month time.Month, day int) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.Local).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+func nextMonth(year int, month time.Month) (int, time.Month) {
+	if month == time.December {
+		return year + 1, time.January
+	}
+	return year, month + 1
+}
+
+func previousMonth(year int, month time.Month) (int, time.Month) {
+	if month == time.January {
+		return year - 1, time.December
+	}
+	return year, month - 1
+}
+
+func toLocalDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
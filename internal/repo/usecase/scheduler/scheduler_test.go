@@ -0,0 +1,490 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/config"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	mockusecase "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/_mocks"
+	mockattendancedb "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/_mocks/attendance"
+	mocktenantdb "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/_mocks/tenant"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	mockAttendanceRepo *mockattendancedb.MockAttendanceRepository
+	mockAttendanceUC   *mockusecase.MockAttendanceUsecaseRepository
+	mockTenantRepo     *mocktenantdb.MockTenantRepository
+
+	errFoo = errors.New("errFoo")
+)
+
+func initMock(t *testing.T) *gomock.Controller {
+	ctrl := gomock.NewController(t)
+	mockAttendanceRepo = mockattendancedb.NewMockAttendanceRepository(ctrl)
+	mockAttendanceUC = mockusecase.NewMockAttendanceUsecaseRepository(ctrl)
+	mockTenantRepo = mocktenantdb.NewMockTenantRepository(ctrl)
+
+	return ctrl
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+func Test_validateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.Scheduler
+		wantErr bool
+	}{
+		{
+			name: "monthly",
+			cfg:  config.Scheduler{Frequency: constant.ScheduleFrequencyMonthly, CutoffDays: []int{20}},
+		},
+		{
+			name: "semi monthly",
+			cfg:  config.Scheduler{Frequency: constant.ScheduleFrequencySemiMonthly, CutoffDays: []int{15, 31}},
+		},
+		{
+			name:    "monthly with two cut-off days",
+			cfg:     config.Scheduler{Frequency: constant.ScheduleFrequencyMonthly, CutoffDays: []int{15, 31}},
+			wantErr: true,
+		},
+		{
+			name:    "semi monthly with one cut-off day",
+			cfg:     config.Scheduler{Frequency: constant.ScheduleFrequencySemiMonthly, CutoffDays: []int{15}},
+			wantErr: true,
+		},
+		{
+			name:    "cut-off day out of range",
+			cfg:     config.Scheduler{Frequency: constant.ScheduleFrequencyMonthly, CutoffDays: []int{32}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown frequency",
+			cfg:     config.Scheduler{Frequency: "weekly", CutoffDays: []int{1}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfig(tt.cfg)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func Test_nextCutoff(t *testing.T) {
+	tests := []struct {
+		name       string
+		date       time.Time
+		cutoffDays []int
+		want       time.Time
+	}{
+		{
+			name:       "cut-off later this month",
+			date:       date(2025, 7, 1),
+			cutoffDays: []int{20},
+			want:       date(2025, 7, 20),
+		},
+		{
+			name:       "date is the cut-off",
+			date:       date(2025, 7, 20),
+			cutoffDays: []int{20},
+			want:       date(2025, 7, 20),
+		},
+		{
+			name:       "cut-off next month",
+			date:       date(2025, 7, 21),
+			cutoffDays: []int{20},
+			want:       date(2025, 8, 20),
+		},
+		{
+			name:       "cut-off clamped to end of february",
+			date:       date(2025, 2, 1),
+			cutoffDays: []int{31},
+			want:       date(2025, 2, 28),
+		},
+		{
+			name:       "semi monthly second half",
+			date:       date(2025, 7, 16),
+			cutoffDays: []int{31, 15},
+			want:       date(2025, 7, 31),
+		},
+		{
+			name:       "cut-off next year",
+			date:       date(2025, 12, 21),
+			cutoffDays: []int{20},
+			want:       date(2026, 1, 20),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nextCutoff(tt.date, tt.cutoffDays))
+		})
+	}
+}
+
+func Test_periodStartContaining(t *testing.T) {
+	tests := []struct {
+		name       string
+		date       time.Time
+		cutoffDays []int
+		want       time.Time
+	}{
+		{
+			name:       "after the cut-off",
+			date:       date(2025, 7, 25),
+			cutoffDays: []int{20},
+			want:       date(2025, 7, 21),
+		},
+		{
+			name:       "on the cut-off",
+			date:       date(2025, 7, 20),
+			cutoffDays: []int{20},
+			want:       date(2025, 6, 21),
+		},
+		{
+			name:       "month end cut-off",
+			date:       date(2025, 3, 10),
+			cutoffDays: []int{31},
+			want:       date(2025, 3, 1),
+		},
+		{
+			name:       "semi monthly first half",
+			date:       date(2025, 1, 10),
+			cutoffDays: []int{15, 31},
+			want:       date(2025, 1, 1),
+		},
+		{
+			name:       "semi monthly second half",
+			date:       date(2025, 1, 20),
+			cutoffDays: []int{15, 31},
+			want:       date(2025, 1, 16),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, periodStartContaining(tt.date, tt.cutoffDays))
+		})
+	}
+}
+
+func Test_RunPayrollSchedule(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	mockNow := time.Date(2025, 7, 25, 9, 0, 0, 0, time.Local)
+	monthlyCfg := config.Scheduler{
+		Frequency:    constant.ScheduleFrequencyMonthly,
+		CutoffDays:   []int{20},
+		PeriodsAhead: 1,
+	}
+
+	tests := []struct {
+		name    string
+		cfg     config.Scheduler
+		want    model.TrxSchedulerRun
+		wantErr bool
+		patch   func()
+		unpatch func()
+	}{
+		{
+			name: "creates current and next period on an empty schedule",
+			cfg:  monthlyCfg,
+			want: model.TrxSchedulerRun{
+				JobName:        constant.SchedulerJobPayrollPeriod,
+				Status:         constant.SchedulerRunStatusSuccess,
+				Message:        "created period 1 (2025-07-21 - 2025-08-20); created period 2 (2025-08-21 - 2025-09-20)",
+				CreatedPeriods: 2,
+				StartedAt:      mockNow,
+				FinishedAt:     mockNow,
+			},
+			patch: func() {
+				timeNow = func() time.Time { return mockNow }
+				mockAttendanceRepo.EXPECT().ListPayrollPeriodByParams(gomock.Any(), model.ListPayrollPeriodParams{}).Return(nil, nil)
+
+				id := int64(0)
+				createPeriod := func(ctx context.Context, payrollPeriod *model.MstPayrollPeriod) error {
+					id++
+					payrollPeriod.ID = id
+					return nil
+				}
+				mockAttendanceRepo.EXPECT().CreatePayrollPeriod(gomock.Any(), &model.MstPayrollPeriod{
+					StartDate: date(2025, 7, 21),
+					EndDate:   date(2025, 8, 20),
+				}).DoAndReturn(createPeriod)
+				mockAttendanceRepo.EXPECT().CreatePayrollPeriod(gomock.Any(), &model.MstPayrollPeriod{
+					StartDate: date(2025, 8, 21),
+					EndDate:   date(2025, 9, 20),
+				}).DoAndReturn(createPeriod)
+				mockAttendanceRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			unpatch: func() {
+				timeNow = time.Now
+			},
+		},
+		{
+			name: "schedule already covered",
+			cfg:  monthlyCfg,
+			want: model.TrxSchedulerRun{
+				JobName:    constant.SchedulerJobPayrollPeriod,
+				Status:     constant.SchedulerRunStatusSuccess,
+				Message:    "nothing to do",
+				StartedAt:  mockNow,
+				FinishedAt: mockNow,
+			},
+			patch: func() {
+				timeNow = func() time.Time { return mockNow }
+				mockAttendanceRepo.EXPECT().ListPayrollPeriodByParams(gomock.Any(), model.ListPayrollPeriodParams{}).Return([]model.MstPayrollPeriod{
+					{ID: 2, StartDate: date(2025, 7, 21), EndDate: date(2025, 8, 20)},
+					{ID: 3, StartDate: date(2025, 8, 21), EndDate: date(2025, 9, 20)},
+				}, nil)
+				mockAttendanceRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			unpatch: func() {
+				timeNow = time.Now
+			},
+		},
+		{
+			name: "catches up contiguously after the last period",
+			cfg: config.Scheduler{
+				Frequency:  constant.ScheduleFrequencyMonthly,
+				CutoffDays: []int{20},
+			},
+			want: model.TrxSchedulerRun{
+				JobName:        constant.SchedulerJobPayrollPeriod,
+				Status:         constant.SchedulerRunStatusSuccess,
+				Message:        "created period 5 (2025-07-16 - 2025-07-20); created period 6 (2025-07-21 - 2025-08-20)",
+				CreatedPeriods: 2,
+				StartedAt:      mockNow,
+				FinishedAt:     mockNow,
+			},
+			patch: func() {
+				timeNow = func() time.Time { return mockNow }
+				mockAttendanceRepo.EXPECT().ListPayrollPeriodByParams(gomock.Any(), model.ListPayrollPeriodParams{}).Return([]model.MstPayrollPeriod{
+					{ID: 4, StartDate: date(2025, 7, 1), EndDate: date(2025, 7, 15)},
+				}, nil)
+				id := int64(4)
+				createPeriod := func(ctx context.Context, payrollPeriod *model.MstPayrollPeriod) error {
+					id++
+					payrollPeriod.ID = id
+					return nil
+				}
+				mockAttendanceRepo.EXPECT().CreatePayrollPeriod(gomock.Any(), &model.MstPayrollPeriod{
+					StartDate: date(2025, 7, 16),
+					EndDate:   date(2025, 7, 20),
+				}).DoAndReturn(createPeriod)
+				mockAttendanceRepo.EXPECT().CreatePayrollPeriod(gomock.Any(), &model.MstPayrollPeriod{
+					StartDate: date(2025, 7, 21),
+					EndDate:   date(2025, 8, 20),
+				}).DoAndReturn(createPeriod)
+				mockAttendanceRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			unpatch: func() {
+				timeNow = time.Now
+			},
+		},
+		{
+			name: "queues payroll for periods past the grace days",
+			cfg: config.Scheduler{
+				Frequency:         constant.ScheduleFrequencyMonthly,
+				CutoffDays:        []int{20},
+				AutoGenerate:      true,
+				GenerateAfterDays: 3,
+			},
+			want: model.TrxSchedulerRun{
+				JobName:          constant.SchedulerJobPayrollPeriod,
+				Status:           constant.SchedulerRunStatusSuccess,
+				Message:          "payroll job 7 queued for period 1",
+				GeneratedPeriods: 1,
+				StartedAt:        mockNow,
+				FinishedAt:       mockNow,
+			},
+			patch: func() {
+				timeNow = func() time.Time { return mockNow }
+				mockAttendanceRepo.EXPECT().ListPayrollPeriodByParams(gomock.Any(), model.ListPayrollPeriodParams{}).Return([]model.MstPayrollPeriod{
+					{ID: 2, StartDate: date(2025, 7, 21), EndDate: date(2025, 8, 20)},
+				}, nil)
+				mockAttendanceRepo.EXPECT().ListPayrollPeriodByParams(gomock.Any(), model.ListPayrollPeriodParams{
+					Status: constant.PayrollPeriodStatusOpen,
+				}).Return([]model.MstPayrollPeriod{
+					{ID: 1, StartDate: date(2025, 6, 21), EndDate: date(2025, 7, 20)},
+					{ID: 2, StartDate: date(2025, 7, 21), EndDate: date(2025, 8, 20)},
+				}, nil)
+				mockAttendanceUC.EXPECT().EnqueuePayrollGeneration(gomock.Any(), model.GeneratePayrollRequest{IDMstPayrollPeriod: 1}).
+					DoAndReturn(func(ctx context.Context, request model.GeneratePayrollRequest) (model.PayrollJobResponse, error) {
+						user, found := auth.GetUserDetailFromCtx(ctx)
+						assert.True(t, found)
+						assert.Equal(t, constant.UserRoleAdmin, user.Role)
+						assert.Equal(t, int64(2), user.TenantID)
+						// a system actor, not a user of some tenant
+						assert.Equal(t, int64(0), user.ID)
+						assert.Equal(t, constant.SystemActorScheduler, user.Username)
+						return model.PayrollJobResponse{ID: 7, PayrollPeriodID: 1, Status: constant.PayrollJobStatusQueued}, nil
+					})
+				mockAttendanceRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			unpatch: func() {
+				timeNow = time.Now
+			},
+		},
+		{
+			name: "failed to queue payroll",
+			cfg: config.Scheduler{
+				Frequency:    constant.ScheduleFrequencyMonthly,
+				CutoffDays:   []int{20},
+				AutoGenerate: true,
+			},
+			want: model.TrxSchedulerRun{
+				JobName:    constant.SchedulerJobPayrollPeriod,
+				Status:     constant.SchedulerRunStatusFailed,
+				Message:    "queue payroll for period 1: errFoo",
+				StartedAt:  mockNow,
+				FinishedAt: mockNow,
+			},
+			patch: func() {
+				timeNow = func() time.Time { return mockNow }
+				mockAttendanceRepo.EXPECT().ListPayrollPeriodByParams(gomock.Any(), model.ListPayrollPeriodParams{}).Return([]model.MstPayrollPeriod{
+					{ID: 2, StartDate: date(2025, 7, 21), EndDate: date(2025, 8, 20)},
+				}, nil)
+				mockAttendanceRepo.EXPECT().ListPayrollPeriodByParams(gomock.Any(), model.ListPayrollPeriodParams{
+					Status: constant.PayrollPeriodStatusOpen,
+				}).Return([]model.MstPayrollPeriod{
+					{ID: 1, StartDate: date(2025, 6, 21), EndDate: date(2025, 7, 20)},
+				}, nil)
+				mockAttendanceUC.EXPECT().EnqueuePayrollGeneration(gomock.Any(), gomock.Any()).Return(model.PayrollJobResponse{}, errFoo)
+				mockAttendanceRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			unpatch: func() {
+				timeNow = time.Now
+			},
+		},
+		{
+			name: "failed to list payroll periods",
+			cfg:  monthlyCfg,
+			want: model.TrxSchedulerRun{
+				JobName:    constant.SchedulerJobPayrollPeriod,
+				Status:     constant.SchedulerRunStatusFailed,
+				Message:    "create periods: errFoo",
+				StartedAt:  mockNow,
+				FinishedAt: mockNow,
+			},
+			patch: func() {
+				timeNow = func() time.Time { return mockNow }
+				mockAttendanceRepo.EXPECT().ListPayrollPeriodByParams(gomock.Any(), model.ListPayrollPeriodParams{}).Return(nil, errFoo)
+				mockAttendanceRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			unpatch: func() {
+				timeNow = time.Now
+			},
+		},
+		{
+			name: "failed to record the run",
+			cfg:  monthlyCfg,
+			want: model.TrxSchedulerRun{
+				JobName:    constant.SchedulerJobPayrollPeriod,
+				Status:     constant.SchedulerRunStatusSuccess,
+				Message:    "nothing to do",
+				StartedAt:  mockNow,
+				FinishedAt: mockNow,
+			},
+			wantErr: true,
+			patch: func() {
+				timeNow = func() time.Time { return mockNow }
+				mockAttendanceRepo.EXPECT().ListPayrollPeriodByParams(gomock.Any(), model.ListPayrollPeriodParams{}).Return([]model.MstPayrollPeriod{
+					{ID: 2, StartDate: date(2025, 7, 21), EndDate: date(2025, 8, 20)},
+					{ID: 3, StartDate: date(2025, 8, 21), EndDate: date(2025, 9, 20)},
+				}, nil)
+				mockAttendanceRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.Any()).Return(errFoo)
+			},
+			unpatch: func() {
+				timeNow = time.Now
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.patch()
+			defer tt.unpatch()
+
+			u := &Usecase{
+				Cfg:               tt.cfg,
+				AttendanceDB:      mockAttendanceRepo,
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			got, err := u.RunPayrollSchedule(tenant.NewContext(context.Background(), 2))
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_runTenantSchedules(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	mockNow := time.Date(2025, 7, 25, 9, 0, 0, 0, time.Local)
+	timeNow = func() time.Time { return mockNow }
+	defer func() { timeNow = time.Now }()
+
+	tests := []struct {
+		name        string
+		patch       func()
+		wantTenants []int64
+	}{
+		{
+			name: "runs the schedule of every tenant",
+			patch: func() {
+				mockTenantRepo.EXPECT().ListTenants(gomock.Any()).Return([]model.MstTenant{
+					{ID: 1, Code: "default"},
+					{ID: 2, Code: "retail"},
+				}, nil)
+				mockAttendanceRepo.EXPECT().ListPayrollPeriodByParams(gomock.Any(), model.ListPayrollPeriodParams{}).
+					Return(nil, errFoo).Times(2)
+			},
+			wantTenants: []int64{1, 2},
+		},
+		{
+			name: "failed to list tenants",
+			patch: func() {
+				mockTenantRepo.EXPECT().ListTenants(gomock.Any()).Return(nil, errFoo)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.patch()
+
+			var gotTenants []int64
+			if len(tt.wantTenants) > 0 {
+				mockAttendanceRepo.EXPECT().CreateSchedulerRun(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, run *model.TrxSchedulerRun) error {
+						tenantID, _ := tenant.FromContext(ctx)
+						gotTenants = append(gotTenants, tenantID)
+						return nil
+					}).Times(len(tt.wantTenants))
+			}
+
+			u := &Usecase{
+				Cfg: config.Scheduler{
+					Frequency:  constant.ScheduleFrequencyMonthly,
+					CutoffDays: []int{20},
+				},
+				AttendanceDB:      mockAttendanceRepo,
+				TenantDB:          mockTenantRepo,
+				AttendanceUsecase: mockAttendanceUC,
+			}
+			u.runTenantSchedules(context.Background())
+			assert.Equal(t, tt.wantTenants, gotTenants)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package tenant
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	tenantrepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/tenant"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+)
+
+var (
+	authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+)
+
+type Usecase struct {
+	TenantDB tenantrepo.TenantRepository
+}
+
+func New(opt *Usecase) *Usecase {
+	return opt
+}
+
+// GetTenant returns the company of the signed in user with its payroll
+// policy.
+func (u *Usecase) GetTenant(ctx context.Context) (resp model.MstTenant, err error) {
+	_, found := authGetUserDetailFromCtx(ctx)
+	if !found {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.GetTenant")
+		return
+	}
+
+	resp, err = u.TenantDB.GetTenant(ctx)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.GetTenant")
+		return
+	}
+	if resp.ID == 0 {
+		err = commonerr.SetNewError(http.StatusNotFound, "not found", "tenant not found")
+		return
+	}
+	return
+}
+
+// UpdatePayrollPolicy sets how overtime is capped and paid in the company of
+// the signed in admin. Payrolls already generated are not recalculated.
+// Admin only.
+func (u *Usecase) UpdatePayrollPolicy(ctx context.Context, request model.PayrollPolicyRequest) (resp model.MstTenant, err error) {
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.UpdatePayrollPolicy")
+		return
+	}
+	ctx = xormlib.WithPrimary(ctx)
+
+	resp, err = u.TenantDB.UpdatePayrollPolicy(ctx, request, user.ID)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.UpdatePayrollPolicy")
+		return
+	}
+	if resp.ID == 0 {
+		err = commonerr.SetNewError(http.StatusNotFound, "not found", "tenant not found")
+		return
+	}
+	resp.OvertimeMultiplier = request.OvertimeMultiplier
+	resp.MaxOvertimeHours = request.MaxOvertimeHours
+	return
+}
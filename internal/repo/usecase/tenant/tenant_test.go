@@ -0,0 +1,165 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	mocktenantdb "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/_mocks/tenant"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	mockTenantRepo *mocktenantdb.MockTenantRepository
+
+	errFoo = errors.New("errFoo")
+)
+
+func initMock(t *testing.T) *gomock.Controller {
+	ctrl := gomock.NewController(t)
+	mockTenantRepo = mocktenantdb.NewMockTenantRepository(ctrl)
+
+	return ctrl
+}
+
+func mockAdminUser(ctx context.Context) (auth.UserJWTPayload, bool) {
+	return auth.UserJWTPayload{ID: 1, Username: "admin", Role: constant.UserRoleAdmin, TenantID: 2}, true
+}
+
+func mockEmployeeUser(ctx context.Context) (auth.UserJWTPayload, bool) {
+	return auth.UserJWTPayload{ID: 2, Username: "employee", Role: constant.UserRoleEmployee, TenantID: 2}, true
+}
+
+func mockNoUser(ctx context.Context) (auth.UserJWTPayload, bool) {
+	return auth.UserJWTPayload{}, false
+}
+
+func Test_GetTenant(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name    string
+		patch   func()
+		wantErr bool
+		want    model.MstTenant
+	}{
+		{
+			name: "success for an employee",
+			patch: func() {
+				authGetUserDetailFromCtx = mockEmployeeUser
+				mockTenantRepo.EXPECT().GetTenant(gomock.Any()).
+					Return(model.MstTenant{ID: 2, Code: "retail", OvertimeMultiplier: 2, MaxOvertimeHours: 3}, nil).Times(1)
+			},
+			want: model.MstTenant{ID: 2, Code: "retail", OvertimeMultiplier: 2, MaxOvertimeHours: 3},
+		},
+		{
+			name: "error without user",
+			patch: func() {
+				authGetUserDetailFromCtx = mockNoUser
+			},
+			wantErr: true,
+		},
+		{
+			name: "error tenant not found",
+			patch: func() {
+				authGetUserDetailFromCtx = mockEmployeeUser
+				mockTenantRepo.EXPECT().GetTenant(gomock.Any()).Return(model.MstTenant{}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error during get tenant",
+			patch: func() {
+				authGetUserDetailFromCtx = mockEmployeeUser
+				mockTenantRepo.EXPECT().GetTenant(gomock.Any()).Return(model.MstTenant{}, errFoo).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u := New(&Usecase{
+				TenantDB: mockTenantRepo,
+			})
+			tc.patch()
+			defer func() { authGetUserDetailFromCtx = auth.GetUserDetailFromCtx }()
+
+			got, err := u.GetTenant(context.Background())
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_UpdatePayrollPolicy(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	request := model.PayrollPolicyRequest{OvertimeMultiplier: 3, MaxOvertimeHours: 4}
+
+	testCases := []struct {
+		name    string
+		patch   func()
+		wantErr bool
+		want    model.MstTenant
+	}{
+		{
+			name: "success",
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockTenantRepo.EXPECT().UpdatePayrollPolicy(gomock.Any(), request, int64(1)).
+					Return(model.MstTenant{ID: 2, Code: "retail", OvertimeMultiplier: 2, MaxOvertimeHours: 3}, nil).Times(1)
+			},
+			want: model.MstTenant{ID: 2, Code: "retail", OvertimeMultiplier: 3, MaxOvertimeHours: 4},
+		},
+		{
+			name: "error not admin",
+			patch: func() {
+				authGetUserDetailFromCtx = mockEmployeeUser
+			},
+			wantErr: true,
+		},
+		{
+			name: "error tenant not found",
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockTenantRepo.EXPECT().UpdatePayrollPolicy(gomock.Any(), request, int64(1)).
+					Return(model.MstTenant{}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error during update",
+			patch: func() {
+				authGetUserDetailFromCtx = mockAdminUser
+				mockTenantRepo.EXPECT().UpdatePayrollPolicy(gomock.Any(), request, int64(1)).
+					Return(model.MstTenant{}, errFoo).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u := New(&Usecase{
+				TenantDB: mockTenantRepo,
+			})
+			tc.patch()
+			defer func() { authGetUserDetailFromCtx = auth.GetUserDetailFromCtx }()
+
+			got, err := u.UpdatePayrollPolicy(context.Background(), request)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
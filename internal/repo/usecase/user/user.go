@@ -2,17 +2,40 @@ package user
 
 import (
 	"context"
+	"database/sql"
+	"net/http"
 	"time"
 
 	"github.com/faisalhardin/employee-payroll-system/internal/config"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	liblog "github.com/faisalhardin/employee-payroll-system/pkg/common/log"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/log/logger"
 	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/requestinfo"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
 	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
 
 	authrepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/auth"
 	userdbrepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/user"
 )
 
+const (
+	defaultLockoutInMinutes = 15
+)
+
+var (
+	authGetUserDetailFromCtx = auth.GetUserDetailFromCtx
+	timeNow                  = time.Now
+
+	// unknownUserPasswordHash is compared against when the username does not
+	// exist, so an unknown user costs as much as a known one
+	unknownUserPasswordHash = []byte("$2a$10$Fc2BS1oCmje.vNzD2oAoXeJUFFrlPZVR9XYwbV92kFYLijGswMVaW")
+)
+
 type Usecase struct {
 	Cfg      *config.Config
 	UserDB   userdbrepo.UserRepository
@@ -23,27 +46,201 @@ func New(opt *Usecase) *Usecase {
 	return opt
 }
 
+// SignIn issues a token for valid credentials and records every attempt. The
+// failed attempt reaching Login.MaxFailedAttempts locks the account until the
+// lock expires. Unknown users, wrong passwords and locked accounts all get the
+// same answer so usernames cannot be probed.
 func (u *Usecase) SignIn(ctx context.Context, params model.SignInRequest) (jwt string, err error) {
-	resp, err := u.UserDB.GetUser(ctx, params)
+	// the lock state must be current, a lagging replica would let attempts through
+	ctx = xormlib.WithPrimary(ctx)
+	resp, err := u.UserDB.GetUserByUsername(ctx, params.Username)
 	if err != nil {
 		err = errors.Wrap(err, "Usecase.SignIn")
 		return
 	}
+
+	info := requestinfo.FromContext(ctx)
+	attempt := model.TrxLoginAttempt{
+		Username:  params.Username,
+		IDMstUser: sql.NullInt64{Int64: resp.ID, Valid: resp.ID > 0},
+		IPAddress: info.IPAddress,
+		RequestID: info.RequestID,
+	}
 	if resp.ID == 0 {
-		err = errors.New("user not found")
+		_ = bcrypt.CompareHashAndPassword(unknownUserPasswordHash, []byte(params.Password))
+		u.recordLoginAttempt(ctx, attempt, constant.LoginFailureUnknownUser)
+		err = invalidCredentialsError()
 		return
 	}
 
-	currTime := time.Now()
+	// the rest of the sign in works on the tenant of the user
+	ctx = tenant.NewContext(ctx, resp.IDMstTenant)
+
+	currTime := timeNow()
+	passwordErr := bcrypt.CompareHashAndPassword([]byte(resp.PasswordHash), []byte(params.Password))
+	if resp.LockedUntil.Valid && resp.LockedUntil.Time.After(currTime) {
+		u.recordLoginAttempt(ctx, attempt, constant.LoginFailureLocked)
+		err = invalidCredentialsError()
+		return
+	}
+
+	if passwordErr != nil {
+		u.recordLoginAttempt(ctx, attempt, constant.LoginFailureInvalidPassword)
+		err = u.registerFailedLogin(ctx, resp.ID, currTime)
+		if err != nil {
+			return
+		}
+		err = invalidCredentialsError()
+		return
+	}
+
+	if resp.FailedLoginAttempts > 0 || resp.LockedUntil.Valid {
+		err = u.UserDB.ResetFailedLogins(ctx, resp.ID)
+		if err != nil {
+			err = errors.Wrap(err, "Usecase.SignIn")
+			return
+		}
+	}
+	u.recordLoginAttempt(ctx, attempt, "")
+
 	expireDuration := time.Duration(u.Cfg.JWTConfig.DurationInHours) * time.Hour
 	expiredTime := currTime.Add(expireDuration)
 	token, err := u.AuthRepo.CreateJWTToken(ctx, auth.UserJWTPayload{
 		ID:       resp.ID,
 		Username: resp.Username,
 		Role:     resp.Role,
+		TenantID: resp.IDMstTenant,
 	}, currTime, expiredTime)
 	if err != nil {
 		return
 	}
 	return token, nil
 }
+
+// registerFailedLogin counts the failure, the attempt reaching the limit locks
+// the account
+func (u *Usecase) registerFailedLogin(ctx context.Context, userID int64, currTime time.Time) error {
+	maxAttempts := u.Cfg.Login.MaxFailedAttempts
+	if maxAttempts <= 0 {
+		return nil
+	}
+	lockoutInMinutes := u.Cfg.Login.LockoutInMinutes
+	if lockoutInMinutes <= 0 {
+		lockoutInMinutes = defaultLockoutInMinutes
+	}
+
+	_, err := u.UserDB.RegisterFailedLogin(ctx, userID, maxAttempts, currTime.Add(time.Duration(lockoutInMinutes)*time.Minute))
+	if err != nil {
+		return errors.Wrap(err, "Usecase.SignIn")
+	}
+	return nil
+}
+
+// recordLoginAttempt keeps the attempt, an empty failure reason marks a
+// success. Failing to record does not fail the sign in.
+func (u *Usecase) recordLoginAttempt(ctx context.Context, attempt model.TrxLoginAttempt, failureReason string) {
+	attempt.Success = failureReason == ""
+	attempt.FailureReason = failureReason
+	err := u.UserDB.RecordLoginAttempt(ctx, &attempt)
+	if err != nil {
+		liblog.WarnCtx(ctx, "failed to record login attempt", logger.KV{"error": err.Error()})
+	}
+}
+
+func invalidCredentialsError() error {
+	return commonerr.SetNewUnauthorizedError("invalid_credentials", "invalid username or password")
+}
+
+// UnlockUser lifts the lock of an account before it expires. Admin only.
+func (u *Usecase) UnlockUser(ctx context.Context, userID int64) (resp model.UnlockUserResponse, err error) {
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.UnlockUser")
+		return
+	}
+
+	unlocked, err := u.UserDB.UnlockUser(ctx, userID)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.UnlockUser")
+		return
+	}
+	if unlocked.ID == 0 {
+		err = commonerr.SetNewError(http.StatusNotFound, "not found", "user not found")
+		return
+	}
+
+	return model.UnlockUserResponse{
+		ID:       unlocked.ID,
+		Username: unlocked.Username,
+	}, nil
+}
+
+// ResetUserDevice unbinds the tap in device of an employee who changed
+// devices, the next tap in binds the new one. Admin only.
+func (u *Usecase) ResetUserDevice(ctx context.Context, userID int64) (resp model.ResetUserDeviceResponse, err error) {
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.ResetUserDevice")
+		return
+	}
+
+	reset, err := u.UserDB.ResetDevice(ctx, userID)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.ResetUserDevice")
+		return
+	}
+	if reset.ID == 0 {
+		err = commonerr.SetNewError(http.StatusNotFound, "not found", "user not found")
+		return
+	}
+
+	return model.ResetUserDeviceResponse{
+		ID:       reset.ID,
+		Username: reset.Username,
+	}, nil
+}
+
+// SetManager names the manager who reviews the attendance corrections of an
+// employee, a zero manager removes it. Admin only.
+func (u *Usecase) SetManager(ctx context.Context, userID int64, request model.SetManagerRequest) (resp model.SetManagerResponse, err error) {
+	user, found := authGetUserDetailFromCtx(ctx)
+	if !found || user.Role != constant.UserRoleAdmin {
+		err = errors.Wrap(commonerr.SetNewUnauthorizedAPICall(), "Usecase.SetManager")
+		return
+	}
+
+	managerID := sql.NullInt64{}
+	if request.ManagerID != 0 {
+		if request.ManagerID == userID {
+			err = commonerr.SetNewBadRequest("invalid", "an employee cannot manage themselves")
+			return
+		}
+
+		manager, errGet := u.UserDB.GetUserByID(ctx, request.ManagerID)
+		if errGet != nil {
+			err = errors.Wrap(errGet, "Usecase.SetManager")
+			return
+		}
+		if manager.ID == 0 {
+			err = commonerr.SetNewBadRequest("invalid", "manager not found")
+			return
+		}
+		managerID = sql.NullInt64{Int64: manager.ID, Valid: true}
+	}
+
+	updated, err := u.UserDB.SetManager(ctx, userID, managerID)
+	if err != nil {
+		err = errors.Wrap(err, "Usecase.SetManager")
+		return
+	}
+	if updated.ID == 0 {
+		err = commonerr.SetNewError(http.StatusNotFound, "not found", "user not found")
+		return
+	}
+
+	return model.SetManagerResponse{
+		ID:        updated.ID,
+		Username:  updated.Username,
+		ManagerID: updated.IDMstManager.Int64,
+	}, nil
+}
@@ -2,16 +2,23 @@ package user
 
 import (
 	"context"
+	"database/sql"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/faisalhardin/employee-payroll-system/internal/config"
+	"github.com/faisalhardin/employee-payroll-system/internal/entity/constant"
 	"github.com/faisalhardin/employee-payroll-system/internal/entity/model"
 	mockrepo "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/_mocks"
 	mockuserdb "github.com/faisalhardin/employee-payroll-system/internal/entity/repo/db/_mocks/user"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
 	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/requestinfo"
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 )
 
 var (
@@ -32,35 +39,93 @@ func Test_SignIn(t *testing.T) {
 	ctrl := initMock(t)
 	defer ctrl.Finish()
 
+	now := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	ctx := requestinfo.NewContext(context.Background(), requestinfo.Info{RequestID: "req-1", IPAddress: "10.0.0.1"})
+	params := model.SignInRequest{
+		Username: "fooname",
+		Password: "foopass",
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte("foopass"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := model.MstUser{
+		ID:           1,
+		IDMstTenant:  2,
+		Username:     "fooname",
+		PasswordHash: string(passwordHash),
+	}
+	expectAttempt := func(success bool, failureReason string) {
+		attempt := &model.TrxLoginAttempt{
+			Username:      "fooname",
+			IDMstUser:     sql.NullInt64{Int64: 1, Valid: true},
+			Success:       success,
+			FailureReason: failureReason,
+			IPAddress:     "10.0.0.1",
+			RequestID:     "req-1",
+		}
+		mockUserDB.
+			EXPECT().RecordLoginAttempt(gomock.Any(), attempt).
+			Return(nil).
+			Times(1)
+	}
+
 	type args struct {
 		ctx    context.Context
 		params model.SignInRequest
 	}
 	testCases := []struct {
-		name    string
-		args    args
-		patch   func()
-		wantErr bool
-		want    string
+		name       string
+		args       args
+		patch      func()
+		wantErr    bool
+		wantStatus int
+		want       string
 	}{
 		{
 			name: "success",
 			args: args{
-				ctx: context.Background(),
-				params: model.SignInRequest{
-					Username: "fooname",
-					Password: "foopass",
-				},
+				ctx:    ctx,
+				params: params,
+			},
+			want: "token",
+			patch: func() {
+				mockUserDB.
+					EXPECT().GetUserByUsername(gomock.Any(), "fooname").
+					Return(user, nil).
+					Times(1)
+				expectAttempt(true, "")
+				mockAuthRepo.
+					EXPECT().
+					CreateJWTToken(gomock.Any(), auth.UserJWTPayload{ID: 1, Username: "fooname", TenantID: 2}, gomock.Any(), gomock.Any()).
+					Return("token", nil).
+					Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success clears failed attempts and an expired lock",
+			args: args{
+				ctx:    ctx,
+				params: params,
 			},
 			want: "token",
 			patch: func() {
+				lockedUser := user
+				lockedUser.FailedLoginAttempts = 2
+				lockedUser.LockedUntil = sql.NullTime{Time: now.Add(-time.Minute), Valid: true}
 				mockUserDB.
-					EXPECT().GetUser(gomock.Any(), gomock.Any()).
-					Return(model.MstUser{
-						ID:       1,
-						Username: "fooname",
-					}, nil).
+					EXPECT().GetUserByUsername(gomock.Any(), "fooname").
+					Return(lockedUser, nil).
 					Times(1)
+				mockUserDB.
+					EXPECT().ResetFailedLogins(gomock.Any(), int64(1)).
+					Return(nil).
+					Times(1)
+				expectAttempt(true, "")
 				mockAuthRepo.
 					EXPECT().
 					CreateJWTToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
@@ -72,21 +137,16 @@ func Test_SignIn(t *testing.T) {
 		{
 			name: "error during jwt creation",
 			args: args{
-				ctx: context.Background(),
-				params: model.SignInRequest{
-					Username: "fooname",
-					Password: "foopass",
-				},
+				ctx:    ctx,
+				params: params,
 			},
 			want: "",
 			patch: func() {
 				mockUserDB.
-					EXPECT().GetUser(gomock.Any(), gomock.Any()).
-					Return(model.MstUser{
-						ID:       1,
-						Username: "fooname",
-					}, nil).
+					EXPECT().GetUserByUsername(gomock.Any(), gomock.Any()).
+					Return(user, nil).
 					Times(1)
+				expectAttempt(true, "")
 				mockAuthRepo.
 					EXPECT().
 					CreateJWTToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
@@ -98,35 +158,130 @@ func Test_SignIn(t *testing.T) {
 		{
 			name: "error user not found",
 			args: args{
-				ctx: context.Background(),
+				ctx:    ctx,
+				params: params,
+			},
+			want: "",
+			patch: func() {
+				mockUserDB.
+					EXPECT().GetUserByUsername(gomock.Any(), gomock.Any()).
+					Return(model.MstUser{}, nil).
+					Times(1)
+				unknownAttempt := &model.TrxLoginAttempt{
+					Username:      "fooname",
+					FailureReason: constant.LoginFailureUnknownUser,
+					IPAddress:     "10.0.0.1",
+					RequestID:     "req-1",
+				}
+				mockUserDB.
+					EXPECT().RecordLoginAttempt(gomock.Any(), unknownAttempt).
+					Return(errFoo).
+					Times(1)
+			},
+			wantErr:    true,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "error during user retrieval",
+			args: args{
+				ctx:    ctx,
+				params: params,
+			},
+			want: "",
+			patch: func() {
+				mockUserDB.
+					EXPECT().GetUserByUsername(gomock.Any(), gomock.Any()).
+					Return(model.MstUser{}, errFoo).
+					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error account locked with the right password",
+			args: args{
+				ctx:    ctx,
+				params: params,
+			},
+			want: "",
+			patch: func() {
+				lockedUser := user
+				lockedUser.LockedUntil = sql.NullTime{Time: now.Add(10 * time.Minute), Valid: true}
+				mockUserDB.
+					EXPECT().GetUserByUsername(gomock.Any(), gomock.Any()).
+					Return(lockedUser, nil).
+					Times(1)
+				expectAttempt(false, constant.LoginFailureLocked)
+			},
+			wantErr:    true,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "error wrong password",
+			args: args{
+				ctx: ctx,
 				params: model.SignInRequest{
 					Username: "fooname",
-					Password: "foopass",
+					Password: "wrong",
 				},
 			},
 			want: "",
 			patch: func() {
 				mockUserDB.
-					EXPECT().GetUser(gomock.Any(), gomock.Any()).
-					Return(model.MstUser{}, nil).
+					EXPECT().GetUserByUsername(gomock.Any(), gomock.Any()).
+					Return(user, nil).
+					Times(1)
+				expectAttempt(false, constant.LoginFailureInvalidPassword)
+				mockUserDB.
+					EXPECT().RegisterFailedLogin(gomock.Any(), int64(1), 3, now.Add(10*time.Minute)).
+					Return(sql.NullTime{}, nil).
 					Times(1)
 			},
-			wantErr: true,
+			wantErr:    true,
+			wantStatus: http.StatusUnauthorized,
 		},
 		{
-			name: "error during user retrieval",
+			name: "error wrong password locks the account",
 			args: args{
-				ctx: context.Background(),
+				ctx: ctx,
 				params: model.SignInRequest{
 					Username: "fooname",
-					Password: "foopass",
+					Password: "wrong",
 				},
 			},
 			want: "",
 			patch: func() {
 				mockUserDB.
-					EXPECT().GetUser(gomock.Any(), gomock.Any()).
-					Return(model.MstUser{}, errFoo).
+					EXPECT().GetUserByUsername(gomock.Any(), gomock.Any()).
+					Return(user, nil).
+					Times(1)
+				expectAttempt(false, constant.LoginFailureInvalidPassword)
+				mockUserDB.
+					EXPECT().RegisterFailedLogin(gomock.Any(), int64(1), 3, now.Add(10*time.Minute)).
+					Return(sql.NullTime{Time: now.Add(10 * time.Minute), Valid: true}, nil).
+					Times(1)
+			},
+			wantErr:    true,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "error during failed login registration",
+			args: args{
+				ctx: ctx,
+				params: model.SignInRequest{
+					Username: "fooname",
+					Password: "wrong",
+				},
+			},
+			want: "",
+			patch: func() {
+				mockUserDB.
+					EXPECT().GetUserByUsername(gomock.Any(), gomock.Any()).
+					Return(user, nil).
+					Times(1)
+				expectAttempt(false, constant.LoginFailureInvalidPassword)
+				mockUserDB.
+					EXPECT().RegisterFailedLogin(gomock.Any(), int64(1), 3, gomock.Any()).
+					Return(sql.NullTime{}, errFoo).
 					Times(1)
 			},
 			wantErr: true,
@@ -140,6 +295,10 @@ func Test_SignIn(t *testing.T) {
 					JWTConfig: auth.JWTConfig{
 						DurationInHours: 1,
 					},
+					Login: config.Login{
+						MaxFailedAttempts: 3,
+						LockoutInMinutes:  10,
+					},
 				},
 				UserDB:   mockUserDB,
 				AuthRepo: mockAuthRepo,
@@ -150,6 +309,254 @@ func Test_SignIn(t *testing.T) {
 			if assert.Equal(t, tc.wantErr, err != nil) {
 				assert.Equal(t, tc.want, got)
 			}
+			if tc.wantStatus != 0 {
+				errMessage, ok := errors.Cause(err).(*commonerr.ErrorMessage)
+				if assert.True(t, ok) {
+					assert.Equal(t, tc.wantStatus, errMessage.Code)
+				}
+			}
+		})
+	}
+}
+
+func Test_UnlockUser(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+	employeeCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 2, Role: constant.UserRoleEmployee})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		patch   func()
+		want    model.UnlockUserResponse
+		wantErr bool
+	}{
+		{
+			name: "success",
+			ctx:  adminCtx,
+			patch: func() {
+				mockUserDB.
+					EXPECT().UnlockUser(gomock.Any(), int64(2)).
+					Return(model.MstUser{ID: 2, Username: "employee_001"}, nil).
+					Times(1)
+			},
+			want: model.UnlockUserResponse{ID: 2, Username: "employee_001"},
+		},
+		{
+			name:    "error not admin",
+			ctx:     employeeCtx,
+			patch:   func() {},
+			wantErr: true,
+		},
+		{
+			name: "error user not found",
+			ctx:  adminCtx,
+			patch: func() {
+				mockUserDB.
+					EXPECT().UnlockUser(gomock.Any(), int64(2)).
+					Return(model.MstUser{}, nil).
+					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error during unlock",
+			ctx:  adminCtx,
+			patch: func() {
+				mockUserDB.
+					EXPECT().UnlockUser(gomock.Any(), int64(2)).
+					Return(model.MstUser{}, errFoo).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				UserDB: mockUserDB,
+			}
+			tt.patch()
+			got, err := u.UnlockUser(tt.ctx, 2)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ResetUserDevice(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+	employeeCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 2, Role: constant.UserRoleEmployee})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		patch   func()
+		want    model.ResetUserDeviceResponse
+		wantErr bool
+	}{
+		{
+			name: "success",
+			ctx:  adminCtx,
+			patch: func() {
+				mockUserDB.
+					EXPECT().ResetDevice(gomock.Any(), int64(2)).
+					Return(model.MstUser{ID: 2, Username: "employee_001", DeviceID: "device-1"}, nil).
+					Times(1)
+			},
+			want: model.ResetUserDeviceResponse{ID: 2, Username: "employee_001"},
+		},
+		{
+			name:    "error not admin",
+			ctx:     employeeCtx,
+			patch:   func() {},
+			wantErr: true,
+		},
+		{
+			name: "error user not found",
+			ctx:  adminCtx,
+			patch: func() {
+				mockUserDB.
+					EXPECT().ResetDevice(gomock.Any(), int64(2)).
+					Return(model.MstUser{}, nil).
+					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error during reset",
+			ctx:  adminCtx,
+			patch: func() {
+				mockUserDB.
+					EXPECT().ResetDevice(gomock.Any(), int64(2)).
+					Return(model.MstUser{}, errFoo).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				UserDB: mockUserDB,
+			}
+			tt.patch()
+			got, err := u.ResetUserDevice(tt.ctx, 2)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_SetManager(t *testing.T) {
+	ctrl := initMock(t)
+	defer ctrl.Finish()
+
+	adminCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 1, Role: constant.UserRoleAdmin})
+	employeeCtx := auth.SetUserDetailToCtx(context.Background(), auth.UserJWTPayload{ID: 2, Role: constant.UserRoleEmployee})
+	manager := sql.NullInt64{Int64: 3, Valid: true}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		request model.SetManagerRequest
+		patch   func()
+		want    model.SetManagerResponse
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			ctx:     adminCtx,
+			request: model.SetManagerRequest{ManagerID: 3},
+			patch: func() {
+				mockUserDB.
+					EXPECT().GetUserByID(gomock.Any(), int64(3)).
+					Return(model.MstUser{ID: 3, Username: "manager_001"}, nil).
+					Times(1)
+				mockUserDB.
+					EXPECT().SetManager(gomock.Any(), int64(2), manager).
+					Return(model.MstUser{ID: 2, Username: "employee_001", IDMstManager: manager}, nil).
+					Times(1)
+			},
+			want: model.SetManagerResponse{ID: 2, Username: "employee_001", ManagerID: 3},
+		},
+		{
+			name:    "success removing the manager",
+			ctx:     adminCtx,
+			request: model.SetManagerRequest{},
+			patch: func() {
+				mockUserDB.
+					EXPECT().SetManager(gomock.Any(), int64(2), sql.NullInt64{}).
+					Return(model.MstUser{ID: 2, Username: "employee_001"}, nil).
+					Times(1)
+			},
+			want: model.SetManagerResponse{ID: 2, Username: "employee_001"},
+		},
+		{
+			name:    "error not admin",
+			ctx:     employeeCtx,
+			request: model.SetManagerRequest{ManagerID: 3},
+			patch:   func() {},
+			wantErr: true,
+		},
+		{
+			name:    "error managing themselves",
+			ctx:     adminCtx,
+			request: model.SetManagerRequest{ManagerID: 2},
+			patch:   func() {},
+			wantErr: true,
+		},
+		{
+			name:    "error manager not found",
+			ctx:     adminCtx,
+			request: model.SetManagerRequest{ManagerID: 3},
+			patch: func() {
+				mockUserDB.
+					EXPECT().GetUserByID(gomock.Any(), int64(3)).
+					Return(model.MstUser{}, nil).
+					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error user not found",
+			ctx:     adminCtx,
+			request: model.SetManagerRequest{},
+			patch: func() {
+				mockUserDB.
+					EXPECT().SetManager(gomock.Any(), int64(2), sql.NullInt64{}).
+					Return(model.MstUser{}, nil).
+					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error during update",
+			ctx:     adminCtx,
+			request: model.SetManagerRequest{},
+			patch: func() {
+				mockUserDB.
+					EXPECT().SetManager(gomock.Any(), int64(2), sql.NullInt64{}).
+					Return(model.MstUser{}, errFoo).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := Usecase{
+				UserDB: mockUserDB,
+			}
+			tt.patch()
+			got, err := u.SetManager(tt.ctx, 2, tt.request)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
 		})
 	}
 }
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/database"
+	commonwriter "github.com/faisalhardin/employee-payroll-system/pkg/common/writer"
+)
+
+const readinessTimeout = 2 * time.Second
+
+// HealthHandler reports that the process is alive. It never touches the
+// database so a slow database does not get the container restarted.
+func (s *Server) HealthHandler(w http.ResponseWriter, r *http.Request) {
+	_, _ = commonwriter.WriteJSONAPIData(w, r, http.StatusOK, map[string]string{
+		"status": database.StatusUp,
+	})
+}
+
+// ReadyHandler reports whether the server can take traffic, answering 503
+// with the database status while the database is unreachable.
+func (s *Server) ReadyHandler(db database.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		defer cancel()
+
+		stats := db.Health(ctx)
+
+		status := http.StatusOK
+		if stats["status"] != database.StatusUp {
+			status = http.StatusServiceUnavailable
+		}
+		_, _ = commonwriter.WriteJSONAPIData(w, r, status, stats)
+	}
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/database"
+)
+
+type stubDatabase struct {
+	stats map[string]string
+}
+
+func (s stubDatabase) Health(ctx context.Context) map[string]string {
+	return s.stats
+}
+
+func (s stubDatabase) Close() error {
+	return nil
+}
+
+func TestHealthHandler(t *testing.T) {
+	s := &Server{}
+	rec := httptest.NewRecorder()
+	s.HealthHandler(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status OK; got %v", rec.Code)
+	}
+	expected := "{\"data\":{\"status\":\"up\"}}"
+	if rec.Body.String() != expected {
+		t.Errorf("expected response body to be %v; got %v", expected, rec.Body.String())
+	}
+}
+
+func TestReadyHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		stats      map[string]string
+		wantStatus int
+	}{
+		{
+			name: "database up",
+			stats: map[string]string{
+				"status":            database.StatusUp,
+				"migration_version": "12",
+				"open_connections":  "1",
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "database down",
+			stats: map[string]string{
+				"status": database.StatusDown,
+				"error":  "db down: connection refused",
+			},
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{}
+			rec := httptest.NewRecorder()
+			s.ReadyHandler(stubDatabase{stats: tt.stats})(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %v; got %v", tt.wantStatus, rec.Code)
+			}
+
+			var body struct {
+				Data map[string]string `json:"data"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("error decoding response body. Err: %v", err)
+			}
+			for key, value := range tt.stats {
+				if body.Data[key] != value {
+					t.Errorf("expected %s to be %v; got %v", key, value, body.Data[key])
+				}
+			}
+		})
+	}
+}
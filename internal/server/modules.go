@@ -1,7 +1,13 @@
 package server
 
 import (
+	"net/http"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/database"
 	attendancehandler "github.com/faisalhardin/employee-payroll-system/internal/repo/handler/attendance"
+	audithandler "github.com/faisalhardin/employee-payroll-system/internal/repo/handler/audit"
+	kioskhandler "github.com/faisalhardin/employee-payroll-system/internal/repo/handler/kiosk"
+	tenanthandler "github.com/faisalhardin/employee-payroll-system/internal/repo/handler/tenant"
 	userhandler "github.com/faisalhardin/employee-payroll-system/internal/repo/handler/user"
 	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
 )
@@ -9,9 +15,22 @@ import (
 type Handlers struct {
 	UserHandler       *userhandler.UserHandler
 	AttendanceHandler *attendancehandler.AttendanceHandler
+	AuditHandler      *audithandler.AuditHandler
+	KioskHandler      *kioskhandler.KioskHandler
+	TenantHandler     *tenanthandler.TenantHandler
 }
 
 type Modules struct {
 	Handlers       *Handlers
 	AuthMiddleware *auth.Options
+	Database       database.Service
+	// LoginLimiter throttles user sign in attempts
+	LoginLimiter func(http.Handler) http.Handler
+	// KioskLoginLimiter throttles kiosk sign in attempts. It keeps its own
+	// buckets so kiosks signing in do not use up the attempts of the
+	// employees behind the same address.
+	KioskLoginLimiter func(http.Handler) http.Handler
+	// Idempotency replays the response of POST requests repeated with the
+	// same Idempotency-Key
+	Idempotency func(http.Handler) http.Handler
 }
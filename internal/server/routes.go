@@ -5,6 +5,14 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/faisalhardin/employee-payroll-system/internal/config"
+	"github.com/faisalhardin/employee-payroll-system/pkg/metrics"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/realip"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/requestinfo"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/requestlog"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/secureheaders"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
@@ -12,31 +20,124 @@ import (
 
 func (s *Server) RegisterRoutes(m *Modules) http.Handler {
 	r := chi.NewRouter()
-	r.Use(middleware.Logger)
+	r.Use(middleware.RequestID)
+	r.Use(realip.Handler(s.cfg.Server.TrustedProxies))
+	r.Use(requestinfo.Handler)
+	r.Use(tracing.Handler)
+	r.Use(requestlog.Handler)
+	r.Use(metrics.HTTPHandler)
 
-	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"https://*", "http://*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
-		AllowCredentials: true,
-		MaxAge:           300,
-	}))
+	r.Use(secureheaders.Handler(s.cfg.SecurityHeaders))
+	r.Use(cors.Handler(corsOptions(s.cfg.CORS)))
 
-	r.Post("/login", m.Handlers.UserHandler.SignIn)
+	r.Get("/health", s.HealthHandler)
+	r.Get("/ready", s.ReadyHandler(m.Database))
+
+	r.With(m.LoginLimiter).Post("/login", m.Handlers.UserHandler.SignIn)
+	r.With(m.KioskLoginLimiter).Post("/kiosk/login", m.Handlers.KioskHandler.SignIn)
+	r.Route("/kiosk/v1", func(kiosk chi.Router) {
+		kiosk.Use(m.AuthMiddleware.KioskAuthHandler)
+		kiosk.Get("/nonce", m.Handlers.KioskHandler.IssueNonce)
+		kiosk.Post("/tap-in", m.Handlers.AttendanceHandler.TapInWithBadge)
+	})
 	r.Route("/v1", func(v1 chi.Router) {
 		v1.Use(m.AuthMiddleware.AuthHandler)
+		v1.Use(m.Idempotency)
 		v1.Post("/tap-in", m.Handlers.AttendanceHandler.TapIn)
-		v1.Post("/payroll-period", m.Handlers.AttendanceHandler.CreatePayrollPeriod)
+		v1.Post("/tap-in/kiosk", m.Handlers.AttendanceHandler.TapInAtKiosk)
+		v1.Post("/attendance/import", m.Handlers.AttendanceHandler.ImportAttendance)
+		v1.Post("/attendance/adjustments", m.Handlers.AttendanceHandler.AdjustAttendance)
+		v1.Route("/attendance-corrections", func(corrections chi.Router) {
+			corrections.Post("/", m.Handlers.AttendanceHandler.RequestAttendanceCorrection)
+			corrections.Get("/", m.Handlers.AttendanceHandler.ListAttendanceCorrections)
+			corrections.Post("/{id}/approve", m.Handlers.AttendanceHandler.ApproveAttendanceCorrection)
+			corrections.Post("/{id}/reject", m.Handlers.AttendanceHandler.RejectAttendanceCorrection)
+		})
+		v1.Route("/kiosks", func(kiosks chi.Router) {
+			kiosks.Post("/", m.Handlers.KioskHandler.RegisterKiosk)
+			kiosks.Get("/", m.Handlers.KioskHandler.ListKiosks)
+			kiosks.Delete("/{id}", m.Handlers.KioskHandler.DeactivateKiosk)
+		})
+		v1.Route("/shifts", func(shifts chi.Router) {
+			shifts.Post("/", m.Handlers.AttendanceHandler.CreateShift)
+			shifts.Get("/", m.Handlers.AttendanceHandler.ListShifts)
+		})
+		v1.Route("/work-patterns", func(patterns chi.Router) {
+			patterns.Post("/", m.Handlers.AttendanceHandler.CreateWorkPattern)
+			patterns.Get("/", m.Handlers.AttendanceHandler.ListWorkPatterns)
+		})
+		v1.Post("/schedules", m.Handlers.AttendanceHandler.AssignSchedule)
+		v1.Post("/rosters", m.Handlers.AttendanceHandler.SetRoster)
+		v1.Route("/departments", func(departments chi.Router) {
+			departments.Post("/", m.Handlers.AttendanceHandler.CreateDepartment)
+			departments.Get("/", m.Handlers.AttendanceHandler.ListDepartments)
+		})
+		v1.Route("/cost-centres", func(costCentres chi.Router) {
+			costCentres.Post("/", m.Handlers.AttendanceHandler.CreateCostCentre)
+			costCentres.Get("/", m.Handlers.AttendanceHandler.ListCostCentres)
+		})
+		v1.Post("/assignments", m.Handlers.AttendanceHandler.AssignOrganization)
+		v1.Route("/payroll-period", func(payrollPeriod chi.Router) {
+			payrollPeriod.Post("/", m.Handlers.AttendanceHandler.CreatePayrollPeriod)
+			payrollPeriod.Get("/", m.Handlers.AttendanceHandler.ListPayrollPeriods)
+			payrollPeriod.Get("/schedule-runs", m.Handlers.AttendanceHandler.ListPayrollScheduleRuns)
+			payrollPeriod.Get("/{id}", m.Handlers.AttendanceHandler.GetPayrollPeriod)
+			payrollPeriod.Put("/{id}", m.Handlers.AttendanceHandler.UpdatePayrollPeriod)
+			payrollPeriod.Delete("/{id}", m.Handlers.AttendanceHandler.DeletePayrollPeriod)
+		})
 		v1.Post("/overtime", m.Handlers.AttendanceHandler.SubmitOvertime)
 		v1.Post("/reimbursement", m.Handlers.AttendanceHandler.SubmitReimbursement)
 		v1.Get("/payroll", m.Handlers.AttendanceHandler.GetPayroll)
 		v1.Post("/payroll/generate", m.Handlers.AttendanceHandler.GeneratePayroll)
+		v1.Get("/payroll/jobs/{id}", m.Handlers.AttendanceHandler.GetPayrollJob)
+		v1.Get("/payroll/labour-cost", m.Handlers.AttendanceHandler.GetLabourCost)
 		v1.Get("/payslip", m.Handlers.AttendanceHandler.GetEmployeePayslip)
+		v1.Get("/audit-logs", m.Handlers.AuditHandler.ListAuditLogs)
+		v1.Get("/tenant", m.Handlers.TenantHandler.GetTenant)
+		v1.Put("/tenant/payroll-policy", m.Handlers.TenantHandler.UpdatePayrollPolicy)
+		v1.Post("/users/{id}/unlock", m.Handlers.UserHandler.UnlockUser)
+		v1.Delete("/users/{id}/device", m.Handlers.UserHandler.ResetUserDevice)
+		v1.Put("/users/{id}/manager", m.Handlers.UserHandler.SetManager)
+		v1.Get("/users/{id}/schedule", m.Handlers.AttendanceHandler.GetEmployeeSchedule)
+		v1.Get("/users/{id}/assignments", m.Handlers.AttendanceHandler.ListAssignments)
+
+		v1.Route("/me", func(me chi.Router) {
+			me.Get("/payslips", m.Handlers.AttendanceHandler.ListMyPayslips)
+			me.Get("/attendance", m.Handlers.AttendanceHandler.ListMyAttendance)
+			me.Get("/overtime", m.Handlers.AttendanceHandler.ListMyOvertime)
+			me.Get("/reimbursements", m.Handlers.AttendanceHandler.ListMyReimbursements)
+			me.Get("/attendance-corrections", m.Handlers.AttendanceHandler.ListMyAttendanceCorrections)
+			me.Get("/schedule", m.Handlers.AttendanceHandler.GetMySchedule)
+		})
 	})
 
 	return r
 }
 
+// corsOptions builds the CORS options from cfg, using the auth package lists
+// for methods and headers not configured
+func corsOptions(cfg config.CORS) cors.Options {
+	options := cors.Options{
+		AllowedOrigins:   cfg.AllowedOrigins,
+		AllowedMethods:   cfg.AllowedMethods,
+		AllowedHeaders:   cfg.AllowedHeaders,
+		ExposedHeaders:   cfg.ExposedHeaders,
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           cfg.MaxAgeInSeconds,
+	}
+	if len(options.AllowedMethods) == 0 {
+		options.AllowedMethods = auth.AllowedMethodRequest
+	}
+	if len(options.AllowedHeaders) == 0 {
+		options.AllowedHeaders = auth.AllowedHeaders
+	}
+	if len(options.AllowedOrigins) == 0 {
+		// cors allows every origin when the list is empty
+		options.AllowOriginFunc = func(r *http.Request, origin string) bool { return false }
+	}
+	return options
+}
+
 func (s *Server) HelloWorldHandler(w http.ResponseWriter, r *http.Request) {
 	resp := make(map[string]string)
 	resp["message"] = "Hello World"
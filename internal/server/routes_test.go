@@ -5,6 +5,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/faisalhardin/employee-payroll-system/internal/config"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/go-chi/cors"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestHandler(t *testing.T) {
@@ -29,3 +34,94 @@ func TestHandler(t *testing.T) {
 		t.Errorf("expected response body to be %v; got %v", expected, string(body))
 	}
 }
+
+func Test_corsOptions(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             config.CORS
+		origin          string
+		wantAllowOrigin string
+		wantCredentials string
+	}{
+		{
+			name:            "allowed origin",
+			cfg:             config.CORS{AllowedOrigins: []string{"https://payroll.example.com"}, AllowCredentials: true},
+			origin:          "https://payroll.example.com",
+			wantAllowOrigin: "https://payroll.example.com",
+			wantCredentials: "true",
+		},
+		{
+			name:   "other origin",
+			cfg:    config.CORS{AllowedOrigins: []string{"https://payroll.example.com"}, AllowCredentials: true},
+			origin: "https://evil.example.com",
+		},
+		{
+			name:   "no origin configured",
+			cfg:    config.CORS{},
+			origin: "https://payroll.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := cors.Handler(corsOptions(tt.cfg))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			req := httptest.NewRequest(http.MethodOptions, "/v1/payroll", nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			req.Header.Set("Access-Control-Request-Headers", "Authorization")
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantAllowOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.wantCredentials, w.Header().Get("Access-Control-Allow-Credentials"))
+		})
+	}
+}
+
+func TestNewMetricsServer(t *testing.T) {
+	assert.Nil(t, NewMetricsServer(&config.Config{}))
+
+	cfg := &config.Config{Server: config.Server{MetricsPort: "9090"}}
+	metricsServer := NewMetricsServer(cfg)
+	if assert.NotNil(t, metricsServer) {
+		assert.Equal(t, ":9090", metricsServer.Addr)
+
+		w := httptest.NewRecorder()
+		metricsServer.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
+
+func TestRegisterRoutes_loginLimiters(t *testing.T) {
+	limiter := func(name string) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Limiter", name)
+				w.WriteHeader(http.StatusTooManyRequests)
+			})
+		}
+	}
+	s := &Server{cfg: &config.Config{}}
+	handler := s.RegisterRoutes(&Modules{
+		Handlers:          &Handlers{},
+		AuthMiddleware:    &auth.Options{},
+		LoginLimiter:      limiter("login"),
+		KioskLoginLimiter: limiter("kiosk_login"),
+		Idempotency:       func(next http.Handler) http.Handler { return next },
+	})
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/login", want: "login"},
+		{path: "/kiosk/login", want: "kiosk_login"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, nil))
+			assert.Equal(t, http.StatusTooManyRequests, w.Code)
+			assert.Equal(t, tt.want, w.Header().Get("X-Limiter"))
+		})
+	}
+}
@@ -7,17 +7,20 @@ import (
 	"time"
 
 	"github.com/faisalhardin/employee-payroll-system/internal/config"
-	_ "github.com/joho/godotenv/autoload"
+	"github.com/faisalhardin/employee-payroll-system/pkg/metrics"
+	"github.com/go-chi/chi/v5"
 )
 
 type Server struct {
 	port int
+	cfg  *config.Config
 }
 
 func NewServer(cfg *config.Config, m *Modules) *http.Server {
 	port, _ := strconv.Atoi(cfg.Server.Port)
 	NewServer := &Server{
 		port: port,
+		cfg:  cfg,
 	}
 
 	// Declare Server config
@@ -31,3 +34,24 @@ func NewServer(cfg *config.Config, m *Modules) *http.Server {
 
 	return server
 }
+
+// NewMetricsServer serves /metrics on the metrics port, a listener apart from
+// the API that is only reachable inside the deployment. It returns nil when
+// no metrics port is configured.
+func NewMetricsServer(cfg *config.Config) *http.Server {
+	if cfg.Server.MetricsPort == "" {
+		return nil
+	}
+	port, _ := strconv.Atoi(cfg.Server.MetricsPort)
+
+	r := chi.NewRouter()
+	r.Handle("/metrics", metrics.Handler())
+
+	return &http.Server{
+		Addr:         fmt.Sprintf(":%d", port),
+		Handler:      r,
+		IdleTimeout:  time.Minute,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+}
@@ -15,8 +15,9 @@ CREATE TABLE IF NOT EXISTS mst_user (
 CREATE INDEX IF NOT EXISTS idx_mst_user_username ON mst_user(username);
 CREATE INDEX IF NOT EXISTS idx_mst_user_role ON mst_user(role);
 
--- Insert sample data
+-- Insert the first admin, signing in with the dev password "password123".
+-- Change it with cmd/migrate set-password outside development.
 INSERT INTO mst_user (username, password_hash, role, salary, created_by) 
 VALUES 
-    ('admin', '$2a$10$example_hash_here', 'admin', 75000.00, 'system')
+    ('admin', '$2a$10$kSGIRFvwOhyo/25PdoKiVeE7tQjigW6Rsiz3aaZIBzTvY77eioFvS', 'admin', 75000.00, 'system')
 ON CONFLICT (username) DO NOTHING;
@@ -1,103 +0,0 @@
--- Generate 100 employee users
-INSERT INTO mst_user (username, password_hash, role, salary, created_by) VALUES
-('employee_001', '$2a$10$hash001', 'employee', 45000, 'admin'),
-('employee_002', '$2a$10$hash002', 'employee', 47500, 'admin'),
-('employee_003', '$2a$10$hash003', 'employee', 52000, 'admin'),
-('employee_004', '$2a$10$hash004', 'employee', 48000, 'admin'),
-('employee_005', '$2a$10$hash005', 'employee', 51000, 'admin'),
-('employee_006', '$2a$10$hash006', 'employee', 46500, 'admin'),
-('employee_007', '$2a$10$hash007', 'employee', 49000, 'admin'),
-('employee_008', '$2a$10$hash008', 'employee', 53500, 'admin'),
-('employee_009', '$2a$10$hash009', 'employee', 44500, 'admin'),
-('employee_010', '$2a$10$hash010', 'employee', 50000, 'admin'),
-('employee_011', '$2a$10$hash011', 'employee', 47000, 'admin'),
-('employee_012', '$2a$10$hash012', 'employee', 48500, 'admin'),
-('employee_013', '$2a$10$hash013', 'employee', 52500, 'admin'),
-('employee_014', '$2a$10$hash014', 'employee', 45500, 'admin'),
-('employee_015', '$2a$10$hash015', 'employee', 49500, 'admin'),
-('employee_016', '$2a$10$hash016', 'employee', 51500, 'admin'),
-('employee_017', '$2a$10$hash017', 'employee', 46000, 'admin'),
-('employee_018', '$2a$10$hash018', 'employee', 48000, 'admin'),
-('employee_019', '$2a$10$hash019', 'employee', 50500, 'admin'),
-('employee_020', '$2a$10$hash020', 'employee', 47500, 'admin'),
-('employee_021', '$2a$10$hash021', 'employee', 49000, 'admin'),
-('employee_022', '$2a$10$hash022', 'employee', 52000, 'admin'),
-('employee_023', '$2a$10$hash023', 'employee', 45000, 'admin'),
-('employee_024', '$2a$10$hash024', 'employee', 48500, 'admin'),
-('employee_025', '$2a$10$hash025', 'employee', 51000, 'admin'),
-('employee_026', '$2a$10$hash026', 'employee', 46500, 'admin'),
-('employee_027', '$2a$10$hash027', 'employee', 49500, 'admin'),
-('employee_028', '$2a$10$hash028', 'employee', 53000, 'admin'),
-('employee_029', '$2a$10$hash029', 'employee', 44000, 'admin'),
-('employee_030', '$2a$10$hash030', 'employee', 50000, 'admin'),
-('employee_031', '$2a$10$hash031', 'employee', 47000, 'admin'),
-('employee_032', '$2a$10$hash032', 'employee', 48000, 'admin'),
-('employee_033', '$2a$10$hash033', 'employee', 52500, 'admin'),
-('employee_034', '$2a$10$hash034', 'employee', 45500, 'admin'),
-('employee_035', '$2a$10$hash035', 'employee', 49000, 'admin'),
-('employee_036', '$2a$10$hash036', 'employee', 51500, 'admin'),
-('employee_037', '$2a$10$hash037', 'employee', 46000, 'admin'),
-('employee_038', '$2a$10$hash038', 'employee', 48500, 'admin'),
-('employee_039', '$2a$10$hash039', 'employee', 50500, 'admin'),
-('employee_040', '$2a$10$hash040', 'employee', 47500, 'admin'),
-('employee_041', '$2a$10$hash041', 'employee', 49500, 'admin'),
-('employee_042', '$2a$10$hash042', 'employee', 52000, 'admin'),
-('employee_043', '$2a$10$hash043', 'employee', 45000, 'admin'),
-('employee_044', '$2a$10$hash044', 'employee', 48000, 'admin'),
-('employee_045', '$2a$10$hash045', 'employee', 51000, 'admin'),
-('employee_046', '$2a$10$hash046', 'employee', 46500, 'admin'),
-('employee_047', '$2a$10$hash047', 'employee', 49000, 'admin'),
-('employee_048', '$2a$10$hash048', 'employee', 53500, 'admin'),
-('employee_049', '$2a$10$hash049', 'employee', 44500, 'admin'),
-('employee_050', '$2a$10$hash050', 'employee', 50000, 'admin'),
-('employee_051', '$2a$10$hash051', 'employee', 47000, 'admin'),
-('employee_052', '$2a$10$hash052', 'employee', 48500, 'admin'),
-('employee_053', '$2a$10$hash053', 'employee', 52500, 'admin'),
-('employee_054', '$2a$10$hash054', 'employee', 45500, 'admin'),
-('employee_055', '$2a$10$hash055', 'employee', 49500, 'admin'),
-('employee_056', '$2a$10$hash056', 'employee', 51500, 'admin'),
-('employee_057', '$2a$10$hash057', 'employee', 46000, 'admin'),
-('employee_058', '$2a$10$hash058', 'employee', 48000, 'admin'),
-('employee_059', '$2a$10$hash059', 'employee', 50500, 'admin'),
-('employee_060', '$2a$10$hash060', 'employee', 47500, 'admin'),
-('employee_061', '$2a$10$hash061', 'employee', 49000, 'admin'),
-('employee_062', '$2a$10$hash062', 'employee', 52000, 'admin'),
-('employee_063', '$2a$10$hash063', 'employee', 45000, 'admin'),
-('employee_064', '$2a$10$hash064', 'employee', 48500, 'admin'),
-('employee_065', '$2a$10$hash065', 'employee', 51000, 'admin'),
-('employee_066', '$2a$10$hash066', 'employee', 46500, 'admin'),
-('employee_067', '$2a$10$hash067', 'employee', 49500, 'admin'),
-('employee_068', '$2a$10$hash068', 'employee', 53000, 'admin'),
-('employee_069', '$2a$10$hash069', 'employee', 44000, 'admin'),
-('employee_070', '$2a$10$hash070', 'employee', 50000, 'admin'),
-('employee_071', '$2a$10$hash071', 'employee', 47000, 'admin'),
-('employee_072', '$2a$10$hash072', 'employee', 48000, 'admin'),
-('employee_073', '$2a$10$hash073', 'employee', 52500, 'admin'),
-('employee_074', '$2a$10$hash074', 'employee', 45500, 'admin'),
-('employee_075', '$2a$10$hash075', 'employee', 49000, 'admin'),
-('employee_076', '$2a$10$hash076', 'employee', 51500, 'admin'),
-('employee_077', '$2a$10$hash077', 'employee', 46000, 'admin'),
-('employee_078', '$2a$10$hash078', 'employee', 48500, 'admin'),
-('employee_079', '$2a$10$hash079', 'employee', 50500, 'admin'),
-('employee_080', '$2a$10$hash080', 'employee', 47500, 'admin'),
-('employee_081', '$2a$10$hash081', 'employee', 49500, 'admin'),
-('employee_082', '$2a$10$hash082', 'employee', 52000, 'admin'),
-('employee_083', '$2a$10$hash083', 'employee', 45000, 'admin'),
-('employee_084', '$2a$10$hash084', 'employee', 48000, 'admin'),
-('employee_085', '$2a$10$hash085', 'employee', 51000, 'admin'),
-('employee_086', '$2a$10$hash086', 'employee', 46500, 'admin'),
-('employee_087', '$2a$10$hash087', 'employee', 49000, 'admin'),
-('employee_088', '$2a$10$hash088', 'employee', 53500, 'admin'),
-('employee_089', '$2a$10$hash089', 'employee', 44500, 'admin'),
-('employee_090', '$2a$10$hash090', 'employee', 50000, 'admin'),
-('employee_091', '$2a$10$hash091', 'employee', 47000, 'admin'),
-('employee_092', '$2a$10$hash092', 'employee', 48500, 'admin'),
-('employee_093', '$2a$10$hash093', 'employee', 52500, 'admin'),
-('employee_094', '$2a$10$hash094', 'employee', 45500, 'admin'),
-('employee_095', '$2a$10$hash095', 'employee', 49500, 'admin'),
-('employee_096', '$2a$10$hash096', 'employee', 51500, 'admin'),
-('employee_097', '$2a$10$hash097', 'employee', 46000, 'admin'),
-('employee_098', '$2a$10$hash098', 'employee', 48000, 'admin'),
-('employee_099', '$2a$10$hash099', 'employee', 50500, 'admin'),
-('employee_100', '$2a$10$hash100', 'employee', 47500, 'admin')
-ON CONFLICT (username) DO NOTHING;
@@ -0,0 +1 @@
+ALTER TABLE mst_payroll_period DROP CONSTRAINT IF EXISTS excl_payroll_period_no_overlap;
@@ -0,0 +1,4 @@
+-- Prevent two payroll periods from claiming the same dates
+ALTER TABLE mst_payroll_period
+    ADD CONSTRAINT excl_payroll_period_no_overlap
+    EXCLUDE USING gist (daterange(start_date, end_date, '[]') WITH &&);
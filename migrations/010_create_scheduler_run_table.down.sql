@@ -0,0 +1 @@
+DROP TABLE IF EXISTS trx_scheduler_run;
@@ -0,0 +1,14 @@
+CREATE TABLE trx_scheduler_run (
+    id BIGSERIAL PRIMARY KEY,
+    job_name VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    created_periods INTEGER NOT NULL DEFAULT 0,
+    generated_periods INTEGER NOT NULL DEFAULT 0,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE INDEX idx_scheduler_run_job_name ON trx_scheduler_run (job_name, id);
+CREATE INDEX idx_scheduler_run_status ON trx_scheduler_run (status);
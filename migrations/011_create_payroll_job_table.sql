@@ -0,0 +1,21 @@
+CREATE TABLE trx_payroll_job (
+    id BIGSERIAL PRIMARY KEY,
+    id_mst_payroll_period BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    progress INTEGER NOT NULL DEFAULT 0,
+    stage VARCHAR(100) NOT NULL DEFAULT '',
+    error_message TEXT NOT NULL DEFAULT '',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    started_at TIMESTAMPTZ NULL,
+    finished_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    created_by BIGINT NOT NULL
+);
+
+CREATE INDEX idx_payroll_job_status ON trx_payroll_job (status, id);
+
+-- only one unfinished job per payroll period
+CREATE UNIQUE INDEX idx_payroll_job_active_period
+ON trx_payroll_job (id_mst_payroll_period)
+WHERE status IN ('queued', 'running');
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS trx_audit_log;
+DROP FUNCTION IF EXISTS prevent_audit_log_change();
@@ -0,0 +1,29 @@
+CREATE TABLE trx_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id BIGINT NULL,
+    actor_username VARCHAR(255) NOT NULL DEFAULT '',
+    action VARCHAR(50) NOT NULL,
+    entity VARCHAR(100) NOT NULL,
+    entity_id BIGINT NULL,
+    before JSONB NULL,
+    after JSONB NULL,
+    request_id VARCHAR(100) NOT NULL DEFAULT '',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_audit_log_entity ON trx_audit_log (entity, entity_id);
+CREATE INDEX idx_audit_log_actor ON trx_audit_log (actor_id);
+CREATE INDEX idx_audit_log_request ON trx_audit_log (request_id);
+CREATE INDEX idx_audit_log_created_at ON trx_audit_log (created_at);
+
+-- the audit trail is append-only
+CREATE FUNCTION prevent_audit_log_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'trx_audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_audit_log_append_only
+BEFORE UPDATE OR DELETE ON trx_audit_log
+FOR EACH ROW EXECUTE FUNCTION prevent_audit_log_change();
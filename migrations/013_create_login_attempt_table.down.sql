@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS trx_login_attempt;
+
+ALTER TABLE mst_user
+    DROP COLUMN IF EXISTS failed_login_attempts,
+    DROP COLUMN IF EXISTS locked_until;
@@ -0,0 +1,16 @@
+ALTER TABLE mst_user
+    ADD COLUMN IF NOT EXISTS failed_login_attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ NULL;
+
+CREATE TABLE trx_login_attempt (
+    id BIGSERIAL PRIMARY KEY,
+    username VARCHAR(255) NOT NULL,
+    id_mst_user BIGINT NULL REFERENCES mst_user(id),
+    success BOOLEAN NOT NULL,
+    failure_reason VARCHAR(50) NOT NULL DEFAULT '',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE INDEX idx_login_attempt_username ON trx_login_attempt (username, created_at);
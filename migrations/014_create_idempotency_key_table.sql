@@ -0,0 +1,14 @@
+CREATE TABLE trx_idempotency_key (
+    id BIGSERIAL PRIMARY KEY,
+    id_mst_user BIGINT NOT NULL REFERENCES mst_user(id),
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    content_type VARCHAR(255) NOT NULL DEFAULT '',
+    response_body BYTEA NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT uq_idempotency_key UNIQUE (id_mst_user, idempotency_key)
+);
+
+CREATE INDEX idx_idempotency_key_expires_at ON trx_idempotency_key (expires_at);
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_attendance_location_status;
+
+ALTER TABLE mst_attendance
+    DROP COLUMN IF EXISTS latitude,
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS device_id,
+    DROP COLUMN IF EXISTS office_name,
+    DROP COLUMN IF EXISTS distance_in_meters,
+    DROP COLUMN IF EXISTS location_status;
+
+ALTER TABLE mst_user
+    DROP COLUMN IF EXISTS work_arrangement,
+    DROP COLUMN IF EXISTS device_id;
@@ -0,0 +1,14 @@
+ALTER TABLE mst_user
+    ADD COLUMN IF NOT EXISTS work_arrangement VARCHAR(20) NOT NULL DEFAULT 'office'
+        CHECK (work_arrangement IN ('office', 'hybrid', 'remote')),
+    ADD COLUMN IF NOT EXISTS device_id VARCHAR(128) NOT NULL DEFAULT '';
+
+ALTER TABLE mst_attendance
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION NULL,
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION NULL,
+    ADD COLUMN IF NOT EXISTS device_id VARCHAR(128) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS office_name VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS distance_in_meters INTEGER NULL,
+    ADD COLUMN IF NOT EXISTS location_status VARCHAR(20) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_attendance_location_status ON mst_attendance (location_status, attendance_date);
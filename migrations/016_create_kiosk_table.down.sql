@@ -0,0 +1,10 @@
+ALTER TABLE mst_attendance
+    DROP COLUMN IF EXISTS source,
+    DROP COLUMN IF EXISTS id_mst_kiosk;
+
+DROP INDEX IF EXISTS idx_mst_user_badge_id;
+
+ALTER TABLE mst_user
+    DROP COLUMN IF EXISTS badge_id;
+
+DROP TABLE IF EXISTS mst_kiosk;
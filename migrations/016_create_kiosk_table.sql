@@ -0,0 +1,20 @@
+CREATE TABLE mst_kiosk (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    office_name VARCHAR(100) NOT NULL DEFAULT '',
+    secret_hash VARCHAR(64) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    created_by BIGINT NULL,
+    updated_by BIGINT NULL
+);
+
+ALTER TABLE mst_user
+    ADD COLUMN IF NOT EXISTS badge_id VARCHAR(64) NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mst_user_badge_id ON mst_user (badge_id);
+
+ALTER TABLE mst_attendance
+    ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'self',
+    ADD COLUMN IF NOT EXISTS id_mst_kiosk BIGINT NULL REFERENCES mst_kiosk(id);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS trx_attendance_correction;
+
+DROP INDEX IF EXISTS idx_mst_user_manager;
+
+ALTER TABLE mst_user
+    DROP COLUMN IF EXISTS id_mst_manager;
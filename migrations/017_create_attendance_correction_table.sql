@@ -0,0 +1,27 @@
+ALTER TABLE mst_user
+    ADD COLUMN IF NOT EXISTS id_mst_manager BIGINT NULL REFERENCES mst_user(id);
+
+CREATE INDEX IF NOT EXISTS idx_mst_user_manager ON mst_user (id_mst_manager);
+
+CREATE TABLE trx_attendance_correction (
+    id BIGSERIAL PRIMARY KEY,
+    id_mst_user BIGINT NOT NULL,
+    attendance_date DATE NOT NULL,
+    action VARCHAR(10) NOT NULL CHECK (action IN ('add', 'remove')),
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'approved', 'rejected')),
+    id_mst_attendance BIGINT NULL,
+    reviewed_by BIGINT NULL,
+    review_note TEXT NOT NULL DEFAULT '',
+    reviewed_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    created_by BIGINT NULL,
+    updated_by BIGINT NULL
+);
+
+CREATE INDEX idx_attendance_correction_user ON trx_attendance_correction (id_mst_user, attendance_date);
+CREATE INDEX idx_attendance_correction_status ON trx_attendance_correction (status);
+-- one open request per employee and day
+CREATE UNIQUE INDEX idx_attendance_correction_pending ON trx_attendance_correction (id_mst_user, attendance_date)
+    WHERE status = 'pending';
@@ -0,0 +1,8 @@
+ALTER TABLE trx_user_payslip
+    DROP COLUMN IF EXISTS working_hours;
+
+DROP TABLE IF EXISTS trx_user_roster;
+DROP TABLE IF EXISTS trx_user_schedule;
+DROP TABLE IF EXISTS dtl_work_pattern_day;
+DROP TABLE IF EXISTS mst_work_pattern;
+DROP TABLE IF EXISTS mst_shift;
@@ -0,0 +1,61 @@
+CREATE TABLE mst_shift (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    start_time VARCHAR(5) NOT NULL,
+    end_time VARCHAR(5) NOT NULL,
+    working_hours INTEGER NOT NULL CHECK (working_hours BETWEEN 1 AND 24),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    created_by BIGINT NULL,
+    updated_by BIGINT NULL
+);
+
+-- a pattern repeats every cycle_days days, days without a row are off
+CREATE TABLE mst_work_pattern (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    cycle_days INTEGER NOT NULL CHECK (cycle_days BETWEEN 1 AND 56),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    created_by BIGINT NULL,
+    updated_by BIGINT NULL
+);
+
+CREATE TABLE dtl_work_pattern_day (
+    id BIGSERIAL PRIMARY KEY,
+    id_mst_work_pattern BIGINT NOT NULL REFERENCES mst_work_pattern(id),
+    day_number INTEGER NOT NULL,
+    id_mst_shift BIGINT NOT NULL REFERENCES mst_shift(id),
+    UNIQUE (id_mst_work_pattern, day_number)
+);
+
+-- the pattern of an employee from start_date, until end_date when set
+CREATE TABLE trx_user_schedule (
+    id BIGSERIAL PRIMARY KEY,
+    id_mst_user BIGINT NOT NULL REFERENCES mst_user(id),
+    id_mst_work_pattern BIGINT NOT NULL REFERENCES mst_work_pattern(id),
+    start_date DATE NOT NULL,
+    end_date DATE NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    created_by BIGINT NULL,
+    updated_by BIGINT NULL
+);
+
+CREATE INDEX idx_user_schedule_user ON trx_user_schedule (id_mst_user, start_date);
+
+-- a roster day overrides the pattern, a null shift is a day off
+CREATE TABLE trx_user_roster (
+    id BIGSERIAL PRIMARY KEY,
+    id_mst_user BIGINT NOT NULL REFERENCES mst_user(id),
+    roster_date DATE NOT NULL,
+    id_mst_shift BIGINT NULL REFERENCES mst_shift(id),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    created_by BIGINT NULL,
+    updated_by BIGINT NULL,
+    UNIQUE (id_mst_user, roster_date)
+);
+
+ALTER TABLE trx_user_payslip
+    ADD COLUMN IF NOT EXISTS working_hours INTEGER NOT NULL DEFAULT 0;
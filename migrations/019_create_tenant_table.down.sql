@@ -0,0 +1,31 @@
+ALTER TABLE mst_user DROP CONSTRAINT IF EXISTS uq_user_tenant_badge_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mst_user_badge_id ON mst_user (badge_id);
+
+ALTER TABLE mst_work_pattern DROP CONSTRAINT IF EXISTS uq_work_pattern_tenant_name;
+ALTER TABLE mst_work_pattern ADD CONSTRAINT mst_work_pattern_name_key UNIQUE (name);
+ALTER TABLE mst_shift DROP CONSTRAINT IF EXISTS uq_shift_tenant_name;
+ALTER TABLE mst_shift ADD CONSTRAINT mst_shift_name_key UNIQUE (name);
+
+ALTER TABLE mst_payroll_period DROP CONSTRAINT IF EXISTS excl_payroll_period_no_overlap;
+ALTER TABLE mst_payroll_period
+    ADD CONSTRAINT excl_payroll_period_no_overlap
+    EXCLUDE USING gist (daterange(start_date, end_date, '[]') WITH &&);
+
+ALTER TABLE trx_audit_log DROP COLUMN IF EXISTS id_mst_tenant;
+ALTER TABLE trx_user_roster DROP COLUMN IF EXISTS id_mst_tenant;
+ALTER TABLE trx_user_schedule DROP COLUMN IF EXISTS id_mst_tenant;
+ALTER TABLE mst_work_pattern DROP COLUMN IF EXISTS id_mst_tenant;
+ALTER TABLE mst_shift DROP COLUMN IF EXISTS id_mst_tenant;
+ALTER TABLE trx_attendance_correction DROP COLUMN IF EXISTS id_mst_tenant;
+ALTER TABLE mst_kiosk DROP COLUMN IF EXISTS id_mst_tenant;
+ALTER TABLE trx_payroll_job DROP COLUMN IF EXISTS id_mst_tenant;
+ALTER TABLE trx_scheduler_run DROP COLUMN IF EXISTS id_mst_tenant;
+ALTER TABLE dtl_payroll DROP COLUMN IF EXISTS id_mst_tenant;
+ALTER TABLE trx_user_payslip DROP COLUMN IF EXISTS id_mst_tenant;
+ALTER TABLE trx_reimbursement DROP COLUMN IF EXISTS id_mst_tenant;
+ALTER TABLE trx_overtime DROP COLUMN IF EXISTS id_mst_tenant;
+ALTER TABLE mst_payroll_period DROP COLUMN IF EXISTS id_mst_tenant;
+ALTER TABLE mst_attendance DROP COLUMN IF EXISTS id_mst_tenant;
+ALTER TABLE mst_user DROP COLUMN IF EXISTS id_mst_tenant;
+
+DROP TABLE IF EXISTS mst_tenant;
@@ -0,0 +1,76 @@
+-- a tenant is one legal entity of the group, with its own employees,
+-- payroll periods and payroll policy
+CREATE TABLE mst_tenant (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(20) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    overtime_multiplier INTEGER NOT NULL DEFAULT 2 CHECK (overtime_multiplier > 0),
+    max_overtime_hours INTEGER NOT NULL DEFAULT 3 CHECK (max_overtime_hours BETWEEN 1 AND 24),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    created_by BIGINT NULL,
+    updated_by BIGINT NULL
+);
+
+-- the data recorded before tenants existed belongs to the first one
+INSERT INTO mst_tenant (id, code, name) VALUES (1, 'default', 'Default company');
+SELECT setval(pg_get_serial_sequence('mst_tenant', 'id'), 1);
+
+ALTER TABLE mst_user ADD COLUMN id_mst_tenant BIGINT NOT NULL DEFAULT 1 REFERENCES mst_tenant(id);
+ALTER TABLE mst_attendance ADD COLUMN id_mst_tenant BIGINT NOT NULL DEFAULT 1 REFERENCES mst_tenant(id);
+ALTER TABLE mst_payroll_period ADD COLUMN id_mst_tenant BIGINT NOT NULL DEFAULT 1 REFERENCES mst_tenant(id);
+ALTER TABLE trx_overtime ADD COLUMN id_mst_tenant BIGINT NOT NULL DEFAULT 1 REFERENCES mst_tenant(id);
+ALTER TABLE trx_reimbursement ADD COLUMN id_mst_tenant BIGINT NOT NULL DEFAULT 1 REFERENCES mst_tenant(id);
+ALTER TABLE trx_user_payslip ADD COLUMN id_mst_tenant BIGINT NOT NULL DEFAULT 1 REFERENCES mst_tenant(id);
+ALTER TABLE dtl_payroll ADD COLUMN id_mst_tenant BIGINT NOT NULL DEFAULT 1 REFERENCES mst_tenant(id);
+ALTER TABLE trx_scheduler_run ADD COLUMN id_mst_tenant BIGINT NOT NULL DEFAULT 1 REFERENCES mst_tenant(id);
+ALTER TABLE trx_payroll_job ADD COLUMN id_mst_tenant BIGINT NOT NULL DEFAULT 1 REFERENCES mst_tenant(id);
+ALTER TABLE mst_kiosk ADD COLUMN id_mst_tenant BIGINT NOT NULL DEFAULT 1 REFERENCES mst_tenant(id);
+ALTER TABLE trx_attendance_correction ADD COLUMN id_mst_tenant BIGINT NOT NULL DEFAULT 1 REFERENCES mst_tenant(id);
+ALTER TABLE mst_shift ADD COLUMN id_mst_tenant BIGINT NOT NULL DEFAULT 1 REFERENCES mst_tenant(id);
+ALTER TABLE mst_work_pattern ADD COLUMN id_mst_tenant BIGINT NOT NULL DEFAULT 1 REFERENCES mst_tenant(id);
+ALTER TABLE trx_user_schedule ADD COLUMN id_mst_tenant BIGINT NOT NULL DEFAULT 1 REFERENCES mst_tenant(id);
+ALTER TABLE trx_user_roster ADD COLUMN id_mst_tenant BIGINT NOT NULL DEFAULT 1 REFERENCES mst_tenant(id);
+-- audit entries of system jobs that act for no tenant have none
+ALTER TABLE trx_audit_log ADD COLUMN id_mst_tenant BIGINT NULL REFERENCES mst_tenant(id);
+
+-- the defaults only served the backfill, the application sets the tenant
+ALTER TABLE mst_user ALTER COLUMN id_mst_tenant DROP DEFAULT;
+ALTER TABLE mst_attendance ALTER COLUMN id_mst_tenant DROP DEFAULT;
+ALTER TABLE mst_payroll_period ALTER COLUMN id_mst_tenant DROP DEFAULT;
+ALTER TABLE trx_overtime ALTER COLUMN id_mst_tenant DROP DEFAULT;
+ALTER TABLE trx_reimbursement ALTER COLUMN id_mst_tenant DROP DEFAULT;
+ALTER TABLE trx_user_payslip ALTER COLUMN id_mst_tenant DROP DEFAULT;
+ALTER TABLE dtl_payroll ALTER COLUMN id_mst_tenant DROP DEFAULT;
+ALTER TABLE trx_scheduler_run ALTER COLUMN id_mst_tenant DROP DEFAULT;
+ALTER TABLE trx_payroll_job ALTER COLUMN id_mst_tenant DROP DEFAULT;
+ALTER TABLE mst_kiosk ALTER COLUMN id_mst_tenant DROP DEFAULT;
+ALTER TABLE trx_attendance_correction ALTER COLUMN id_mst_tenant DROP DEFAULT;
+ALTER TABLE mst_shift ALTER COLUMN id_mst_tenant DROP DEFAULT;
+ALTER TABLE mst_work_pattern ALTER COLUMN id_mst_tenant DROP DEFAULT;
+ALTER TABLE trx_user_schedule ALTER COLUMN id_mst_tenant DROP DEFAULT;
+ALTER TABLE trx_user_roster ALTER COLUMN id_mst_tenant DROP DEFAULT;
+
+CREATE INDEX idx_mst_user_tenant ON mst_user (id_mst_tenant);
+CREATE INDEX idx_attendance_tenant_date ON mst_attendance (id_mst_tenant, attendance_date);
+CREATE INDEX idx_overtime_tenant ON trx_overtime (id_mst_tenant);
+CREATE INDEX idx_reimbursement_tenant ON trx_reimbursement (id_mst_tenant);
+CREATE INDEX idx_payslip_tenant_period ON trx_user_payslip (id_mst_tenant, id_mst_payroll_period);
+CREATE INDEX idx_audit_log_tenant ON trx_audit_log (id_mst_tenant, id);
+
+-- periods only have to be apart within a tenant
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+ALTER TABLE mst_payroll_period DROP CONSTRAINT IF EXISTS excl_payroll_period_no_overlap;
+ALTER TABLE mst_payroll_period
+    ADD CONSTRAINT excl_payroll_period_no_overlap
+    EXCLUDE USING gist (id_mst_tenant WITH =, daterange(start_date, end_date, '[]') WITH &&);
+
+-- badges only have to be unique within a tenant
+DROP INDEX IF EXISTS idx_mst_user_badge_id;
+ALTER TABLE mst_user ADD CONSTRAINT uq_user_tenant_badge_id UNIQUE (id_mst_tenant, badge_id);
+
+-- shift and pattern names only have to be unique within a tenant
+ALTER TABLE mst_shift DROP CONSTRAINT IF EXISTS mst_shift_name_key;
+ALTER TABLE mst_shift ADD CONSTRAINT uq_shift_tenant_name UNIQUE (id_mst_tenant, name);
+ALTER TABLE mst_work_pattern DROP CONSTRAINT IF EXISTS mst_work_pattern_name_key;
+ALTER TABLE mst_work_pattern ADD CONSTRAINT uq_work_pattern_tenant_name UNIQUE (id_mst_tenant, name);
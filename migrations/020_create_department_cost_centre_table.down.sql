@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS trx_user_assignment;
+DROP TABLE IF EXISTS mst_cost_centre;
+DROP TABLE IF EXISTS mst_department;
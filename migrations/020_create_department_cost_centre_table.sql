@@ -0,0 +1,41 @@
+CREATE TABLE mst_department (
+    id BIGSERIAL PRIMARY KEY,
+    id_mst_tenant BIGINT NOT NULL REFERENCES mst_tenant(id),
+    code VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    created_by BIGINT NULL,
+    updated_by BIGINT NULL,
+    CONSTRAINT uq_department_tenant_code UNIQUE (id_mst_tenant, code)
+);
+
+CREATE TABLE mst_cost_centre (
+    id BIGSERIAL PRIMARY KEY,
+    id_mst_tenant BIGINT NOT NULL REFERENCES mst_tenant(id),
+    code VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    created_by BIGINT NULL,
+    updated_by BIGINT NULL,
+    CONSTRAINT uq_cost_centre_tenant_code UNIQUE (id_mst_tenant, code)
+);
+
+-- the department and cost centre of an employee from start_date, until
+-- end_date when set
+CREATE TABLE trx_user_assignment (
+    id BIGSERIAL PRIMARY KEY,
+    id_mst_tenant BIGINT NOT NULL REFERENCES mst_tenant(id),
+    id_mst_user BIGINT NOT NULL REFERENCES mst_user(id),
+    id_mst_department BIGINT NOT NULL REFERENCES mst_department(id),
+    id_mst_cost_centre BIGINT NOT NULL REFERENCES mst_cost_centre(id),
+    start_date DATE NOT NULL,
+    end_date DATE NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    created_by BIGINT NULL,
+    updated_by BIGINT NULL
+);
+
+CREATE INDEX idx_user_assignment_user ON trx_user_assignment (id_mst_tenant, id_mst_user, start_date);
@@ -0,0 +1,5 @@
+UPDATE dtl_payroll SET created_by = 0 WHERE created_by IS NULL;
+ALTER TABLE dtl_payroll ALTER COLUMN created_by SET NOT NULL;
+
+UPDATE trx_payroll_job SET created_by = 0 WHERE created_by IS NULL;
+ALTER TABLE trx_payroll_job ALTER COLUMN created_by SET NOT NULL;
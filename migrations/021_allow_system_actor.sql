@@ -0,0 +1,4 @@
+-- payroll jobs queued by the scheduler and the payroll they generate have no
+-- user behind them, created_by stays empty for them
+ALTER TABLE trx_payroll_job ALTER COLUMN created_by DROP NOT NULL;
+ALTER TABLE dtl_payroll ALTER COLUMN created_by DROP NOT NULL;
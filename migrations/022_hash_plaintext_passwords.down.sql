@@ -0,0 +1,2 @@
+-- the plain text passwords cannot be recovered, the hashes keep working
+SELECT 1;
@@ -0,0 +1,7 @@
+-- sign in compares bcrypt hashes, so passwords stored in plain text before
+-- that are hashed in place. pgcrypto's bf hashes are read by bcrypt.
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+UPDATE mst_user
+SET password_hash = crypt(password_hash, gen_salt('bf', 10))
+WHERE password_hash !~ '^\$2[aby]\$';
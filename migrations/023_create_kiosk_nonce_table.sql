@@ -0,0 +1,12 @@
+-- a kiosk nonce is kept once used until it expires, so the same QR code
+-- cannot tap in a second employee
+CREATE TABLE trx_kiosk_nonce (
+    id VARCHAR(64) PRIMARY KEY,
+    id_mst_tenant BIGINT NOT NULL REFERENCES mst_tenant(id),
+    id_mst_kiosk BIGINT NOT NULL REFERENCES mst_kiosk(id),
+    id_mst_user BIGINT NOT NULL REFERENCES mst_user(id),
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE INDEX idx_kiosk_nonce_expires_at ON trx_kiosk_nonce (expires_at);
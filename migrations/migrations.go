@@ -0,0 +1,29 @@
+// Package migrations embeds the versioned schema migrations and the optional
+// sample data so they ship inside the binaries.
+//
+// Migrations are named NNN_description.sql with an optional
+// NNN_description.down.sql that reverts them. Seeds follow the same naming
+// under seeds/ and are never reverted.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed *.sql
+var schema embed.FS
+
+//go:embed seeds/*.sql
+var seeds embed.FS
+
+// Schema returns the schema migrations.
+func Schema() fs.FS {
+	return schema
+}
+
+// Seeds returns the sample data.
+func Seeds() fs.FS {
+	sub, _ := fs.Sub(seeds, "seeds")
+	return sub
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/faisalhardin/employee-payroll-system/pkg/migration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchema(t *testing.T) {
+	_, err := migration.New(nil, Schema(), migration.MigrationsTable)
+	assert.NoError(t, err)
+
+	files, err := fs.Glob(Schema(), "*.sql")
+	assert.NoError(t, err)
+	for _, file := range files {
+		if strings.HasSuffix(file, ".down.sql") {
+			continue
+		}
+		_, err := fs.Stat(Schema(), strings.TrimSuffix(file, ".sql")+".down.sql")
+		assert.NoError(t, err, "%s has no down migration", file)
+	}
+}
+
+func TestSeeds(t *testing.T) {
+	_, err := migration.New(nil, Seeds(), migration.SeedsTable)
+	assert.NoError(t, err)
+
+	files, err := fs.Glob(Seeds(), "*.sql")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, files)
+}
@@ -0,0 +1,103 @@
+-- Generate 100 employee users, all signing in with the dev password "password123"
+INSERT INTO mst_user (username, password_hash, role, salary, created_by, id_mst_tenant) VALUES
+('employee_001', '$2a$10$awIG47RC95EgDrRKy.0GY.moA8ma2QM3o7URBI4wJN/GoVwuyLbkO', 'employee', 45000, 'admin', 1),
+('employee_002', '$2a$10$OP2Cmor50wXqviS7hDAXs.rDSvrqSyJE7DlKgQjPgPnZeRiGAlQMy', 'employee', 47500, 'admin', 1),
+('employee_003', '$2a$10$ou4d8vcUhr8iu7m4m5OQnODAmbujh1Oqyf2MtRr0mgVZPIA3KMH2W', 'employee', 52000, 'admin', 1),
+('employee_004', '$2a$10$Y7ckpVK7NYynLvWR5hn0buSdiOEOxNFh/guod6poSI0mwGkTF9Hxm', 'employee', 48000, 'admin', 1),
+('employee_005', '$2a$10$LvKAmGEhBaCvXSeoMEhlseMCCyVg9B3F6cAhDhdz7S7pKiOTtp84.', 'employee', 51000, 'admin', 1),
+('employee_006', '$2a$10$liERDa3RxpYY.l.J7sVl7ueLSri4cV8888wcRqncgEZr5LY1z.qBK', 'employee', 46500, 'admin', 1),
+('employee_007', '$2a$10$G9397zyIWzc9fIr8jDb0K.hBedNRgSf6PUFT44UETblrxjfVvElwa', 'employee', 49000, 'admin', 1),
+('employee_008', '$2a$10$OuyUkXlpagmQACnfg9P0k.yT6Mo.lzlsfpxTgCsAGTa9bQ2aB.xQ.', 'employee', 53500, 'admin', 1),
+('employee_009', '$2a$10$usDWlfc5hpaQgqbOtoki6eLX.Elg1XeX9bxaCuaZdVm0dDbzmId7i', 'employee', 44500, 'admin', 1),
+('employee_010', '$2a$10$EjU/dIF1ykVmiIbyxn6C.u16lAVU2xbBxJiVEma6m5y.0Dbqguesa', 'employee', 50000, 'admin', 1),
+('employee_011', '$2a$10$KEmuwUKV6gJX7k4V78mZIO3UJCjrkSL6HYmCCmWOCny0N5waqefoW', 'employee', 47000, 'admin', 1),
+('employee_012', '$2a$10$s39OsToNLQVwU8A426aY2O2xwCjpg4ePjEV3jcZAmlBuYEARQpvHa', 'employee', 48500, 'admin', 1),
+('employee_013', '$2a$10$WVxk2NM.wgLcOJKdAVXPze7pPamSrSy3rQYH.yL37hKSuxXj2AHo.', 'employee', 52500, 'admin', 1),
+('employee_014', '$2a$10$aeM85Toc.ROywLSBMA4YdeNHCpTiLs6cg.GNRvXFdeP5vwmEPZGYu', 'employee', 45500, 'admin', 1),
+('employee_015', '$2a$10$gxFRlNlQw150Z3.OhQw6huwdSNSjZyNg5U9YO3laHrwzXGRUp28me', 'employee', 49500, 'admin', 1),
+('employee_016', '$2a$10$U0gNqdCgYfUPYDlkQjjjdeuknrENjwrHr8vqbWEqtM73j/sjVmlmO', 'employee', 51500, 'admin', 1),
+('employee_017', '$2a$10$SHiUIIRLZazkcBSB1Jvwc.BYenI1oDlv1AiDVUb3eucrBndNtONY6', 'employee', 46000, 'admin', 1),
+('employee_018', '$2a$10$9.brwSFXTRuP7gueZrMAmuaCDPrbZRhtrNBJEA/ul0S2rPkpMnmIm', 'employee', 48000, 'admin', 1),
+('employee_019', '$2a$10$fcS9OUBOgsVBIu0lefgqbO/EL1V1Vt3BbqpYzHKXR7.CAWjub1/8u', 'employee', 50500, 'admin', 1),
+('employee_020', '$2a$10$XmJrDKMoPZHXOLGzrToOjOKGQtxWGwrj6VQBV/ASWIBsk5rcxdMye', 'employee', 47500, 'admin', 1),
+('employee_021', '$2a$10$HwscE1DjoTLVgOejwtXr5eG3m3DhWDOCFgajJvZTZPswvW5lL5YI6', 'employee', 49000, 'admin', 1),
+('employee_022', '$2a$10$n9/7nMqtHLkkwPtrJEugz.AcX9d2c7L7p4naUrS8ttBRo6ibz3YMW', 'employee', 52000, 'admin', 1),
+('employee_023', '$2a$10$9vM8oM7kOtD6.SEkQXW79e7FmHfzdsNRX4nwxbaxUp5bA/OCKoynu', 'employee', 45000, 'admin', 1),
+('employee_024', '$2a$10$6lmuLYxlf9ycERD1rfVz7.NbWqn.xEO1pcPTt4l6ZUx5imuAAxkqO', 'employee', 48500, 'admin', 1),
+('employee_025', '$2a$10$.HhyxhnWCg3pBujntVUM3.L8MuIdJexazBWRzyxS3P75NNmvxiG0u', 'employee', 51000, 'admin', 1),
+('employee_026', '$2a$10$Y6sdMqj5/lK2gfcap7j9l.wIRgUKzYzSS0jjIrWdKFfkR8djHtxQq', 'employee', 46500, 'admin', 1),
+('employee_027', '$2a$10$V97Jlx8Ch6elsbMcWIVMb.w9ba85nnzkn7YJJhMAzfjtNaOnyTDPK', 'employee', 49500, 'admin', 1),
+('employee_028', '$2a$10$qLLbbnU0Q5IoX.PBWd.xMO5xkTRkJ6b9yux.fz1YDItt9aI9I0TjK', 'employee', 53000, 'admin', 1),
+('employee_029', '$2a$10$ro20HGYIN5yJaRUWWeGQguSpMdgHWnCaBvYCsSBYUEr1BBL24b5w6', 'employee', 44000, 'admin', 1),
+('employee_030', '$2a$10$BPoNuUwKONQnZhYeOhDtEOH.fpIDyElWxNf9nMIyDGsNaV52AvUCi', 'employee', 50000, 'admin', 1),
+('employee_031', '$2a$10$IhuDls4RhyS2AfiEtPJoou0mAHT/dNIR7eVJCwZf6PYca6w4av8bq', 'employee', 47000, 'admin', 1),
+('employee_032', '$2a$10$sUh//hq.P6Sl.xNU68KOGumSjuIuVJ8job6j7OSeaS0uU7rmVVa0O', 'employee', 48000, 'admin', 1),
+('employee_033', '$2a$10$QBOc17v8QHoxQD40M2b7RuV8h/361JRzTA7fFd/U8e8QRZYtGA2LS', 'employee', 52500, 'admin', 1),
+('employee_034', '$2a$10$BeI42QzTn0OO3qkWhG2vsevMwAF8MXWkTNG0VNmsjezNXohg8ItKq', 'employee', 45500, 'admin', 1),
+('employee_035', '$2a$10$bwuXg2Uj8TYsyySTEtwCXODvF10H6lAQ5NjVYU7XBrY9cRiKCQPny', 'employee', 49000, 'admin', 1),
+('employee_036', '$2a$10$/LXzJafHtbPzNuHQbL66k.YH1idgSunF9X/kThnZiHkWb0iG0.XdG', 'employee', 51500, 'admin', 1),
+('employee_037', '$2a$10$2dvfjEuNvm37KilJMOtaq.HV2H3xmWA0UOZW1K5STqOb.sLAGlCkK', 'employee', 46000, 'admin', 1),
+('employee_038', '$2a$10$SN0.E0M3MNteNrW3dLH7cuQxArXzFSX7Qo9zXDcLYp6nkZtv/r2nS', 'employee', 48500, 'admin', 1),
+('employee_039', '$2a$10$9MYtH1VzRbducdMX73s8huKw2lc3UdS2C2UMWlFDt2Q8xb8TQ2btG', 'employee', 50500, 'admin', 1),
+('employee_040', '$2a$10$lH3EmjIF468IYhEMC5L0veWmc64oQ7TCBgsgdEuz2i6WvpYfwl4aG', 'employee', 47500, 'admin', 1),
+('employee_041', '$2a$10$8/pWoR3vO3Q2fXA9sCiYmOBX2QUMu2wHjbPS11aXz4VTSLSEbuT.u', 'employee', 49500, 'admin', 1),
+('employee_042', '$2a$10$40sLCBGHKdf/BJQr96DQB.n4LKmlRqnfyka8WeBCEDVSWSS0DzTSa', 'employee', 52000, 'admin', 1),
+('employee_043', '$2a$10$iqqz2qRsHU54zuNYzlJl3uBxKl/DmXuuEfKYBA6Hk3.rOaLwU9lmO', 'employee', 45000, 'admin', 1),
+('employee_044', '$2a$10$NCrjdxJtViUt.VZPPaPrnOrfGubj3w5TAYqcBbbpMszH9UeB98x5C', 'employee', 48000, 'admin', 1),
+('employee_045', '$2a$10$bXtrRP9vrkce0uPvQmwLLOG6t652/Vj/KiQFZ4SY509ZXrgtuCGWK', 'employee', 51000, 'admin', 1),
+('employee_046', '$2a$10$mtGSsOwlpJvlrnf4aChD6eVP8hSZRmAvRSAq3vRO4iqezwlUEIxNS', 'employee', 46500, 'admin', 1),
+('employee_047', '$2a$10$/0Y8krpv6VWELYC15iF14.RA78WoXsVzFrSubgW78ud2BRG6PIxKO', 'employee', 49000, 'admin', 1),
+('employee_048', '$2a$10$Z3LHtY7KnhkMemLJAajzEO/2t/q0FMQymPe69oXWl0CD9dj4i2K32', 'employee', 53500, 'admin', 1),
+('employee_049', '$2a$10$cf0dNeKolikr8R74meFZue4ha/MW7UMiIZE52rQjj/SDRCunAMAtq', 'employee', 44500, 'admin', 1),
+('employee_050', '$2a$10$rknC4bVMetv0ZCSzSHkkremZX1U2pLUc9tOqn4PKgGUgydv3k4Ywa', 'employee', 50000, 'admin', 1),
+('employee_051', '$2a$10$fuOa71byGZVqMdYCSnzKIOmfr33H6gYM.OqF6E/TomVcyIqgYUUru', 'employee', 47000, 'admin', 1),
+('employee_052', '$2a$10$6UDrC2HtoexHmuZlMqorXuDdkgZFf1UPCtFym33/mSJlkO6pZmnG2', 'employee', 48500, 'admin', 1),
+('employee_053', '$2a$10$DhbmSRdSY1v2uh8W14cFu.c6OGYWbHMemjPcQXvg9wTrEVkE89Xaa', 'employee', 52500, 'admin', 1),
+('employee_054', '$2a$10$0WEDrvKi3qbc5RhwZhxo0.L1d4ECsMVxbRr2U./SzfQI7Br/0ku7i', 'employee', 45500, 'admin', 1),
+('employee_055', '$2a$10$RpcY3Cur1NJEXDrN87KFuOM2ijxSAGx18zLAT5zvz19x96x/tr9UC', 'employee', 49500, 'admin', 1),
+('employee_056', '$2a$10$Ap0EfFQTRCzySP1aDwHfsuKeM.p/BDlVxsSEjRzBn/sdIr6NQYtlq', 'employee', 51500, 'admin', 1),
+('employee_057', '$2a$10$lFkZ.vheX5BmtitdHS46QOxus7QlzTM7G24r47Q7FP5QdLDipBPvS', 'employee', 46000, 'admin', 1),
+('employee_058', '$2a$10$D0H0Quv7U9tBUjtiPpy9IuvJ9y1R.S5G5TJZjf6lgAyfImwJwuTNG', 'employee', 48000, 'admin', 1),
+('employee_059', '$2a$10$4zG/zqtO6wuMXT16FrYdoeNBDoabr2uYFf9jy4Gv8HJ1WGE3cGjVm', 'employee', 50500, 'admin', 1),
+('employee_060', '$2a$10$gPveg.u3OQb/kXBsL61ViewDo4.H.nzemNjzRjVorT/9H8eKYtWsu', 'employee', 47500, 'admin', 1),
+('employee_061', '$2a$10$qWYwSEc1BK8vR2M5nNoas.zHZ1XwVHfFhykVkrRlE.SYdBysoIdh2', 'employee', 49000, 'admin', 1),
+('employee_062', '$2a$10$EpzQ5RJiDcVZMKX1BMwYAOODmG0geEmxj/bpD5dyiJ9jGER0dw5xi', 'employee', 52000, 'admin', 1),
+('employee_063', '$2a$10$fJ08UqCVJ3gVDCIsOfCyLuDICwDQzCz2XCWFaoS3YpCrjyeNTIjFC', 'employee', 45000, 'admin', 1),
+('employee_064', '$2a$10$4wVrlbKTyldBfL9.qTe6ZO57qUFN1BozkTMe4VCLwL5hV9aJ2KTG2', 'employee', 48500, 'admin', 1),
+('employee_065', '$2a$10$d2hfMp4P8ESFzYYygBJyVeywqt11e8p9kXWPs2Sauw4ZkwzPwZ06i', 'employee', 51000, 'admin', 1),
+('employee_066', '$2a$10$g1SjaJz/jhoMeLhsApRR8.4uBu2dnkj8J5xWJPFYG7E/pUDkwzAAC', 'employee', 46500, 'admin', 1),
+('employee_067', '$2a$10$GWiprM4sKHZYYmpfRg18MeoaxJ1bqBZcvOyxHl/pyVu9mwU9UMJq6', 'employee', 49500, 'admin', 1),
+('employee_068', '$2a$10$brtMu4YtSflhntTKf2ak1.0lHfT3JJwkuv1wYm4bYGDG3aHG3RtvW', 'employee', 53000, 'admin', 1),
+('employee_069', '$2a$10$SCoMx.lvHLQxY01qL5AbTeqwB2kWyVxWcPtdEFgb38XOaaCFgt4iq', 'employee', 44000, 'admin', 1),
+('employee_070', '$2a$10$QEM5J6fVAUCs1MPL.fAQzOXObn3gQWiCDd08L6S/xO04sLpgbQNLi', 'employee', 50000, 'admin', 1),
+('employee_071', '$2a$10$JagrmNB5nvhLd4TKmmHe4.NSVv1DuBdBrC0v6dI/PUeWI1JRsmdLy', 'employee', 47000, 'admin', 1),
+('employee_072', '$2a$10$o2ZgRUIJUdX30yJbO2Bt6eGZlIlC.VYgcTBHQPImM4KHhXWenFC/2', 'employee', 48000, 'admin', 1),
+('employee_073', '$2a$10$ODPjQstX1isGvBXceoRZpeSMhiM5Fg48g5Gc9d035sefaIhPF47Ge', 'employee', 52500, 'admin', 1),
+('employee_074', '$2a$10$Nu4cSebnzJyMlpV8k1Nr2O4tEPJUBM9pzqvyFGwZGGcFmiYy/pUpq', 'employee', 45500, 'admin', 1),
+('employee_075', '$2a$10$AGU6BMAV78Pi.EvV1JCU/OKzIY5LP4fMv5AJ8mc7kA2r6vGh/nTBW', 'employee', 49000, 'admin', 1),
+('employee_076', '$2a$10$Rt6EJu.PSappsthE5RBdTu7CsWfiUdZLrun1EHu9jMTQZnMjHf0Qe', 'employee', 51500, 'admin', 1),
+('employee_077', '$2a$10$E3Wr4ztH9LuKtxfC6oS1Ku4eGkfIc5JsIvvFso6kjzsebaOqHyfnq', 'employee', 46000, 'admin', 1),
+('employee_078', '$2a$10$anwlR/WZsWQTTGxDVd3GlOhs56dv68p26RMRYLVmMiW1oJsOa91p.', 'employee', 48500, 'admin', 1),
+('employee_079', '$2a$10$de3QIrmYbnbrij0jbvgyiOaHNsvkH4ocX161F2tL9P7iGBfu25z/K', 'employee', 50500, 'admin', 1),
+('employee_080', '$2a$10$rdFQ6G3o8TRj0LPSeR.0Vuyk7CBElMkkR7FMzhAZX3ysovZs4MumS', 'employee', 47500, 'admin', 1),
+('employee_081', '$2a$10$P2kCGPaitUi3hNTGZadRPuxqGZzC2KmizrBk2LVYm2wrZC5DdtSYO', 'employee', 49500, 'admin', 1),
+('employee_082', '$2a$10$KVLNMSVXvsiZmTntYtwn0et.U2vnhNJmL82UYiJmmmUT49QrKK2wm', 'employee', 52000, 'admin', 1),
+('employee_083', '$2a$10$ZgllvHxCJ4rP7saPbAJCWuDjApnY.hGTbtgjpO0G1kOlwed.VhdOW', 'employee', 45000, 'admin', 1),
+('employee_084', '$2a$10$zidHWgDP4xW5fHE57OxkSOgE2lPgO46ks9TCdYNajlz55MMaGGlsC', 'employee', 48000, 'admin', 1),
+('employee_085', '$2a$10$e3NTxcAFVWggp/tQ6OD/W.9IjfOMPruVC6eY5RZaqr4IxJ16P/6LG', 'employee', 51000, 'admin', 1),
+('employee_086', '$2a$10$E3S0c6S7BeWkWt7JleCdDeROGnnsKrScbFT4CVEG0TZfE//PTXQ5W', 'employee', 46500, 'admin', 1),
+('employee_087', '$2a$10$YBmIpnFNPDEp7FmieuNHp.YI9U3fPsCFPzy.sgep9owMmkTXW9.oS', 'employee', 49000, 'admin', 1),
+('employee_088', '$2a$10$0g1xPjZt74bL9ArivzBNBOA.FQuVAq/6hfrVQdrFAwTmw/mADDYf.', 'employee', 53500, 'admin', 1),
+('employee_089', '$2a$10$LiYHtF2ifq58KSW73hJ8Z.lrrmwF1YEZJYePfLSXVIufmSbpmW01.', 'employee', 44500, 'admin', 1),
+('employee_090', '$2a$10$rzWIvgwug4s0tPWkMaq8jO4bZy.RG7CX8EFBozqEDHRkH7xIvjhjK', 'employee', 50000, 'admin', 1),
+('employee_091', '$2a$10$yu.JMBNGLCKbdq894Q9i7uh1w4hzBkPcA/bWwcZoNwz3qEvib22u2', 'employee', 47000, 'admin', 1),
+('employee_092', '$2a$10$bmifQ36EdQUKS4DS/OpFBeEBHqpLx5usz9WiZXVUgZ.s28IdlRmbS', 'employee', 48500, 'admin', 1),
+('employee_093', '$2a$10$sqoIeFTLNP/snYrniMu/xOidVexA6U4sykT3rMVcA7zfMpKj60IJW', 'employee', 52500, 'admin', 1),
+('employee_094', '$2a$10$eA4KBLHaiaoCkQ6BqRxEF.65sTCsJpTIvhCM5FLpTag/ebe7dgDie', 'employee', 45500, 'admin', 1),
+('employee_095', '$2a$10$RNFSzFbdsf4tGkRBlI5PDO9gQG0p4vwqX2Cp.uyo7QoxEuAZ8uLYa', 'employee', 49500, 'admin', 1),
+('employee_096', '$2a$10$ICA5z9YQmLRdhoXBUnyhX..GMVfAHUKRhSK5BFTxQe1E36xR623Z.', 'employee', 51500, 'admin', 1),
+('employee_097', '$2a$10$iVow8t0.NgzHFULPD/7bOussjxrKKsez7ID/PLm2wh58IY4Yg/EwO', 'employee', 46000, 'admin', 1),
+('employee_098', '$2a$10$HqyZTQqtC6HaD/qgLDdIuuARyhvB/WRg6CidBPPxSgL/tg.TF2zpS', 'employee', 48000, 'admin', 1),
+('employee_099', '$2a$10$y01ly0Uh.ZXz0r0tI.m32ebWvCM5InH0Zi.NzPhiKLOAMVoEstoam', 'employee', 50500, 'admin', 1),
+('employee_100', '$2a$10$yoYhTYr48Qj0.4.iJpsobeVRXVZ8tNQywB9LN7GpZoWUr58ODvXCm', 'employee', 47500, 'admin', 1)
+ON CONFLICT (username) DO NOTHING;
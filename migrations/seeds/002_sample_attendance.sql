@@ -0,0 +1,13 @@
+-- Attendance of the sample employees for the 2025-06-20 - 2025-07-20 period
+INSERT INTO mst_attendance (id_mst_user, id_mst_payroll_period, attendance_date, created_by, id_mst_tenant) VALUES
+(2, NULL, '2025-06-20', 1, 1), (2, NULL, '2025-06-21', 1, 1), (2, NULL, '2025-06-22', 1, 1), (2, NULL, '2025-06-23', 1, 1), (2, NULL, '2025-06-24', 1, 1), (2, NULL, '2025-06-25', 1, 1), (2, NULL, '2025-06-26', 1, 1), (2, NULL, '2025-06-27', 1, 1), (2, NULL, '2025-06-28', 1, 1), (2, NULL, '2025-06-29', 1, 1), (2, NULL, '2025-06-30', 1, 1), (2, NULL, '2025-07-01', 1, 1), (2, NULL, '2025-07-02', 1, 1), (2, NULL, '2025-07-03', 1, 1), (2, NULL, '2025-07-04', 1, 1), (2, NULL, '2025-07-05', 1, 1), (2, NULL, '2025-07-06', 1, 1), (2, NULL, '2025-07-07', 1, 1), (2, NULL, '2025-07-08', 1, 1), (2, NULL, '2025-07-09', 1, 1), (2, NULL, '2025-07-10', 1, 1), (2, NULL, '2025-07-11', 1, 1), (2, NULL, '2025-07-12', 1, 1), (2, NULL, '2025-07-13', 1, 1), (2, NULL, '2025-07-14', 1, 1), (2, NULL, '2025-07-15', 1, 1), (2, NULL, '2025-07-16', 1, 1), (2, NULL, '2025-07-17', 1, 1), (2, NULL, '2025-07-18', 1, 1), (2, NULL, '2025-07-19', 1, 1), (2, NULL, '2025-07-20', 1, 1),
+(3, NULL, '2025-06-20', 1, 1), (3, NULL, '2025-06-21', 1, 1), (3, NULL, '2025-06-22', 1, 1), (3, NULL, '2025-06-23', 1, 1), (3, NULL, '2025-06-24', 1, 1), (3, NULL, '2025-06-25', 1, 1), (3, NULL, '2025-06-26', 1, 1), (3, NULL, '2025-06-27', 1, 1), (3, NULL, '2025-06-28', 1, 1), (3, NULL, '2025-06-29', 1, 1), (3, NULL, '2025-06-30', 1, 1), (3, NULL, '2025-07-01', 1, 1), (3, NULL, '2025-07-02', 1, 1), (3, NULL, '2025-07-03', 1, 1), (3, NULL, '2025-07-04', 1, 1), (3, NULL, '2025-07-05', 1, 1), (3, NULL, '2025-07-06', 1, 1), (3, NULL, '2025-07-07', 1, 1), (3, NULL, '2025-07-08', 1, 1), (3, NULL, '2025-07-09', 1, 1), (3, NULL, '2025-07-10', 1, 1), (3, NULL, '2025-07-11', 1, 1), (3, NULL, '2025-07-12', 1, 1), (3, NULL, '2025-07-13', 1, 1), (3, NULL, '2025-07-14', 1, 1), (3, NULL, '2025-07-15', 1, 1), (3, NULL, '2025-07-16', 1, 1), (3, NULL, '2025-07-17', 1, 1), (3, NULL, '2025-07-18', 1, 1), (3, NULL, '2025-07-19', 1, 1), (3, NULL, '2025-07-20', 1, 1),
+(4, NULL, '2025-06-20', 1, 1), (4, NULL, '2025-06-21', 1, 1), (4, NULL, '2025-06-22', 1, 1), (4, NULL, '2025-06-23', 1, 1), (4, NULL, '2025-06-24', 1, 1), (4, NULL, '2025-06-25', 1, 1), (4, NULL, '2025-06-26', 1, 1), (4, NULL, '2025-06-27', 1, 1), (4, NULL, '2025-06-28', 1, 1), (4, NULL, '2025-06-29', 1, 1), (4, NULL, '2025-06-30', 1, 1), (4, NULL, '2025-07-01', 1, 1), (4, NULL, '2025-07-02', 1, 1), (4, NULL, '2025-07-03', 1, 1), (4, NULL, '2025-07-04', 1, 1), (4, NULL, '2025-07-05', 1, 1), (4, NULL, '2025-07-06', 1, 1), (4, NULL, '2025-07-07', 1, 1), (4, NULL, '2025-07-08', 1, 1), (4, NULL, '2025-07-09', 1, 1), (4, NULL, '2025-07-10', 1, 1), (4, NULL, '2025-07-11', 1, 1), (4, NULL, '2025-07-12', 1, 1), (4, NULL, '2025-07-13', 1, 1), (4, NULL, '2025-07-14', 1, 1), (4, NULL, '2025-07-15', 1, 1), (4, NULL, '2025-07-16', 1, 1), (4, NULL, '2025-07-17', 1, 1), (4, NULL, '2025-07-18', 1, 1), (4, NULL, '2025-07-19', 1, 1), (4, NULL, '2025-07-20', 1, 1),
+(5, NULL, '2025-06-20', 1, 1), (5, NULL, '2025-06-21', 1, 1), (5, NULL, '2025-06-22', 1, 1), (5, NULL, '2025-06-23', 1, 1), (5, NULL, '2025-06-24', 1, 1), (5, NULL, '2025-06-25', 1, 1), (5, NULL, '2025-06-26', 1, 1), (5, NULL, '2025-06-27', 1, 1), (5, NULL, '2025-06-28', 1, 1), (5, NULL, '2025-06-29', 1, 1), (5, NULL, '2025-06-30', 1, 1), (5, NULL, '2025-07-01', 1, 1), (5, NULL, '2025-07-02', 1, 1), (5, NULL, '2025-07-03', 1, 1), (5, NULL, '2025-07-04', 1, 1), (5, NULL, '2025-07-05', 1, 1), (5, NULL, '2025-07-06', 1, 1), (5, NULL, '2025-07-07', 1, 1), (5, NULL, '2025-07-08', 1, 1), (5, NULL, '2025-07-09', 1, 1), (5, NULL, '2025-07-10', 1, 1), (5, NULL, '2025-07-11', 1, 1), (5, NULL, '2025-07-12', 1, 1), (5, NULL, '2025-07-13', 1, 1), (5, NULL, '2025-07-14', 1, 1), (5, NULL, '2025-07-15', 1, 1), (5, NULL, '2025-07-16', 1, 1), (5, NULL, '2025-07-17', 1, 1), (5, NULL, '2025-07-18', 1, 1), (5, NULL, '2025-07-19', 1, 1), (5, NULL, '2025-07-20', 1, 1),
+(6, NULL, '2025-06-20', 1, 1), (6, NULL, '2025-06-21', 1, 1), (6, NULL, '2025-06-22', 1, 1), (6, NULL, '2025-06-23', 1, 1), (6, NULL, '2025-06-24', 1, 1), (6, NULL, '2025-06-25', 1, 1), (6, NULL, '2025-06-26', 1, 1), (6, NULL, '2025-06-27', 1, 1), (6, NULL, '2025-06-28', 1, 1), (6, NULL, '2025-06-29', 1, 1), (6, NULL, '2025-06-30', 1, 1), (6, NULL, '2025-07-01', 1, 1), (6, NULL, '2025-07-02', 1, 1), (6, NULL, '2025-07-03', 1, 1), (6, NULL, '2025-07-04', 1, 1), (6, NULL, '2025-07-05', 1, 1), (6, NULL, '2025-07-06', 1, 1), (6, NULL, '2025-07-07', 1, 1), (6, NULL, '2025-07-08', 1, 1), (6, NULL, '2025-07-09', 1, 1), (6, NULL, '2025-07-10', 1, 1), (6, NULL, '2025-07-11', 1, 1), (6, NULL, '2025-07-12', 1, 1), (6, NULL, '2025-07-13', 1, 1), (6, NULL, '2025-07-14', 1, 1), (6, NULL, '2025-07-15', 1, 1), (6, NULL, '2025-07-16', 1, 1), (6, NULL, '2025-07-17', 1, 1), (6, NULL, '2025-07-18', 1, 1), (6, NULL, '2025-07-19', 1, 1), (6, NULL, '2025-07-20', 1, 1),
+(7, NULL, '2025-06-20', 1, 1), (7, NULL, '2025-06-21', 1, 1), (7, NULL, '2025-06-22', 1, 1), (7, NULL, '2025-06-23', 1, 1), (7, NULL, '2025-06-24', 1, 1), (7, NULL, '2025-06-25', 1, 1), (7, NULL, '2025-06-26', 1, 1), (7, NULL, '2025-06-27', 1, 1), (7, NULL, '2025-06-28', 1, 1), (7, NULL, '2025-06-29', 1, 1), (7, NULL, '2025-06-30', 1, 1), (7, NULL, '2025-07-01', 1, 1), (7, NULL, '2025-07-02', 1, 1), (7, NULL, '2025-07-03', 1, 1), (7, NULL, '2025-07-04', 1, 1), (7, NULL, '2025-07-05', 1, 1), (7, NULL, '2025-07-06', 1, 1), (7, NULL, '2025-07-07', 1, 1), (7, NULL, '2025-07-08', 1, 1), (7, NULL, '2025-07-09', 1, 1), (7, NULL, '2025-07-10', 1, 1), (7, NULL, '2025-07-11', 1, 1), (7, NULL, '2025-07-12', 1, 1), (7, NULL, '2025-07-13', 1, 1), (7, NULL, '2025-07-14', 1, 1), (7, NULL, '2025-07-15', 1, 1), (7, NULL, '2025-07-16', 1, 1), (7, NULL, '2025-07-17', 1, 1), (7, NULL, '2025-07-18', 1, 1), (7, NULL, '2025-07-19', 1, 1), (7, NULL, '2025-07-20', 1, 1),
+(8, NULL, '2025-06-20', 1, 1), (8, NULL, '2025-06-21', 1, 1), (8, NULL, '2025-06-22', 1, 1), (8, NULL, '2025-06-23', 1, 1), (8, NULL, '2025-06-24', 1, 1), (8, NULL, '2025-06-25', 1, 1), (8, NULL, '2025-06-26', 1, 1), (8, NULL, '2025-06-27', 1, 1), (8, NULL, '2025-06-28', 1, 1), (8, NULL, '2025-06-29', 1, 1), (8, NULL, '2025-06-30', 1, 1), (8, NULL, '2025-07-01', 1, 1), (8, NULL, '2025-07-02', 1, 1), (8, NULL, '2025-07-03', 1, 1), (8, NULL, '2025-07-04', 1, 1), (8, NULL, '2025-07-05', 1, 1), (8, NULL, '2025-07-06', 1, 1), (8, NULL, '2025-07-07', 1, 1), (8, NULL, '2025-07-08', 1, 1), (8, NULL, '2025-07-09', 1, 1), (8, NULL, '2025-07-10', 1, 1), (8, NULL, '2025-07-11', 1, 1), (8, NULL, '2025-07-12', 1, 1), (8, NULL, '2025-07-13', 1, 1), (8, NULL, '2025-07-14', 1, 1), (8, NULL, '2025-07-15', 1, 1), (8, NULL, '2025-07-16', 1, 1), (8, NULL, '2025-07-17', 1, 1), (8, NULL, '2025-07-18', 1, 1), (8, NULL, '2025-07-19', 1, 1), (8, NULL, '2025-07-20', 1, 1),
+(9, NULL, '2025-06-20', 1, 1), (9, NULL, '2025-06-21', 1, 1), (9, NULL, '2025-06-22', 1, 1), (9, NULL, '2025-06-23', 1, 1), (9, NULL, '2025-06-24', 1, 1), (9, NULL, '2025-06-25', 1, 1), (9, NULL, '2025-06-26', 1, 1), (9, NULL, '2025-06-27', 1, 1), (9, NULL, '2025-06-28', 1, 1), (9, NULL, '2025-06-29', 1, 1), (9, NULL, '2025-06-30', 1, 1), (9, NULL, '2025-07-01', 1, 1), (9, NULL, '2025-07-02', 1, 1), (9, NULL, '2025-07-03', 1, 1), (9, NULL, '2025-07-04', 1, 1), (9, NULL, '2025-07-05', 1, 1), (9, NULL, '2025-07-06', 1, 1), (9, NULL, '2025-07-07', 1, 1), (9, NULL, '2025-07-08', 1, 1), (9, NULL, '2025-07-09', 1, 1), (9, NULL, '2025-07-10', 1, 1), (9, NULL, '2025-07-11', 1, 1), (9, NULL, '2025-07-12', 1, 1), (9, NULL, '2025-07-13', 1, 1), (9, NULL, '2025-07-14', 1, 1), (9, NULL, '2025-07-15', 1, 1), (9, NULL, '2025-07-16', 1, 1), (9, NULL, '2025-07-17', 1, 1), (9, NULL, '2025-07-18', 1, 1), (9, NULL, '2025-07-19', 1, 1), (9, NULL, '2025-07-20', 1, 1),
+(10, NULL, '2025-06-20', 1, 1), (10, NULL, '2025-06-21', 1, 1), (10, NULL, '2025-06-22', 1, 1), (10, NULL, '2025-06-23', 1, 1), (10, NULL, '2025-06-24', 1, 1), (10, NULL, '2025-06-25', 1, 1), (10, NULL, '2025-06-26', 1, 1), (10, NULL, '2025-06-27', 1, 1), (10, NULL, '2025-06-28', 1, 1), (10, NULL, '2025-06-29', 1, 1), (10, NULL, '2025-06-30', 1, 1), (10, NULL, '2025-07-01', 1, 1), (10, NULL, '2025-07-02', 1, 1), (10, NULL, '2025-07-03', 1, 1), (10, NULL, '2025-07-04', 1, 1), (10, NULL, '2025-07-05', 1, 1), (10, NULL, '2025-07-06', 1, 1), (10, NULL, '2025-07-07', 1, 1), (10, NULL, '2025-07-08', 1, 1), (10, NULL, '2025-07-09', 1, 1), (10, NULL, '2025-07-10', 1, 1), (10, NULL, '2025-07-11', 1, 1), (10, NULL, '2025-07-12', 1, 1), (10, NULL, '2025-07-13', 1, 1), (10, NULL, '2025-07-14', 1, 1), (10, NULL, '2025-07-15', 1, 1), (10, NULL, '2025-07-16', 1, 1), (10, NULL, '2025-07-17', 1, 1), (10, NULL, '2025-07-18', 1, 1), (10, NULL, '2025-07-19', 1, 1), (10, NULL, '2025-07-20', 1, 1),
+(11, NULL, '2025-06-20', 1, 1), (11, NULL, '2025-06-21', 1, 1), (11, NULL, '2025-06-22', 1, 1), (11, NULL, '2025-06-23', 1, 1), (11, NULL, '2025-06-24', 1, 1), (11, NULL, '2025-06-25', 1, 1), (11, NULL, '2025-06-26', 1, 1), (11, NULL, '2025-06-27', 1, 1), (11, NULL, '2025-06-28', 1, 1), (11, NULL, '2025-06-29', 1, 1), (11, NULL, '2025-06-30', 1, 1), (11, NULL, '2025-07-01', 1, 1), (11, NULL, '2025-07-02', 1, 1), (11, NULL, '2025-07-03', 1, 1), (11, NULL, '2025-07-04', 1, 1), (11, NULL, '2025-07-05', 1, 1), (11, NULL, '2025-07-06', 1, 1), (11, NULL, '2025-07-07', 1, 1), (11, NULL, '2025-07-08', 1, 1), (11, NULL, '2025-07-09', 1, 1), (11, NULL, '2025-07-10', 1, 1), (11, NULL, '2025-07-11', 1, 1), (11, NULL, '2025-07-12', 1, 1), (11, NULL, '2025-07-13', 1, 1), (11, NULL, '2025-07-14', 1, 1), (11, NULL, '2025-07-15', 1, 1), (11, NULL, '2025-07-16', 1, 1), (11, NULL, '2025-07-17', 1, 1), (11, NULL, '2025-07-18', 1, 1), (11, NULL, '2025-07-19', 1, 1), (11, NULL, '2025-07-20', 1, 1),
+(101, NULL, '2025-06-20', 1, 1), (101, NULL, '2025-06-21', 1, 1), (101, NULL, '2025-06-22', 1, 1), (101, NULL, '2025-06-23', 1, 1), (101, NULL, '2025-06-24', 1, 1), (101, NULL, '2025-06-25', 1, 1), (101, NULL, '2025-06-26', 1, 1), (101, NULL, '2025-06-27', 1, 1), (101, NULL, '2025-06-28', 1, 1), (101, NULL, '2025-06-29', 1, 1), (101, NULL, '2025-06-30', 1, 1), (101, NULL, '2025-07-01', 1, 1), (101, NULL, '2025-07-02', 1, 1), (101, NULL, '2025-07-03', 1, 1), (101, NULL, '2025-07-04', 1, 1), (101, NULL, '2025-07-05', 1, 1), (101, NULL, '2025-07-06', 1, 1), (101, NULL, '2025-07-07', 1, 1), (101, NULL, '2025-07-08', 1, 1), (101, NULL, '2025-07-09', 1, 1), (101, NULL, '2025-07-10', 1, 1), (101, NULL, '2025-07-11', 1, 1), (101, NULL, '2025-07-12', 1, 1), (101, NULL, '2025-07-13', 1, 1), (101, NULL, '2025-07-14', 1, 1), (101, NULL, '2025-07-15', 1, 1), (101, NULL, '2025-07-16', 1, 1), (101, NULL, '2025-07-17', 1, 1), (101, NULL, '2025-07-18', 1, 1), (101, NULL, '2025-07-19', 1, 1), (101, NULL, '2025-07-20', 1, 1);
@@ -0,0 +1,101 @@
+-- Insert 100 reimbursement records with various statuses, amounts, and descriptions
+INSERT INTO trx_reimbursement (id_mst_user, id_mst_payroll_period, status, amount, description, created_by, created_at, id_mst_tenant) VALUES
+(2, NULL, 'pending', 150000, 'Transportation allowance for client meeting', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(3, NULL, 'pending', 75000, 'Lunch expense during overtime work', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(4, NULL, 'pending', 200000, 'Personal shopping expenses', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(5, NULL, 'pending', 120000, 'Taxi fare for late night work', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(6, NULL, 'pending', 85000, 'Office supplies purchase', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(7, NULL, 'pending', 300000, 'Hotel accommodation for business trip', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(8, NULL, 'pending', 45000, 'Parking fee at client office', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(9, NULL, 'pending', 180000, 'Meal allowance for team meeting', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(10, NULL, 'pending', 95000, 'Internet reimbursement for remote work', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(11, NULL, 'pending', 250000, 'Entertainment expenses not work-related', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(12, NULL, 'pending', 110000, 'Fuel reimbursement for company car', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(13, NULL, 'pending', 65000, 'Coffee and snacks for client presentation', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(14, NULL, 'pending', 175000, 'Training course registration fee', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(15, NULL, 'pending', 80000, 'Mobile phone bill reimbursement', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(16, NULL, 'pending', 220000, 'Flight ticket for business conference', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(17, NULL, 'pending', 55000, 'Stationery and printing costs', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(18, NULL, 'pending', 400000, 'Luxury dinner not pending', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(19, NULL, 'pending', 135000, 'Medical checkup reimbursement', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(20, NULL, 'pending', 90000, 'Team building activity expenses', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(21, NULL, 'pending', 160000, 'Software license purchase', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(22, NULL, 'pending', 70000, 'Courier service for urgent documents', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(23, NULL, 'pending', 195000, 'Equipment maintenance cost', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(24, NULL, 'pending', 85000, 'Workshop attendance fee', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(25, NULL, 'pending', 350000, 'Personal vacation expenses', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(26, NULL, 'pending', 125000, 'Client entertainment dinner', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(27, NULL, 'pending', 60000, 'Office cleaning supplies', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(28, NULL, 'pending', 210000, 'Professional certification exam fee', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(29, NULL, 'pending', 95000, 'Travel insurance for business trip', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(30, NULL, 'pending', 140000, 'Emergency taxi fare during storm', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(31, NULL, 'pending', 75000, 'Working lunch with department head', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(32, NULL, 'pending', 180000, 'Personal gym membership', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(33, NULL, 'pending', 115000, 'Overtime meal allowance', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(34, NULL, 'pending', 85000, 'Client gift purchase', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(35, NULL, 'pending', 165000, 'Conference registration and materials', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(36, NULL, 'pending', 50000, 'Office birthday celebration', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(37, NULL, 'pending', 190000, 'Laptop repair and upgrade', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(38, NULL, 'pending', 80000, 'Project documentation printing', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(39, NULL, 'pending', 300000, 'Personal shopping during business trip', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(40, NULL, 'pending', 130000, 'Team dinner after project completion', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(41, NULL, 'pending', 65000, 'Office pantry supplies', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(42, NULL, 'pending', 205000, 'External training program fee', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(43, NULL, 'pending', 90000, 'Client meeting venue rental', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(44, NULL, 'pending', 155000, 'Business card printing and design', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(45, NULL, 'pending', 70000, 'Welcome lunch for new employee', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(46, NULL, 'pending', 250000, 'Personal medical expenses', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(47, NULL, 'pending', 120000, 'Urgent document delivery service', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(48, NULL, 'pending', 85000, 'Office equipment maintenance', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(49, NULL, 'pending', 175000, 'Industry seminar attendance', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(50, NULL, 'pending', 95000, 'Client presentation materials', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(51, NULL, 'pending', 145000, 'Emergency accommodation during overtime', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(52, NULL, 'pending', 60000, 'Office farewell party expenses', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(53, NULL, 'pending', 320000, 'Personal entertainment expenses', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(54, NULL, 'pending', 110000, 'Professional magazine subscription', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(55, NULL, 'pending', 75000, 'Client lunch meeting', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(56, NULL, 'pending', 185000, 'Computer accessories purchase', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(57, NULL, 'pending', 80000, 'Office decoration for event', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(58, NULL, 'pending', 135000, 'Business trip meal allowance', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(59, NULL, 'pending', 65000, 'Team building snacks and drinks', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(60, NULL, 'pending', 280000, 'Personal clothing expenses', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(61, NULL, 'pending', 125000, 'Client appreciation gift', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(62, NULL, 'pending', 90000, 'Office internet upgrade', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(63, NULL, 'pending', 170000, 'Professional development course', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(64, NULL, 'pending', 55000, 'Office supplies restocking', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(65, NULL, 'pending', 195000, 'Business conference accommodation', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(66, NULL, 'pending', 85000, 'Client meeting refreshments', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(67, NULL, 'pending', 350000, 'Personal vacation hotel booking', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(68, NULL, 'pending', 115000, 'Overtime transportation allowance', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(69, NULL, 'pending', 70000, 'Office celebration cake and decorations', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(70, NULL, 'pending', 160000, 'Professional tool purchase', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(71, NULL, 'pending', 95000, 'Client site visit expenses', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(72, NULL, 'pending', 140000, 'Emergency equipment rental', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(73, NULL, 'pending', 75000, 'Department meeting catering', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(74, NULL, 'pending', 220000, 'Personal fitness equipment', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(75, NULL, 'pending', 105000, 'Business card holder and accessories', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(76, NULL, 'pending', 80000, 'Office plant maintenance', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(77, NULL, 'pending', 185000, 'Industry certification exam', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(78, NULL, 'pending', 65000, 'Client welcome gift basket', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(79, NULL, 'pending', 150000, 'Business trip parking fees', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(80, NULL, 'pending', 90000, 'Office security system upgrade', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(81, NULL, 'pending', 300000, 'Personal electronics purchase', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(82, NULL, 'pending', 125000, 'Team performance bonus dinner', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(83, NULL, 'pending', 70000, 'Office cleaning service', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(84, NULL, 'pending', 175000, 'Professional membership renewal', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(85, NULL, 'pending', 85000, 'Client project celebration', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(86, NULL, 'pending', 135000, 'Business trip meal vouchers', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(87, NULL, 'pending', 60000, 'Office coffee machine maintenance', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(88, NULL, 'pending', 250000, 'Personal travel insurance', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(89, NULL, 'pending', 110000, 'Professional workshop materials', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(90, NULL, 'pending', 95000, 'Client meeting room rental', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(91, NULL, 'pending', 165000, 'Business conference travel allowance', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(92, NULL, 'pending', 75000, 'Office anniversary celebration', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(93, NULL, 'pending', 190000, 'Professional equipment calibration', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(94, NULL, 'pending', 80000, 'Client presentation equipment rental', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(95, NULL, 'pending', 320000, 'Personal luxury item purchase', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(96, NULL, 'pending', 120000, 'Emergency overtime meal allowance', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(97, NULL, 'pending', 65000, 'Office welcome mat and signage', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(98, NULL, 'pending', 180000, 'Professional development seminar', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(99, NULL, 'pending', 90000, 'Client appreciation event', 1, '2025-07-05 00:11:55.586 +0700', 1),
+(101, NULL, 'pending', 155000, 'Business trip communication expenses', 1, '2025-07-05 00:11:55.586 +0700', 1);
@@ -3,7 +3,10 @@ package commonerr
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	ut "github.com/go-playground/universal-translator"
 	validator "github.com/go-playground/validator/v10"
@@ -13,6 +16,10 @@ import (
 const (
 	InternalServerName        = "internal_server_error"
 	InternalServerDescription = "The server is unable to complete your request"
+
+	// StatusClientClosedRequest marks requests the client gave up on before
+	// the response, following nginx
+	StatusClientClosedRequest = 499
 )
 
 var (
@@ -29,6 +36,8 @@ type ErrorMessage struct {
 	ErrorList  []*ErrorFormat `json:"error_list"`
 	Code       int            `json:"code"`
 	Translator ut.Translator  `json:"-"`
+	// Header is added to the response carrying the error
+	Header http.Header `json:"-"`
 }
 
 // Get error byte
@@ -162,6 +171,16 @@ func SetNewUnauthorizedError(errorName, errDesc string) *ErrorMessage {
 	return SetNewError(http.StatusUnauthorized, errorName, errDesc)
 }
 
+// SetNewTimeoutError is function return new error message with gateway timeout error code(504).
+func SetNewTimeoutError() *ErrorMessage {
+	return SetNewError(http.StatusGatewayTimeout, "timeout", "The server took too long to complete your request")
+}
+
+// SetNewClientClosedRequest is function return new error message with client closed request code(499).
+func SetNewClientClosedRequest() *ErrorMessage {
+	return SetNewError(StatusClientClosedRequest, "client_closed_request", "The request was cancelled")
+}
+
 func SetNewTokenExpiredError() *ErrorMessage {
 	return SetNewUnauthorizedError("unauthorized", "expired token")
 }
@@ -169,3 +188,15 @@ func SetNewTokenExpiredError() *ErrorMessage {
 func SetNewUnauthorizedAPICall() *ErrorMessage {
 	return SetNewUnauthorizedError("api call is unauthorized", "api is unauthorized for user")
 }
+
+// SetNewTooManyRequests is function return new error message with too many requests error code(429).
+// The wait is sent in the Retry-After header, rounded up to whole seconds.
+func SetNewTooManyRequests(errorName, errDesc string, retryAfter time.Duration) *ErrorMessage {
+	errorMessage := SetNewError(http.StatusTooManyRequests, errorName, errDesc)
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	errorMessage.Header = http.Header{"Retry-After": []string{strconv.Itoa(seconds)}}
+	return errorMessage
+}
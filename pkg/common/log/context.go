@@ -0,0 +1,77 @@
+package log
+
+import (
+	"context"
+	"sync"
+
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/log/logger"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type scopeKey struct{}
+
+// scope holds the fields every log of a request carries. It is shared by
+// pointer so fields known only deeper in the chain, such as the user, are
+// visible to the middleware that started it.
+type scope struct {
+	mu     sync.RWMutex
+	fields logger.KV
+}
+
+// NewContext starts a logging scope with fields.
+func NewContext(ctx context.Context, fields logger.KV) context.Context {
+	s := &scope{fields: logger.KV{}}
+	for key, value := range fields {
+		s.fields[key] = value
+	}
+	return context.WithValue(ctx, scopeKey{}, s)
+}
+
+// AddFields adds fields to the scope of ctx, it does nothing outside a scope.
+func AddFields(ctx context.Context, fields logger.KV) {
+	s, ok := ctx.Value(scopeKey{}).(*scope)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, value := range fields {
+		s.fields[key] = value
+	}
+}
+
+// Fields returns the fields of the scope of ctx together with extra, and the
+// trace id when ctx is traced.
+func Fields(ctx context.Context, extra logger.KV) logger.KV {
+	fields := logger.KV{}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		fields["trace_id"] = spanContext.TraceID().String()
+	}
+	if s, ok := ctx.Value(scopeKey{}).(*scope); ok {
+		s.mu.RLock()
+		for key, value := range s.fields {
+			fields[key] = value
+		}
+		s.mu.RUnlock()
+	}
+	for key, value := range extra {
+		fields[key] = value
+	}
+	return fields
+}
+
+func DebugCtx(ctx context.Context, msg string, fields logger.KV) {
+	debugLogger.DebugWithFields(msg, Fields(ctx, fields))
+}
+
+func InfoCtx(ctx context.Context, msg string, fields logger.KV) {
+	infoLogger.InfoWithFields(msg, Fields(ctx, fields))
+}
+
+func WarnCtx(ctx context.Context, msg string, fields logger.KV) {
+	warnLogger.WarnWithFields(msg, Fields(ctx, fields))
+}
+
+func ErrorCtx(ctx context.Context, msg string, fields logger.KV) {
+	errorLogger.ErrorWithFields(msg, Fields(ctx, fields))
+}
@@ -0,0 +1,32 @@
+package log
+
+import (
+	"context"
+	"testing"
+
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/log/logger"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestFields(t *testing.T) {
+	ctx := NewContext(context.Background(), logger.KV{"request_id": "req-1"})
+	// fields added deeper in the chain are seen through the parent context
+	AddFields(context.WithValue(ctx, struct{}{}, nil), logger.KV{"user_id": int64(2)})
+
+	assert.Equal(t, logger.KV{"request_id": "req-1", "user_id": int64(2), "route": "/v1/payroll"},
+		Fields(ctx, logger.KV{"route": "/v1/payroll"}))
+	assert.Equal(t, logger.KV{"request_id": "req-1", "user_id": int64(2)}, Fields(ctx, nil))
+
+	AddFields(context.Background(), logger.KV{"user_id": int64(3)})
+	assert.Equal(t, logger.KV{"route": "/v1/payroll"}, Fields(context.Background(), logger.KV{"route": "/v1/payroll"}))
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	tracedCtx := trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+	assert.Equal(t, logger.KV{"request_id": "req-1", "user_id": int64(2), "trace_id": "4bf92f3577b34da6a3ce929d0e0e4736"},
+		Fields(tracedCtx, nil))
+}
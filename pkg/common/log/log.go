@@ -55,3 +55,27 @@ func Infof(format string, v ...interface{}) {
 func InfoWithFields(msg string, fields logger.KV) {
 	infoLogger.InfoWithFields(msg, fields)
 }
+
+func Debug(args ...interface{}) {
+	debugLogger.Debug(args...)
+}
+
+func Debugf(format string, v ...interface{}) {
+	debugLogger.Debugf(format, v...)
+}
+
+func DebugWithFields(msg string, fields logger.KV) {
+	debugLogger.DebugWithFields(msg, fields)
+}
+
+func Warn(args ...interface{}) {
+	warnLogger.Warn(args...)
+}
+
+func Warnf(format string, v ...interface{}) {
+	warnLogger.Warnf(format, v...)
+}
+
+func WarnWithFields(msg string, fields logger.KV) {
+	warnLogger.WarnWithFields(msg, fields)
+}
@@ -3,11 +3,13 @@ package httpwriter
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
 	liblog "github.com/faisalhardin/employee-payroll-system/pkg/common/log"
 	"github.com/faisalhardin/employee-payroll-system/pkg/common/log/logger"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tracing"
 	"github.com/pkg/errors"
 )
 
@@ -20,8 +22,11 @@ type Response struct {
 	Errors []interface{} `json:"errors,omitempty"`
 }
 
+// ErrorMessage is the body of error responses. TraceID is set when the
+// request is traced, so a reported error can be looked up in the traces.
 type ErrorMessage struct {
 	ErrorMessage []*commonerr.ErrorFormat `json:"error_messages"`
+	TraceID      string                   `json:"trace_id,omitempty"`
 }
 
 func WriteJSONAPIData(w http.ResponseWriter, r *http.Request, status int, data interface{}) (int, error) {
@@ -71,6 +76,11 @@ func SetOKWithData(ctx context.Context, w http.ResponseWriter, data interface{})
 	return err
 }
 
+func SetAcceptedWithData(ctx context.Context, w http.ResponseWriter, data interface{}) (err error) {
+	_, err = WriteJSONAPIData(w, nil, http.StatusAccepted, data)
+	return err
+}
+
 func SetOKWithByte(ctx context.Context, w http.ResponseWriter, b []byte) (err error) {
 	_, err = w.Write(b)
 	return
@@ -88,18 +98,37 @@ func SetError(ctx context.Context, w http.ResponseWriter, errValue error) (err e
 	case *commonerr.ErrorMessage:
 		err = SetErrorFormat(ctx, w, errCause)
 	default:
+		// a query stopped by its deadline or by the client leaving
+		if errors.Is(errValue, context.DeadlineExceeded) {
+			err = SetErrorFormat(ctx, w, commonerr.SetNewTimeoutError())
+			postProcess(ctx, errValue)
+			return
+		}
+		if errors.Is(errValue, context.Canceled) {
+			err = SetErrorFormat(ctx, w, commonerr.SetNewClientClosedRequest())
+			liblog.WarnCtx(ctx, "request cancelled", logger.KV{"error": errValue.Error()})
+			return
+		}
+
 		_, err = WriteJSON(w, http.StatusInternalServerError, &ErrorMessage{
 			ErrorMessage: commonerr.SetNewInternalError().ErrorList,
+			TraceID:      tracing.TraceID(ctx),
 		})
-		go postProcess(ctx, errValue)
+		postProcess(ctx, errValue)
 	}
 	return
 }
 
 // SetErrorFormat http
 func SetErrorFormat(ctx context.Context, w http.ResponseWriter, errFormat *commonerr.ErrorMessage) (err error) {
+	for key, values := range errFormat.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
 	_, err = WriteJSON(w, errFormat.Code, &ErrorMessage{
 		ErrorMessage: errFormat.ErrorList,
+		TraceID:      tracing.TraceID(ctx),
 	})
 	return
 }
@@ -120,13 +149,17 @@ func WriteJSON(w http.ResponseWriter, status int, data interface{}) (int, error)
 	return w.Write(b)
 }
 
+// postProcess reports unexpected errors together with the fields of the
+// request's logging scope
 func postProcess(ctx context.Context, err error) {
-
-	logData := logger.KV{}
-	if err != nil {
-
-		// Report errors
-		liblog.ErrorWithFields(err.Error(), logData)
+	if err == nil {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
+	liblog.ErrorCtx(ctx, err.Error(), logger.KV{
+		"error": fmt.Sprintf("%+v", err),
+	})
 }
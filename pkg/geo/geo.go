@@ -0,0 +1,31 @@
+// Package geo measures distances between coordinates on the earth.
+package geo
+
+import "math"
+
+// earthRadiusInMeters is the mean radius of the earth
+const earthRadiusInMeters = 6371008.8
+
+// Point is a position in decimal degrees.
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Distance returns the great-circle distance between a and b in meters
+// using the haversine formula, accurate to well under a meter at office
+// scale.
+func Distance(a, b Point) float64 {
+	lat1 := radians(a.Latitude)
+	lat2 := radians(b.Latitude)
+	dLat := lat2 - lat1
+	dLon := radians(b.Longitude - a.Longitude)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusInMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
@@ -0,0 +1,30 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	monas := Point{Latitude: -6.175392, Longitude: 106.827153}
+	tests := []struct {
+		name  string
+		a     Point
+		b     Point
+		want  float64
+		delta float64
+	}{
+		{name: "same point", a: monas, b: monas, want: 0, delta: 0.001},
+		{name: "one degree of latitude", a: Point{}, b: Point{Latitude: 1}, want: 111195, delta: 1},
+		{name: "bundaran HI", a: monas, b: Point{Latitude: -6.194911, Longitude: 106.823102}, want: 2215, delta: 5},
+		{name: "across the antimeridian", a: Point{Longitude: 179.9995}, b: Point{Longitude: -179.9995}, want: 111, delta: 1},
+		{name: "antipodes", a: Point{}, b: Point{Longitude: 180}, want: 20015115, delta: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, Distance(tt.a, tt.b), tt.delta)
+			assert.InDelta(t, tt.want, Distance(tt.b, tt.a), tt.delta)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Package metrics exposes the service metrics in the prometheus format.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace = "payroll"
+
+	PayrollGenerationStatusSuccess = "success"
+	PayrollGenerationStatusFailed  = "failed"
+
+	// unmatchedRoute labels requests no route matched, so that probing random
+	// paths cannot grow the number of series
+	unmatchedRoute = "unmatched"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	payrollGenerationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "generation_duration_seconds",
+		Help:      "Duration of payroll generations by outcome.",
+		Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 600, 1200},
+	}, []string{"status"})
+
+	payrollGenerationEmployees = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "generation_employees_processed",
+		Help:      "Employees processed by the latest successful payroll generation.",
+	})
+
+	payrollGenerationTakeHome = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "generation_take_home_total",
+		Help:      "Total take-home pay of the latest successful payroll generation.",
+	})
+
+	payrollGenerationLastSuccess = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "generation_last_success_timestamp_seconds",
+		Help:      "Unix time of the latest successful payroll generation.",
+	})
+
+	payrollEmployeesProcessedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "generation_employees_processed_total",
+		Help:      "Employees processed by all successful payroll generations.",
+	})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestsTotal,
+		httpRequestDuration,
+		payrollGenerationDuration,
+		payrollGenerationEmployees,
+		payrollGenerationTakeHome,
+		payrollGenerationLastSuccess,
+		payrollEmployeesProcessedTotal,
+	)
+}
+
+// Handler serves every registered metric.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// RegisterDB exposes the pool stats of db, labelled with name.
+func RegisterDB(name string, db *sql.DB) error {
+	return registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// HTTPHandler counts requests and observes their latency. Requests are
+// labelled with the matched chi route pattern instead of the raw path, so
+// ids in the path do not create a series each.
+func HTTPHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		route := unmatchedRoute
+		if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil && routeCtx.RoutePattern() != "" {
+			route = routeCtx.RoutePattern()
+		}
+
+		labels := prometheus.Labels{
+			"method": r.Method,
+			"route":  route,
+			"status": strconv.Itoa(status),
+		}
+		httpRequestsTotal.With(labels).Inc()
+		httpRequestDuration.With(labels).Observe(time.Since(start).Seconds())
+	})
+}
+
+// ObservePayrollGeneration records one payroll generation. The employee and
+// take-home figures are only kept for successful runs.
+func ObservePayrollGeneration(duration time.Duration, employees int, totalTakeHome int64, failed bool) {
+	if failed {
+		payrollGenerationDuration.WithLabelValues(PayrollGenerationStatusFailed).Observe(duration.Seconds())
+		return
+	}
+
+	payrollGenerationDuration.WithLabelValues(PayrollGenerationStatusSuccess).Observe(duration.Seconds())
+	payrollGenerationEmployees.Set(float64(employees))
+	payrollGenerationTakeHome.Set(float64(totalTakeHome))
+	payrollGenerationLastSuccess.SetToCurrentTime()
+	payrollEmployeesProcessedTotal.Add(float64(employees))
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(HTTPHandler)
+	r.Get("/v1/payroll-period/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	r.Get("/v1/payroll", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	for _, target := range []string{"/v1/payroll-period/1", "/v1/payroll-period/2", "/v1/payroll", "/random"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	tests := []struct {
+		route  string
+		status string
+		want   float64
+	}{
+		{route: "/v1/payroll-period/{id}", status: "404", want: 2},
+		{route: "/v1/payroll", status: "200", want: 1},
+		{route: unmatchedRoute, status: "404", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.route, func(t *testing.T) {
+			got := testutil.ToFloat64(httpRequestsTotal.WithLabelValues(http.MethodGet, tt.route, tt.status))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	assert.Equal(t, 3, testutil.CollectAndCount(httpRequestDuration))
+}
+
+func TestObservePayrollGeneration(t *testing.T) {
+	ObservePayrollGeneration(2*time.Second, 120, 900_000_000, false)
+	ObservePayrollGeneration(time.Second, 0, 0, true)
+
+	assert.Equal(t, float64(120), testutil.ToFloat64(payrollGenerationEmployees))
+	assert.Equal(t, float64(900_000_000), testutil.ToFloat64(payrollGenerationTakeHome))
+	assert.Equal(t, float64(120), testutil.ToFloat64(payrollEmployeesProcessedTotal))
+	assert.NotZero(t, testutil.ToFloat64(payrollGenerationLastSuccess))
+	assert.Equal(t, 2, testutil.CollectAndCount(payrollGenerationDuration))
+}
+
+func TestHandler(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	assert.NoError(t, RegisterDB("master", db))
+
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.True(t, strings.Contains(body, `go_sql_open_connections{db_name="master"}`))
+	assert.True(t, strings.Contains(body, "go_goroutines"))
+}
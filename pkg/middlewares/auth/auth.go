@@ -9,7 +9,10 @@ import (
 
 	"github.com/cristalhq/jwt/v5"
 	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	liblog "github.com/faisalhardin/employee-payroll-system/pkg/common/log"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/log/logger"
 	commonwriter "github.com/faisalhardin/employee-payroll-system/pkg/common/writer"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
 )
 
 const (
@@ -36,6 +39,7 @@ var AllowedHeaders = []string{
 	"X-Element-ID",
 	"x-requested-with",
 	XAppKey,
+	"Idempotency-Key",
 }
 
 var AllowedMethodRequest = []string{
@@ -71,6 +75,12 @@ func (opt *Options) AuthHandler(next http.Handler) http.Handler {
 		}
 
 		ctx = SetUserDetailToCtx(ctx, userDetail)
+		ctx = tenant.NewContext(ctx, userDetail.TenantID)
+		liblog.AddFields(ctx, logger.KV{
+			"user_id":   userDetail.ID,
+			"role":      userDetail.Role,
+			"tenant_id": userDetail.TenantID,
+		})
 		r = r.WithContext(ctx)
 		next.ServeHTTP(w, r)
 
@@ -123,6 +133,18 @@ func (opt *Options) HandleAuthMiddleware(ctx context.Context, token string) (ret
 		return
 	}
 
+	// kiosk tokens and nonces are signed with the same key
+	if claims.Subject != "" {
+		err = commonerr.SetNewUnauthorizedError("authorization", "token is not a user token")
+		return
+	}
+
+	// tokens signed before tenants existed cannot be scoped, users sign in again
+	if claims.Payload.TenantID <= 0 {
+		err = commonerr.SetNewUnauthorizedError("authorization", "token has no tenant")
+		return
+	}
+
 	return claims.Payload, nil
 }
 
@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/cristalhq/jwt/v5"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	liblog "github.com/faisalhardin/employee-payroll-system/pkg/common/log"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/log/logger"
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+)
+
+// Subjects set apart the tokens signed for kiosks from user tokens, which
+// carry no subject. A token is only accepted where its subject is expected.
+const (
+	SubjectKiosk      = "kiosk"
+	SubjectKioskNonce = "kiosk_nonce"
+)
+
+type kioskAuth struct{}
+
+var (
+	kioskContextKey = kioskAuth{}
+)
+
+type KioskJWTPayload struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	TenantID int64  `json:"tenant_id"`
+}
+
+type KioskClaims struct {
+	jwt.RegisteredClaims
+	Kiosk KioskJWTPayload `json:"kiosk"`
+}
+
+// Verify checks the subject as well as the expiry so a nonce cannot be used
+// as a kiosk token or the other way round.
+func (claims KioskClaims) Verify(subject string) error {
+	if !claims.IsSubject(subject) {
+		return commonerr.SetNewUnauthorizedError("authorization", "token is not a "+subject+" token")
+	}
+	if claims.ExpiresAt == nil || !claims.IsValidExpiresAt(time.Now()) {
+		return commonerr.SetNewTokenExpiredError()
+	}
+	return nil
+}
+
+// CreateKioskToken signs the token a kiosk authenticates with.
+func (opt *Options) CreateKioskToken(ctx context.Context, kiosk KioskJWTPayload, timeNow, timeExpired time.Time) (tokenStr string, err error) {
+	return opt.createKioskClaims(ctx, SubjectKiosk, "", kiosk, timeNow, timeExpired)
+}
+
+// CreateKioskNonce signs the short lived nonce a kiosk shows as a QR code.
+// Each nonce gets a random id so two codes are never the same.
+func (opt *Options) CreateKioskNonce(ctx context.Context, kiosk KioskJWTPayload, timeNow, timeExpired time.Time) (nonce string, err error) {
+	id := make([]byte, 16)
+	_, err = rand.Read(id)
+	if err != nil {
+		return
+	}
+	return opt.createKioskClaims(ctx, SubjectKioskNonce, hex.EncodeToString(id), kiosk, timeNow, timeExpired)
+}
+
+// KioskNonce is a verified nonce. ID and ExpiresAt let the caller remember
+// the nonce as used until it expires.
+type KioskNonce struct {
+	ID        string
+	ExpiresAt time.Time
+	Kiosk     KioskJWTPayload
+}
+
+// VerifyKioskNonce returns a valid, unexpired nonce and the kiosk that issued
+// it. A nonce without an id is refused since it could not be told apart from
+// the others.
+func (opt *Options) VerifyKioskNonce(nonce string) (res KioskNonce, err error) {
+	claims, err := opt.verifyKioskClaims(nonce, SubjectKioskNonce)
+	if err != nil {
+		return
+	}
+	if claims.ID == "" {
+		return res, commonerr.SetNewUnauthorizedError("authorization", "nonce has no id")
+	}
+	return KioskNonce{
+		ID:        claims.ID,
+		ExpiresAt: claims.ExpiresAt.Time,
+		Kiosk:     claims.Kiosk,
+	}, nil
+}
+
+// KioskAuthHandler authenticates the kiosk token of the request and puts the
+// kiosk in the context. User tokens are refused.
+func (opt *Options) KioskAuthHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		token, err := GetBearerToken(r.Header.Get("Authorization"))
+		if err != nil {
+			handleError(ctx, w, r, err)
+			return
+		}
+
+		claims, err := opt.verifyKioskClaims(token, SubjectKiosk)
+		if err != nil {
+			handleError(ctx, w, r, err)
+			return
+		}
+		if claims.Kiosk.TenantID <= 0 {
+			handleError(ctx, w, r, commonerr.SetNewUnauthorizedError("authorization", "token has no tenant"))
+			return
+		}
+
+		ctx = SetKioskDetailToCtx(ctx, claims.Kiosk)
+		ctx = tenant.NewContext(ctx, claims.Kiosk.TenantID)
+		liblog.AddFields(ctx, logger.KV{
+			"kiosk_id":  claims.Kiosk.ID,
+			"tenant_id": claims.Kiosk.TenantID,
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (opt *Options) createKioskClaims(ctx context.Context, subject, id string, kiosk KioskJWTPayload, timeNow, timeExpired time.Time) (string, error) {
+	claims := KioskClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			Issuer:    opt.Cfg.ServerHost,
+			Audience:  jwt.Audience{opt.Cfg.ServerHost},
+			Subject:   subject,
+			ExpiresAt: jwt.NewNumericDate(timeExpired),
+			IssuedAt:  jwt.NewNumericDate(timeNow),
+		},
+		Kiosk: kiosk,
+	}
+	return opt.generateToken(ctx, claims, timeNow, timeExpired)
+}
+
+func (opt *Options) verifyKioskClaims(token, subject string) (claims KioskClaims, err error) {
+	err = opt.VerifyJWT(token, &claims)
+	if err != nil {
+		return
+	}
+	err = claims.Verify(subject)
+	return
+}
+
+func SetKioskDetailToCtx(ctx context.Context, data KioskJWTPayload) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, kioskContextKey, data)
+}
+
+func GetKioskDetailFromCtx(ctx context.Context) (KioskJWTPayload, bool) {
+	kiosk, ok := ctx.Value(kioskContextKey).(KioskJWTPayload)
+	return kiosk, ok
+}
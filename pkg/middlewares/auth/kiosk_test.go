@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/pkg/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKioskTokens(t *testing.T) {
+	opt, err := New(&JWTConfig{
+		ServerHost:  "localhost",
+		Credentials: JWTCredential{Secret: "0123456789abcdef0123456789abcdef"},
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Now()
+	kiosk := KioskJWTPayload{ID: 3, Name: "Warehouse gate", TenantID: 1}
+	kioskToken, err := opt.CreateKioskToken(ctx, kiosk, now, now.Add(time.Hour))
+	require.NoError(t, err)
+	nonce, err := opt.CreateKioskNonce(ctx, kiosk, now, now.Add(30*time.Second))
+	require.NoError(t, err)
+	otherNonce, err := opt.CreateKioskNonce(ctx, kiosk, now, now.Add(30*time.Second))
+	require.NoError(t, err)
+	expiredNonce, err := opt.CreateKioskNonce(ctx, kiosk, now.Add(-time.Minute), now.Add(-30*time.Second))
+	require.NoError(t, err)
+	userToken, err := opt.CreateJWTToken(ctx, UserJWTPayload{ID: 3, Role: "employee", TenantID: 1}, now, now.Add(time.Hour))
+	require.NoError(t, err)
+	noIDNonce, err := opt.createKioskClaims(ctx, SubjectKioskNonce, "", kiosk, now, now.Add(30*time.Second))
+	require.NoError(t, err)
+	noTenantKioskToken, err := opt.CreateKioskToken(ctx, KioskJWTPayload{ID: 3, Name: "Warehouse gate"}, now, now.Add(time.Hour))
+	require.NoError(t, err)
+	noTenantUserToken, err := opt.CreateJWTToken(ctx, UserJWTPayload{ID: 3, Role: "employee"}, now, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.NotEqual(t, nonce, otherNonce)
+
+	t.Run("VerifyKioskNonce", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			nonce   string
+			wantErr bool
+		}{
+			{name: "nonce", nonce: nonce},
+			{name: "expired nonce", nonce: expiredNonce, wantErr: true},
+			{name: "nonce without id", nonce: noIDNonce, wantErr: true},
+			{name: "kiosk token", nonce: kioskToken, wantErr: true},
+			{name: "user token", nonce: userToken, wantErr: true},
+			{name: "garbage", nonce: "not-a-token", wantErr: true},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := opt.VerifyKioskNonce(tt.nonce)
+				assert.Equal(t, tt.wantErr, err != nil)
+				if !tt.wantErr {
+					assert.Equal(t, kiosk, got.Kiosk)
+					assert.NotEmpty(t, got.ID)
+					assert.WithinDuration(t, now.Add(30*time.Second), got.ExpiresAt, time.Second)
+				}
+			})
+		}
+	})
+
+	t.Run("handlers accept only their own tokens", func(t *testing.T) {
+		serve := func(handler func(http.Handler) http.Handler, token string) (int, context.Context) {
+			var got context.Context
+			h := handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Context()
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+			return w.Code, got
+		}
+
+		code, ctx := serve(opt.KioskAuthHandler, kioskToken)
+		assert.Equal(t, http.StatusOK, code)
+		got, found := GetKioskDetailFromCtx(ctx)
+		assert.True(t, found)
+		assert.Equal(t, kiosk, got)
+		tenantID, found := tenant.FromContext(ctx)
+		assert.True(t, found)
+		assert.Equal(t, int64(1), tenantID)
+
+		for _, token := range []string{userToken, nonce, noTenantKioskToken} {
+			code, _ = serve(opt.KioskAuthHandler, token)
+			assert.NotEqual(t, http.StatusOK, code)
+		}
+		for _, token := range []string{kioskToken, nonce, noTenantUserToken} {
+			code, _ = serve(opt.AuthHandler, token)
+			assert.Equal(t, http.StatusUnauthorized, code)
+		}
+		code, ctx = serve(opt.AuthHandler, userToken)
+		assert.Equal(t, http.StatusOK, code)
+		tenantID, found = tenant.FromContext(ctx)
+		assert.True(t, found)
+		assert.Equal(t, int64(1), tenantID)
+	})
+}
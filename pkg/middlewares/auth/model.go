@@ -10,8 +10,11 @@ type JWTCredential struct {
 	Secret string `yaml:"secret"`
 }
 
+// UserJWTPayload is the signed in user. TenantID is the legal entity the
+// user belongs to, every request is kept within it.
 type UserJWTPayload struct {
 	ID       int64  `json:"id"`
 	Username string `json:"username"`
 	Role     string `json:"role"`
+	TenantID int64  `json:"tenant_id"`
 }
@@ -0,0 +1,163 @@
+// Package idempotency replays the stored response of a request sent again
+// with the same Idempotency-Key, so a client retrying after a lost response
+// does not submit twice. Keys belong to the signed in user.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	liblog "github.com/faisalhardin/employee-payroll-system/pkg/common/log"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/log/logger"
+	httpwriter "github.com/faisalhardin/employee-payroll-system/pkg/common/writer"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	HeaderKey      = "Idempotency-Key"
+	HeaderReplayed = "Idempotency-Replayed"
+
+	maxKeyLength = 255
+	// DefaultTTL is how long a key is remembered when no TTL is configured
+	DefaultTTL = 24 * time.Hour
+	// DefaultMaxBodyBytes caps the body buffered to hash a request when no
+	// limit is configured, as large as the biggest upload, a device log
+	DefaultMaxBodyBytes = 10 << 20
+)
+
+var timeNow = time.Now
+
+// Handler makes POST requests carrying an Idempotency-Key header
+// idempotent. The first request with a key runs and its response is stored
+// for ttl; a repeat gets the stored response with an Idempotency-Replayed
+// header. A repeat with a different body is refused with 422 and a repeat
+// while the first is still running with 409. A 5xx response is not stored,
+// so the request can be retried. A body over maxBodyBytes is refused with
+// 413 before it is buffered. Requests without the header, of other methods
+// or without a signed in user pass through.
+func Handler(store Store, ttl time.Duration, maxBodyBytes int64) func(http.Handler) http.Handler {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = DefaultMaxBodyBytes
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			key := r.Header.Get(HeaderKey)
+			user, found := auth.GetUserDetailFromCtx(ctx)
+			if r.Method != http.MethodPost || key == "" || !found {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxKeyLength {
+				httpwriter.SetErrorFormat(ctx, w, commonerr.SetNewBadRequest("invalid_idempotency_key", "Idempotency-Key is longer than 255 characters"))
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				httpwriter.SetErrorFormat(ctx, w, commonerr.SetNewError(http.StatusRequestEntityTooLarge,
+					"request_too_large", fmt.Sprintf("the body of a request with an Idempotency-Key is limited to %d bytes", maxBodyBytes)))
+				return
+			}
+			if err != nil {
+				httpwriter.SetErrorFormat(ctx, w, commonerr.SetDefaultErrBodyRequest())
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			record := Record{
+				UserID:      user.ID,
+				Key:         key,
+				RequestHash: requestHash(r, body),
+			}
+			existing, reserved, err := store.Reserve(ctx, record, timeNow().Add(ttl))
+			if err != nil {
+				httpwriter.SetError(ctx, w, err)
+				return
+			}
+			if !reserved {
+				replay(ctx, w, record, existing)
+				return
+			}
+
+			run(ctx, w, r, next, store, record)
+		})
+	}
+}
+
+// run serves a request holding a fresh reservation and stores its response.
+// The reservation is released when the handler fails or panics.
+func run(ctx context.Context, w http.ResponseWriter, r *http.Request, next http.Handler, store Store, record Record) {
+	// the outcome is kept even when the client has gone away
+	storeCtx := context.WithoutCancel(ctx)
+	completed := false
+	defer func() {
+		if completed {
+			return
+		}
+		if err := store.Release(storeCtx, record); err != nil {
+			liblog.WarnCtx(ctx, "failed to release idempotency key", logger.KV{"error": err.Error()})
+		}
+	}()
+
+	var body bytes.Buffer
+	ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+	ww.Tee(&body)
+	next.ServeHTTP(ww, r)
+
+	record.StatusCode = ww.Status()
+	if record.StatusCode == 0 {
+		record.StatusCode = http.StatusOK
+	}
+	if record.StatusCode >= http.StatusInternalServerError {
+		return
+	}
+	record.ContentType = ww.Header().Get("Content-Type")
+	record.Body = body.Bytes()
+	if err := store.Complete(storeCtx, record); err != nil {
+		liblog.WarnCtx(ctx, "failed to store idempotent response", logger.KV{"error": err.Error()})
+		return
+	}
+	completed = true
+}
+
+func replay(ctx context.Context, w http.ResponseWriter, record, existing Record) {
+	if existing.RequestHash != record.RequestHash {
+		httpwriter.SetErrorFormat(ctx, w, commonerr.SetNewError(http.StatusUnprocessableEntity,
+			"idempotency_key_reused", "Idempotency-Key was already used for a different request"))
+		return
+	}
+	if existing.StatusCode == 0 {
+		httpwriter.SetErrorFormat(ctx, w, commonerr.SetNewError(http.StatusConflict,
+			"request_in_progress", "a request with this Idempotency-Key is still being processed"))
+		return
+	}
+
+	if existing.ContentType != "" {
+		w.Header().Set("Content-Type", existing.ContentType)
+	}
+	w.Header().Set(HeaderReplayed, "true")
+	w.WriteHeader(existing.StatusCode)
+	_, _ = w.Write(existing.Body)
+}
+
+// requestHash identifies a request by its method, path and body
+func requestHash(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	_, _ = io.WriteString(hash, r.Method+" "+r.URL.Path+"\n")
+	_, _ = hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
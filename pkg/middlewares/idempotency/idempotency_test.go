@@ -0,0 +1,170 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStore keeps records in memory, ignoring expiry
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]Record
+	err     error
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: map[string]Record{}}
+}
+
+func storeKey(record Record) string {
+	return fmt.Sprintf("%d:%s", record.UserID, record.Key)
+}
+
+func (s *memoryStore) Reserve(ctx context.Context, record Record, expiresAt time.Time) (Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return Record{}, false, s.err
+	}
+	if existing, found := s.records[storeKey(record)]; found {
+		return existing, false, nil
+	}
+	s.records[storeKey(record)] = record
+	return Record{}, true, nil
+}
+
+func (s *memoryStore) Complete(ctx context.Context, record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[storeKey(record)] = record
+	return nil
+}
+
+func (s *memoryStore) Release(ctx context.Context, record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, storeKey(record))
+	return nil
+}
+
+func TestHandler(t *testing.T) {
+	store := newMemoryStore()
+	calls := 0
+	status := http.StatusCreated
+	handler := Handler(store, time.Hour, 64)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	}))
+
+	newRequest := func(method, key, body string, userID int64) *http.Request {
+		req := httptest.NewRequest(method, "/v1/reimbursement", bytes.NewBufferString(body))
+		if key != "" {
+			req.Header.Set(HeaderKey, key)
+		}
+		if userID == 0 {
+			return req
+		}
+		return req.WithContext(auth.SetUserDetailToCtx(req.Context(), auth.UserJWTPayload{ID: userID}))
+	}
+
+	tests := []struct {
+		name         string
+		req          *http.Request
+		patch        func()
+		wantStatus   int
+		wantCalls    int
+		wantReplayed string
+	}{
+		{name: "first request", req: newRequest(http.MethodPost, "key-1", `{"amount":1}`, 1), wantStatus: http.StatusCreated, wantCalls: 1},
+		{name: "repeated request is replayed", req: newRequest(http.MethodPost, "key-1", `{"amount":1}`, 1), wantStatus: http.StatusCreated, wantCalls: 1, wantReplayed: "true"},
+		{name: "key reused with another body", req: newRequest(http.MethodPost, "key-1", `{"amount":2}`, 1), wantStatus: http.StatusUnprocessableEntity, wantCalls: 1},
+		{name: "same key of another user", req: newRequest(http.MethodPost, "key-1", `{"amount":1}`, 2), wantStatus: http.StatusCreated, wantCalls: 2},
+		{name: "without key", req: newRequest(http.MethodPost, "", `{"amount":1}`, 1), wantStatus: http.StatusCreated, wantCalls: 3},
+		{name: "not a post", req: newRequest(http.MethodPut, "key-1", `{"amount":2}`, 1), wantStatus: http.StatusCreated, wantCalls: 4},
+		{name: "signed out", req: newRequest(http.MethodPost, "key-1", `{"amount":2}`, 0), wantStatus: http.StatusCreated, wantCalls: 5},
+		{
+			name:       "key too long",
+			req:        newRequest(http.MethodPost, string(bytes.Repeat([]byte("k"), 256)), `{}`, 1),
+			wantStatus: http.StatusBadRequest,
+			wantCalls:  5,
+		},
+		{
+			name: "still in progress",
+			req:  newRequest(http.MethodPost, "key-2", `{}`, 1),
+			patch: func() {
+				running := Record{UserID: 1, Key: "key-2", RequestHash: requestHash(newRequest(http.MethodPost, "key-2", `{}`, 1), []byte(`{}`))}
+				store.records[storeKey(running)] = running
+			},
+			wantStatus: http.StatusConflict,
+			wantCalls:  5,
+		},
+		{
+			name:       "server error is not stored",
+			req:        newRequest(http.MethodPost, "key-3", `{}`, 1),
+			patch:      func() { status = http.StatusInternalServerError },
+			wantStatus: http.StatusInternalServerError,
+			wantCalls:  6,
+		},
+		{
+			name:       "retry after a server error runs again",
+			req:        newRequest(http.MethodPost, "key-3", `{}`, 1),
+			patch:      func() { status = http.StatusCreated },
+			wantStatus: http.StatusCreated,
+			wantCalls:  7,
+		},
+		{
+			name:       "body too large",
+			req:        newRequest(http.MethodPost, "key-5", string(bytes.Repeat([]byte("a"), 65)), 1),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCalls:  7,
+		},
+		{
+			name:       "store failure",
+			req:        newRequest(http.MethodPost, "key-4", `{}`, 1),
+			patch:      func() { store.err = errors.New("database error") },
+			wantStatus: http.StatusInternalServerError,
+			wantCalls:  7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.patch != nil {
+				tt.patch()
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, tt.req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Equal(t, tt.wantReplayed, w.Header().Get(HeaderReplayed))
+			if tt.wantReplayed != "" {
+				assert.Equal(t, `{"data":{"id":1}}`, w.Body.String())
+				assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestHandler_panicReleasesKey(t *testing.T) {
+	store := newMemoryStore()
+	handler := Handler(store, time.Hour, 64)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("handler failed")
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/v1/overtime", bytes.NewBufferString(`{}`))
+	req.Header.Set(HeaderKey, "key-1")
+	req = req.WithContext(auth.SetUserDetailToCtx(req.Context(), auth.UserJWTPayload{ID: 1}))
+
+	assert.Panics(t, func() { handler.ServeHTTP(httptest.NewRecorder(), req) })
+	assert.Empty(t, store.records)
+}
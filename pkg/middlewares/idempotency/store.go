@@ -0,0 +1,152 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	liblog "github.com/faisalhardin/employee-payroll-system/pkg/common/log"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/log/logger"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+)
+
+const (
+	TrxIdempotencyKeyTable = "trx_idempotency_key"
+)
+
+// Record is a key with the response of its first request. StatusCode is
+// zero while that request is still running.
+type Record struct {
+	UserID      int64
+	Key         string
+	RequestHash string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// Store keeps the records, shared by every instance.
+type Store interface {
+	// Reserve claims the key of record until expiresAt. A key claimed before
+	// and not expired is returned instead, with reserved false.
+	Reserve(ctx context.Context, record Record, expiresAt time.Time) (existing Record, reserved bool, err error)
+	// Complete stores the response of a reserved key
+	Complete(ctx context.Context, record Record) error
+	// Release frees a reserved key whose request failed, so it can be retried
+	Release(ctx context.Context, record Record) error
+}
+
+type trxIdempotencyKey struct {
+	ID             int64  `xorm:"'id' pk autoincr"`
+	IDMstUser      int64  `xorm:"'id_mst_user'"`
+	IdempotencyKey string `xorm:"'idempotency_key'"`
+	RequestHash    string `xorm:"'request_hash'"`
+	StatusCode     int    `xorm:"'status_code'"`
+	ContentType    string `xorm:"'content_type'"`
+	ResponseBody   []byte `xorm:"'response_body'"`
+}
+
+// DBStore keeps the records in trx_idempotency_key.
+type DBStore struct {
+	DB *xormlib.DBConnect
+}
+
+func NewDBStore(db *xormlib.DBConnect) *DBStore {
+	return &DBStore{DB: db}
+}
+
+func (s *DBStore) Reserve(ctx context.Context, record Record, expiresAt time.Time) (existing Record, reserved bool, err error) {
+	ctx, finish := s.DB.Operation(ctx, "idempotency.DBStore.Reserve")
+	defer finish(&err)
+
+	// an expired key is taken over as if it were new
+	var row trxIdempotencyKey
+	reserved, err = s.DB.MasterDB.Context(ctx).SQL(`INSERT INTO `+TrxIdempotencyKeyTable+`
+		(id_mst_user, idempotency_key, request_hash, expires_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (id_mst_user, idempotency_key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, status_code = 0, content_type = '', response_body = NULL,
+			created_at = now(), expires_at = EXCLUDED.expires_at
+		WHERE `+TrxIdempotencyKeyTable+`.expires_at < now()
+		RETURNING id`, record.UserID, record.Key, record.RequestHash, expiresAt).
+		Get(&row)
+	if err != nil {
+		return existing, false, errors.Wrap(err, "DBStore.Reserve")
+	}
+	if reserved {
+		return existing, true, nil
+	}
+
+	found, err := s.DB.MasterDB.Context(ctx).Table(TrxIdempotencyKeyTable).
+		Where("id_mst_user = ? AND idempotency_key = ?", record.UserID, record.Key).
+		Get(&row)
+	if err != nil {
+		return existing, false, errors.Wrap(err, "DBStore.Reserve")
+	}
+	if !found {
+		// released in between, report it as still running so the client retries
+		return Record{UserID: record.UserID, Key: record.Key, RequestHash: record.RequestHash}, false, nil
+	}
+	return Record{
+		UserID:      row.IDMstUser,
+		Key:         row.IdempotencyKey,
+		RequestHash: row.RequestHash,
+		StatusCode:  row.StatusCode,
+		ContentType: row.ContentType,
+		Body:        row.ResponseBody,
+	}, false, nil
+}
+
+func (s *DBStore) Complete(ctx context.Context, record Record) (err error) {
+	ctx, finish := s.DB.Operation(ctx, "idempotency.DBStore.Complete")
+	defer finish(&err)
+
+	_, err = s.DB.MasterDB.Context(ctx).Exec(`UPDATE `+TrxIdempotencyKeyTable+`
+		SET status_code = ?, content_type = ?, response_body = ?
+		WHERE id_mst_user = ? AND idempotency_key = ?`,
+		record.StatusCode, record.ContentType, record.Body, record.UserID, record.Key)
+	if err != nil {
+		return errors.Wrap(err, "DBStore.Complete")
+	}
+	return nil
+}
+
+func (s *DBStore) Release(ctx context.Context, record Record) (err error) {
+	ctx, finish := s.DB.Operation(ctx, "idempotency.DBStore.Release")
+	defer finish(&err)
+
+	_, err = s.DB.MasterDB.Context(ctx).Exec(`DELETE FROM `+TrxIdempotencyKeyTable+`
+		WHERE id_mst_user = ? AND idempotency_key = ? AND status_code = 0`, record.UserID, record.Key)
+	if err != nil {
+		return errors.Wrap(err, "DBStore.Release")
+	}
+	return nil
+}
+
+// DeleteExpired removes the keys past their expiry.
+func (s *DBStore) DeleteExpired(ctx context.Context) (deleted int64, err error) {
+	ctx, finish := s.DB.Operation(ctx, "idempotency.DBStore.DeleteExpired")
+	defer finish(&err)
+
+	result, err := s.DB.MasterDB.Context(ctx).Exec(`DELETE FROM ` + TrxIdempotencyKeyTable + ` WHERE expires_at < now()`)
+	if err != nil {
+		return 0, errors.Wrap(err, "DBStore.DeleteExpired")
+	}
+	return result.RowsAffected()
+}
+
+// RunCleanup deletes the expired keys every interval until ctx ends.
+func (s *DBStore) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.DeleteExpired(ctx); err != nil {
+				liblog.WarnCtx(ctx, "failed to delete expired idempotency keys", logger.KV{"error": err.Error()})
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	xormlib "github.com/faisalhardin/employee-payroll-system/pkg/xorm"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBStore_Reserve(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	expiresAt := time.Date(2025, 7, 2, 9, 0, 0, 0, time.UTC)
+	record := Record{UserID: 1, Key: "key-1", RequestHash: "hash"}
+	tests := []struct {
+		name         string
+		want         Record
+		wantReserved bool
+		wantErr      bool
+		patch        func()
+	}{
+		{
+			name:         "Successful new key",
+			wantReserved: true,
+			patch: func() {
+				mockDB.ExpectQuery("^INSERT INTO trx_idempotency_key").
+					WithArgs(int64(1), "key-1", "hash", expiresAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+		},
+		{
+			name: "Successful existing key",
+			want: Record{UserID: 1, Key: "key-1", RequestHash: "hash", StatusCode: 201, ContentType: "application/json", Body: []byte(`{}`)},
+			patch: func() {
+				mockDB.ExpectQuery("^INSERT INTO trx_idempotency_key").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mockDB.ExpectQuery("^SELECT .* FROM \"trx_idempotency_key\" WHERE \\(id_mst_user = \\$1 AND idempotency_key = \\$2\\)").
+					WithArgs(int64(1), "key-1").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "id_mst_user", "idempotency_key", "request_hash", "status_code", "content_type", "response_body"}).
+						AddRow(1, 1, "key-1", "hash", 201, "application/json", []byte(`{}`)))
+			},
+		},
+		{
+			name:    "Failed because insert method",
+			wantErr: true,
+			patch: func() {
+				mockDB.ExpectQuery("^INSERT INTO trx_idempotency_key").
+					WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewDBStore(&xormlib.DBConnect{MasterDB: mockConn})
+			tt.patch()
+			got, reserved, err := s.Reserve(context.Background(), record, expiresAt)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DBStore.Reserve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantReserved, reserved)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDBStore_Complete(t *testing.T) {
+	mockConn, mockDB := xormlib.NewMockDB()
+	defer func() {
+		mockConn.Close()
+		err := mockDB.ExpectationsWereMet()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	mockDB.ExpectExec("^UPDATE trx_idempotency_key\\s+SET status_code = \\$1").
+		WithArgs(201, "application/json", []byte(`{}`), int64(1), "key-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectExec("^DELETE FROM trx_idempotency_key\\s+WHERE id_mst_user = \\$1 AND idempotency_key = \\$2 AND status_code = 0").
+		WithArgs(int64(1), "key-2").
+		WillReturnError(errors.New("database error"))
+
+	s := NewDBStore(&xormlib.DBConnect{MasterDB: mockConn})
+	err := s.Complete(context.Background(), Record{UserID: 1, Key: "key-1", StatusCode: 201, ContentType: "application/json", Body: []byte(`{}`)})
+	assert.NoError(t, err)
+	err = s.Release(context.Background(), Record{UserID: 1, Key: "key-2"})
+	assert.Error(t, err)
+}
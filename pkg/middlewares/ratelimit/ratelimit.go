@@ -0,0 +1,122 @@
+// Package ratelimit throttles requests with token buckets. The buckets live in
+// a Store, so instances behind a load balancer can share them by plugging in
+// a shared backend instead of the in-memory one.
+package ratelimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/commonerr"
+	liblog "github.com/faisalhardin/employee-payroll-system/pkg/common/log"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/log/logger"
+	httpwriter "github.com/faisalhardin/employee-payroll-system/pkg/common/writer"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/requestinfo"
+)
+
+const (
+	// maxKeyBodySize caps how much of the body is read to find a key
+	maxKeyBodySize = 1 << 20
+)
+
+// Limit is a token bucket refilled with RequestsPerMinute tokens a minute that
+// holds at most Burst tokens. A zero RequestsPerMinute does not limit.
+type Limit struct {
+	RequestsPerMinute int `yaml:"requests_per_minute"`
+	Burst             int `yaml:"burst"`
+}
+
+// KeyFunc names the bucket a request takes its token from. Requests with an
+// empty key are not limited.
+type KeyFunc func(r *http.Request) string
+
+// Rule limits the requests sharing a key.
+type Rule struct {
+	Key   KeyFunc
+	Limit Limit
+}
+
+// Handler takes a token for every rule before serving the request and
+// answers 429 with a Retry-After header once a bucket is empty. A failing
+// store lets the request through rather than locking everyone out.
+func Handler(store Store, rules ...Rule) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			for _, rule := range rules {
+				if rule.Limit.RequestsPerMinute <= 0 {
+					continue
+				}
+				key := rule.Key(r)
+				if key == "" {
+					continue
+				}
+
+				allowed, retryAfter, err := store.Take(ctx, key, rule.Limit)
+				if err != nil {
+					liblog.WarnCtx(ctx, "rate limit store failed", logger.KV{"error": err.Error()})
+					continue
+				}
+				if !allowed {
+					httpwriter.SetErrorFormat(ctx, w, commonerr.SetNewTooManyRequests(
+						"too_many_requests", "too many requests, try again later", retryAfter))
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ByIP keys requests by the client address.
+func ByIP(r *http.Request) string {
+	ip := requestinfo.FromContext(r.Context()).IPAddress
+	if ip == "" {
+		ip = r.RemoteAddr
+	}
+	return "ip:" + ip
+}
+
+// ByBodyField keys requests by a field of a JSON or form body, compared case
+// insensitively. The body is restored for the handler.
+func ByBodyField(field string) KeyFunc {
+	return func(r *http.Request) string {
+		if r.Body == nil {
+			return ""
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxKeyBodySize))
+		if err != nil {
+			return ""
+		}
+		r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+
+		value := bodyField(r.Header.Get("Content-Type"), body, field)
+		if value == "" {
+			return ""
+		}
+		return field + ":" + strings.ToLower(value)
+	}
+}
+
+func bodyField(contentType string, body []byte, field string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/x-www-form-urlencoded" {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return ""
+		}
+		return values.Get(field)
+	}
+
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return ""
+	}
+	value, _ := fields[field].(string)
+	return value
+}
@@ -0,0 +1,131 @@
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/requestinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	return false, 0, errors.New("store unavailable")
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	now := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	limit := Limit{RequestsPerMinute: 6, Burst: 2}
+
+	tests := []struct {
+		name           string
+		advance        time.Duration
+		key            string
+		wantAllowed    bool
+		wantRetryAfter time.Duration
+	}{
+		{name: "full bucket", key: "ip:10.0.0.1", wantAllowed: true},
+		{name: "burst", key: "ip:10.0.0.1", wantAllowed: true},
+		{name: "empty bucket", key: "ip:10.0.0.1", wantRetryAfter: 10 * time.Second},
+		{name: "other key has its own bucket", key: "ip:10.0.0.2", wantAllowed: true},
+		{name: "partly refilled", advance: 4 * time.Second, key: "ip:10.0.0.1", wantRetryAfter: 6 * time.Second},
+		{name: "refilled", advance: 6 * time.Second, key: "ip:10.0.0.1", wantAllowed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.advance)
+			allowed, retryAfter, err := store.Take(context.Background(), tt.key, limit)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantAllowed, allowed)
+			assert.InDelta(t, tt.wantRetryAfter, retryAfter, float64(time.Millisecond))
+		})
+	}
+
+	now = now.Add(sweepInterval)
+	_, _, _ = store.Take(context.Background(), "ip:10.0.0.3", limit)
+	assert.Len(t, store.buckets, 1)
+}
+
+func TestHandler(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	})
+	newRequest := func(ip, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req.WithContext(requestinfo.NewContext(req.Context(), requestinfo.Info{IPAddress: ip}))
+	}
+
+	handler := Handler(NewMemoryStore(),
+		Rule{Key: ByIP, Limit: Limit{RequestsPerMinute: 60, Burst: 3}},
+		Rule{Key: ByBodyField("username"), Limit: Limit{RequestsPerMinute: 1, Burst: 1}},
+		Rule{Key: ByIP, Limit: Limit{}},
+	)(ok)
+
+	tests := []struct {
+		name           string
+		ip             string
+		body           string
+		wantStatus     int
+		wantRetryAfter string
+	}{
+		{name: "first attempt", ip: "10.0.0.1", body: `{"username":"Admin"}`, wantStatus: http.StatusOK},
+		{name: "same username in other case", ip: "10.0.0.2", body: `{"username":"admin"}`, wantStatus: http.StatusTooManyRequests, wantRetryAfter: "60"},
+		{name: "other username", ip: "10.0.0.1", body: `{"username":"employee_001"}`, wantStatus: http.StatusOK},
+		{name: "no username", ip: "10.0.0.1", body: `{}`, wantStatus: http.StatusOK},
+		{name: "address out of tokens", ip: "10.0.0.1", body: `{"username":"employee_002"}`, wantStatus: http.StatusTooManyRequests, wantRetryAfter: "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, newRequest(tt.ip, tt.body))
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantRetryAfter, w.Header().Get("Retry-After"))
+			if tt.wantStatus == http.StatusOK {
+				// the handler still reads the whole body
+				assert.Equal(t, tt.body, w.Body.String())
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	Handler(failingStore{}, Rule{Key: ByIP, Limit: Limit{RequestsPerMinute: 1}})(ok).
+		ServeHTTP(w, newRequest("10.0.0.1", `{}`))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestByBodyField(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+	}{
+		{name: "json", contentType: "application/json", body: `{"username":"Admin"}`, want: "username:admin"},
+		{name: "json with charset", contentType: "application/json; charset=utf-8", body: `{"username":"admin"}`, want: "username:admin"},
+		{name: "form", contentType: "application/x-www-form-urlencoded", body: "username=admin&password=x", want: "username:admin"},
+		{name: "not a string", contentType: "application/json", body: `{"username":1}`},
+		{name: "invalid json", contentType: "application/json", body: `{invalid`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			assert.Equal(t, tt.want, ByBodyField("username")(req))
+
+			body, err := io.ReadAll(req.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.body, string(body))
+		})
+	}
+}
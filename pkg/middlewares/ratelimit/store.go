@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// sweepInterval is how often the memory store drops refilled buckets
+	sweepInterval = time.Minute
+)
+
+// Store keeps the token buckets. Take removes a token from the bucket of key
+// and, when the bucket is empty, reports how long until the next token.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// MemoryStore keeps the buckets in the memory of one instance.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens    float64
+	burst     float64
+	rate      float64
+	updatedAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (allowed bool, retryAfter time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		burst := float64(limit.Burst)
+		if burst < 1 {
+			burst = 1
+		}
+		b = &bucket{
+			tokens:    burst,
+			burst:     burst,
+			rate:      float64(limit.RequestsPerMinute) / time.Minute.Seconds(),
+			updatedAt: now,
+		}
+		s.buckets[key] = b
+	}
+	b.refill(now)
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0, nil
+	}
+	wait := (1 - b.tokens) / b.rate
+	return false, time.Duration(wait * float64(time.Second)), nil
+}
+
+// sweep drops the buckets that have refilled, a new bucket starts full anyway
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		b.refill(now)
+		if b.tokens >= b.burst {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
+
+func (b *bucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.updatedAt).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.updatedAt = now
+}
@@ -0,0 +1,113 @@
+// Package realip resolves the client address of requests that come through
+// reverse proxies. The forwarding headers are only believed when the request
+// comes from a trusted proxy, any client can send them otherwise.
+package realip
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// Parse reads the trusted proxies, each an address such as 10.0.0.1 or a
+// range such as 10.0.0.0/8.
+func Parse(trustedProxies []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(trustedProxies))
+	for _, proxy := range trustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if strings.Contains(proxy, "/") {
+			prefix, err := netip.ParsePrefix(proxy)
+			if err != nil {
+				return nil, fmt.Errorf("trusted proxy %q is not an address or a range", proxy)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q is not an address or a range", proxy)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// Handler replaces the RemoteAddr of requests sent by one of trustedProxies
+// with the client address they forwarded. X-Forwarded-For is read from the
+// right, skipping the trusted proxies, so entries a client made up in front of
+// the chain are ignored. X-Real-IP is used when there is no X-Forwarded-For.
+// Requests from any other address keep their RemoteAddr. Invalid entries are
+// left out, config.Validate reports them on start.
+func Handler(trustedProxies []string) func(http.Handler) http.Handler {
+	prefixes := make([]netip.Prefix, 0, len(trustedProxies))
+	for _, proxy := range trustedProxies {
+		if parsed, err := Parse([]string{proxy}); err == nil {
+			prefixes = append(prefixes, parsed...)
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		if len(prefixes) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ip, ok := clientIP(r, prefixes); ok {
+				r.RemoteAddr = ip
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientIP returns the forwarded client address of a request sent by a
+// trusted proxy
+func clientIP(r *http.Request, prefixes []netip.Prefix) (string, bool) {
+	peer, ok := parseAddr(r.RemoteAddr)
+	if !ok || !trusted(peer, prefixes) {
+		return "", false
+	}
+
+	if forwardedFor := r.Header.Values("X-Forwarded-For"); len(forwardedFor) > 0 {
+		hops := strings.Split(strings.Join(forwardedFor, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			addr, ok := parseAddr(hops[i])
+			if !ok {
+				// nothing left of a malformed hop can be trusted
+				return "", false
+			}
+			if !trusted(addr, prefixes) || i == 0 {
+				return addr.String(), true
+			}
+		}
+	}
+
+	if addr, ok := parseAddr(r.Header.Get("X-Real-IP")); ok {
+		return addr.String(), true
+	}
+	return "", false
+}
+
+// parseAddr reads an address with or without a port
+func parseAddr(value string) (netip.Addr, bool) {
+	value = strings.TrimSpace(value)
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		value = host
+	}
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
+
+func trusted(addr netip.Addr, prefixes []netip.Prefix) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
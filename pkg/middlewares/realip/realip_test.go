@@ -0,0 +1,98 @@
+package realip
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	trustedProxies := []string{"10.0.0.0/8", "192.168.1.2"}
+
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		headers        map[string]string
+		want           string
+	}{
+		{
+			name:           "untrusted peer keeps its address",
+			trustedProxies: trustedProxies,
+			remoteAddr:     "203.0.113.9:5555",
+			headers:        map[string]string{"X-Forwarded-For": "1.2.3.4", "X-Real-IP": "1.2.3.4"},
+			want:           "203.0.113.9:5555",
+		},
+		{
+			name:       "no trusted proxies ignores the headers",
+			remoteAddr: "10.0.0.5:5555",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4"},
+			want:       "10.0.0.5:5555",
+		},
+		{
+			name:           "trusted proxy forwards the client",
+			trustedProxies: trustedProxies,
+			remoteAddr:     "10.0.0.5:5555",
+			headers:        map[string]string{"X-Forwarded-For": "203.0.113.9"},
+			want:           "203.0.113.9",
+		},
+		{
+			name:           "entries made up by the client are skipped",
+			trustedProxies: trustedProxies,
+			remoteAddr:     "192.168.1.2:5555",
+			headers:        map[string]string{"X-Forwarded-For": "1.2.3.4, 203.0.113.9, 10.0.0.7"},
+			want:           "203.0.113.9",
+		},
+		{
+			name:           "chain of trusted proxies only",
+			trustedProxies: trustedProxies,
+			remoteAddr:     "10.0.0.5:5555",
+			headers:        map[string]string{"X-Forwarded-For": "10.0.0.8, 10.0.0.7"},
+			want:           "10.0.0.8",
+		},
+		{
+			name:           "malformed hop keeps the peer",
+			trustedProxies: trustedProxies,
+			remoteAddr:     "10.0.0.5:5555",
+			headers:        map[string]string{"X-Forwarded-For": "203.0.113.9, not-an-ip"},
+			want:           "10.0.0.5:5555",
+		},
+		{
+			name:           "real ip without forwarded for",
+			trustedProxies: trustedProxies,
+			remoteAddr:     "10.0.0.5:5555",
+			headers:        map[string]string{"X-Real-IP": "203.0.113.9"},
+			want:           "203.0.113.9",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := Handler(tt.trustedProxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.RemoteAddr
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	got, err := Parse([]string{"10.0.0.0/8", " 192.168.1.2 ", "::1"})
+	if assert.NoError(t, err) {
+		assert.Len(t, got, 3)
+		assert.Equal(t, "192.168.1.2/32", got[1].String())
+	}
+
+	_, err = Parse([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+	_, err = Parse([]string{"proxy.internal"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,44 @@
+// Package requestinfo keeps the request id and client address in the request
+// context so that code far from the handler, such as the audit trail, can
+// record where a change came from.
+package requestinfo
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type contextKey struct{}
+
+type Info struct {
+	RequestID string
+	IPAddress string
+}
+
+// Handler stores the request info. It expects chi's RequestID and the realip
+// middlewares to run first.
+func Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info := Info{
+			RequestID: middleware.GetReqID(r.Context()),
+			IPAddress: r.RemoteAddr,
+		}
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			info.IPAddress = host
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), info)))
+	})
+}
+
+func NewContext(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, contextKey{}, info)
+}
+
+// FromContext returns the request info, empty outside of a request
+func FromContext(ctx context.Context) Info {
+	info, _ := ctx.Value(contextKey{}).(Info)
+	return info
+}
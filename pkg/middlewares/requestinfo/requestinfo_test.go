@@ -0,0 +1,40 @@
+package requestinfo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/realip"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	var got Info
+	handler := middleware.RequestID(realip.Handler([]string{"192.0.2.1"})(Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = FromContext(r.Context())
+	}))))
+
+	// httptest requests come from 192.0.2.1, the trusted proxy
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-1")
+	req.Header.Set("X-Forwarded-For", "10.0.0.7")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, Info{RequestID: "req-1", IPAddress: "10.0.0.7"}, got)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "192.168.1.2:5555"
+	req.Header.Set("X-Forwarded-For", "10.0.0.7")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.NotEmpty(t, got.RequestID)
+	assert.Equal(t, "192.168.1.2", got.IPAddress)
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, Info{}, FromContext(context.Background()))
+	assert.Equal(t, Info{RequestID: "a"}, FromContext(NewContext(context.Background(), Info{RequestID: "a"})))
+}
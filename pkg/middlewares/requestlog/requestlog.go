@@ -0,0 +1,58 @@
+// Package requestlog echoes the request id to the client and logs one line per
+// request from a logging scope that the rest of the request can add to.
+package requestlog
+
+import (
+	"net/http"
+	"time"
+
+	liblog "github.com/faisalhardin/employee-payroll-system/pkg/common/log"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/log/logger"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/requestinfo"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+)
+
+// Handler expects chi's RequestID middleware and requestinfo.Handler to run
+// first, so an incoming X-Request-ID is kept and a missing one is generated.
+func Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		info := requestinfo.FromContext(r.Context())
+		if info.RequestID != "" {
+			w.Header().Set(RequestIDHeader, info.RequestID)
+		}
+
+		ctx := liblog.NewContext(r.Context(), logger.KV{
+			"request_id": info.RequestID,
+			"ip":         info.IPAddress,
+			"method":     r.Method,
+			"path":       r.URL.Path,
+		})
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		fields := logger.KV{
+			"status":     status,
+			"bytes":      ww.BytesWritten(),
+			"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+		}
+		if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil && routeCtx.RoutePattern() != "" {
+			fields["route"] = routeCtx.RoutePattern()
+		}
+
+		if status >= http.StatusInternalServerError {
+			liblog.WarnCtx(ctx, "request completed", fields)
+			return
+		}
+		liblog.InfoCtx(ctx, "request completed", fields)
+	})
+}
@@ -0,0 +1,56 @@
+package requestlog
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	liblog "github.com/faisalhardin/employee-payroll-system/pkg/common/log"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/log/logger"
+	"github.com/faisalhardin/employee-payroll-system/pkg/middlewares/requestinfo"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	var got logger.KV
+	handler := middleware.RequestID(requestinfo.Handler(Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		liblog.AddFields(r.Context(), logger.KV{"user_id": int64(2)})
+		got = liblog.Fields(r.Context(), nil)
+	}))))
+
+	tests := []struct {
+		name          string
+		requestID     string
+		wantRequestID bool
+	}{
+		{
+			name:          "propagates the incoming id",
+			requestID:     "req-1",
+			wantRequestID: true,
+		},
+		{
+			name: "assigns an id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/payroll", nil)
+			if tt.requestID != "" {
+				req.Header.Set(RequestIDHeader, tt.requestID)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			requestID := w.Header().Get(RequestIDHeader)
+			assert.NotEmpty(t, requestID)
+			if tt.wantRequestID {
+				assert.Equal(t, tt.requestID, requestID)
+			}
+			assert.Equal(t, requestID, got["request_id"])
+			assert.Equal(t, http.MethodGet, got["method"])
+			assert.Equal(t, "/v1/payroll", got["path"])
+			assert.Equal(t, int64(2), got["user_id"])
+		})
+	}
+}
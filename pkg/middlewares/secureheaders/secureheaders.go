@@ -0,0 +1,64 @@
+// Package secureheaders sets the response headers that tell browsers to
+// restrict what they do with the API responses.
+package secureheaders
+
+import (
+	"net/http"
+	"strconv"
+)
+
+const (
+	// Off leaves a header out of the responses
+	Off = "off"
+
+	DefaultFrameOptions          = "DENY"
+	DefaultContentSecurityPolicy = "default-src 'none'; frame-ancestors 'none'"
+	DefaultReferrerPolicy        = "no-referrer"
+)
+
+// Config sets the headers per environment. Strict-Transport-Security is only
+// sent with a positive HSTSMaxAgeInSeconds, as it pins browsers to HTTPS. The
+// other headers fall back to their default when empty and are left out when
+// set to off. X-Content-Type-Options: nosniff is always sent.
+type Config struct {
+	HSTSMaxAgeInSeconds   int    `yaml:"hsts_max_age_in_seconds"`
+	HSTSIncludeSubdomains bool   `yaml:"hsts_include_subdomains"`
+	FrameOptions          string `yaml:"frame_options"`
+	ContentSecurityPolicy string `yaml:"content_security_policy"`
+	ReferrerPolicy        string `yaml:"referrer_policy"`
+}
+
+// Handler sets the headers of cfg on every response.
+func Handler(cfg Config) func(http.Handler) http.Handler {
+	headers := http.Header{}
+	headers.Set("X-Content-Type-Options", "nosniff")
+	if cfg.HSTSMaxAgeInSeconds > 0 {
+		hsts := "max-age=" + strconv.Itoa(cfg.HSTSMaxAgeInSeconds)
+		if cfg.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+		headers.Set("Strict-Transport-Security", hsts)
+	}
+	setOrDefault(headers, "X-Frame-Options", cfg.FrameOptions, DefaultFrameOptions)
+	setOrDefault(headers, "Content-Security-Policy", cfg.ContentSecurityPolicy, DefaultContentSecurityPolicy)
+	setOrDefault(headers, "Referrer-Policy", cfg.ReferrerPolicy, DefaultReferrerPolicy)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for key := range headers {
+				w.Header().Set(key, headers.Get(key))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func setOrDefault(headers http.Header, key, value, defaultValue string) {
+	switch value {
+	case Off:
+		return
+	case "":
+		value = defaultValue
+	}
+	headers.Set(key, value)
+}
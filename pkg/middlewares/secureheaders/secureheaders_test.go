@@ -0,0 +1,58 @@
+package secureheaders
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want map[string]string
+	}{
+		{
+			name: "defaults",
+			cfg:  Config{},
+			want: map[string]string{
+				"X-Content-Type-Options":    "nosniff",
+				"Strict-Transport-Security": "",
+				"X-Frame-Options":           DefaultFrameOptions,
+				"Content-Security-Policy":   DefaultContentSecurityPolicy,
+				"Referrer-Policy":           DefaultReferrerPolicy,
+			},
+		},
+		{
+			name: "production",
+			cfg: Config{
+				HSTSMaxAgeInSeconds:   31536000,
+				HSTSIncludeSubdomains: true,
+				FrameOptions:          "SAMEORIGIN",
+				ReferrerPolicy:        Off,
+			},
+			want: map[string]string{
+				"X-Content-Type-Options":    "nosniff",
+				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+				"X-Frame-Options":           "SAMEORIGIN",
+				"Content-Security-Policy":   DefaultContentSecurityPolicy,
+				"Referrer-Policy":           "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Handler(tt.cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+			for key, value := range tt.want {
+				assert.Equal(t, value, w.Header().Get(key), key)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+// Package migration applies numbered SQL files to postgres and records the
+// applied versions in a tracking table.
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MigrationsTable tracks the schema migrations
+	MigrationsTable = "schema_migrations"
+	// SeedsTable tracks the sample data, separately so seeding stays optional
+	SeedsTable = "schema_seeds"
+
+	// advisoryLockID serialises runners of concurrently starting instances
+	advisoryLockID = 727_001
+)
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+?)(\.down)?\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+type Runner struct {
+	db         *sql.DB
+	table      string
+	migrations []Migration
+}
+
+// New loads the migrations of fsys, ordered by version.
+func New(db *sql.DB, fsys fs.FS, table string) (*Runner, error) {
+	migrations, err := load(fsys)
+	if err != nil {
+		return nil, errors.Wrap(err, "migration.New")
+	}
+	return &Runner{
+		db:         db,
+		table:      table,
+		migrations: migrations,
+	}, nil
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("version %d is used by both %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] != "" {
+			migration.Down = string(content)
+		} else {
+			migration.Up = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Up applies every migration that has not been applied yet, each in its own
+// transaction together with its tracking row.
+func (r *Runner) Up(ctx context.Context) (applied []Migration, err error) {
+	err = r.withLock(ctx, func(conn *sql.Conn) error {
+		appliedVersions, err := r.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range r.migrations {
+			if _, ok := appliedVersions[migration.Version]; ok {
+				continue
+			}
+			err = r.exec(ctx, conn, migration.Up,
+				"INSERT INTO "+r.table+" (version, name) VALUES ($1, $2)", migration.Version, migration.Name)
+			if err != nil {
+				return errors.Wrapf(err, "apply %d_%s", migration.Version, migration.Name)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	if err != nil {
+		return applied, errors.Wrap(err, "migration.Up")
+	}
+	return applied, nil
+}
+
+// Down reverts the latest steps applied migrations, newest first.
+func (r *Runner) Down(ctx context.Context, steps int) (reverted []Migration, err error) {
+	err = r.withLock(ctx, func(conn *sql.Conn) error {
+		appliedVersions, err := r.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(r.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := r.migrations[i]
+			if _, ok := appliedVersions[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s cannot be reverted", migration.Version, migration.Name)
+			}
+			err = r.exec(ctx, conn, migration.Down,
+				"DELETE FROM "+r.table+" WHERE version = $1", migration.Version)
+			if err != nil {
+				return errors.Wrapf(err, "revert %d_%s", migration.Version, migration.Name)
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	if err != nil {
+		return reverted, errors.Wrap(err, "migration.Down")
+	}
+	return reverted, nil
+}
+
+// Force records every migration up to version as applied without running it,
+// for databases created before the migrations were tracked.
+func (r *Runner) Force(ctx context.Context, version int64) (err error) {
+	err = r.withLock(ctx, func(conn *sql.Conn) error {
+		for _, migration := range r.migrations {
+			if migration.Version > version {
+				break
+			}
+			_, err := conn.ExecContext(ctx,
+				"INSERT INTO "+r.table+" (version, name) VALUES ($1, $2) ON CONFLICT (version) DO NOTHING",
+				migration.Version, migration.Name)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "migration.Force")
+	}
+	return nil
+}
+
+// Status lists every known migration and whether it has been applied.
+func (r *Runner) Status(ctx context.Context) (statuses []Status, err error) {
+	err = r.withLock(ctx, func(conn *sql.Conn) error {
+		appliedVersions, err := r.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range r.migrations {
+			appliedAt, applied := appliedVersions[migration.Version]
+			statuses = append(statuses, Status{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				Applied:   applied,
+				AppliedAt: appliedAt,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "migration.Status")
+	}
+	return statuses, nil
+}
+
+func (r *Runner) withLock(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockID)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", advisoryLockID)
+	}()
+
+	_, err = conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+r.table+` (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+func (r *Runner) appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM "+r.table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	appliedVersions := map[int64]time.Time{}
+	for rows.Next() {
+		var (
+			version   int64
+			appliedAt time.Time
+		)
+		if err = rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		appliedVersions[version] = appliedAt
+	}
+	return appliedVersions, rows.Err()
+}
+
+// exec runs the migration script and the tracking statement atomically.
+func (r *Runner) exec(ctx context.Context, conn *sql.Conn, script, trackQuery string, trackArgs ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, script)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	_, err = tx.ExecContext(ctx, trackQuery, trackArgs...)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
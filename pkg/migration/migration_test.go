@@ -0,0 +1,240 @@
+package migration
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var testFS = fstest.MapFS{
+	"001_create_foo.sql":      {Data: []byte("CREATE TABLE foo (id INT);")},
+	"001_create_foo.down.sql": {Data: []byte("DROP TABLE foo;")},
+	"002_add_bar.sql":         {Data: []byte("ALTER TABLE foo ADD bar INT;")},
+	"README.md":               {Data: []byte("ignored")},
+}
+
+func Test_load(t *testing.T) {
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []Migration
+		wantErr bool
+	}{
+		{
+			name: "ordered by version",
+			fsys: testFS,
+			want: []Migration{
+				{Version: 1, Name: "create_foo", Up: "CREATE TABLE foo (id INT);", Down: "DROP TABLE foo;"},
+				{Version: 2, Name: "add_bar", Up: "ALTER TABLE foo ADD bar INT;"},
+			},
+		},
+		{
+			name: "duplicate version",
+			fsys: fstest.MapFS{
+				"001_create_foo.sql": {Data: []byte("SELECT 1;")},
+				"001_create_bar.sql": {Data: []byte("SELECT 1;")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "down without up",
+			fsys: fstest.MapFS{
+				"001_create_foo.down.sql": {Data: []byte("SELECT 1;")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := load(tt.fsys)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func expectLock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).
+		WithArgs(advisoryLockID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).
+		WithArgs(advisoryLockID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectApplied(mock sqlmock.Sqlmock, versions ...int64) {
+	rows := sqlmock.NewRows([]string{"version", "applied_at"})
+	for _, version := range versions {
+		rows.AddRow(version, time.Date(2025, 7, 21, 0, 0, 0, 0, time.UTC))
+	}
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").WillReturnRows(rows)
+}
+
+func TestRunner_Up(t *testing.T) {
+	tests := []struct {
+		name        string
+		patch       func(mock sqlmock.Sqlmock)
+		wantApplied []int64
+		wantErr     bool
+	}{
+		{
+			name: "applies pending migrations",
+			patch: func(mock sqlmock.Sqlmock) {
+				expectLock(mock)
+				expectApplied(mock, 1)
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE foo ADD bar INT;")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO schema_migrations").
+					WithArgs(int64(2), "add_bar").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				expectUnlock(mock)
+			},
+			wantApplied: []int64{2},
+		},
+		{
+			name: "nothing pending",
+			patch: func(mock sqlmock.Sqlmock) {
+				expectLock(mock)
+				expectApplied(mock, 1, 2)
+				expectUnlock(mock)
+			},
+		},
+		{
+			name: "failed migration is rolled back",
+			patch: func(mock sqlmock.Sqlmock) {
+				expectLock(mock)
+				expectApplied(mock)
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE foo (id INT);")).
+					WillReturnError(errors.New("syntax error"))
+				mock.ExpectRollback()
+				expectUnlock(mock)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+			tt.patch(mock)
+
+			runner, err := New(db, testFS, MigrationsTable)
+			assert.NoError(t, err)
+
+			applied, err := runner.Up(context.Background())
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			versions := []int64{}
+			for _, m := range applied {
+				versions = append(versions, m.Version)
+			}
+			if tt.wantApplied == nil {
+				tt.wantApplied = []int64{}
+			}
+			assert.Equal(t, tt.wantApplied, versions)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRunner_Down(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   int
+		patch   func(mock sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name:  "reverts the latest applied migration",
+			steps: 1,
+			patch: func(mock sqlmock.Sqlmock) {
+				expectLock(mock)
+				expectApplied(mock, 1)
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("DROP TABLE foo;")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM schema_migrations WHERE version = $1")).
+					WithArgs(int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				expectUnlock(mock)
+			},
+		},
+		{
+			name:  "migration without down file",
+			steps: 1,
+			patch: func(mock sqlmock.Sqlmock) {
+				expectLock(mock)
+				expectApplied(mock, 1, 2)
+				expectUnlock(mock)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+			tt.patch(mock)
+
+			runner, err := New(db, testFS, MigrationsTable)
+			assert.NoError(t, err)
+
+			_, err = runner.Down(context.Background(), tt.steps)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRunner_Status(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	expectLock(mock)
+	expectApplied(mock, 1)
+	expectUnlock(mock)
+
+	runner, err := New(db, testFS, MigrationsTable)
+	assert.NoError(t, err)
+
+	statuses, err := runner.Status(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []Status{
+		{Version: 1, Name: "create_foo", Applied: true, AppliedAt: time.Date(2025, 7, 21, 0, 0, 0, 0, time.UTC)},
+		{Version: 2, Name: "add_bar"},
+	}, statuses)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunner_Force(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	expectLock(mock)
+	mock.ExpectExec("INSERT INTO schema_migrations .* ON CONFLICT").
+		WithArgs(int64(1), "create_foo").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectUnlock(mock)
+
+	runner, err := New(db, testFS, MigrationsTable)
+	assert.NoError(t, err)
+	assert.NoError(t, runner.Force(context.Background(), 1))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
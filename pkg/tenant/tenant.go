@@ -0,0 +1,33 @@
+// Package tenant keeps the tenant, the legal entity a request acts for, in
+// the request context so the repositories can keep every query within it.
+package tenant
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrMissing is returned by Require when ctx carries no tenant. Scoped
+// queries refuse to run rather than read or write across tenants.
+var ErrMissing = errors.New("no tenant in context")
+
+type contextKey struct{}
+
+func NewContext(ctx context.Context, tenantID int64) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenantID)
+}
+
+// FromContext returns the tenant of ctx, false when there is none
+func FromContext(ctx context.Context) (int64, bool) {
+	tenantID, _ := ctx.Value(contextKey{}).(int64)
+	return tenantID, tenantID > 0
+}
+
+// Require returns the tenant of ctx or ErrMissing
+func Require(ctx context.Context) (int64, error) {
+	tenantID, found := FromContext(ctx)
+	if !found {
+		return 0, ErrMissing
+	}
+	return tenantID, nil
+}
@@ -0,0 +1,40 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequire(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    int64
+		wantErr error
+	}{
+		{
+			name: "Found",
+			ctx:  NewContext(context.Background(), 2),
+			want: 2,
+		},
+		{
+			name:    "Missing",
+			ctx:     context.Background(),
+			wantErr: ErrMissing,
+		},
+		{
+			name:    "Invalid id",
+			ctx:     NewContext(context.Background(), 0),
+			wantErr: ErrMissing,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Require(tt.ctx)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var recorder = tracetest.NewSpanRecorder()
+
+func TestMain(m *testing.M) {
+	// the global provider delegates to the first provider set, so every test
+	// shares one recorder
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	os.Exit(m.Run())
+}
+
+func endedSpan(t *testing.T, name string) sdktrace.ReadOnlySpan {
+	for _, span := range recorder.Ended() {
+		if span.Name() == name {
+			return span
+		}
+	}
+	t.Fatalf("span %q not ended", name)
+	return nil
+}
+
+func TestHandler(t *testing.T) {
+	var traceID string
+	r := chi.NewRouter()
+	r.Use(Handler)
+	r.Get("/v1/payroll-period/{id}", func(w http.ResponseWriter, r *http.Request) {
+		traceID = TraceID(r.Context())
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/payroll-period/1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	span := endedSpan(t, "GET /v1/payroll-period/{id}")
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceID)
+	assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Contains(t, span.Attributes(), attribute.String("http.route", "/v1/payroll-period/{id}"))
+	assert.Contains(t, span.Attributes(), attribute.Int("http.response.status_code", http.StatusInternalServerError))
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/random", nil))
+	span = endedSpan(t, "POST "+unmatchedRoute)
+	assert.False(t, span.Parent().IsValid())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+}
+
+func TestEnd(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus codes.Code
+		wantEvents int
+	}{
+		{name: "test.ok", wantStatus: codes.Unset},
+		{name: "test.failed", err: errors.New("connection refused"), wantStatus: codes.Error, wantEvents: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, span := Start(context.Background(), tt.name, attribute.Int64("payroll_period.id", 1))
+			assert.NotEmpty(t, TraceID(ctx))
+			End(span, tt.err)
+
+			got := endedSpan(t, tt.name)
+			assert.Equal(t, tt.wantStatus, got.Status().Code)
+			assert.Len(t, got.Events(), tt.wantEvents)
+		})
+	}
+	assert.Empty(t, TraceID(context.Background()))
+}
+
+func Test_newExporter(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "traces.jsonl")
+	tests := []struct {
+		name         string
+		cfg          Config
+		wantExporter bool
+		wantCloser   bool
+		wantErr      bool
+	}{
+		{name: "empty is off", cfg: Config{}},
+		{name: "none", cfg: Config{Exporter: ExporterNone}},
+		{name: "stdout", cfg: Config{Exporter: ExporterStdout}, wantExporter: true},
+		{name: "otlp", cfg: Config{Exporter: ExporterOTLP, Endpoint: "localhost:4318", Insecure: true}, wantExporter: true},
+		{name: "file", cfg: Config{Exporter: ExporterFile, FilePath: filePath}, wantExporter: true, wantCloser: true},
+		{name: "file without path", cfg: Config{Exporter: ExporterFile}, wantErr: true},
+		{name: "unknown", cfg: Config{Exporter: "zipkin"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter, closer, err := newExporter(context.Background(), tt.cfg)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantExporter, exporter != nil)
+			assert.Equal(t, tt.wantCloser, closer != nil)
+			if exporter != nil {
+				assert.NoError(t, exporter.Shutdown(context.Background()))
+			}
+			if closer != nil {
+				assert.NoError(t, closer.Close())
+			}
+		})
+	}
+}
@@ -1,5 +1,64 @@
 package xorm
 
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
 type Config struct {
 	DSN string `yaml:"dsn"`
+	// Password, when set, replaces the password in DSN so the DSN can be
+	// stored without the secret.
+	Password string `yaml:"password"`
+	// SQLLogLevel is the level statements are logged at, one of debug, info,
+	// warn or off. It defaults to debug.
+	SQLLogLevel string `yaml:"sql_log_level"`
+
+	// Pool settings, zero keeps the database/sql default: unlimited open
+	// connections, 2 idle ones and no lifetime or idle time limit.
+	MaxOpenConns             int `yaml:"max_open_conns"`
+	MaxIdleConns             int `yaml:"max_idle_conns"`
+	ConnMaxLifetimeInSeconds int `yaml:"conn_max_lifetime_in_seconds"`
+	ConnMaxIdleTimeInSeconds int `yaml:"conn_max_idle_time_in_seconds"`
+
+	// StartupRetry retries the first ping while the database is unreachable
+	// or starting up. ConnectRetry retries opening a connection for a
+	// request, nothing has been sent on it yet so it is always safe.
+	StartupRetry Retry `yaml:"startup_retry"`
+	ConnectRetry Retry `yaml:"connect_retry"`
+}
+
+// DataSourceName returns DSN with Password applied. DSN is either a
+// postgres:// URL or key=value pairs. Errors never echo the DSN, it may hold
+// the password.
+func (c Config) DataSourceName() (string, error) {
+	dsn := strings.TrimSpace(c.DSN)
+	if dsn == "" {
+		return "", errors.New("dsn is empty")
+	}
+
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil || u.Host == "" {
+			return "", errors.New("dsn is not a valid postgres url")
+		}
+		if c.Password != "" {
+			u.User = url.UserPassword(u.User.Username(), c.Password)
+		}
+		return u.String(), nil
+	}
+
+	if !strings.Contains(dsn, "=") {
+		return "", errors.New("dsn is neither a postgres url nor key=value pairs")
+	}
+	if c.Password != "" {
+		// a later key wins, so the password overrides one already in the dsn
+		dsn += " password=" + quoteDSNValue(c.Password)
+	}
+	return dsn, nil
+}
+
+func quoteDSNValue(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
 }
@@ -0,0 +1,128 @@
+package xorm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	liblog "github.com/faisalhardin/employee-payroll-system/pkg/common/log"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/log/logger"
+	"xorm.io/core"
+)
+
+const (
+	// SQLLogLevelOff disables statement logging
+	SQLLogLevelOff = "off"
+
+	sqlLogPrefix = "[SQL]"
+)
+
+// sqlLogger sends xorm's logs to the service logger. Statements are logged
+// with their arguments and duration at the configured level, so they can be
+// kept at debug in production and raised while investigating.
+type sqlLogger struct {
+	level    core.LogLevel
+	showSQL  bool
+	sqlLevel logger.Level
+	logf     func(level logger.Level, msg string, fields logger.KV)
+}
+
+var _ core.ILogger = (*sqlLogger)(nil)
+
+func newSQLLogger(sqlLogLevel string) *sqlLogger {
+	return &sqlLogger{
+		level:    core.LOG_INFO,
+		showSQL:  sqlLogLevel != SQLLogLevelOff,
+		sqlLevel: logger.StringToLevel(sqlLogLevel),
+		logf:     logWithFields,
+	}
+}
+
+func logWithFields(level logger.Level, msg string, fields logger.KV) {
+	switch level {
+	case logger.TraceLevel, logger.DebugLevel:
+		liblog.DebugWithFields(msg, fields)
+	case logger.WarnLevel:
+		liblog.WarnWithFields(msg, fields)
+	case logger.ErrorLevel, logger.FatalLevel:
+		liblog.ErrorWithFields(msg, fields)
+	default:
+		liblog.InfoWithFields(msg, fields)
+	}
+}
+
+func (l *sqlLogger) Debug(v ...interface{}) {
+	l.log(core.LOG_DEBUG, logger.DebugLevel, fmt.Sprint(v...))
+}
+
+func (l *sqlLogger) Debugf(format string, v ...interface{}) {
+	l.log(core.LOG_DEBUG, logger.DebugLevel, fmt.Sprintf(format, v...))
+}
+
+func (l *sqlLogger) Error(v ...interface{}) {
+	l.log(core.LOG_ERR, logger.ErrorLevel, fmt.Sprint(v...))
+}
+
+func (l *sqlLogger) Errorf(format string, v ...interface{}) {
+	l.log(core.LOG_ERR, logger.ErrorLevel, fmt.Sprintf(format, v...))
+}
+
+func (l *sqlLogger) Info(v ...interface{}) {
+	l.log(core.LOG_INFO, logger.InfoLevel, fmt.Sprint(v...))
+}
+
+// Infof receives the statements, xorm logs them as "[SQL] query args - took: duration"
+func (l *sqlLogger) Infof(format string, v ...interface{}) {
+	if !strings.HasPrefix(format, sqlLogPrefix) {
+		l.log(core.LOG_INFO, logger.InfoLevel, fmt.Sprintf(format, v...))
+		return
+	}
+	if !l.showSQL || len(v) == 0 {
+		return
+	}
+
+	fields := logger.KV{"sql": fmt.Sprint(v[0])}
+	for _, arg := range v[1:] {
+		if took, ok := arg.(time.Duration); ok {
+			fields["duration_ms"] = float64(took.Microseconds()) / 1000
+			continue
+		}
+		fields["args"] = fmt.Sprintf("%v", arg)
+	}
+	l.logf(l.sqlLevel, "sql statement", fields)
+}
+
+func (l *sqlLogger) Warn(v ...interface{}) {
+	l.log(core.LOG_WARNING, logger.WarnLevel, fmt.Sprint(v...))
+}
+
+func (l *sqlLogger) Warnf(format string, v ...interface{}) {
+	l.log(core.LOG_WARNING, logger.WarnLevel, fmt.Sprintf(format, v...))
+}
+
+func (l *sqlLogger) Level() core.LogLevel {
+	return l.level
+}
+
+func (l *sqlLogger) SetLevel(level core.LogLevel) {
+	l.level = level
+}
+
+func (l *sqlLogger) ShowSQL(show ...bool) {
+	if len(show) == 0 {
+		l.showSQL = true
+		return
+	}
+	l.showSQL = show[0]
+}
+
+func (l *sqlLogger) IsShowSQL() bool {
+	return l.showSQL
+}
+
+func (l *sqlLogger) log(level core.LogLevel, logLevel logger.Level, msg string) {
+	if level < l.level {
+		return
+	}
+	l.logf(logLevel, msg, logger.KV{"component": "xorm"})
+}
@@ -0,0 +1,92 @@
+package xorm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/log/logger"
+	"github.com/stretchr/testify/assert"
+	"xorm.io/core"
+)
+
+type loggedLine struct {
+	level  logger.Level
+	msg    string
+	fields logger.KV
+}
+
+func Test_sqlLogger(t *testing.T) {
+	tests := []struct {
+		name        string
+		sqlLogLevel string
+		log         func(l *sqlLogger)
+		want        []loggedLine
+	}{
+		{
+			name:        "statement with args and duration",
+			sqlLogLevel: "info",
+			log: func(l *sqlLogger) {
+				l.Infof("[SQL] %s %#v - took: %v", "SELECT 1 WHERE id = $1", []interface{}{1}, 1500*time.Microsecond)
+			},
+			want: []loggedLine{{
+				level: logger.InfoLevel,
+				msg:   "sql statement",
+				fields: logger.KV{
+					"sql":         "SELECT 1 WHERE id = $1",
+					"args":        "[1]",
+					"duration_ms": 1.5,
+				},
+			}},
+		},
+		{
+			name:        "statement at debug",
+			sqlLogLevel: "debug",
+			log: func(l *sqlLogger) {
+				l.Infof("[SQL] %s - took: %v", "SELECT 1", time.Millisecond)
+			},
+			want: []loggedLine{{
+				level:  logger.DebugLevel,
+				msg:    "sql statement",
+				fields: logger.KV{"sql": "SELECT 1", "duration_ms": float64(1)},
+			}},
+		},
+		{
+			name:        "statements off",
+			sqlLogLevel: SQLLogLevelOff,
+			log: func(l *sqlLogger) {
+				l.Infof("[SQL] %s - took: %v", "SELECT 1", time.Millisecond)
+				l.Errorf("failed %d", 1)
+			},
+			want: []loggedLine{{
+				level:  logger.ErrorLevel,
+				msg:    "failed 1",
+				fields: logger.KV{"component": "xorm"},
+			}},
+		},
+		{
+			name:        "below the xorm level",
+			sqlLogLevel: "debug",
+			log: func(l *sqlLogger) {
+				l.SetLevel(core.LOG_WARNING)
+				l.Info("enable cache on table:", "mst_user")
+				l.Warn("slow")
+			},
+			want: []loggedLine{{
+				level:  logger.WarnLevel,
+				msg:    "slow",
+				fields: logger.KV{"component": "xorm"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []loggedLine{}
+			l := newSQLLogger(tt.sqlLogLevel)
+			l.logf = func(level logger.Level, msg string, fields logger.KV) {
+				got = append(got, loggedLine{level: level, msg: msg, fields: fields})
+			}
+			tt.log(l)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package xorm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-xorm/xorm"
+)
+
+// Replica policies
+const (
+	PolicyRoundRobin = "round_robin"
+	PolicyRandom     = "random"
+	PolicyLeastConn  = "least_conn"
+)
+
+// NewGroupPolicy returns the replica policy with the given name, round robin
+// when the name is empty.
+func NewGroupPolicy(name string) (xorm.GroupPolicy, error) {
+	switch name {
+	case "", PolicyRoundRobin:
+		return xorm.RoundRobinPolicy(), nil
+	case PolicyRandom:
+		return xorm.RandomPolicy(), nil
+	case PolicyLeastConn:
+		return xorm.LeastConnPolicy(), nil
+	}
+	return nil, fmt.Errorf("unknown replica policy %q", name)
+}
+
+// WithPrimary marks ctx so Reader sends its reads to the master. Use it for
+// reads that must see a write made just before, replicas may lag behind.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dbContextPrimary, true)
+}
+
+// UsesPrimary reports whether ctx was marked with WithPrimary.
+func UsesPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(dbContextPrimary).(bool)
+	return primary
+}
+
+// Reader returns an auto closed session for reads. Queries go to a replica
+// unless there are none or ctx was marked with WithPrimary.
+func (c *DBConnect) Reader(ctx context.Context) *xorm.Session {
+	if c.ReadDB == nil || UsesPrimary(ctx) {
+		return c.MasterDB.Context(ctx)
+	}
+	return c.ReadDB.Context(ctx)
+}
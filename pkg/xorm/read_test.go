@@ -0,0 +1,72 @@
+package xorm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-xorm/xorm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBConnect_Reader(t *testing.T) {
+	masterConn, masterDB := NewMockDB()
+	replicaConn, replicaDB := NewMockDB()
+	defer func() {
+		masterConn.Close()
+		replicaConn.Close()
+		assert.NoError(t, masterDB.ExpectationsWereMet())
+		assert.NoError(t, replicaDB.ExpectationsWereMet())
+	}()
+	group, err := xorm.NewEngineGroup(masterConn, []*xorm.Engine{replicaConn})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		conn  *DBConnect
+		ctx   context.Context
+		patch func()
+	}{
+		{
+			name: "replica by default",
+			conn: &DBConnect{MasterDB: masterConn, ReadDB: group},
+			ctx:  context.Background(),
+			patch: func() {
+				replicaDB.ExpectQuery("^SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"one"}).AddRow(1))
+			},
+		},
+		{
+			name: "master when asked for",
+			conn: &DBConnect{MasterDB: masterConn, ReadDB: group},
+			ctx:  WithPrimary(context.Background()),
+			patch: func() {
+				masterDB.ExpectQuery("^SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"one"}).AddRow(1))
+			},
+		},
+		{
+			name: "master without replicas",
+			conn: &DBConnect{MasterDB: masterConn},
+			ctx:  context.Background(),
+			patch: func() {
+				masterDB.ExpectQuery("^SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"one"}).AddRow(1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.patch()
+			_, err := tt.conn.Reader(tt.ctx).QueryString("SELECT 1")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewGroupPolicy(t *testing.T) {
+	for _, name := range []string{"", PolicyRoundRobin, PolicyRandom, PolicyLeastConn} {
+		policy, err := NewGroupPolicy(name)
+		assert.NoError(t, err, name)
+		assert.NotNil(t, policy, name)
+	}
+	_, err := NewGroupPolicy("weighted")
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package xorm
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// sleep is replaced in tests
+var sleep = defaultSleep
+
+// defaultSleep waits for d or until ctx ends
+func defaultSleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Retry makes up to MaxAttempts attempts. The wait starts at
+// InitialBackoffInMilliseconds and doubles up to MaxBackoffInMilliseconds.
+// A zero MaxAttempts makes a single attempt.
+type Retry struct {
+	MaxAttempts                  int `yaml:"max_attempts"`
+	InitialBackoffInMilliseconds int `yaml:"initial_backoff_in_milliseconds"`
+	MaxBackoffInMilliseconds     int `yaml:"max_backoff_in_milliseconds"`
+}
+
+// Do runs fn until it succeeds, fails with an error that is not transient,
+// runs out of attempts or ctx ends. The last error is returned.
+func (r Retry) Do(ctx context.Context, fn func() error) error {
+	backoff := time.Duration(r.InitialBackoffInMilliseconds) * time.Millisecond
+	maxBackoff := time.Duration(r.MaxBackoffInMilliseconds) * time.Millisecond
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= r.MaxAttempts || !isTransient(err) {
+			return err
+		}
+		if sleepErr := sleep(ctx, backoff); sleepErr != nil {
+			return err
+		}
+		backoff *= 2
+		if maxBackoff > 0 && backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// isTransient reports errors of a database that is unreachable, still
+// starting or out of connections, which are worth another attempt. Failed
+// authentication or a missing database are not.
+func isTransient(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "57P03", // cannot_connect_now, the database is starting up
+			"53300", // too_many_connections
+			"57P01": // admin_shutdown
+			return true
+		}
+		// class 08 is connection_exception
+		return strings.HasPrefix(string(pqErr.Code), "08")
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// retryConnector retries opening a connection. Nothing has been sent on a
+// connection being opened, so retrying is always safe, and a request that
+// needs a new connection while the database restarts waits instead of
+// failing.
+type retryConnector struct {
+	driver.Connector
+	retry Retry
+}
+
+func (c retryConnector) Connect(ctx context.Context) (conn driver.Conn, err error) {
+	err = c.retry.Do(ctx, func() error {
+		conn, err = c.Connector.Connect(ctx)
+		return err
+	})
+	return conn, err
+}
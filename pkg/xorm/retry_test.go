@@ -0,0 +1,129 @@
+package xorm
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeConnector struct {
+	driver.Connector
+	errs  []error
+	calls int
+}
+
+func (c *fakeConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	c.calls++
+	if c.calls <= len(c.errs) {
+		return nil, c.errs[c.calls-1]
+	}
+	return nil, nil
+}
+
+func TestRetry_Do(t *testing.T) {
+	var waits []time.Duration
+	sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	defer func() { sleep = defaultSleep }()
+
+	refused := &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}
+	retry := Retry{MaxAttempts: 5, InitialBackoffInMilliseconds: 100, MaxBackoffInMilliseconds: 300}
+
+	tests := []struct {
+		name      string
+		retry     Retry
+		ctx       context.Context
+		errs      []error
+		wantCalls int
+		wantWaits []time.Duration
+		wantErr   bool
+	}{
+		{
+			name:      "success after transient errors",
+			retry:     retry,
+			ctx:       context.Background(),
+			errs:      []error{refused, refused, &pq.Error{Code: "57P03"}},
+			wantCalls: 4,
+			wantWaits: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond},
+		},
+		{
+			name:      "out of attempts",
+			retry:     Retry{MaxAttempts: 2, InitialBackoffInMilliseconds: 100},
+			ctx:       context.Background(),
+			errs:      []error{refused, refused, refused},
+			wantCalls: 2,
+			wantWaits: []time.Duration{100 * time.Millisecond},
+			wantErr:   true,
+		},
+		{
+			name:      "not transient",
+			retry:     retry,
+			ctx:       context.Background(),
+			errs:      []error{&pq.Error{Code: "28P01"}},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "single attempt by default",
+			ctx:       context.Background(),
+			errs:      []error{refused},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:  "context ended",
+			retry: retry,
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			}(),
+			errs:      []error{refused},
+			wantCalls: 1,
+			wantWaits: []time.Duration{100 * time.Millisecond},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			waits = nil
+			connector := &fakeConnector{errs: tt.errs}
+			_, err := retryConnector{Connector: connector, retry: tt.retry}.Connect(tt.ctx)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantCalls, connector.calls)
+			assert.Equal(t, tt.wantWaits, waits)
+		})
+	}
+}
+
+func Test_isTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "bad connection", err: driver.ErrBadConn, want: true},
+		{name: "refused", err: fmt.Errorf("dial: %w", syscall.ECONNREFUSED), want: true},
+		{name: "unknown host", err: &net.DNSError{Err: "no such host", Name: "psql_bp"}, want: true},
+		{name: "starting up", err: &pq.Error{Code: "57P03"}, want: true},
+		{name: "connection failure", err: &pq.Error{Code: "08006"}, want: true},
+		{name: "wrong password", err: &pq.Error{Code: "28P01"}},
+		{name: "unique violation", err: &pq.Error{Code: "23505"}},
+		{name: "other", err: errors.New("syntax error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isTransient(tt.err))
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package xorm
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Timeouts bound how long a repository operation may run. OperationsInMilliseconds
+// maps an operation, named like its span e.g. attendance.Conn.GetPayslips, to
+// its own timeout; the others get DefaultInMilliseconds. Zero means no
+// timeout.
+type Timeouts struct {
+	DefaultInMilliseconds    int            `yaml:"default_in_milliseconds"`
+	OperationsInMilliseconds map[string]int `yaml:"operations_in_milliseconds"`
+}
+
+// timeout returns the timeout of operation, zero when it has none
+func (t Timeouts) timeout(operation string) time.Duration {
+	milliseconds, found := t.OperationsInMilliseconds[operation]
+	if !found {
+		milliseconds = t.DefaultInMilliseconds
+	}
+	if milliseconds <= 0 {
+		return 0
+	}
+	return time.Duration(milliseconds) * time.Millisecond
+}
+
+// Operation bounds ctx by the timeout of operation. The deadline reaches the
+// driver, which cancels the running statement on the server once it passes or
+// once the caller goes away.
+//
+// finish releases the timer. A driver reports a cancelled statement with its
+// own error, so when ctx has ended finish replaces *err with the context error
+// and callers can tell a timeout from a failing query with errors.Is.
+func (c *DBConnect) Operation(ctx context.Context, operation string) (context.Context, func(err *error)) {
+	cancel := context.CancelFunc(func() {})
+	if timeout := c.Timeouts.timeout(operation); timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	return ctx, func(err *error) {
+		if *err != nil && ctx.Err() != nil && !errors.Is(*err, ctx.Err()) {
+			*err = errors.WithMessage(ctx.Err(), (*err).Error())
+		}
+		cancel()
+	}
+}
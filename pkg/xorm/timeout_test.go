@@ -0,0 +1,84 @@
+package xorm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBConnect_Operation(t *testing.T) {
+	mockConn, mockDB := NewMockDB()
+	defer func() {
+		mockConn.Close()
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+	}()
+
+	conn := &DBConnect{
+		MasterDB: mockConn,
+		Timeouts: Timeouts{
+			DefaultInMilliseconds:    1000,
+			OperationsInMilliseconds: map[string]int{"test.Slow": 10, "test.Unbounded": 0},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		operation    string
+		wantDeadline bool
+		wantErr      error
+		patch        func()
+	}{
+		{
+			name:         "default timeout",
+			operation:    "test.Fast",
+			wantDeadline: true,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"one"}).AddRow(1))
+			},
+		},
+		{
+			name:         "statement past its timeout",
+			operation:    "test.Slow",
+			wantDeadline: true,
+			wantErr:      context.DeadlineExceeded,
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT 1").WillDelayFor(time.Second).WillReturnRows(sqlmock.NewRows([]string{"one"}).AddRow(1))
+			},
+		},
+		{
+			name:      "no timeout",
+			operation: "test.Unbounded",
+			wantErr:   errors.New("database error"),
+			patch: func() {
+				mockDB.ExpectQuery("^SELECT 1").WillReturnError(errors.New("database error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.patch()
+			err := func() (err error) {
+				ctx, finish := conn.Operation(context.Background(), tt.operation)
+				defer finish(&err)
+
+				_, hasDeadline := ctx.Deadline()
+				assert.Equal(t, tt.wantDeadline, hasDeadline)
+				_, err = conn.MasterDB.Context(ctx).QueryString("SELECT 1")
+				return err
+			}()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			if errors.Is(tt.wantErr, context.DeadlineExceeded) {
+				assert.ErrorIs(t, err, context.DeadlineExceeded)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr.Error())
+		})
+	}
+}
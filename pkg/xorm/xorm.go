@@ -1,47 +1,135 @@
 package xorm
 
 import (
+	"context"
+	"database/sql/driver"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/XSAM/otelsql"
+	liblog "github.com/faisalhardin/employee-payroll-system/pkg/common/log"
+	"github.com/faisalhardin/employee-payroll-system/pkg/common/log/logger"
 	"github.com/go-xorm/xorm"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"xorm.io/core"
 )
 
 type DBConnect struct {
 	MasterDB *xorm.Engine
+	// ReadDB groups the master with its replicas. Queries of its sessions go
+	// to a replica picked by the group policy, writes go to the master. It is
+	// nil without replicas, use Reader rather than reading it directly.
+	ReadDB *xorm.EngineGroup
+	// Timeouts bound the repository operations, see Operation
+	Timeouts Timeouts
 }
 
 type dbContext string
 
 // Context keys
-var dbContextSession = dbContext("session")
-
-func NewDBConnection(cfg Config) (dbConnection *DBConnect, err error) {
+var (
+	dbContextSession = dbContext("session")
+	dbContextPrimary = dbContext("primary")
+)
 
-	masterDB, err := generateXormEngineInstance(cfg.DSN)
+// NewDBConnection connects to the master and to every replica. Reads are
+// balanced over the replicas with policy, see NewGroupPolicy.
+func NewDBConnection(cfg Config, replicas []Config, policy string) (dbConnection *DBConnect, err error) {
+	groupPolicy, err := NewGroupPolicy(policy)
 	if err != nil {
-		return nil, errors.New("failed to make connection to master db")
+		return nil, err
 	}
 
-	return &DBConnect{
+	masterDB, err := generateXormEngineInstance(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make connection to master db: %v", err)
+	}
+	dbConnection = &DBConnect{
 		MasterDB: masterDB,
-	}, nil
+	}
+	if len(replicas) == 0 {
+		return dbConnection, nil
+	}
+
+	replicaDBs := make([]*xorm.Engine, 0, len(replicas))
+	for i, replica := range replicas {
+		replicaDB, err := generateXormEngineInstance(replica)
+		if err != nil {
+			for _, opened := range replicaDBs {
+				_ = opened.Close()
+			}
+			_ = masterDB.Close()
+			return nil, fmt.Errorf("failed to make connection to replica db %d: %v", i, err)
+		}
+		replicaDBs = append(replicaDBs, replicaDB)
+	}
+
+	dbConnection.ReadDB, err = xorm.NewEngineGroup(masterDB, replicaDBs, groupPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to group replica dbs: %v", err)
+	}
+	return dbConnection, nil
 }
 
-func generateXormEngineInstance(dsn string) (*xorm.Engine, error) {
+func generateXormEngineInstance(cfg Config) (*xorm.Engine, error) {
+
+	dsn, err := cfg.DataSourceName()
+	if err != nil {
+		return nil, err
+	}
 
 	engine, err := xorm.NewEngine("postgres", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create engine: %v", err)
 	}
 
-	engine.ShowSQL(true)
+	sqlLogLevel := cfg.SQLLogLevel
+	if sqlLogLevel == "" {
+		sqlLogLevel = logger.DebugLevelString
+	}
+	connector, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, errors.New("failed to parse dsn")
+	}
 
-	// Ping the database to verify the connection
-	if err := engine.Ping(); err != nil {
+	// Statements go through an instrumented pool, so a session given a traced
+	// context reports each statement as a child span. Statements run outside
+	// a trace are not reported.
+	tracedDB := otelsql.OpenDB(retryConnector{Connector: connector, retry: cfg.ConnectRetry},
+		otelsql.WithAttributes(attribute.String("db.system", "postgresql")),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			OmitConnResetSession: true,
+			OmitRows:             true,
+			SpanFilter:           inTrace,
+		}),
+	)
+	tracedDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	if cfg.MaxIdleConns > 0 {
+		tracedDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	tracedDB.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetimeInSeconds) * time.Second)
+	tracedDB.SetConnMaxIdleTime(time.Duration(cfg.ConnMaxIdleTimeInSeconds) * time.Second)
+	_ = engine.DB().DB.Close()
+	engine.DB().DB = tracedDB
+
+	engine.SetLogger(newSQLLogger(sqlLogLevel))
+	engine.ShowExecTime(true)
+
+	// Postgres may still be starting, e.g. under docker compose, so the first
+	// ping is retried with backoff
+	err = cfg.StartupRetry.Do(context.Background(), func() error {
+		err := engine.PingContext(context.Background())
+		if err != nil && isTransient(err) {
+			liblog.WarnWithFields("database not ready, retrying", logger.KV{"error": err.Error()})
+		}
+		return err
+	})
+	if err != nil {
+		_ = engine.Close()
 		return nil, err
 	}
 
@@ -52,6 +140,10 @@ func generateXormEngineInstance(dsn string) (*xorm.Engine, error) {
 
 }
 
+func inTrace(ctx context.Context, _ otelsql.Method, _ string, _ []driver.NamedValue) bool {
+	return trace.SpanContextFromContext(ctx).IsValid()
+}
+
 func NewMockDB() (*xorm.Engine, sqlmock.Sqlmock) {
 	db, mock, _ := sqlmock.New()
 